@@ -0,0 +1,496 @@
+// backup package writes every table to a versioned archive and restores it again
+package backup
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"os"
+	"time"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+)
+
+// FormatVersion is the version of the archive layout. Restores refuse archives from a newer version.
+const FormatVersion = 1
+
+// ManifestFile is the name of the manifest, always the first file of an archive
+const ManifestFile = "manifest.json"
+
+// ErrInvalidArchive is returned when an archive can't be restored
+var ErrInvalidArchive = errors.New("invalid backup archive")
+
+// Manifest describes the contents of an archive
+type Manifest struct {
+	FormatVersion int             `json:"format_version"`
+	SchemaVersion string          `json:"schema_version"` // latest migration applied when the backup was made
+	CreatedAt     time.Time       `json:"created_at"`
+	Tables        []TableManifest `json:"tables"`
+}
+
+// TableManifest describes one table's file in an archive
+type TableManifest struct {
+	Name string `json:"name"`
+	File string `json:"file"`
+	Rows int    `json:"rows"`
+}
+
+// TableReport counts what a restore did with one table's rows
+type TableReport struct {
+	Name     string `json:"name"`
+	Restored int    `json:"restored"`
+	Skipped  int    `json:"skipped"`
+}
+
+// Report is the outcome of a restore
+type Report struct {
+	Manifest Manifest                    `json:"manifest"`
+	Strategy repository.ConflictStrategy `json:"strategy"`
+	Tables   []TableReport               `json:"tables"`
+}
+
+// table is a backed up table. Tables are listed in restore order: a table only refers
+// to tables before it, so their new IDs are known by the time its rows are remapped.
+type table struct {
+	name    string
+	columns []string // id first
+	remap   func(row map[string]any, ids idMap) error
+}
+
+var tables = []table{
+	{
+		name:    "lists",
+		columns: []string{"id", "title", "created_at", "updated_at"},
+	},
+	{
+		name:    "items",
+		columns: []string{"id", "title", "content", "item_date", "list_id", "completed", "created_at", "updated_at"},
+		remap: func(row map[string]any, ids idMap) error {
+			return ids.remap(row, "list_id", "lists", true)
+		},
+	},
+	{
+		name:    "revisions",
+		columns: []string{"id", "entity_type", "entity_id", "revision", "author", "old_values", "new_values", "created_at"},
+		remap: func(row map[string]any, ids idMap) error {
+			return ids.remap(row, "entity_id", entityTable(row), false)
+		},
+	},
+	{
+		name:    "smart_lists",
+		columns: []string{"id", "owner", "title", "filter", "created_at", "updated_at"},
+		remap: func(row map[string]any, ids idMap) error {
+			filter, _ := row["filter"].(map[string]any)
+			listIDs, _ := filter["list_ids"].([]any)
+			for i, id := range listIDs {
+				listIDs[i] = ids.lookup("lists", id)
+			}
+			return nil
+		},
+	},
+	{
+		name:    "calendar_feeds",
+		columns: []string{"id", "owner", "token", "list_id", "created_at"},
+		remap: func(row map[string]any, ids idMap) error {
+			return ids.remap(row, "list_id", "lists", true)
+		},
+	},
+	{
+		name:    "activity",
+		columns: []string{"id", "user_name", "action", "entity_type", "entity_id", "list_id", "from_list_id", "created_at"},
+		remap: func(row map[string]any, ids idMap) error {
+			if err := ids.remap(row, "entity_id", entityTable(row), false); err != nil {
+				return err
+			}
+			if err := ids.remap(row, "from_list_id", "lists", false); err != nil {
+				return err
+			}
+			return ids.remap(row, "list_id", "lists", false)
+		},
+	},
+}
+
+// entityTable returns the table of the entity a revision or activity row is about
+func entityTable(row map[string]any) string {
+	switch row["entity_type"] {
+	case models.EntityItem:
+		return "items"
+	case models.EntityList:
+		return "lists"
+	}
+	return ""
+}
+
+// idMap maps the IDs in an archive to the IDs the rows were restored under, per table
+type idMap map[string]map[string]json.Number
+
+func (ids idMap) lookup(table string, id any) any {
+	number, ok := id.(json.Number)
+	if !ok {
+		return id
+	}
+	if restored, ok := ids[table][number.String()]; ok {
+		return restored
+	}
+	return id
+}
+
+// remap replaces the ID in column with its restored ID. Columns with a foreign key are
+// required to refer to a restored row; others may refer to rows that have since been
+// deleted, and keep their ID.
+func (ids idMap) remap(row map[string]any, column, table string, required bool) error {
+	id, ok := row[column].(json.Number)
+	if !ok {
+		return nil
+	}
+	restored, ok := ids[table][id.String()]
+	if !ok {
+		if required {
+			return fmt.Errorf("%w: %s refers to %s %s, which is not in the archive", ErrInvalidArchive, column, table, id)
+		}
+		return nil
+	}
+	row[column] = restored
+	return nil
+}
+
+// Export writes every table from snapshot to w as a gzipped tarball: manifest.json
+// followed by one JSON lines file per table. Tables are spooled to temporary files
+// first, so nothing is written to w if reading the database fails.
+func Export(w io.Writer, snapshot repository.BackupSnapshot, now time.Time) (*Manifest, error) {
+	defer snapshot.Close()
+
+	schemaVersion, err := snapshot.SchemaVersion()
+	if err != nil {
+		return nil, err
+	}
+	manifest := &Manifest{FormatVersion: FormatVersion, SchemaVersion: schemaVersion, CreatedAt: now.UTC()}
+
+	spools := make([]*os.File, 0, len(tables))
+	defer func() {
+		for _, spool := range spools {
+			spool.Close()
+			os.Remove(spool.Name())
+		}
+	}()
+
+	for _, t := range tables {
+		spool, err := os.CreateTemp("", "backup-"+t.name+"-*.jsonl")
+		if err != nil {
+			return nil, fmt.Errorf("could not create temporary file: %w", err)
+		}
+		spools = append(spools, spool)
+
+		buffered := bufio.NewWriter(spool)
+		rows := 0
+		err = snapshot.Rows(t.name, t.columns, func(row json.RawMessage) error {
+			rows++
+			buffered.Write(row)
+			return buffered.WriteByte('\n')
+		})
+		if err == nil {
+			err = buffered.Flush()
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		manifest.Tables = append(manifest.Tables, TableManifest{Name: t.name, File: t.name + ".jsonl", Rows: rows})
+	}
+
+	gz := gzip.NewWriter(w)
+	tw := tar.NewWriter(gz)
+
+	manifestJSON, err := json.MarshalIndent(manifest, "", "  ")
+	if err != nil {
+		return nil, err
+	}
+	if err := writeFile(tw, ManifestFile, bytes.NewReader(manifestJSON), int64(len(manifestJSON)), manifest.CreatedAt); err != nil {
+		return nil, err
+	}
+
+	for i, spool := range spools {
+		info, err := spool.Stat()
+		if err != nil {
+			return nil, err
+		}
+		if _, err := spool.Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		if err := writeFile(tw, manifest.Tables[i].File, spool, info.Size(), manifest.CreatedAt); err != nil {
+			return nil, err
+		}
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, err
+	}
+	if err := gz.Close(); err != nil {
+		return nil, err
+	}
+	return manifest, nil
+}
+
+func writeFile(tw *tar.Writer, name string, contents io.Reader, size int64, modTime time.Time) error {
+	header := &tar.Header{Name: name, Mode: 0o644, Size: size, ModTime: modTime, Typeflag: tar.TypeReg}
+	if err := tw.WriteHeader(header); err != nil {
+		return err
+	}
+	_, err := io.Copy(tw, contents)
+	return err
+}
+
+// Restore reads an archive written by Export and inserts its rows through a restorer from
+// backups in a single transaction. The archive is checked and spooled to temporary files
+// before the transaction begins, so a slow upload doesn't keep it open. Rows keep their
+// IDs unless strategy is ConflictCopy and the ID is taken; references between tables are
+// rewritten to follow rows that got new IDs. Archives from a newer format or schema
+// version are refused.
+func Restore(r io.Reader, backups repository.BackupRepositoryInterface, strategy repository.ConflictStrategy) (*Report, error) {
+	manifest, spools, err := spoolArchive(r)
+	defer func() {
+		for _, spool := range spools {
+			spool.Close()
+			os.Remove(spool.Name())
+		}
+	}()
+	if err != nil {
+		return nil, err
+	}
+
+	restorer, err := backups.BeginRestore()
+	if err != nil {
+		return nil, err
+	}
+	defer restorer.Abort()
+
+	schemaVersion, err := restorer.SchemaVersion()
+	if err != nil {
+		return nil, err
+	}
+	if manifest.SchemaVersion > schemaVersion {
+		return nil, fmt.Errorf("%w: archive schema %s is newer than database schema %s", ErrInvalidArchive, manifest.SchemaVersion, schemaVersion)
+	}
+
+	report := &Report{Manifest: *manifest, Strategy: strategy}
+	ids := idMap{}
+	for i, entry := range manifest.Tables {
+		t, _ := lookupTable(entry.Name) // known, or spoolArchive would have failed
+		if _, err := spools[i].Seek(0, io.SeekStart); err != nil {
+			return nil, err
+		}
+		tableReport, err := restoreTable(spools[i], restorer, t, strategy, ids)
+		if err != nil {
+			return nil, err
+		}
+		report.Tables = append(report.Tables, *tableReport)
+	}
+
+	if err := restorer.Finish(); err != nil {
+		return nil, err
+	}
+	return report, nil
+}
+
+// spoolArchive reads the whole archive from r, copying each table's file to a temporary
+// file once it has checked that every row is a JSON object with an id, and that the file
+// has as many rows as the manifest lists. The files are returned in the manifest's order,
+// along with any made before an error so they can be removed.
+func spoolArchive(r io.Reader) (*Manifest, []*os.File, error) {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return nil, nil, fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+	}
+	tr := tar.NewReader(gz)
+
+	manifest, err := readManifest(tr)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var spools []*os.File
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			return nil, spools, fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+		}
+
+		// files must come in the manifest's order, which is the order of tables
+		next := len(spools)
+		if next >= len(manifest.Tables) || header.Name != manifest.Tables[next].File {
+			return nil, spools, fmt.Errorf("%w: unexpected file %s", ErrInvalidArchive, header.Name)
+		}
+		entry := manifest.Tables[next]
+		if _, ok := lookupTable(entry.Name); !ok {
+			return nil, spools, fmt.Errorf("%w: unknown table %s", ErrInvalidArchive, entry.Name)
+		}
+
+		spool, err := os.CreateTemp("", "restore-"+entry.Name+"-*.jsonl")
+		if err != nil {
+			return nil, spools, fmt.Errorf("could not create temporary file: %w", err)
+		}
+		spools = append(spools, spool)
+
+		rows, err := spoolTable(tr, spool, entry.Name)
+		if err != nil {
+			return nil, spools, err
+		}
+		if rows != entry.Rows {
+			return nil, spools, fmt.Errorf("%w: %s has %d rows, the manifest lists %d", ErrInvalidArchive, entry.File, rows, entry.Rows)
+		}
+	}
+
+	if len(spools) != len(manifest.Tables) {
+		return nil, spools, fmt.Errorf("%w: missing file %s", ErrInvalidArchive, manifest.Tables[len(spools)].File)
+	}
+	return manifest, spools, nil
+}
+
+// spoolTable copies the rows of table from r to w, counting them
+func spoolTable(r io.Reader, w io.Writer, table string) (int, error) {
+	buffered := bufio.NewWriter(w)
+	scanner := newRowScanner(r)
+	rows := 0
+	for scanner.Scan() {
+		rows++
+		if _, _, err := decodeRow(scanner.Bytes(), table, rows); err != nil {
+			return 0, err
+		}
+		buffered.Write(scanner.Bytes())
+		if err := buffered.WriteByte('\n'); err != nil {
+			return 0, err
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return 0, fmt.Errorf("%w: %s: %w", ErrInvalidArchive, table, err)
+	}
+	return rows, buffered.Flush()
+}
+
+// newRowScanner reads the lines of a table's file, each one row
+func newRowScanner(r io.Reader) *bufio.Scanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	return scanner
+}
+
+// decodeRow decodes line of table's file, which must be a JSON object with an id
+func decodeRow(data []byte, table string, line int) (map[string]any, json.Number, error) {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var row map[string]any
+	if err := decoder.Decode(&row); err != nil {
+		return nil, "", fmt.Errorf("%w: %s line %d: %w", ErrInvalidArchive, table, line, err)
+	}
+	id, ok := row["id"].(json.Number)
+	if !ok {
+		return nil, "", fmt.Errorf("%w: %s line %d: missing id", ErrInvalidArchive, table, line)
+	}
+	return row, id, nil
+}
+
+func readManifest(tr *tar.Reader) (*Manifest, error) {
+	header, err := tr.Next()
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrInvalidArchive, err)
+	}
+	if header.Name != ManifestFile {
+		return nil, fmt.Errorf("%w: %s must be the first file", ErrInvalidArchive, ManifestFile)
+	}
+
+	var manifest Manifest
+	if err := json.NewDecoder(tr).Decode(&manifest); err != nil {
+		return nil, fmt.Errorf("%w: invalid manifest: %w", ErrInvalidArchive, err)
+	}
+	if manifest.FormatVersion < 1 || manifest.FormatVersion > FormatVersion {
+		return nil, fmt.Errorf("%w: unsupported format version %d", ErrInvalidArchive, manifest.FormatVersion)
+	}
+
+	// the archive must list tables in restore order, so references can be remapped
+	last := -1
+	for _, entry := range manifest.Tables {
+		index := tableIndex(entry.Name)
+		if index <= last {
+			return nil, fmt.Errorf("%w: unknown or out of order table %s", ErrInvalidArchive, entry.Name)
+		}
+		last = index
+	}
+
+	return &manifest, nil
+}
+
+func restoreTable(r io.Reader, restorer repository.BackupRestorer, t table, strategy repository.ConflictStrategy, ids idMap) (*TableReport, error) {
+	report := &TableReport{Name: t.name}
+	ids[t.name] = map[string]json.Number{}
+
+	scanner := newRowScanner(r)
+	for line := 1; scanner.Scan(); line++ {
+		row, id, err := decodeRow(scanner.Bytes(), t.name, line)
+		if err != nil {
+			return nil, err
+		}
+
+		if t.remap != nil {
+			if err := t.remap(row, ids); err != nil {
+				return nil, err
+			}
+		}
+
+		// only restore the columns the archive has, so columns added since it was made get their defaults
+		columns := make([]string, 0, len(t.columns))
+		for _, column := range t.columns {
+			if _, ok := row[column]; ok {
+				columns = append(columns, column)
+			}
+		}
+
+		encoded, err := json.Marshal(row)
+		if err != nil {
+			return nil, err
+		}
+
+		restoredID, restored, err := restorer.RestoreRow(t.name, columns, encoded, strategy)
+		if err != nil {
+			return nil, err
+		}
+		if restored {
+			report.Restored++
+		} else {
+			report.Skipped++
+		}
+		if restoredID != 0 {
+			ids[t.name][id.String()] = json.Number(fmt.Sprint(restoredID))
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, fmt.Errorf("%w: %s: %w", ErrInvalidArchive, t.name, err)
+	}
+
+	return report, nil
+}
+
+func lookupTable(name string) (table, bool) {
+	if index := tableIndex(name); index >= 0 {
+		return tables[index], true
+	}
+	return table{}, false
+}
+
+func tableIndex(name string) int {
+	for i, t := range tables {
+		if t.name == name {
+			return i
+		}
+	}
+	return -1
+}
@@ -0,0 +1,365 @@
+package backup_test
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/backup"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+)
+
+// fakeDatabase keeps rows as decoded JSON objects, per table and id
+type fakeDatabase struct {
+	schemaVersion string
+	tables        map[string]map[int64]map[string]any
+	nextID        map[string]int64
+	restorer      *fakeRestorer // the last restore begun
+}
+
+func newFakeDatabase(rows map[string][]string) *fakeDatabase {
+	db := &fakeDatabase{schemaVersion: "003_calendar_feeds", tables: map[string]map[int64]map[string]any{}, nextID: map[string]int64{}}
+	for table, tableRows := range rows {
+		for _, row := range tableRows {
+			var decoded map[string]any
+			if err := json.Unmarshal([]byte(row), &decoded); err != nil {
+				panic(err)
+			}
+			db.insert(table, int64(decoded["id"].(float64)), decoded)
+		}
+	}
+	return db
+}
+
+func (db *fakeDatabase) insert(table string, id int64, row map[string]any) {
+	if db.tables[table] == nil {
+		db.tables[table] = map[int64]map[string]any{}
+	}
+	row["id"] = float64(id)
+	db.tables[table][id] = row
+	if id >= db.nextID[table] {
+		db.nextID[table] = id + 1
+	}
+}
+
+func (db *fakeDatabase) BeginBackup() (repository.BackupSnapshot, error) {
+	return &fakeSnapshot{db: db}, nil
+}
+
+func (db *fakeDatabase) BeginRestore() (repository.BackupRestorer, error) {
+	db.restorer = &fakeRestorer{db: db}
+	return db.restorer, nil
+}
+
+type fakeSnapshot struct {
+	db     *fakeDatabase
+	closed bool
+}
+
+func (s *fakeSnapshot) SchemaVersion() (string, error) {
+	return s.db.schemaVersion, nil
+}
+
+func (s *fakeSnapshot) Rows(table string, columns []string, fn func(row json.RawMessage) error) error {
+	for id := int64(0); id < s.db.nextID[table]; id++ {
+		row, ok := s.db.tables[table][id]
+		if !ok {
+			continue
+		}
+		encoded, _ := json.Marshal(row)
+		if err := fn(encoded); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+func (s *fakeSnapshot) Close() error {
+	s.closed = true
+	return nil
+}
+
+// fakeRestorer applies conflict strategies to a copy of the database, which replaces
+// the original on Finish
+type fakeRestorer struct {
+	db       *fakeDatabase
+	pending  *fakeDatabase
+	finished bool
+}
+
+func (r *fakeRestorer) SchemaVersion() (string, error) {
+	return r.db.schemaVersion, nil
+}
+
+func (r *fakeRestorer) RestoreRow(table string, columns []string, row json.RawMessage, strategy repository.ConflictStrategy) (int64, bool, error) {
+	if r.pending == nil {
+		r.pending = newFakeDatabase(nil)
+		for name, rows := range r.db.tables {
+			for id, existing := range rows {
+				r.pending.insert(name, id, existing)
+			}
+		}
+	}
+
+	var decoded map[string]any
+	if err := json.Unmarshal(row, &decoded); err != nil {
+		return 0, false, err
+	}
+	id := int64(decoded["id"].(float64))
+
+	if _, taken := r.pending.tables[table][id]; taken {
+		switch strategy {
+		case repository.ConflictFail:
+			return 0, false, fmt.Errorf("%w: %s %d", repository.ErrRestoreConflict, table, id)
+		case repository.ConflictSkip:
+			return id, false, nil
+		case repository.ConflictCopy:
+			id = r.pending.nextID[table]
+		}
+	}
+
+	r.pending.insert(table, id, decoded)
+	return id, true, nil
+}
+
+func (r *fakeRestorer) Finish() error {
+	if r.pending != nil {
+		r.db.tables, r.db.nextID = r.pending.tables, r.pending.nextID
+	}
+	r.finished = true
+	return nil
+}
+
+func (r *fakeRestorer) Abort() error {
+	return nil
+}
+
+var now = time.Date(2025, 10, 8, 12, 0, 0, 0, time.UTC)
+
+var sampleRows = map[string][]string{
+	"lists": {
+		`{"id": 1, "title": "Daily Tasks", "created_at": "2025-09-01T08:00:00", "updated_at": "2025-09-01T08:00:00"}`,
+		`{"id": 2, "title": "Goals", "created_at": "2025-09-01T08:00:00", "updated_at": "2025-09-01T08:00:00"}`,
+	},
+	"items": {
+		`{"id": 1, "title": "First Item", "content": "", "item_date": "2025-10-03", "list_id": 1, "completed": false}`,
+		`{"id": 5, "title": "Third Item", "content": "", "item_date": "2025-12-25", "list_id": 2, "completed": true}`,
+	},
+	"revisions": {
+		`{"id": 1, "entity_type": "item", "entity_id": 5, "revision": 1, "author": "jenna", "old_values": {"title": "3rd"}, "new_values": {"title": "Third Item"}}`,
+	},
+	"smart_lists": {
+		`{"id": 1, "owner": "jenna", "title": "Goals due", "filter": {"list_ids": [2], "due": "this_week"}}`,
+	},
+	"calendar_feeds": {
+		`{"id": 1, "owner": "jenna", "token": "abc", "list_id": 2}`,
+		`{"id": 2, "owner": "jenna", "token": "def", "list_id": null}`,
+	},
+	"activity": {
+		`{"id": 1, "user_name": "jenna", "action": "delete", "entity_type": "list", "entity_id": 9, "list_id": 9}`,
+		`{"id": 2, "user_name": "jenna", "action": "update", "entity_type": "item", "entity_id": 5, "list_id": 2}`,
+	},
+}
+
+func exportSample(t *testing.T) []byte {
+	t.Helper()
+
+	snapshot, _ := newFakeDatabase(sampleRows).BeginBackup()
+
+	var archive bytes.Buffer
+	manifest, err := backup.Export(&archive, snapshot, now)
+	if err != nil {
+		t.Fatalf("failed to export: %v", err)
+	}
+	if manifest.FormatVersion != backup.FormatVersion || manifest.SchemaVersion != "003_calendar_feeds" {
+		t.Errorf("unexpected manifest %+v", manifest)
+	}
+	if !snapshot.(*fakeSnapshot).closed {
+		t.Errorf("expected the snapshot to be closed")
+	}
+	return archive.Bytes()
+}
+
+func TestExportArchiveLayout(t *testing.T) {
+	tr := tar.NewReader(gunzip(t, exportSample(t)))
+
+	var names []string
+	for {
+		header, err := tr.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+		if err != nil {
+			t.Fatalf("invalid tarball: %v", err)
+		}
+		names = append(names, header.Name)
+
+		if header.Name == "items.jsonl" {
+			contents, _ := io.ReadAll(tr)
+			if lines := strings.Count(string(contents), "\n"); lines != 2 {
+				t.Errorf("expected 2 lines in items.jsonl, got %d", lines)
+			}
+		}
+	}
+
+	expected := "manifest.json lists.jsonl items.jsonl revisions.jsonl smart_lists.jsonl calendar_feeds.jsonl activity.jsonl"
+	if strings.Join(names, " ") != expected {
+		t.Errorf("expected files %s, got %v", expected, names)
+	}
+}
+
+func TestRestoreIntoEmptyDatabase(t *testing.T) {
+	archive := exportSample(t)
+
+	db := newFakeDatabase(nil)
+	report, err := backup.Restore(bytes.NewReader(archive), db, repository.ConflictFail)
+	if err != nil {
+		t.Fatalf("failed to restore: %v", err)
+	}
+
+	for _, table := range report.Tables {
+		if table.Restored != len(sampleRows[table.Name]) || table.Skipped != 0 {
+			t.Errorf("unexpected report for %s: %+v", table.Name, table)
+		}
+	}
+	if len(db.tables["items"]) != 2 || db.tables["items"][5]["list_id"].(float64) != 2 {
+		t.Errorf("items were not restored with their ids: %+v", db.tables["items"])
+	}
+}
+
+func TestRestoreConflicts(t *testing.T) {
+	archive := exportSample(t)
+	existing := map[string][]string{
+		"lists": {`{"id": 2, "title": "Existing"}`},
+	}
+
+	t.Run("fail", func(t *testing.T) {
+		db := newFakeDatabase(existing)
+		_, err := backup.Restore(bytes.NewReader(archive), db, repository.ConflictFail)
+		if !errors.Is(err, repository.ErrRestoreConflict) {
+			t.Errorf("expected ErrRestoreConflict, got %v", err)
+		}
+		if len(db.tables["lists"]) != 1 {
+			t.Errorf("expected nothing to be restored")
+		}
+	})
+
+	t.Run("skip", func(t *testing.T) {
+		db := newFakeDatabase(existing)
+		report, err := backup.Restore(bytes.NewReader(archive), db, repository.ConflictSkip)
+		if err != nil {
+			t.Fatalf("failed to restore: %v", err)
+		}
+		if report.Tables[0].Restored != 1 || report.Tables[0].Skipped != 1 {
+			t.Errorf("expected one list restored and one skipped, got %+v", report.Tables[0])
+		}
+		if db.tables["lists"][2]["title"] != "Existing" {
+			t.Errorf("expected the existing list to be kept")
+		}
+		if db.tables["items"][5]["list_id"].(float64) != 2 {
+			t.Errorf("expected items to refer to the existing list")
+		}
+	})
+
+	t.Run("copy", func(t *testing.T) {
+		db := newFakeDatabase(existing)
+		if _, err := backup.Restore(bytes.NewReader(archive), db, repository.ConflictCopy); err != nil {
+			t.Fatalf("failed to restore: %v", err)
+		}
+
+		goals := db.tables["lists"][3]
+		if goals == nil || goals["title"] != "Goals" {
+			t.Fatalf("expected Goals to be copied to id 3, got %+v", db.tables["lists"])
+		}
+
+		checks := []struct {
+			name     string
+			actual   any
+			expected any
+		}{
+			{"item list_id", db.tables["items"][5]["list_id"], float64(3)},
+			{"smart list filter", db.tables["smart_lists"][1]["filter"].(map[string]any)["list_ids"], []any{float64(3)}},
+			{"feed list_id", db.tables["calendar_feeds"][1]["list_id"], float64(3)},
+			{"feed of every list", db.tables["calendar_feeds"][2]["list_id"], nil},
+			{"activity list_id", db.tables["activity"][2]["list_id"], float64(3)},
+			{"activity for a deleted list", db.tables["activity"][1]["list_id"], float64(9)},
+			{"revision entity_id", db.tables["revisions"][1]["entity_id"], float64(5)},
+		}
+		for _, check := range checks {
+			if fmt.Sprint(check.actual) != fmt.Sprint(check.expected) {
+				t.Errorf("%s: expected %v, got %v", check.name, check.expected, check.actual)
+			}
+		}
+	})
+}
+
+func TestRestoreRejectsInvalidArchives(t *testing.T) {
+	archive := func(files ...string) []byte {
+		var buf bytes.Buffer
+		gz := gzip.NewWriter(&buf)
+		tw := tar.NewWriter(gz)
+		for i := 0; i < len(files); i += 2 {
+			tw.WriteHeader(&tar.Header{Name: files[i], Mode: 0o644, Size: int64(len(files[i+1]))})
+			tw.Write([]byte(files[i+1]))
+		}
+		tw.Close()
+		gz.Close()
+		return buf.Bytes()
+	}
+
+	tests := []struct {
+		name    string
+		archive []byte
+		begun   bool // whether the restore gets as far as beginning a transaction
+	}{
+		{"not gzipped", []byte("lists"), false},
+		{"no manifest", archive("lists.jsonl", `{"id": 1}`), false},
+		{"newer format", archive("manifest.json", `{"format_version": 2}`), false},
+		{"newer schema", archive("manifest.json", `{"format_version": 1, "schema_version": "999_future"}`), true},
+		{"tables out of order", archive("manifest.json",
+			`{"format_version": 1, "tables": [{"name": "items", "file": "items.jsonl"}, {"name": "lists", "file": "lists.jsonl"}]}`), false},
+		{"missing file", archive("manifest.json",
+			`{"format_version": 1, "tables": [{"name": "lists", "file": "lists.jsonl", "rows": 1}]}`), false},
+		{"row count mismatch", archive("manifest.json",
+			`{"format_version": 1, "tables": [{"name": "lists", "file": "lists.jsonl", "rows": 2}]}`,
+			"lists.jsonl", `{"id": 1, "title": "Daily Tasks"}`+"\n"), false},
+		{"row without an id", archive("manifest.json",
+			`{"format_version": 1, "tables": [{"name": "lists", "file": "lists.jsonl", "rows": 1}]}`,
+			"lists.jsonl", `{"title": "Daily Tasks"}`+"\n"), false},
+		{"item of a list not in the archive", archive("manifest.json",
+			`{"format_version": 1, "tables": [{"name": "items", "file": "items.jsonl", "rows": 1}]}`,
+			"items.jsonl", `{"id": 1, "title": "Orphan", "list_id": 4}`+"\n"), true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			db := newFakeDatabase(nil)
+			_, err := backup.Restore(bytes.NewReader(tt.archive), db, repository.ConflictFail)
+			if !errors.Is(err, backup.ErrInvalidArchive) {
+				t.Errorf("expected ErrInvalidArchive, got %v", err)
+			}
+			if begun := db.restorer != nil; begun != tt.begun {
+				t.Errorf("expected the restore begun: %v, got %v", tt.begun, begun)
+			}
+			if db.restorer != nil && db.restorer.finished {
+				t.Errorf("expected the restore not to be committed")
+			}
+		})
+	}
+}
+
+func gunzip(t *testing.T, data []byte) io.Reader {
+	t.Helper()
+	gz, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		t.Fatalf("invalid gzip: %v", err)
+	}
+	return gz
+}
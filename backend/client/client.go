@@ -0,0 +1,232 @@
+// client package is a typed Go client for the lists and items API
+package client
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+	"math/rand/v2"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Client calls the API. It is safe for concurrent use.
+type Client struct {
+	baseURL     *url.URL
+	http        *http.Client
+	token       string
+	user        string
+	maxAttempts int
+	minBackoff  time.Duration
+	maxBackoff  time.Duration
+}
+
+// Option configures a Client
+type Option func(*Client)
+
+// WithHTTPClient sets the http.Client requests are sent with
+func WithHTTPClient(httpClient *http.Client) Option {
+	return func(c *Client) { c.http = httpClient }
+}
+
+// WithToken sends token as a bearer token with every request
+func WithToken(token string) Option {
+	return func(c *Client) { c.token = token }
+}
+
+// WithUser sends user in the X-User header, which the API records as the author of changes
+func WithUser(user string) Option {
+	return func(c *Client) { c.user = user }
+}
+
+// WithRetry sets how often idempotent requests are attempted and the range of the
+// exponential backoff between attempts. maxAttempts of 1 disables retries.
+func WithRetry(maxAttempts int, minBackoff, maxBackoff time.Duration) Option {
+	return func(c *Client) {
+		c.maxAttempts, c.minBackoff, c.maxBackoff = max(maxAttempts, 1), minBackoff, maxBackoff
+	}
+}
+
+// New creates a client for the API at baseURL, such as http://localhost:8080
+func New(baseURL string, opts ...Option) (*Client, error) {
+	parsed, err := url.Parse(strings.TrimRight(baseURL, "/"))
+	if err != nil {
+		return nil, fmt.Errorf("invalid base URL: %w", err)
+	}
+	if parsed.Scheme != "http" && parsed.Scheme != "https" {
+		return nil, fmt.Errorf("invalid base URL %q: scheme must be http or https", baseURL)
+	}
+
+	c := &Client{
+		baseURL:     parsed,
+		http:        &http.Client{Timeout: 30 * time.Second},
+		maxAttempts: 3,
+		minBackoff:  100 * time.Millisecond,
+		maxBackoff:  2 * time.Second,
+	}
+	for _, opt := range opts {
+		opt(c)
+	}
+	return c, nil
+}
+
+// ErrNotFound matches, with errors.Is, an *Error for a missing item, list or revision
+var ErrNotFound = errors.New("not found")
+
+// Error is an error response from the API
+type Error struct {
+	StatusCode int
+	Message    string // the error field of the response, or the status text if it had none
+	Method     string
+	Path       string
+}
+
+func (e *Error) Error() string {
+	return fmt.Sprintf("%s (HTTP %d)", e.Message, e.StatusCode)
+}
+
+func (e *Error) Is(target error) bool {
+	return target == ErrNotFound && e.StatusCode == http.StatusNotFound
+}
+
+// do sends a request with body encoded as JSON, when it isn't nil, and decodes the
+// response into out, when it isn't nil. Requests other than POST are idempotent and are
+// retried on network errors and on 429, 502, 503 and 504 responses.
+func (c *Client) do(ctx context.Context, method, path string, query url.Values, body, out any) error {
+	var encoded []byte
+	if body != nil {
+		var err error
+		if encoded, err = json.Marshal(body); err != nil {
+			return err
+		}
+	}
+
+	endpoint := c.baseURL.JoinPath(path)
+	endpoint.RawQuery = query.Encode()
+
+	attempts := c.maxAttempts
+	if method == http.MethodPost {
+		attempts = 1
+	}
+
+	for attempt := 1; ; attempt++ {
+		resp, err := c.send(ctx, method, endpoint.String(), encoded)
+		if err == nil && !retryableStatus(resp.StatusCode) {
+			defer resp.Body.Close()
+			return decodeResponse(resp, method, path, out)
+		}
+		if ctx.Err() != nil {
+			return ctx.Err()
+		}
+
+		var retryAfter time.Duration
+		if err == nil {
+			retryAfter = parseRetryAfter(resp.Header.Get("Retry-After"))
+			if attempt >= attempts {
+				defer resp.Body.Close()
+				return decodeResponse(resp, method, path, out)
+			}
+			io.Copy(io.Discard, resp.Body)
+			resp.Body.Close()
+		} else if attempt >= attempts {
+			return err
+		}
+
+		timer := time.NewTimer(c.backoff(attempt, retryAfter))
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return ctx.Err()
+		case <-timer.C:
+		}
+	}
+}
+
+func (c *Client) send(ctx context.Context, method, endpoint string, body []byte) (*http.Response, error) {
+	var reader io.Reader
+	if body != nil {
+		reader = bytes.NewReader(body)
+	}
+
+	req, err := http.NewRequestWithContext(ctx, method, endpoint, reader)
+	if err != nil {
+		return nil, err
+	}
+	req.Header.Set("Accept", "application/json")
+	if body != nil {
+		req.Header.Set("Content-Type", "application/json")
+	}
+	if c.token != "" {
+		req.Header.Set("Authorization", "Bearer "+c.token)
+	}
+	if c.user != "" {
+		req.Header.Set("X-User", c.user)
+	}
+
+	return c.http.Do(req)
+}
+
+// backoff returns how long to wait after a failed attempt: exponential with jitter,
+// or what the server asked for in Retry-After, capped at maxBackoff either way
+func (c *Client) backoff(attempt int, retryAfter time.Duration) time.Duration {
+	if retryAfter > 0 {
+		return min(retryAfter, c.maxBackoff)
+	}
+	delay := min(c.minBackoff<<(attempt-1), c.maxBackoff)
+	if delay <= 0 {
+		return 0
+	}
+	return delay/2 + rand.N(delay/2+1)
+}
+
+func retryableStatus(status int) bool {
+	switch status {
+	case http.StatusTooManyRequests, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout:
+		return true
+	}
+	return false
+}
+
+// parseRetryAfter reads a Retry-After header given in seconds or as an HTTP date
+func parseRetryAfter(value string) time.Duration {
+	if value == "" {
+		return 0
+	}
+	if seconds, err := strconv.Atoi(value); err == nil {
+		return time.Duration(seconds) * time.Second
+	}
+	if t, err := http.ParseTime(value); err == nil {
+		return time.Until(t)
+	}
+	return 0
+}
+
+func decodeResponse(resp *http.Response, method, path string, out any) error {
+	if resp.StatusCode >= 400 {
+		apiErr := &Error{StatusCode: resp.StatusCode, Method: method, Path: path}
+
+		var errorBody struct {
+			Error string `json:"error"`
+		}
+		if json.NewDecoder(resp.Body).Decode(&errorBody) == nil && errorBody.Error != "" {
+			apiErr.Message = errorBody.Error
+		} else {
+			apiErr.Message = http.StatusText(resp.StatusCode)
+		}
+		return apiErr
+	}
+
+	if out == nil || resp.StatusCode == http.StatusNoContent {
+		return nil
+	}
+	if err := json.NewDecoder(resp.Body).Decode(out); err != nil {
+		return fmt.Errorf("decoding %s %s response: %w", method, path, err)
+	}
+	return nil
+}
@@ -0,0 +1,383 @@
+package client_test
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jennaborowy/fullstack-Go-Docker/client"
+	"github.com/jennaborowy/fullstack-Go-Docker/config"
+	"github.com/jennaborowy/fullstack-Go-Docker/mocks"
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+	"github.com/jennaborowy/fullstack-Go-Docker/routes"
+	"go.uber.org/mock/gomock"
+)
+
+// testAPI is the real router on top of mocked repositories
+type testAPI struct {
+	items     *mocks.MockItemRepositoryInterface
+	lists     *mocks.MockListRepositoryInterface
+	revisions *mocks.MockRevisionRepositoryInterface
+	router    http.Handler
+}
+
+func newTestAPI(t *testing.T) *testAPI {
+	t.Helper()
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+	activity := mocks.NewMockActivityRepositoryInterface(ctrl)
+	activity.EXPECT().LogActivity(gomock.Any()).Return(nil).AnyTimes()
+
+	api := &testAPI{
+		items:     mocks.NewMockItemRepositoryInterface(ctrl),
+		lists:     mocks.NewMockListRepositoryInterface(ctrl),
+		revisions: mocks.NewMockRevisionRepositoryInterface(ctrl),
+	}
+	cfg := config.Default()
+	cfg.Features.OpenAPIValidation = "all" // responses the client decodes must match the document
+	api.router = routes.NewRouter(routes.Repositories{
+		Items:     api.items,
+		Lists:     api.lists,
+		Revisions: api.revisions,
+		Activity:  activity,
+	}, cfg)
+	return api
+}
+
+// serve starts a server for handler and returns a client for it
+func serve(t *testing.T, handler http.Handler, opts ...client.Option) *client.Client {
+	t.Helper()
+	server := httptest.NewServer(handler)
+	t.Cleanup(server.Close)
+
+	opts = append([]client.Option{client.WithUser("jenna"), client.WithRetry(3, time.Millisecond, 5*time.Millisecond)}, opts...)
+	c, err := client.New(server.URL, opts...)
+	if err != nil {
+		t.Fatalf("failed to create client: %v", err)
+	}
+	return c
+}
+
+var (
+	date     = time.Date(2025, 10, 7, 0, 0, 0, 0, time.UTC)
+	item     = &models.Item{ID: 1, Title: "Item 1", Date: date, Content: "test description uno", ListID: 1}
+	list     = &models.List{ID: 1, Title: "test list", Items: []models.Item{*item}}
+	revision = models.NewRevision(models.EntityItem, 1, "jenna",
+		models.ItemSnapshot("Old title", date, "test description uno"),
+		models.ItemSnapshot("Item 1", date, "test description uno"))
+)
+
+func TestItemMethods(t *testing.T) {
+	completed := true
+	listID := 2
+
+	tests := []struct {
+		name      string
+		setupMock func(api *testAPI)
+		call      func(t *testing.T, c *client.Client) error
+	}{
+		{
+			name: "GetItems with a filter",
+			setupMock: func(api *testAPI) {
+				api.items.EXPECT().StreamFiltered(gomock.Any(), gomock.Any()).DoAndReturn(func(filter models.ItemFilter, fn func(models.Item) error) error {
+					if len(filter.ListIDs) != 2 || filter.Completed == nil || !*filter.Completed || filter.Query != "milk" {
+						t.Errorf("unexpected filter %+v", filter)
+					}
+					return fn(*item)
+				}).Times(1)
+			},
+			call: func(t *testing.T, c *client.Client) error {
+				items, err := c.GetItems(context.Background(), &models.ItemFilterDefinition{ListIDs: []int{1, 2}, Completed: &completed, Query: "milk"})
+				if err == nil && (len(items) != 1 || items[0].Title != item.Title) {
+					t.Errorf("unexpected items %+v", items)
+				}
+				return err
+			},
+		},
+		{
+			name: "GetItem",
+			setupMock: func(api *testAPI) {
+				api.items.EXPECT().GetByID(1, nil).Return(item, nil).Times(1)
+			},
+			call: func(t *testing.T, c *client.Client) error {
+				got, err := c.GetItem(context.Background(), 1)
+				if err == nil && (got.ID != 1 || !got.Date.Equal(date)) {
+					t.Errorf("unexpected item %+v", got)
+				}
+				return err
+			},
+		},
+		{
+			name: "CreateItem",
+			setupMock: func(api *testAPI) {
+				api.items.EXPECT().CreateItem("Item 1", date, "test description uno", 1).Return(item, nil).Times(1)
+			},
+			call: func(t *testing.T, c *client.Client) error {
+				got, err := c.CreateItem(context.Background(), client.NewItem{Title: "Item 1", Content: "test description uno", Date: date, ListID: 1})
+				if err == nil && got.ID != 1 {
+					t.Errorf("unexpected item %+v", got)
+				}
+				return err
+			},
+		},
+		{
+			name: "UpdateItem",
+			setupMock: func(api *testAPI) {
+				api.items.EXPECT().GetByID(1, nil).Return(item, nil).Times(1)
+				api.items.EXPECT().ChangeItem(1, models.ItemChanges{Title: "new title", Date: date, Completed: &completed, ListID: &listID}, gomock.Any()).
+					DoAndReturn(func(_ int, _ models.ItemChanges, rev *models.Revision) error {
+						if rev.Author != "jenna" {
+							t.Errorf("expected the X-User header to be sent, got author %q", rev.Author)
+						}
+						return nil
+					}).Times(1)
+			},
+			call: func(t *testing.T, c *client.Client) error {
+				got, err := c.UpdateItem(context.Background(), 1, client.ItemUpdate{Title: "new title", Date: date, Completed: &completed, ListID: &listID})
+				if err == nil && (!got.Completed || got.ListID != 2) {
+					t.Errorf("unexpected item %+v", got)
+				}
+				return err
+			},
+		},
+		{
+			name: "DeleteItem",
+			setupMock: func(api *testAPI) {
+				api.items.EXPECT().GetByID(1, nil).Return(item, nil).Times(1)
+				api.items.EXPECT().DeleteItemByID(1).Return(nil).Times(1)
+			},
+			call: func(t *testing.T, c *client.Client) error {
+				return c.DeleteItem(context.Background(), 1)
+			},
+		},
+		{
+			name: "GetItemRevisions and GetItemRevision",
+			setupMock: func(api *testAPI) {
+				api.revisions.EXPECT().GetRevisions(models.EntityItem, 1).Return([]models.Revision{*revision}, nil).Times(1)
+				api.revisions.EXPECT().GetRevision(models.EntityItem, 1, 1).Return(revision, nil).Times(1)
+			},
+			call: func(t *testing.T, c *client.Client) error {
+				revisions, err := c.GetItemRevisions(context.Background(), 1)
+				if err != nil {
+					return err
+				}
+				if len(revisions) != 1 || revisions[0].Changes["title"].Old != "Old title" {
+					t.Errorf("unexpected revisions %+v", revisions)
+				}
+				_, err = c.GetItemRevision(context.Background(), 1, 1)
+				return err
+			},
+		},
+		{
+			name: "RevertItem",
+			setupMock: func(api *testAPI) {
+				api.revisions.EXPECT().GetRevision(models.EntityItem, 1, 1).Return(revision, nil).Times(1)
+				api.items.EXPECT().GetByID(1, nil).Return(item, nil).Times(1)
+				api.items.EXPECT().ChangeItem(1, models.ItemChanges{Title: "Old title", Date: date, Content: "test description uno"}, gomock.Not(gomock.Nil())).Return(nil).Times(1)
+			},
+			call: func(t *testing.T, c *client.Client) error {
+				got, err := c.RevertItem(context.Background(), 1, 1)
+				if err == nil && got.Title != "Old title" {
+					t.Errorf("unexpected item %+v", got)
+				}
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newTestAPI(t)
+			tt.setupMock(api)
+
+			if err := tt.call(t, serve(t, api.router)); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestListMethods(t *testing.T) {
+	tests := []struct {
+		name      string
+		setupMock func(api *testAPI)
+		call      func(t *testing.T, c *client.Client) error
+	}{
+		{
+			name: "GetLists",
+			setupMock: func(api *testAPI) {
+				api.lists.EXPECT().GetAllLists(models.ListInclude{}, nil).Return([]models.List{*list}, nil).Times(1)
+			},
+			call: func(t *testing.T, c *client.Client) error {
+				lists, err := c.GetLists(context.Background())
+				if err == nil && (len(lists) != 1 || lists[0].Title != list.Title) {
+					t.Errorf("unexpected lists %+v", lists)
+				}
+				return err
+			},
+		},
+		{
+			name: "GetList",
+			setupMock: func(api *testAPI) {
+				api.lists.EXPECT().GetList(1, nil).Return(list, nil).Times(1)
+			},
+			call: func(t *testing.T, c *client.Client) error {
+				got, err := c.GetList(context.Background(), 1)
+				if err == nil && len(got.Items) != 1 {
+					t.Errorf("unexpected list %+v", got)
+				}
+				return err
+			},
+		},
+		{
+			name: "CreateList",
+			setupMock: func(api *testAPI) {
+				api.lists.EXPECT().CreateList("Goals").Return(&models.List{ID: 2, Title: "Goals"}, nil).Times(1)
+			},
+			call: func(t *testing.T, c *client.Client) error {
+				got, err := c.CreateList(context.Background(), "Goals")
+				if err == nil && got.ID != 2 {
+					t.Errorf("unexpected list %+v", got)
+				}
+				return err
+			},
+		},
+		{
+			name: "UpdateListTitle",
+			setupMock: func(api *testAPI) {
+				api.lists.EXPECT().GetList(1, nil).Return(list, nil).Times(1)
+				api.lists.EXPECT().UpdateTitle(1, "renamed", gomock.Not(gomock.Nil())).Return(&models.List{ID: 1, Title: "renamed"}, nil).Times(1)
+			},
+			call: func(t *testing.T, c *client.Client) error {
+				got, err := c.UpdateListTitle(context.Background(), 1, "renamed")
+				if err == nil && got.Title != "renamed" {
+					t.Errorf("unexpected list %+v", got)
+				}
+				return err
+			},
+		},
+		{
+			name: "DeleteList",
+			setupMock: func(api *testAPI) {
+				api.lists.EXPECT().DeleteList(1).Return(nil).Times(1)
+			},
+			call: func(t *testing.T, c *client.Client) error {
+				return c.DeleteList(context.Background(), 1)
+			},
+		},
+		{
+			name: "GetListRevisions",
+			setupMock: func(api *testAPI) {
+				api.revisions.EXPECT().GetRevisions(models.EntityList, 1).Return([]models.Revision{}, nil).Times(1)
+			},
+			call: func(t *testing.T, c *client.Client) error {
+				_, err := c.GetListRevisions(context.Background(), 1)
+				return err
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			api := newTestAPI(t)
+			tt.setupMock(api)
+
+			if err := tt.call(t, serve(t, api.router)); err != nil {
+				t.Errorf("unexpected error: %v", err)
+			}
+		})
+	}
+}
+
+func TestErrorDecoding(t *testing.T) {
+	api := newTestAPI(t)
+	api.items.EXPECT().GetByID(999, nil).Return(nil, repository.ErrNotFound).Times(1)
+	api.items.EXPECT().CreateItem("x", date, "", 1).Return(nil, errors.New("database error")).Times(1)
+	c := serve(t, api.router)
+
+	_, err := c.GetItem(context.Background(), 999)
+	if !errors.Is(err, client.ErrNotFound) {
+		t.Errorf("expected ErrNotFound, got %v", err)
+	}
+
+	var apiErr *client.Error
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusNotFound || apiErr.Message != "item not found" || apiErr.Path != "/api/items/999" {
+		t.Errorf("unexpected error %+v", apiErr)
+	}
+
+	_, err = c.CreateItem(context.Background(), client.NewItem{Title: "x", Date: date, ListID: 1})
+	if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusInternalServerError || apiErr.Message != "database error" || errors.Is(err, client.ErrNotFound) {
+		t.Errorf("expected a server error, got %v", err)
+	}
+}
+
+// flaky answers the first failures requests with 503 before handing requests to next
+func flaky(failures int32, next http.Handler) (http.Handler, *atomic.Int32) {
+	var requests atomic.Int32
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if requests.Add(1) <= failures {
+			w.Header().Set("Retry-After", "0")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			return
+		}
+		next.ServeHTTP(w, r)
+	}), &requests
+}
+
+func TestRetry(t *testing.T) {
+	t.Run("idempotent requests are retried", func(t *testing.T) {
+		api := newTestAPI(t)
+		api.items.EXPECT().GetByID(1, nil).Return(item, nil).Times(1)
+		handler, requests := flaky(2, api.router)
+
+		if _, err := serve(t, handler).GetItem(context.Background(), 1); err != nil {
+			t.Errorf("unexpected error: %v", err)
+		}
+		if requests.Load() != 3 {
+			t.Errorf("expected 3 attempts, got %d", requests.Load())
+		}
+	})
+
+	t.Run("gives up after the last attempt", func(t *testing.T) {
+		handler, requests := flaky(5, newTestAPI(t).router)
+
+		err := serve(t, handler).DeleteItem(context.Background(), 1)
+		var apiErr *client.Error
+		if !errors.As(err, &apiErr) || apiErr.StatusCode != http.StatusServiceUnavailable {
+			t.Errorf("expected a 503 error, got %v", err)
+		}
+		if requests.Load() != 3 {
+			t.Errorf("expected 3 attempts, got %d", requests.Load())
+		}
+	})
+
+	t.Run("POST is not retried", func(t *testing.T) {
+		handler, requests := flaky(1, newTestAPI(t).router)
+
+		if _, err := serve(t, handler).CreateList(context.Background(), "Goals"); err == nil {
+			t.Errorf("expected an error")
+		}
+		if requests.Load() != 1 {
+			t.Errorf("expected 1 attempt, got %d", requests.Load())
+		}
+	})
+
+	t.Run("stops when the context is done", func(t *testing.T) {
+		handler, _ := flaky(5, newTestAPI(t).router)
+		c := serve(t, handler, client.WithRetry(5, time.Hour, time.Hour))
+
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+
+		if _, err := c.GetLists(ctx); !errors.Is(err, context.DeadlineExceeded) {
+			t.Errorf("expected context.DeadlineExceeded, got %v", err)
+		}
+	})
+}
@@ -0,0 +1,132 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"net/url"
+	"strconv"
+	"time"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+)
+
+// NewItem is an item to create
+type NewItem struct {
+	Title   string
+	Content string
+	Date    time.Time
+	ListID  int
+}
+
+// ItemUpdate replaces an item's title, content and date. Completed and ListID are
+// only changed when set.
+type ItemUpdate struct {
+	Title     string
+	Content   string
+	Date      time.Time
+	Completed *bool
+	ListID    *int
+}
+
+// GetItems returns every item, or the items matching filter when it isn't nil
+func (c *Client) GetItems(ctx context.Context, filter *models.ItemFilterDefinition) ([]models.Item, error) {
+	query := url.Values{}
+	if filter != nil {
+		for _, id := range filter.ListIDs {
+			query.Add("list_id", strconv.Itoa(id))
+		}
+		for key, value := range map[string]string{"due": filter.Due, "from": filter.From, "to": filter.To, "q": filter.Query} {
+			if value != "" {
+				query.Set(key, value)
+			}
+		}
+		if filter.Completed != nil {
+			query.Set("completed", strconv.FormatBool(*filter.Completed))
+		}
+	}
+
+	var items []models.Item
+	if err := c.do(ctx, http.MethodGet, "/api/items", query, nil, &items); err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// GetItem returns a single item
+func (c *Client) GetItem(ctx context.Context, id int) (*models.Item, error) {
+	var item models.Item
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/api/items/%d", id), nil, nil, &item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
+
+// CreateItem creates an item and returns it
+func (c *Client) CreateItem(ctx context.Context, item NewItem) (*models.Item, error) {
+	body := map[string]any{
+		"title":     item.Title,
+		"content":   item.Content,
+		"item_date": item.Date.Format("2006-01-02"),
+		"list_id":   item.ListID,
+	}
+
+	var created models.Item
+	if err := c.do(ctx, http.MethodPost, "/api/items", nil, body, &created); err != nil {
+		return nil, err
+	}
+	return &created, nil
+}
+
+// UpdateItem changes an item and returns it
+func (c *Client) UpdateItem(ctx context.Context, id int, update ItemUpdate) (*models.Item, error) {
+	body := map[string]any{
+		"title":     update.Title,
+		"content":   update.Content,
+		"item_date": update.Date.Format("2006-01-02"),
+	}
+	if update.Completed != nil {
+		body["completed"] = *update.Completed
+	}
+	if update.ListID != nil {
+		body["list_id"] = *update.ListID
+	}
+
+	var updated models.Item
+	if err := c.do(ctx, http.MethodPut, fmt.Sprintf("/api/items/%d", id), nil, body, &updated); err != nil {
+		return nil, err
+	}
+	return &updated, nil
+}
+
+// DeleteItem deletes an item
+func (c *Client) DeleteItem(ctx context.Context, id int) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/api/items/%d", id), nil, nil, nil)
+}
+
+// GetItemRevisions returns the revision history of an item, oldest first
+func (c *Client) GetItemRevisions(ctx context.Context, id int) ([]models.Revision, error) {
+	var revisions []models.Revision
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/api/items/%d/revisions", id), nil, nil, &revisions); err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// GetItemRevision returns a single revision of an item
+func (c *Client) GetItemRevision(ctx context.Context, id, rev int) (*models.Revision, error) {
+	var revision models.Revision
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/api/items/%d/revisions/%d", id, rev), nil, nil, &revision); err != nil {
+		return nil, err
+	}
+	return &revision, nil
+}
+
+// RevertItem restores an item to how it was before a revision and returns it
+func (c *Client) RevertItem(ctx context.Context, id, rev int) (*models.Item, error) {
+	var item models.Item
+	if err := c.do(ctx, http.MethodPost, fmt.Sprintf("/api/items/%d/revisions/%d/revert", id, rev), nil, nil, &item); err != nil {
+		return nil, err
+	}
+	return &item, nil
+}
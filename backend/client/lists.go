@@ -0,0 +1,68 @@
+package client
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+)
+
+// GetLists returns every list, without their items
+func (c *Client) GetLists(ctx context.Context) ([]models.List, error) {
+	var lists []models.List
+	if err := c.do(ctx, http.MethodGet, "/api/lists", nil, nil, &lists); err != nil {
+		return nil, err
+	}
+	return lists, nil
+}
+
+// GetList returns a list with its items
+func (c *Client) GetList(ctx context.Context, id int) (*models.List, error) {
+	var list models.List
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/api/lists/%d", id), nil, nil, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// CreateList creates an empty list and returns it
+func (c *Client) CreateList(ctx context.Context, title string) (*models.List, error) {
+	var list models.List
+	if err := c.do(ctx, http.MethodPost, "/api/lists", nil, map[string]string{"title": title}, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// UpdateListTitle renames a list and returns it
+func (c *Client) UpdateListTitle(ctx context.Context, id int, title string) (*models.List, error) {
+	var list models.List
+	if err := c.do(ctx, http.MethodPut, fmt.Sprintf("/api/lists/%d", id), nil, map[string]string{"title": title}, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
+
+// DeleteList deletes a list and all its items
+func (c *Client) DeleteList(ctx context.Context, id int) error {
+	return c.do(ctx, http.MethodDelete, fmt.Sprintf("/api/lists/%d", id), nil, nil, nil)
+}
+
+// GetListRevisions returns the revision history of a list, oldest first
+func (c *Client) GetListRevisions(ctx context.Context, id int) ([]models.Revision, error) {
+	var revisions []models.Revision
+	if err := c.do(ctx, http.MethodGet, fmt.Sprintf("/api/lists/%d/revisions", id), nil, nil, &revisions); err != nil {
+		return nil, err
+	}
+	return revisions, nil
+}
+
+// RevertList restores a list's title to what it was before a revision and returns the list
+func (c *Client) RevertList(ctx context.Context, id, rev int) (*models.List, error) {
+	var list models.List
+	if err := c.do(ctx, http.MethodPost, fmt.Sprintf("/api/lists/%d/revisions/%d/revert", id, rev), nil, nil, &list); err != nil {
+		return nil, err
+	}
+	return &list, nil
+}
@@ -0,0 +1,279 @@
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/client"
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+)
+
+// app is what a command runs with
+type app struct {
+	ctx    context.Context
+	api    *client.Client
+	output string
+	out    io.Writer
+}
+
+func newApp(ctx context.Context, opts options, out io.Writer) (*app, error) {
+	cfg, err := loadConfig(opts)
+	if err != nil {
+		return nil, err
+	}
+	api, err := client.New(cfg.URL, client.WithToken(cfg.Token), client.WithUser(cfg.User))
+	if err != nil {
+		return nil, err
+	}
+	return &app{ctx: ctx, api: api, output: cfg.Output, out: out}, nil
+}
+
+var listsCommand = &command{
+	name:    "lists",
+	usage:   "[list-id]",
+	summary: "show every list, or the items of one list",
+	run: func(a *app, fs *flag.FlagSet) error {
+		switch fs.NArg() {
+		case 0:
+			lists, err := a.api.GetLists(a.ctx)
+			if err != nil {
+				return err
+			}
+			return a.printLists(lists)
+		case 1:
+			id, err := parseID(fs.Arg(0))
+			if err != nil {
+				return err
+			}
+			list, err := a.api.GetList(a.ctx, id)
+			if err != nil {
+				return err
+			}
+			if a.output == "json" {
+				return a.printJSON(list)
+			}
+			return a.printItems(list.Items)
+		default:
+			return errUsage
+		}
+	},
+}
+
+var addCommand = &command{
+	name:    "add",
+	usage:   "-list <list-id> [-date YYYY-MM-DD] [-content text] <title...>",
+	summary: "add an item to a list",
+	flags: func(fs *flag.FlagSet) {
+		fs.Int("list", 0, "list to add the item to (required)")
+		fs.String("date", "", "item date as YYYY-MM-DD (default today)")
+		fs.String("content", "", "item content")
+	},
+	run: func(a *app, fs *flag.FlagSet) error {
+		listID := flagValue(fs, "list").(int)
+		title := strings.Join(fs.Args(), " ")
+		if listID == 0 || title == "" {
+			return errUsage
+		}
+		date := time.Now()
+		if value := flagValue(fs, "date").(string); value != "" {
+			var err error
+			if date, err = parseDate(value); err != nil {
+				return err
+			}
+		}
+
+		item, err := a.api.CreateItem(a.ctx, client.NewItem{
+			Title:   title,
+			Content: flagValue(fs, "content").(string),
+			Date:    date,
+			ListID:  listID,
+		})
+		if err != nil {
+			return err
+		}
+		return a.printItems([]models.Item{*item})
+	},
+}
+
+var doneCommand = &command{
+	name:    "done",
+	usage:   "[-undo] <item-id>...",
+	summary: "mark items as done",
+	flags: func(fs *flag.FlagSet) {
+		fs.Bool("undo", false, "mark the items as not done instead")
+	},
+	run: func(a *app, fs *flag.FlagSet) error {
+		completed := !flagValue(fs, "undo").(bool)
+		return a.updateItems(fs.Args(), func(update *client.ItemUpdate) error {
+			update.Completed = &completed
+			return nil
+		})
+	},
+}
+
+var editCommand = &command{
+	name:    "edit",
+	usage:   "[-title text] [-date YYYY-MM-DD] [-content text] <item-id>",
+	summary: "change an item's title, date or content",
+	flags: func(fs *flag.FlagSet) {
+		fs.String("title", "", "new title")
+		fs.String("date", "", "new date as YYYY-MM-DD")
+		fs.String("content", "", "new content")
+	},
+	run: func(a *app, fs *flag.FlagSet) error {
+		if fs.NArg() != 1 {
+			return errUsage
+		}
+
+		changed := map[string]bool{}
+		fs.Visit(func(f *flag.Flag) { changed[f.Name] = true })
+		if !changed["title"] && !changed["date"] && !changed["content"] {
+			return fmt.Errorf("nothing to change, give -title, -date or -content")
+		}
+
+		return a.updateItems(fs.Args(), func(update *client.ItemUpdate) error {
+			if changed["title"] {
+				update.Title = flagValue(fs, "title").(string)
+			}
+			if changed["date"] {
+				date, err := parseDate(flagValue(fs, "date").(string))
+				if err != nil {
+					return err
+				}
+				update.Date = date
+			}
+			if changed["content"] {
+				update.Content = flagValue(fs, "content").(string)
+			}
+			return nil
+		})
+	},
+}
+
+var rmCommand = &command{
+	name:    "rm",
+	usage:   "[-list] <id>...",
+	summary: "delete items, or whole lists with -list",
+	flags: func(fs *flag.FlagSet) {
+		fs.Bool("list", false, "the ids are lists; delete them with all their items")
+	},
+	run: func(a *app, fs *flag.FlagSet) error {
+		if fs.NArg() == 0 {
+			return errUsage
+		}
+		kind, remove := "item", a.api.DeleteItem
+		if flagValue(fs, "list").(bool) {
+			kind, remove = "list", a.api.DeleteList
+		}
+
+		ids, err := parseIDs(fs.Args())
+		if err != nil {
+			return err
+		}
+
+		deleted := []int{}
+		for _, id := range ids {
+			if err := remove(a.ctx, id); err != nil {
+				return fmt.Errorf("deleting %s %d: %w", kind, id, err)
+			}
+			deleted = append(deleted, id)
+			if a.output == "table" {
+				fmt.Fprintf(a.out, "deleted %s %d\n", kind, id)
+			}
+		}
+		if a.output == "json" {
+			return a.printJSON(map[string]any{"deleted": deleted})
+		}
+		return nil
+	},
+}
+
+var mvCommand = &command{
+	name:    "mv",
+	usage:   "<item-id>... <list-id>",
+	summary: "move items to another list",
+	run: func(a *app, fs *flag.FlagSet) error {
+		if fs.NArg() < 2 {
+			return errUsage
+		}
+		listID, err := parseID(fs.Arg(fs.NArg() - 1))
+		if err != nil {
+			return err
+		}
+		return a.updateItems(fs.Args()[:fs.NArg()-1], func(update *client.ItemUpdate) error {
+			update.ListID = &listID
+			return nil
+		})
+	},
+}
+
+// updateItems applies change to each item. The API replaces every editable field on
+// update, so each item is fetched first and sent back with the change applied.
+func (a *app) updateItems(args []string, change func(update *client.ItemUpdate) error) error {
+	if len(args) == 0 {
+		return errUsage
+	}
+	ids, err := parseIDs(args)
+	if err != nil {
+		return err
+	}
+
+	updated := make([]models.Item, 0, len(ids))
+	for _, id := range ids {
+		item, err := a.api.GetItem(a.ctx, id)
+		if err != nil {
+			return fmt.Errorf("item %d: %w", id, err)
+		}
+
+		update := client.ItemUpdate{Title: item.Title, Content: item.Content, Date: item.Date}
+		if err := change(&update); err != nil {
+			return err
+		}
+
+		result, err := a.api.UpdateItem(a.ctx, id, update)
+		if err != nil {
+			return fmt.Errorf("item %d: %w", id, err)
+		}
+		result.ID = id
+		updated = append(updated, *result)
+	}
+	return a.printItems(updated)
+}
+
+// flagValue returns the value of one of a command's own flags
+func flagValue(fs *flag.FlagSet, name string) any {
+	return fs.Lookup(name).Value.(flag.Getter).Get()
+}
+
+func parseDate(value string) (time.Time, error) {
+	date, err := time.Parse("2006-01-02", value)
+	if err != nil {
+		return time.Time{}, fmt.Errorf("invalid date %q, expected YYYY-MM-DD", value)
+	}
+	return date, nil
+}
+
+func parseID(arg string) (int, error) {
+	id, err := strconv.Atoi(arg)
+	if err != nil || id <= 0 {
+		return 0, fmt.Errorf("invalid id %q", arg)
+	}
+	return id, nil
+}
+
+func parseIDs(args []string) ([]int, error) {
+	ids := make([]int, 0, len(args))
+	for _, arg := range args {
+		id, err := parseID(arg)
+		if err != nil {
+			return nil, err
+		}
+		ids = append(ids, id)
+	}
+	return ids, nil
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"flag"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+)
+
+var completionCommand = &command{
+	name:    "completion",
+	usage:   "bash|zsh|fish",
+	summary: "print a shell completion script",
+	run: func(a *app, fs *flag.FlagSet) error {
+		if fs.NArg() != 1 {
+			return errUsage
+		}
+		switch fs.Arg(0) {
+		case "bash":
+			writeBashCompletion(a.out)
+		case "zsh":
+			// zsh runs bash completion scripts through bashcompinit
+			fmt.Fprintln(a.out, "autoload -U +X bashcompinit && bashcompinit")
+			writeBashCompletion(a.out)
+		case "fish":
+			writeFishCompletion(a.out)
+		default:
+			return fmt.Errorf("unsupported shell %q, expected bash, zsh or fish", fs.Arg(0))
+		}
+		return nil
+	},
+}
+
+// commandFlags returns the names of every flag a command accepts, sorted
+func commandFlags(cmd *command) []string {
+	fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+	var opts options
+	opts.register(fs)
+	if cmd.flags != nil {
+		cmd.flags(fs)
+	}
+
+	var names []string
+	fs.VisitAll(func(f *flag.Flag) { names = append(names, f.Name) })
+	sort.Strings(names)
+	return names
+}
+
+func writeBashCompletion(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for _, cmd := range commands {
+		names = append(names, cmd.name)
+	}
+
+	fmt.Fprintln(w, "# bash completion for notes, load with: source <(notes completion bash)")
+	fmt.Fprintln(w, "_notes() {")
+	fmt.Fprintln(w, `    local cur="${COMP_WORDS[COMP_CWORD]}" prev="${COMP_WORDS[COMP_CWORD-1]}" cmd="" word skip=""`)
+	fmt.Fprintln(w, `    for word in "${COMP_WORDS[@]:1:COMP_CWORD-1}"; do`)
+	fmt.Fprintln(w, `        if [[ -n "$skip" ]]; then skip=""; continue; fi`)
+	fmt.Fprintln(w, `        case "$word" in -config|-url|-token|-user|-o) skip=1 ;; -*) ;; *) cmd="$word"; break ;; esac`)
+	fmt.Fprintln(w, "    done")
+	fmt.Fprintln(w, `    if [[ "$prev" == "-o" ]]; then COMPREPLY=($(compgen -W "table json" -- "$cur")); return; fi`)
+	fmt.Fprintln(w, `    case "$cmd" in`)
+	fmt.Fprintf(w, "        \"\") COMPREPLY=($(compgen -W \"%s\" -- \"$cur\")) ;;\n", strings.Join(names, " "))
+	fmt.Fprintln(w, `        completion) COMPREPLY=($(compgen -W "bash zsh fish" -- "$cur")) ;;`)
+	for _, cmd := range commands {
+		if cmd.name == "completion" {
+			continue
+		}
+		fmt.Fprintf(w, "        %s) [[ \"$cur\" == -* ]] && COMPREPLY=($(compgen -W \"%s\" -- \"$cur\")) ;;\n",
+			cmd.name, "-"+strings.Join(commandFlags(cmd), " -"))
+	}
+	fmt.Fprintln(w, "    esac")
+	fmt.Fprintln(w, "}")
+	fmt.Fprintln(w, "complete -o default -F _notes notes")
+}
+
+func writeFishCompletion(w io.Writer) {
+	names := make([]string, 0, len(commands))
+	for _, cmd := range commands {
+		names = append(names, cmd.name)
+	}
+
+	fmt.Fprintln(w, "# fish completion for notes, load with: notes completion fish | source")
+	fmt.Fprintln(w, "complete -c notes -f")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "complete -c notes -n 'not __fish_seen_subcommand_from %s' -a %s -d '%s'\n",
+			strings.Join(names, " "), cmd.name, cmd.summary)
+	}
+	for _, cmd := range commands {
+		for _, name := range commandFlags(cmd) {
+			fmt.Fprintf(w, "complete -c notes -n '__fish_seen_subcommand_from %s' -o %s\n", cmd.name, name)
+		}
+	}
+	fmt.Fprintln(w, "complete -c notes -n '__fish_seen_subcommand_from completion' -a 'bash zsh fish'")
+	fmt.Fprintln(w, "complete -c notes -l o -o o -a 'table json'")
+}
@@ -0,0 +1,98 @@
+package main
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+
+	"github.com/joho/godotenv"
+)
+
+const defaultURL = "http://localhost:8080"
+
+// options are the settings shared by every command
+type options struct {
+	configPath string
+	url        string
+	token      string
+	user       string
+	output     string
+}
+
+// register adds the shared flags to fs. Every command's flag set gets them too, so they
+// can be given before or after the command name.
+func (o *options) register(fs *flag.FlagSet) {
+	fs.StringVar(&o.configPath, "config", o.configPath, "config file (default "+defaultConfigPath()+")")
+	fs.StringVar(&o.url, "url", o.url, "base URL of the API, e.g. "+defaultURL)
+	fs.StringVar(&o.token, "token", o.token, "API token, sent as a bearer token")
+	fs.StringVar(&o.user, "user", o.user, "user name, sent in the X-User header")
+	fs.StringVar(&o.output, "o", o.output, "output format: table or json (default table)")
+}
+
+// config is the resolved configuration
+type config struct {
+	URL    string
+	Token  string
+	User   string
+	Output string
+}
+
+// defaultConfigPath returns where the config file is looked for when -config isn't given
+func defaultConfigPath() string {
+	if path := os.Getenv("NOTES_CONFIG"); path != "" {
+		return path
+	}
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "notes.env"
+	}
+	return filepath.Join(dir, "notes", "config.env")
+}
+
+// loadConfig resolves the configuration from, in increasing precedence, the defaults, the
+// config file, the environment and flags. The config file uses the same KEY=value syntax
+// as config.env: NOTES_URL, NOTES_TOKEN and NOTES_USER.
+func loadConfig(opts options) (*config, error) {
+	cfg := &config{URL: defaultURL, Output: "table"}
+
+	path := opts.configPath
+	if path == "" {
+		path = defaultConfigPath()
+	}
+	values, err := godotenv.Read(path)
+	if errors.Is(err, fs.ErrNotExist) && opts.configPath == "" {
+		values, err = map[string]string{}, nil
+	}
+	if err != nil {
+		return nil, fmt.Errorf("reading config file: %w", err)
+	}
+
+	for key, target := range map[string]*string{"NOTES_URL": &cfg.URL, "NOTES_TOKEN": &cfg.Token, "NOTES_USER": &cfg.User} {
+		if value := values[key]; value != "" {
+			*target = value
+		}
+		if value := os.Getenv(key); value != "" {
+			*target = value
+		}
+	}
+
+	flags := []struct {
+		value  string
+		target *string
+	}{
+		{opts.url, &cfg.URL}, {opts.token, &cfg.Token}, {opts.user, &cfg.User}, {opts.output, &cfg.Output},
+	}
+	for _, f := range flags {
+		if f.value != "" {
+			*f.target = f.value
+		}
+	}
+
+	if cfg.Output != "table" && cfg.Output != "json" {
+		return nil, fmt.Errorf("invalid output format %q, expected table or json", cfg.Output)
+	}
+	return cfg, nil
+}
@@ -0,0 +1,129 @@
+// notes is a command-line client for the lists and items API.
+//
+//	notes [global flags] <command> [flags] [args]
+//
+// Run notes help for the list of commands.
+package main
+
+import (
+	"context"
+	"errors"
+	"flag"
+	"fmt"
+	"io"
+	"os"
+	"os/signal"
+)
+
+// command is a subcommand of notes
+type command struct {
+	name    string
+	usage   string
+	summary string
+	flags   func(fs *flag.FlagSet) // registers the command's own flags
+	run     func(a *app, fs *flag.FlagSet) error
+}
+
+// commands is filled in by init, as the completion command refers back to it
+var commands []*command
+
+func init() {
+	commands = []*command{
+		listsCommand,
+		addCommand,
+		doneCommand,
+		editCommand,
+		rmCommand,
+		mvCommand,
+		completionCommand,
+	}
+}
+
+func lookupCommand(name string) *command {
+	for _, cmd := range commands {
+		if cmd.name == name {
+			return cmd
+		}
+	}
+	return nil
+}
+
+// errUsage is returned when a command is called with the wrong arguments; usage has already been printed
+var errUsage = errors.New("usage error")
+
+func main() {
+	ctx, stop := signal.NotifyContext(context.Background(), os.Interrupt)
+	code := run(ctx, os.Args[1:], os.Stdout, os.Stderr)
+	stop()
+	os.Exit(code)
+}
+
+// run runs notes with the given arguments and returns the exit code
+func run(ctx context.Context, args []string, stdout, stderr io.Writer) int {
+	var opts options
+	global := flag.NewFlagSet("notes", flag.ContinueOnError)
+	global.SetOutput(stderr)
+	opts.register(global)
+	global.Usage = func() { printUsage(stderr, global) }
+
+	if err := global.Parse(args); err != nil {
+		return 2
+	}
+	if global.NArg() == 0 || global.Arg(0) == "help" {
+		printUsage(stdout, global)
+		return 0
+	}
+
+	cmd := lookupCommand(global.Arg(0))
+	if cmd == nil {
+		fmt.Fprintf(stderr, "notes: unknown command %q\n", global.Arg(0))
+		printUsage(stderr, global)
+		return 2
+	}
+
+	fs := flag.NewFlagSet(cmd.name, flag.ContinueOnError)
+	fs.SetOutput(stderr)
+	opts.register(fs)
+	if cmd.flags != nil {
+		cmd.flags(fs)
+	}
+	fs.Usage = func() {
+		fmt.Fprintf(stderr, "usage: notes %s %s\n\n%s\n\nflags:\n", cmd.name, cmd.usage, cmd.summary)
+		fs.PrintDefaults()
+	}
+	if err := fs.Parse(global.Args()[1:]); err != nil {
+		return 2
+	}
+
+	a, err := newApp(ctx, opts, stdout)
+	if err == nil {
+		err = cmd.run(a, fs)
+	}
+	if errors.Is(err, errUsage) {
+		fs.Usage()
+		return 2
+	}
+	if err != nil {
+		fmt.Fprintln(stderr, "notes:", err)
+		return 1
+	}
+	return 0
+}
+
+func printUsage(w io.Writer, global *flag.FlagSet) {
+	fmt.Fprintln(w, "notes is a command-line client for lists and items.")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "usage: notes [global flags] <command> [flags] [args]")
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "commands:")
+	for _, cmd := range commands {
+		fmt.Fprintf(w, "  %-11s %s\n", cmd.name, cmd.summary)
+	}
+	fmt.Fprintln(w)
+	fmt.Fprintln(w, "global flags, which every command also accepts:")
+	global.SetOutput(w)
+	global.PrintDefaults()
+	fmt.Fprintln(w)
+	fmt.Fprintf(w, "The base URL, token and user are read from %s (or -config), then the\n", defaultConfigPath())
+	fmt.Fprintln(w, "NOTES_URL, NOTES_TOKEN and NOTES_USER environment variables, then flags.")
+}
@@ -0,0 +1,242 @@
+package main
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+)
+
+// request is a request received by the fake API
+type request struct {
+	method string
+	path   string
+	body   map[string]any
+	user   string
+	token  string
+}
+
+// fakeAPI answers the few routes the CLI uses with canned responses and records every request
+func fakeAPI(t *testing.T) (*httptest.Server, *[]request) {
+	t.Helper()
+	var requests []request
+
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		received := request{method: r.Method, path: r.URL.Path, user: r.Header.Get("X-User"), token: r.Header.Get("Authorization")}
+		json.NewDecoder(r.Body).Decode(&received.body)
+		requests = append(requests, received)
+
+		w.Header().Set("Content-Type", "application/json")
+		switch {
+		case r.Method == http.MethodGet && r.URL.Path == "/api/lists":
+			io.WriteString(w, `[{"ID": 1, "Title": "Daily Tasks", "UpdatedAt": "2025-10-01T09:00:00Z"}, {"ID": 2, "Title": "Goals"}]`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/lists/1":
+			io.WriteString(w, `{"ID": 1, "Title": "Daily Tasks", "Items": [{"id": 3, "title": "Water plants", "item_date": "2025-10-09T00:00:00Z", "list_id": 1, "completed": true}]}`)
+		case r.Method == http.MethodGet && r.URL.Path == "/api/items/3":
+			io.WriteString(w, `{"id": 3, "title": "Water plants", "content": "twice", "item_date": "2025-10-09T00:00:00Z", "list_id": 1}`)
+		case r.Method == http.MethodPut && r.URL.Path == "/api/items/3":
+			listID := received.body["list_id"]
+			if listID == nil {
+				listID = 1
+			}
+			json.NewEncoder(w).Encode(map[string]any{
+				"title": received.body["title"], "item_date": fmt.Sprint(received.body["item_date"]) + "T00:00:00Z",
+				"list_id": listID, "completed": received.body["completed"] == true,
+			})
+		case r.Method == http.MethodPost && r.URL.Path == "/api/items":
+			w.WriteHeader(http.StatusCreated)
+			io.WriteString(w, `{"id": 4, "title": "Pay rent", "item_date": "2025-10-31T00:00:00Z", "list_id": 2}`)
+		case r.Method == http.MethodDelete && r.URL.Path == "/api/items/3":
+			w.WriteHeader(http.StatusNoContent)
+		default:
+			w.WriteHeader(http.StatusNotFound)
+			io.WriteString(w, `{"error": "item not found"}`)
+		}
+	}))
+	t.Cleanup(server.Close)
+
+	return server, &requests
+}
+
+func runNotes(t *testing.T, args ...string) (int, string, string) {
+	t.Helper()
+	t.Setenv("NOTES_CONFIG", filepath.Join(t.TempDir(), "missing.env"))
+	t.Setenv("NOTES_URL", "")
+	t.Setenv("NOTES_TOKEN", "")
+	t.Setenv("NOTES_USER", "")
+
+	var stdout, stderr bytes.Buffer
+	code := run(context.Background(), args, &stdout, &stderr)
+	return code, stdout.String(), stderr.String()
+}
+
+func TestCommands(t *testing.T) {
+	tests := []struct {
+		name             string
+		args             []string
+		expectedCode     int
+		expectedOutput   []string
+		expectedRequests []string
+		checkRequests    func(t *testing.T, requests []request)
+	}{
+		{
+			name:             "show lists",
+			args:             []string{"lists"},
+			expectedOutput:   []string{"ID  TITLE        UPDATED", "1   Daily Tasks", "2   Goals"},
+			expectedRequests: []string{"GET /api/lists"},
+		},
+		{
+			name:             "show the items of a list",
+			args:             []string{"lists", "1"},
+			expectedOutput:   []string{"3   [x]   2025-10-09  1     Water plants"},
+			expectedRequests: []string{"GET /api/lists/1"},
+		},
+		{
+			name:             "add an item",
+			args:             []string{"add", "-list", "2", "-date", "2025-10-31", "Pay", "rent"},
+			expectedOutput:   []string{"4   [ ]   2025-10-31  2     Pay rent"},
+			expectedRequests: []string{"POST /api/items"},
+			checkRequests: func(t *testing.T, requests []request) {
+				body := requests[0].body
+				if body["title"] != "Pay rent" || body["list_id"] != float64(2) || body["item_date"] != "2025-10-31" {
+					t.Errorf("unexpected body %v", body)
+				}
+			},
+		},
+		{
+			name:             "mark an item as done keeps its other fields",
+			args:             []string{"done", "3"},
+			expectedOutput:   []string{"3   [x]"},
+			expectedRequests: []string{"GET /api/items/3", "PUT /api/items/3"},
+			checkRequests: func(t *testing.T, requests []request) {
+				body := requests[1].body
+				if body["completed"] != true || body["title"] != "Water plants" || body["content"] != "twice" || body["item_date"] != "2025-10-09" {
+					t.Errorf("unexpected body %v", body)
+				}
+			},
+		},
+		{
+			name:             "edit an item",
+			args:             []string{"edit", "-title", "Water the plants", "3"},
+			expectedOutput:   []string{"Water the plants"},
+			expectedRequests: []string{"GET /api/items/3", "PUT /api/items/3"},
+			checkRequests: func(t *testing.T, requests []request) {
+				if body := requests[1].body; body["title"] != "Water the plants" || body["content"] != "twice" {
+					t.Errorf("unexpected body %v", body)
+				}
+			},
+		},
+		{
+			name:           "move an item",
+			args:           []string{"-o", "json", "mv", "3", "2"},
+			expectedOutput: []string{`"list_id": 2`},
+			checkRequests: func(t *testing.T, requests []request) {
+				if body := requests[1].body; body["list_id"] != float64(2) {
+					t.Errorf("unexpected body %v", body)
+				}
+			},
+			expectedRequests: []string{"GET /api/items/3", "PUT /api/items/3"},
+		},
+		{
+			name:             "delete an item",
+			args:             []string{"rm", "-o", "json", "3"},
+			expectedOutput:   []string{`"deleted": [`},
+			expectedRequests: []string{"DELETE /api/items/3"},
+		},
+		{
+			name:             "API errors are reported",
+			args:             []string{"rm", "9"},
+			expectedCode:     1,
+			expectedOutput:   []string{"deleting item 9: item not found (HTTP 404)"},
+			expectedRequests: []string{"DELETE /api/items/9"},
+		},
+		{
+			name:         "missing arguments",
+			args:         []string{"mv", "3"},
+			expectedCode: 2,
+		},
+		{
+			name:         "unknown command",
+			args:         []string{"share", "3"},
+			expectedCode: 2,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			server, requests := fakeAPI(t)
+
+			code, stdout, stderr := runNotes(t, append([]string{"-url", server.URL}, tt.args...)...)
+			if code != tt.expectedCode {
+				t.Fatalf("expected exit code %d, got %d. stderr: %s", tt.expectedCode, code, stderr)
+			}
+			for _, expected := range tt.expectedOutput {
+				if !strings.Contains(stdout+stderr, expected) {
+					t.Errorf("expected output to contain %q, got:\n%s%s", expected, stdout, stderr)
+				}
+			}
+
+			var received []string
+			for _, r := range *requests {
+				received = append(received, r.method+" "+r.path)
+			}
+			if strings.Join(received, ", ") != strings.Join(tt.expectedRequests, ", ") {
+				t.Errorf("expected requests %v, got %v", tt.expectedRequests, received)
+			}
+			if tt.checkRequests != nil && len(*requests) == len(tt.expectedRequests) {
+				tt.checkRequests(t, *requests)
+			}
+		})
+	}
+}
+
+func TestConfigPrecedence(t *testing.T) {
+	server, requests := fakeAPI(t)
+
+	path := filepath.Join(t.TempDir(), "config.env")
+	config := "NOTES_URL=" + server.URL + "\nNOTES_TOKEN=from-file\nNOTES_USER=from-file\n"
+	if err := os.WriteFile(path, []byte(config), 0o600); err != nil {
+		t.Fatal(err)
+	}
+
+	t.Setenv("NOTES_URL", "")
+	t.Setenv("NOTES_TOKEN", "from-env")
+	t.Setenv("NOTES_USER", "")
+
+	var stdout, stderr bytes.Buffer
+	if code := run(context.Background(), []string{"-config", path, "lists", "-user", "from-flag"}, &stdout, &stderr); code != 0 {
+		t.Fatalf("expected exit code 0, got %d. stderr: %s", code, stderr.String())
+	}
+
+	received := (*requests)[0]
+	if received.token != "Bearer from-env" || received.user != "from-flag" {
+		t.Errorf("expected the env token and flag user, got %q and %q", received.token, received.user)
+	}
+
+	if code := run(context.Background(), []string{"-config", filepath.Join(t.TempDir(), "missing.env"), "lists"}, &stdout, &stderr); code != 1 {
+		t.Errorf("expected a missing explicit config file to fail, got exit code %d", code)
+	}
+}
+
+func TestCompletion(t *testing.T) {
+	for _, shell := range []string{"bash", "zsh", "fish"} {
+		t.Run(shell, func(t *testing.T) {
+			code, stdout, stderr := runNotes(t, "completion", shell)
+			if code != 0 {
+				t.Fatalf("expected exit code 0, got %d. stderr: %s", code, stderr)
+			}
+			for _, cmd := range commands {
+				if !strings.Contains(stdout, cmd.name) {
+					t.Errorf("expected the %s script to complete %s", shell, cmd.name)
+				}
+			}
+		})
+	}
+}
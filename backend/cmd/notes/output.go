@@ -0,0 +1,45 @@
+package main
+
+import (
+	"encoding/json"
+	"fmt"
+	"text/tabwriter"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+)
+
+func (a *app) printJSON(v any) error {
+	encoder := json.NewEncoder(a.out)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(v)
+}
+
+func (a *app) printLists(lists []models.List) error {
+	if a.output == "json" {
+		return a.printJSON(lists)
+	}
+
+	tw := tabwriter.NewWriter(a.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tTITLE\tUPDATED")
+	for _, list := range lists {
+		fmt.Fprintf(tw, "%d\t%s\t%s\n", list.ID, list.Title, list.UpdatedAt.Local().Format("2006-01-02 15:04"))
+	}
+	return tw.Flush()
+}
+
+func (a *app) printItems(items []models.Item) error {
+	if a.output == "json" {
+		return a.printJSON(items)
+	}
+
+	tw := tabwriter.NewWriter(a.out, 0, 4, 2, ' ', 0)
+	fmt.Fprintln(tw, "ID\tDONE\tDATE\tLIST\tTITLE")
+	for _, item := range items {
+		done := " "
+		if item.Completed {
+			done = "x"
+		}
+		fmt.Fprintf(tw, "%d\t[%s]\t%s\t%d\t%s\n", item.ID, done, item.Date.Format("2006-01-02"), item.ListID, item.Title)
+	}
+	return tw.Flush()
+}
@@ -0,0 +1,101 @@
+package main
+
+import (
+	"encoding/json"
+	"flag"
+	"fmt"
+	"io"
+	"log"
+	"os"
+	"time"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/backup"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+)
+
+// runCommand runs a maintenance subcommand instead of the server
+func runCommand(name string, args []string, backups repository.BackupRepositoryInterface) error {
+	switch name {
+	case "backup":
+		return runBackup(args, backups)
+	case "restore":
+		return runRestore(args, backups)
+	default:
+		return fmt.Errorf("unknown command %q, expected backup or restore", name)
+	}
+}
+
+// runBackup writes an archive of every table: backup [-o file]
+func runBackup(args []string, backups repository.BackupRepositoryInterface) error {
+	now := time.Now()
+	flags := flag.NewFlagSet("backup", flag.ExitOnError)
+	output := flags.String("o", fmt.Sprintf("backup-%s.tar.gz", now.UTC().Format("20060102-150405")), "archive to write, or - for stdout")
+	flags.Parse(args)
+
+	snapshot, err := backups.BeginBackup()
+	if err != nil {
+		return err
+	}
+
+	var w io.Writer = os.Stdout
+	if *output != "-" {
+		file, err := os.Create(*output)
+		if err != nil {
+			snapshot.Close()
+			return err
+		}
+		defer file.Close()
+		w = file
+	}
+
+	manifest, err := backup.Export(w, snapshot, now)
+	if err != nil {
+		return err
+	}
+
+	for _, table := range manifest.Tables {
+		log.Printf("Backed up %d rows from %s", table.Rows, table.Name)
+	}
+	if *output != "-" {
+		log.Printf("Wrote %s", *output)
+	}
+	return nil
+}
+
+// runRestore restores an archive: restore [-strategy fail|skip|overwrite|copy] file
+func runRestore(args []string, backups repository.BackupRepositoryInterface) error {
+	flags := flag.NewFlagSet("restore", flag.ExitOnError)
+	strategy := flags.String("strategy", string(repository.ConflictFail), "what to do with rows whose id is taken: fail, skip, overwrite or copy")
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "usage: restore [-strategy fail|skip|overwrite|copy] <archive|->")
+		flags.PrintDefaults()
+	}
+	flags.Parse(args)
+
+	if flags.NArg() != 1 {
+		flags.Usage()
+		os.Exit(2)
+	}
+	if !repository.ValidConflictStrategy(repository.ConflictStrategy(*strategy)) {
+		return fmt.Errorf("invalid strategy %q", *strategy)
+	}
+
+	var r io.Reader = os.Stdin
+	if path := flags.Arg(0); path != "-" {
+		file, err := os.Open(path)
+		if err != nil {
+			return err
+		}
+		defer file.Close()
+		r = file
+	}
+
+	report, err := backup.Restore(r, backups, repository.ConflictStrategy(*strategy))
+	if err != nil {
+		return err
+	}
+
+	summary, _ := json.MarshalIndent(report.Tables, "", "  ")
+	log.Printf("Restored backup from %s:\n%s", report.Manifest.CreatedAt.Format(time.RFC3339), summary)
+	return nil
+}
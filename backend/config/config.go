@@ -1,24 +1,181 @@
+// Package config holds the backend's settings. Each setting has a key in the config
+// file, an environment variable and a flag, see Load; the struct tags below name them.
 package config
 
 import (
-	"log"
-	"os"
+	"time"
 
-	"github.com/joho/godotenv"
+	"github.com/jennaborowy/fullstack-Go-Docker/ratelimit"
 )
 
+// Storage backends selectable with STORAGE
+const (
+	StoragePostgres = "postgres"
+	StorageSQLite   = "sqlite"
+	StorageMemory   = "memory" // nothing is saved when the server stops
+)
+
+// Rate limit stores selectable with RATE_LIMIT_STORE
+const (
+	RateLimitStoreMemory   = "memory"   // each replica limits on its own
+	RateLimitStorePostgres = "postgres" // replicas share the limits, needs STORAGE=postgres
+)
+
+// Log formats selectable with LOG_FORMAT
+const (
+	LogText = "text"
+	LogJSON = "json"
+)
+
+// Config is the backend's configuration. Settings are grouped in sections, which are
+// the tables of the config file.
 type Config struct {
-	DatabaseURL string
-	Port        string
+	Server    ServerConfig    `key:"server"`
+	Database  DatabaseConfig  `key:"database"`
+	Cache     CacheConfig     `key:"cache"`
+	Log       LogConfig       `key:"log"`
+	Auth      AuthConfig      `key:"auth"`
+	CORS      CORSConfig      `key:"cors"`
+	Security  SecurityConfig  `key:"security"`
+	RateLimit RateLimitConfig `key:"rate_limit"`
+	Features  FeatureFlags    `key:"features"`
 }
 
-func Load() *Config {
-	if err := godotenv.Load("../config.env"); err != nil {
-		log.Println("No config.env file found")
-	}
+// ServerConfig is where the APIs listen, and how long the REST API waits on clients
+type ServerConfig struct {
+	Port              string        `key:"port" env:"PORT" help:"port the REST API listens on"`
+	GRPCPort          string        `key:"grpc_port" env:"GRPC_PORT" help:"port the gRPC API listens on"`
+	ReadHeaderTimeout time.Duration `key:"read_header_timeout" env:"READ_HEADER_TIMEOUT" help:"how long a client may take to send the request headers"`
+	ReadTimeout       time.Duration `key:"read_timeout" env:"READ_TIMEOUT" help:"how long a client may take to send the whole request, 0 for no limit"`
+	// WriteTimeout is off by default: streamed item lists and backups take as long as
+	// the client takes to read them
+	WriteTimeout time.Duration `key:"write_timeout" env:"WRITE_TIMEOUT" help:"how long a response may take to write, 0 for no limit"`
+	IdleTimeout  time.Duration `key:"idle_timeout" env:"IDLE_TIMEOUT" help:"how long an idle keep-alive connection is kept open"`
+	// Replicas is how many backends share the database. An in-process cache only sees
+	// its own replica's writes, so more than one needs Redis or the cache off.
+	Replicas int `key:"replicas" env:"REPLICAS" help:"how many backends share the database"`
+}
+
+// DatabaseConfig picks the storage backend and sizes the Postgres connection pool
+type DatabaseConfig struct {
+	Storage         string        `key:"storage" env:"STORAGE" help:"where data is kept: postgres, sqlite or memory"`
+	URL             string        `key:"url" env:"DATABASE_URL" secret:"url" help:"Postgres URL, needed with postgres storage"`
+	SQLitePath      string        `key:"sqlite_path" env:"SQLITE_PATH" help:"SQLite database file, created on first run"`
+	MaxOpenConns    int           `key:"max_open_conns" env:"DB_MAX_OPEN_CONNS" help:"most Postgres connections open at once, 0 for no limit"`
+	MaxIdleConns    int           `key:"max_idle_conns" env:"DB_MAX_IDLE_CONNS" help:"most idle Postgres connections kept"`
+	ConnMaxLifetime time.Duration `key:"conn_max_lifetime" env:"DB_CONN_MAX_LIFETIME" help:"how long a Postgres connection is reused, 0 for ever"`
+}
+
+// CacheConfig is the read-through cache of lists and items
+type CacheConfig struct {
+	TTL      time.Duration `key:"ttl" env:"CACHE_TTL" help:"how long reads are cached, 0 turns the cache off"`
+	Size     int           `key:"size" env:"CACHE_SIZE" help:"most entries cached in process"`
+	RedisURL string        `key:"redis_url" env:"REDIS_URL" secret:"url" help:"cache in this Redis server instead, such as redis://host:6379/0"`
+}
+
+// LogConfig is how the backend logs
+type LogConfig struct {
+	Level    string `key:"level" env:"LOG_LEVEL" help:"least severe level logged: debug, info, warn or error"`
+	Format   string `key:"format" env:"LOG_FORMAT" help:"text or json"`
+	Requests bool   `key:"requests" env:"LOG_REQUESTS" help:"log every request"`
+}
+
+// AuthConfig holds the credentials the API checks
+type AuthConfig struct {
+	AdminToken string `key:"admin_token" env:"ADMIN_TOKEN" secret:"true" help:"bearer token enabling the /api/admin endpoints"`
+}
+
+// CORSConfig decides which browser origins may call the API
+type CORSConfig struct {
+	// AllowedOrigins are the origins allowed, such as http://localhost:5173 (the default
+	// for the frontend's dev server). * allows any origin, but not with AllowCredentials.
+	AllowedOrigins   []string      `key:"allowed_origins" env:"CORS_ALLOWED_ORIGINS" help:"origins browsers may call the API from, or *"`
+	AllowedMethods   []string      `key:"allowed_methods" env:"CORS_ALLOWED_METHODS" help:"methods browsers may use"`
+	AllowedHeaders   []string      `key:"allowed_headers" env:"CORS_ALLOWED_HEADERS" help:"request headers browsers may send"`
+	MaxAge           time.Duration `key:"max_age" env:"CORS_MAX_AGE" help:"how long browsers may cache a preflight"`
+	AllowCredentials bool          `key:"allow_credentials" env:"CORS_ALLOW_CREDENTIALS" help:"let browsers send cookies along"`
+}
+
+// SecurityConfig holds the security headers sent with every response. Empty or off
+// leaves the header out.
+type SecurityConfig struct {
+	HSTSMaxAge            time.Duration `key:"hsts_max_age" env:"HSTS_MAX_AGE" help:"Strict-Transport-Security max-age, only sent over HTTPS; 0 turns it off"`
+	ContentSecurityPolicy string        `key:"content_security_policy" env:"CONTENT_SECURITY_POLICY" help:"Content-Security-Policy header, or off"`
+	FrameOptions          string        `key:"frame_options" env:"FRAME_OPTIONS" help:"X-Frame-Options header, or off"`
+}
+
+// RateLimitConfig limits how often each client may call the API
+type RateLimitConfig struct {
+	Limit  string   `key:"limit" env:"RATE_LIMIT" help:"requests each client may make, such as 300/1m, or off"`
+	Routes []string `key:"routes" env:"RATE_LIMIT_ROUTES" help:"limits of their own for some routes, such as POST /items=10/1m"`
+	Key    string   `key:"key" env:"RATE_LIMIT_KEY" help:"count requests by ip, user or api_key"`
+	Store  string   `key:"store" env:"RATE_LIMIT_STORE" help:"keep limits in memory, or in postgres to share them between replicas"`
+
+	// Limit and Routes as parsed by Validate
+	ParsedLimit  ratelimit.Limit  `key:"-"`
+	ParsedRoutes []ratelimit.Rule `key:"-"`
+}
+
+// FeatureFlags turn optional parts of the backend on and off
+type FeatureFlags struct {
+	GraphQL     bool `key:"graphql" env:"FEATURE_GRAPHQL" help:"serve the GraphQL API at /graphql"`
+	GRPC        bool `key:"grpc" env:"FEATURE_GRPC" help:"serve the gRPC API"`
+	Docs        bool `key:"docs" env:"FEATURE_DOCS" help:"serve the OpenAPI document and reference page"`
+	Compression bool `key:"compression" env:"FEATURE_COMPRESSION" help:"compress responses"`
+	// OpenAPIValidation checks traffic against the OpenAPI document: off, requests, or
+	// all to check responses too, for development
+	OpenAPIValidation string `key:"openapi_validation" env:"OPENAPI_VALIDATION" help:"check traffic against the OpenAPI document: off, requests or all"`
+}
 
+// Default returns the configuration used for whatever isn't set
+func Default() *Config {
 	return &Config{
-		DatabaseURL: os.Getenv("DATABASE_URL"),
-		Port:        os.Getenv("PORT"),
+		Server: ServerConfig{
+			Port:              "8080",
+			GRPCPort:          "9090",
+			ReadHeaderTimeout: 10 * time.Second,
+			ReadTimeout:       time.Minute,
+			IdleTimeout:       2 * time.Minute,
+			Replicas:          1,
+		},
+		Database: DatabaseConfig{
+			Storage:      StoragePostgres,
+			SQLitePath:   "notes.db",
+			MaxOpenConns: 25,
+			MaxIdleConns: 5,
+		},
+		Cache: CacheConfig{
+			TTL:  time.Minute,
+			Size: 10000,
+		},
+		Log: LogConfig{
+			Level:    "info",
+			Format:   LogText,
+			Requests: true,
+		},
+		CORS: CORSConfig{
+			AllowedOrigins: []string{"http://localhost:5173"},
+			AllowedMethods: []string{"GET", "POST", "PUT", "DELETE"},
+			AllowedHeaders: []string{"Content-Type", "Authorization", "X-User"},
+			MaxAge:         10 * time.Minute,
+		},
+		Security: SecurityConfig{
+			HSTSMaxAge:            365 * 24 * time.Hour,
+			ContentSecurityPolicy: "default-src 'none'; frame-ancestors 'none'",
+			FrameOptions:          "DENY",
+		},
+		RateLimit: RateLimitConfig{
+			Limit:       "300/1m",
+			Key:         "ip",
+			Store:       RateLimitStoreMemory,
+			ParsedLimit: ratelimit.Limit{Requests: 300, Period: time.Minute},
+		},
+		Features: FeatureFlags{
+			GraphQL:           true,
+			GRPC:              true,
+			Docs:              true,
+			Compression:       true,
+			OpenAPIValidation: "off",
+		},
 	}
 }
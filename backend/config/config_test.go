@@ -0,0 +1,263 @@
+package config_test
+
+import (
+	"os"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/config"
+	"github.com/jennaborowy/fullstack-Go-Docker/ratelimit"
+)
+
+// writeFile writes content to name in a temporary directory and returns its path
+func writeFile(t *testing.T, name, content string) string {
+	t.Helper()
+	path := filepath.Join(t.TempDir(), name)
+	if err := os.WriteFile(path, []byte(content), 0o600); err != nil {
+		t.Fatalf("failed to write %s: %v", name, err)
+	}
+	return path
+}
+
+// load runs config.Load without reading ../config.env
+func load(t *testing.T, args ...string) (*config.Config, []string, error) {
+	t.Helper()
+	return config.Load(append([]string{"-env-file", writeFile(t, "empty.env", "")}, args...))
+}
+
+func TestLoadPrecedence(t *testing.T) {
+	file := writeFile(t, "config.yaml", `
+server:
+  port: "1111"
+  read_timeout: 30s
+database:
+  storage: memory
+cache:
+  size: 5
+`)
+	t.Setenv("PORT", "2222")
+	t.Setenv("CACHE_SIZE", "6")
+
+	cfg, args, err := load(t, "-config", file, "-server.port", "3333", "-log.requests=false", "backup", "-o", "out.tar.gz")
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+
+	if cfg.Server.Port != "3333" {
+		t.Errorf("expected the flag to win, got port %q", cfg.Server.Port)
+	}
+	if cfg.Cache.Size != 6 {
+		t.Errorf("expected the environment to beat the file, got cache size %d", cfg.Cache.Size)
+	}
+	if cfg.Server.ReadTimeout != 30*time.Second || cfg.Database.Storage != config.StorageMemory {
+		t.Errorf("expected the file's settings, got %+v %+v", cfg.Server, cfg.Database)
+	}
+	if cfg.Server.IdleTimeout != config.Default().Server.IdleTimeout || cfg.Database.MaxOpenConns != 25 {
+		t.Errorf("expected the defaults for what isn't set, got %+v %+v", cfg.Server, cfg.Database)
+	}
+	if cfg.Log.Requests {
+		t.Error("expected -log.requests=false to turn request logging off")
+	}
+	if !reflect.DeepEqual(args, []string{"backup", "-o", "out.tar.gz"}) {
+		t.Errorf("expected the command's arguments to be left, got %q", args)
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	yaml := `
+database:
+  storage: sqlite
+  sqlite_path: /data/notes.db
+cors:
+  allowed_origins:
+    - https://notes.example.com
+    - http://localhost:5173
+  max_age: 0
+rate_limit:
+  routes: POST /items=10/1m, GET /search=off
+features:
+  graphql: false
+`
+	toml := `
+[database]
+storage = "sqlite"
+sqlite_path = "/data/notes.db"
+
+[cors]
+allowed_origins = ["https://notes.example.com", "http://localhost:5173"]
+max_age = "0s"
+
+[rate_limit]
+routes = ["POST /items=10/1m", "GET /search=off"]
+
+[features]
+graphql = false
+`
+
+	for name, content := range map[string]string{"config.yaml": yaml, "config.toml": toml} {
+		t.Run(name, func(t *testing.T) {
+			cfg, _, err := load(t, "-config", writeFile(t, name, content))
+			if err != nil {
+				t.Fatalf("Load: %v", err)
+			}
+
+			expected := config.Default()
+			expected.Database.Storage = config.StorageSQLite
+			expected.Database.SQLitePath = "/data/notes.db"
+			expected.CORS.AllowedOrigins = []string{"https://notes.example.com", "http://localhost:5173"}
+			expected.CORS.MaxAge = 0
+			expected.RateLimit.Routes = []string{"POST /items=10/1m", "GET /search=off"}
+			expected.RateLimit.ParsedRoutes = []ratelimit.Rule{
+				{Method: "POST", Path: "/items", Limit: ratelimit.Limit{Requests: 10, Period: time.Minute}},
+				{Method: "GET", Path: "/search"},
+			}
+			expected.Features.GraphQL = false
+			if !reflect.DeepEqual(cfg, expected) {
+				t.Errorf("expected %+v, got %+v", expected, cfg)
+			}
+		})
+	}
+}
+
+func TestLoadEnvFile(t *testing.T) {
+	// restored when the test ends, as the env file sets them for the whole process
+	t.Setenv("STORAGE", "")
+	t.Setenv("CACHE_TTL", "")
+	t.Setenv("CACHE_SIZE", "7")
+	os.Unsetenv("STORAGE")
+	os.Unsetenv("CACHE_TTL")
+
+	cfg, _, err := config.Load([]string{"-env-file", writeFile(t, "config.env", "STORAGE=memory\nCACHE_TTL=5m\nCACHE_SIZE=8\n")})
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if cfg.Database.Storage != config.StorageMemory || cfg.Cache.TTL != 5*time.Minute {
+		t.Errorf("expected the env file's settings, got %+v %+v", cfg.Database, cfg.Cache)
+	}
+	if cfg.Cache.Size != 7 {
+		t.Errorf("expected the environment to beat the env file, got cache size %d", cfg.Cache.Size)
+	}
+
+	if _, _, err := config.Load([]string{"-env-file", filepath.Join(t.TempDir(), "missing.env")}); err == nil {
+		t.Error("expected a missing env file to be an error when asked for")
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tests := []struct {
+		name     string
+		file     string // config.yaml, when set
+		env      map[string]string
+		args     []string
+		expected []string // each must be in the error
+	}{
+		{
+			name:     "postgres without a URL",
+			env:      map[string]string{"STORAGE": "", "DATABASE_URL": ""},
+			expected: []string{"database.url (DATABASE_URL): required with postgres storage"},
+		},
+		{
+			name: "every problem at once",
+			env:  map[string]string{"CACHE_TTL": "soon", "DB_MAX_OPEN_CONNS": "many"},
+			args: []string{"-server.port", "http", "-log.requests=maybe"},
+			expected: []string{
+				`CACHE_TTL: invalid duration "soon"`,
+				`DB_MAX_OPEN_CONNS: invalid number "many"`,
+				`server.port (PORT): invalid port "http"`,
+				`-log.requests: invalid boolean "maybe"`,
+			},
+		},
+		{
+			name: "invalid values",
+			args: []string{
+				"-database.storage", "sqlite", "-database.sqlite_path", "",
+				"-database.max_open_conns", "2", "-database.max_idle_conns", "3",
+				"-server.read_timeout", "-1s",
+				"-log.level", "loud",
+				"-cors.allowed_origins", "*,notes.example.com", "-cors.allow_credentials",
+				"-rate_limit.limit", "lots", "-rate_limit.store", "postgres",
+				"-features.openapi_validation", "strict",
+			},
+			expected: []string{
+				"database.sqlite_path (SQLITE_PATH): required with sqlite storage",
+				"database.max_idle_conns (DB_MAX_IDLE_CONNS): must not be more than database.max_open_conns (2)",
+				"server.read_timeout (READ_TIMEOUT) must not be negative",
+				`log.level (LOG_LEVEL): invalid value "loud", expected debug, info, warn, error`,
+				"cors.allowed_origins (CORS_ALLOWED_ORIGINS): can't be * with cors.allow_credentials",
+				`cors.allowed_origins (CORS_ALLOWED_ORIGINS): invalid origin "notes.example.com"`,
+				`rate_limit.limit (RATE_LIMIT): invalid rate limit "lots"`,
+				"rate_limit.store (RATE_LIMIT_STORE): postgres needs postgres storage",
+				`features.openapi_validation (OPENAPI_VALIDATION): invalid value "strict"`,
+			},
+		},
+		{
+			name:     "replicas with an in-process cache",
+			env:      map[string]string{"REPLICAS": "3"},
+			expected: []string{"cache.redis_url (REDIS_URL): required with more than one replica"},
+		},
+		{
+			name: "file problems",
+			file: "database:\n  storage: memory\n  pool: 10\nserver:\n  port: [1, 2]\nlogging: debug\n",
+			expected: []string{
+				"unknown setting database.pool",
+				"server.port: expected a single value, got a list",
+				"logging: expected a section of settings",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			for name, value := range tt.env {
+				t.Setenv(name, value)
+			}
+			args := tt.args
+			if tt.file != "" {
+				args = append([]string{"-config", writeFile(t, "config.yaml", tt.file)}, args...)
+			}
+
+			_, _, err := load(t, args...)
+			if err == nil {
+				t.Fatal("expected an error")
+			}
+			for _, expected := range tt.expected {
+				if !strings.Contains(err.Error(), expected) {
+					t.Errorf("expected the error to contain %q, got:\n%v", expected, err)
+				}
+			}
+		})
+	}
+}
+
+func TestString(t *testing.T) {
+	cfg := config.Default()
+	cfg.Database.URL = "postgres://notes:hunter2@db:5432/notes?sslmode=disable"
+	cfg.Cache.RedisURL = "redis://cache:6379/0?password=swordfish"
+	cfg.Auth.AdminToken = "s3cret"
+
+	printed := cfg.String()
+	for _, secret := range []string{"hunter2", "swordfish", "s3cret"} {
+		if strings.Contains(printed, secret) {
+			t.Errorf("expected %q to be redacted:\n%s", secret, printed)
+		}
+	}
+	for _, expected := range []string{"postgres://notes:xxxxx@db:5432/notes", `admin_token: "[redacted]"`, "read_timeout: 1m0s"} {
+		if !strings.Contains(printed, expected) {
+			t.Errorf("expected %q in:\n%s", expected, printed)
+		}
+	}
+
+	// what is printed reads back as the same configuration, but for the secrets
+	cfg.Database.URL, cfg.Cache.RedisURL, cfg.Auth.AdminToken = "", "", ""
+	cfg.Database.Storage = config.StorageMemory
+	read, _, err := load(t, "-config", writeFile(t, "config.yaml", cfg.String()))
+	if err != nil {
+		t.Fatalf("Load: %v", err)
+	}
+	if !reflect.DeepEqual(read, cfg) {
+		t.Errorf("expected %+v, got %+v", cfg, read)
+	}
+}
@@ -0,0 +1,251 @@
+package config
+
+import (
+	"errors"
+	"flag"
+	"fmt"
+	"io/fs"
+	"os"
+	"path/filepath"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+	"github.com/joho/godotenv"
+	"github.com/pelletier/go-toml/v2"
+)
+
+// defaultEnvFile is read when -env-file isn't given, if it exists: the config.env Docker
+// Compose reads, for running the backend from its directory
+const defaultEnvFile = "../config.env"
+
+// Load resolves the configuration from, in increasing precedence, the defaults, the
+// YAML or TOML file given with -config or CONFIG_FILE, the environment and the flags in
+// args, then validates it. It returns the arguments left after the flags. Every
+// problem found is reported at once.
+//
+// Each setting is named after its section and key: database.max_open_conns is the
+// max_open_conns key of the file's database section, and the -database.max_open_conns
+// flag. Environment variables are listed by -h.
+func Load(args []string) (*Config, []string, error) {
+	cfg := Default()
+	list := settings(cfg)
+
+	flags := flag.NewFlagSet("backend", flag.ContinueOnError)
+	configPath := flags.String("config", "", "YAML or TOML config file (CONFIG_FILE)")
+	envFile := flags.String("env-file", "", "file of KEY=value environment variables, which don't override the environment (default "+defaultEnvFile+" if it exists)")
+	flagged := map[string]string{}
+	for _, s := range list {
+		usage := fmt.Sprintf("%s (%s, default %v)", s.help, s.env, s.format(false))
+		record := func(value string) error {
+			flagged[s.key] = value
+			return nil
+		}
+		if s.value.Kind() == reflect.Bool {
+			flags.BoolFunc(s.key, usage, record)
+		} else {
+			flags.Func(s.key, usage, record)
+		}
+	}
+	flags.Usage = func() {
+		fmt.Fprintln(flags.Output(), "Usage: backend [flags] [command [args]]")
+		fmt.Fprintln(flags.Output(), "Commands: backup, restore, config (prints the configuration). Without one the server runs.")
+		flags.PrintDefaults()
+	}
+	if err := flags.Parse(args); err != nil {
+		return nil, nil, err
+	}
+
+	var errs []error
+	if *envFile != "" {
+		if err := godotenv.Load(*envFile); err != nil {
+			return nil, nil, fmt.Errorf("reading env file: %w", err)
+		}
+	} else if err := godotenv.Load(defaultEnvFile); err != nil && !errors.Is(err, fs.ErrNotExist) {
+		return nil, nil, fmt.Errorf("reading env file: %w", err)
+	}
+
+	path := *configPath
+	if path == "" {
+		path = os.Getenv("CONFIG_FILE")
+	}
+	if path != "" {
+		if err := loadFile(path, list); err != nil {
+			errs = append(errs, err)
+		}
+	}
+
+	for _, s := range list {
+		if value := os.Getenv(s.env); value != "" {
+			if err := s.set(value); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", s.env, err))
+			}
+		}
+	}
+	for _, s := range list {
+		if value, ok := flagged[s.key]; ok {
+			if err := s.set(value); err != nil {
+				errs = append(errs, fmt.Errorf("-%s: %w", s.key, err))
+			}
+		}
+	}
+
+	// settings that couldn't be read keep their previous values, which are checked too
+	if err := cfg.validate(); err != nil {
+		errs = append(errs, err)
+	}
+	if len(errs) > 0 {
+		return nil, nil, fmt.Errorf("invalid configuration:\n%w", errors.Join(errs...))
+	}
+	return cfg, flags.Args(), nil
+}
+
+// setting is one setting of a Config, described by its struct tags
+type setting struct {
+	key    string // section.key
+	env    string
+	help   string
+	secret string // true to hide the value when printed, url to hide a URL's password
+	value  reflect.Value
+}
+
+// settings lists the settings of cfg in order. Their values point into cfg.
+func settings(cfg *Config) []setting {
+	var list []setting
+	sections := reflect.ValueOf(cfg).Elem()
+	for i := range sections.NumField() {
+		section := sections.Type().Field(i)
+		for j := range section.Type.NumField() {
+			field := section.Type.Field(j)
+			if field.Tag.Get("key") == "-" {
+				continue // derived from other settings
+			}
+			list = append(list, setting{
+				key:    section.Tag.Get("key") + "." + field.Tag.Get("key"),
+				env:    field.Tag.Get("env"),
+				help:   field.Tag.Get("help"),
+				secret: field.Tag.Get("secret"),
+				value:  sections.Field(i).Field(j),
+			})
+		}
+	}
+	return list
+}
+
+var durationType = reflect.TypeFor[time.Duration]()
+
+// set parses value, as given in the environment or a flag. Lists are comma-separated.
+func (s setting) set(value string) error {
+	v := s.value
+	switch {
+	case v.Type() == durationType:
+		d, err := time.ParseDuration(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("invalid duration %q, expected one such as 30s or 10m", value)
+		}
+		v.SetInt(int64(d))
+	case v.Kind() == reflect.Int:
+		n, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("invalid number %q", value)
+		}
+		v.SetInt(int64(n))
+	case v.Kind() == reflect.Bool:
+		b, err := strconv.ParseBool(strings.TrimSpace(value))
+		if err != nil {
+			return fmt.Errorf("invalid boolean %q, expected true or false", value)
+		}
+		v.SetBool(b)
+	case v.Kind() == reflect.Slice:
+		var values []string
+		for _, part := range strings.Split(value, ",") {
+			if part = strings.TrimSpace(part); part != "" {
+				values = append(values, part)
+			}
+		}
+		v.Set(reflect.ValueOf(values))
+	default:
+		v.SetString(value)
+	}
+	return nil
+}
+
+// setFile sets a value decoded from a config file. Lists may be given as such, or as a
+// comma-separated string.
+func (s setting) setFile(value any) error {
+	switch value := value.(type) {
+	case nil:
+		return nil
+	case map[string]any:
+		return errors.New("expected a value, got a section")
+	case []any:
+		if s.value.Kind() != reflect.Slice {
+			return errors.New("expected a single value, got a list")
+		}
+		var values []string
+		for _, v := range value {
+			values = append(values, fmt.Sprint(v))
+		}
+		s.value.Set(reflect.ValueOf(values))
+		return nil
+	default:
+		return s.set(fmt.Sprint(value))
+	}
+}
+
+// loadFile sets the settings in the config file at path, by its extension YAML or TOML
+func loadFile(path string, list []setting) error {
+	data, err := os.ReadFile(path)
+	if err != nil {
+		return fmt.Errorf("reading config file: %w", err)
+	}
+
+	var values map[string]any
+	switch strings.ToLower(filepath.Ext(path)) {
+	case ".yaml", ".yml":
+		err = yaml.Unmarshal(data, &values)
+	case ".toml":
+		err = toml.Unmarshal(data, &values)
+	default:
+		return fmt.Errorf("config file %s must be .yaml, .yml or .toml", path)
+	}
+	if err != nil {
+		return fmt.Errorf("reading config file %s: %w", path, err)
+	}
+
+	byKey := make(map[string]setting, len(list))
+	for _, s := range list {
+		byKey[s.key] = s
+	}
+	var errs []error
+	for _, name := range sortedKeys(values) {
+		section, ok := values[name].(map[string]any)
+		if !ok {
+			errs = append(errs, fmt.Errorf("%s: %s: expected a section of settings", path, name))
+			continue
+		}
+		for _, key := range sortedKeys(section) {
+			s, ok := byKey[name+"."+key]
+			if !ok {
+				errs = append(errs, fmt.Errorf("%s: unknown setting %s.%s", path, name, key))
+				continue
+			}
+			if err := s.setFile(section[key]); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %s: %w", path, s.key, err))
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+func sortedKeys(m map[string]any) []string {
+	keys := make([]string, 0, len(m))
+	for key := range m {
+		keys = append(keys, key)
+	}
+	slices.Sort(keys)
+	return keys
+}
@@ -0,0 +1,69 @@
+package config
+
+import (
+	"net/url"
+	"strings"
+	"time"
+
+	"github.com/goccy/go-yaml"
+)
+
+// redacted replaces secrets when the configuration is printed
+const redacted = "[redacted]"
+
+// String renders the configuration as a YAML config file Load can read back, with
+// secrets redacted
+func (c *Config) String() string {
+	var doc yaml.MapSlice
+	for _, s := range settings(c) {
+		name, key, _ := strings.Cut(s.key, ".")
+		if len(doc) == 0 || doc[len(doc)-1].Key != name {
+			doc = append(doc, yaml.MapItem{Key: name, Value: yaml.MapSlice{}})
+		}
+		section := &doc[len(doc)-1]
+		section.Value = append(section.Value.(yaml.MapSlice), yaml.MapItem{Key: key, Value: s.format(true)})
+	}
+
+	out, err := yaml.Marshal(doc)
+	if err != nil {
+		return err.Error()
+	}
+	return string(out)
+}
+
+// format returns the setting's value as it is written in a config file, with secrets
+// redacted if asked to
+func (s setting) format(redact bool) any {
+	value := s.value.Interface()
+	switch v := value.(type) {
+	case time.Duration:
+		return v.String()
+	case string:
+		if redact && v != "" {
+			switch s.secret {
+			case "true":
+				return redacted
+			case "url":
+				return RedactURL(v)
+			}
+		}
+	}
+	return value
+}
+
+// RedactURL hides the password in a URL, whether in its user info or its query, so it can
+// be logged. Anything that isn't a URL is hidden whole.
+func RedactURL(s string) string {
+	if s == "" {
+		return ""
+	}
+	u, err := url.Parse(s)
+	if err != nil || u.Scheme == "" {
+		return redacted
+	}
+	if query := u.Query(); query.Has("password") {
+		query.Set("password", "xxxxx")
+		u.RawQuery = query.Encode()
+	}
+	return u.Redacted()
+}
@@ -0,0 +1,115 @@
+package config
+
+import (
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"reflect"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/ratelimit"
+)
+
+// Validate checks that the settings make sense on their own and together, reporting
+// every problem at once. Each problem names the setting and its environment variable.
+// It also fills in the rate limits parsed from their settings.
+func (c *Config) Validate() error {
+	if err := c.validate(); err != nil {
+		return fmt.Errorf("invalid configuration:\n%w", err)
+	}
+	return nil
+}
+
+func (c *Config) validate() error {
+	names := map[string]string{}
+	var errs []error
+	for _, s := range settings(c) {
+		names[s.key] = fmt.Sprintf("%s (%s)", s.key, s.env)
+		switch s.value.Kind() {
+		case reflect.Int, reflect.Int64: // durations too
+			if s.value.Int() < 0 {
+				errs = append(errs, fmt.Errorf("%s must not be negative", names[s.key]))
+			}
+		}
+	}
+	fail := func(key, format string, args ...any) {
+		errs = append(errs, fmt.Errorf("%s: %s", names[key], fmt.Sprintf(format, args...)))
+	}
+	oneOf := func(key, value string, allowed ...string) {
+		if !slices.Contains(allowed, value) {
+			fail(key, "invalid value %q, expected %s", value, strings.Join(allowed, ", "))
+		}
+	}
+
+	for _, port := range []struct{ key, value string }{{"server.port", c.Server.Port}, {"server.grpc_port", c.Server.GRPCPort}} {
+		if n, err := strconv.Atoi(port.value); err != nil || n < 1 || n > 65535 {
+			fail(port.key, "invalid port %q, expected a number from 1 to 65535", port.value)
+		}
+	}
+	if c.Features.GRPC && c.Server.Port == c.Server.GRPCPort {
+		fail("server.grpc_port", "must differ from server.port")
+	}
+	if c.Server.Replicas < 1 {
+		fail("server.replicas", "must be at least 1")
+	}
+
+	oneOf("database.storage", c.Database.Storage, StoragePostgres, StorageSQLite, StorageMemory)
+	if c.Database.Storage == StoragePostgres && c.Database.URL == "" {
+		fail("database.url", "required with %s storage", StoragePostgres)
+	}
+	if c.Database.Storage == StorageSQLite && c.Database.SQLitePath == "" {
+		fail("database.sqlite_path", "required with %s storage", StorageSQLite)
+	}
+	if c.Database.MaxOpenConns > 0 && c.Database.MaxIdleConns > c.Database.MaxOpenConns {
+		fail("database.max_idle_conns", "must not be more than database.max_open_conns (%d)", c.Database.MaxOpenConns)
+	}
+
+	if c.Cache.TTL > 0 && c.Cache.Size < 1 {
+		fail("cache.size", "must be at least 1 while the cache is on")
+	}
+	if c.Server.Replicas > 1 && c.Cache.TTL > 0 && c.Cache.RedisURL == "" {
+		fail("cache.redis_url", "required with more than one replica while the cache is on, or set cache.ttl to 0")
+	}
+
+	oneOf("log.level", c.Log.Level, "debug", "info", "warn", "error")
+	oneOf("log.format", c.Log.Format, LogText, LogJSON)
+
+	for _, origin := range c.CORS.AllowedOrigins {
+		if origin == "*" {
+			if c.CORS.AllowCredentials {
+				fail("cors.allowed_origins", "can't be * with cors.allow_credentials, list the origins instead")
+			}
+			continue
+		}
+		u, err := url.Parse(origin)
+		if err != nil || (u.Scheme != "http" && u.Scheme != "https") || u.Host == "" || u.User != nil || (u.Path != "" && u.Path != "/") || u.RawQuery != "" {
+			fail("cors.allowed_origins", "invalid origin %q, expected a scheme and host such as https://notes.example.com", origin)
+		}
+	}
+	for _, method := range c.CORS.AllowedMethods {
+		oneOf("cors.allowed_methods", method, http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete)
+	}
+
+	if limit, err := ratelimit.ParseLimit(c.RateLimit.Limit); err != nil {
+		fail("rate_limit.limit", "%v", err)
+	} else {
+		c.RateLimit.ParsedLimit = limit
+	}
+	if rules, err := ratelimit.ParseRules(strings.Join(c.RateLimit.Routes, ",")); err != nil {
+		fail("rate_limit.routes", "%v", err)
+	} else {
+		c.RateLimit.ParsedRoutes = rules
+	}
+	oneOf("rate_limit.key", c.RateLimit.Key, "ip", "user", "api_key")
+	oneOf("rate_limit.store", c.RateLimit.Store, RateLimitStoreMemory, RateLimitStorePostgres)
+	if c.RateLimit.Store == RateLimitStorePostgres && c.Database.Storage != StoragePostgres {
+		fail("rate_limit.store", "%s needs %s storage", RateLimitStorePostgres, StoragePostgres)
+	}
+
+	oneOf("features.openapi_validation", c.Features.OpenAPIValidation, "off", "requests", "all")
+
+	return errors.Join(errs...)
+}
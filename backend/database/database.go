@@ -4,12 +4,20 @@ import (
 	"database/sql"
 	"fmt"
 	"log"
+	"time"
 
 	_ "github.com/lib/pq" // PostgreSQL driver
 )
 
-// Connect establishes a connection to the PostgreSQL database
-func Connect(databaseURL string) (*sql.DB, error) {
+// Pool sizes a connection pool. Zero values leave the database/sql defaults.
+type Pool struct {
+	MaxOpenConns    int
+	MaxIdleConns    int
+	ConnMaxLifetime time.Duration
+}
+
+// Connect establishes a connection to the PostgreSQL database, with a pool sized by pool
+func Connect(databaseURL string, pool Pool) (*sql.DB, error) {
 	// Open connection
 	db, err := sql.Open("postgres", databaseURL)
 	if err != nil {
@@ -23,9 +31,13 @@ func Connect(databaseURL string) (*sql.DB, error) {
 
 	log.Println("Successfully connected to PostgreSQL")
 
-	// Set connection pool settings (optional but recommended)
-	db.SetMaxOpenConns(25)
-	db.SetMaxIdleConns(5)
+	if pool.MaxOpenConns > 0 {
+		db.SetMaxOpenConns(pool.MaxOpenConns)
+	}
+	if pool.MaxIdleConns > 0 {
+		db.SetMaxIdleConns(pool.MaxIdleConns)
+	}
+	db.SetConnMaxLifetime(pool.ConnMaxLifetime)
 
 	return db, nil
 }
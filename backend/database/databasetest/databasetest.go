@@ -0,0 +1,22 @@
+// Package databasetest finds the Postgres database tests run against
+package databasetest
+
+import (
+	"os"
+	"testing"
+)
+
+// URL returns TEST_DATABASE_URL, the Postgres database tests create their tables in, such
+// as the postgres-test service of Docker Compose. Without it the test is skipped, unless
+// CI is set: CI starts a database for the tests, so there a missing one is a failure.
+func URL(t testing.TB) string {
+	t.Helper()
+	databaseURL := os.Getenv("TEST_DATABASE_URL")
+	if databaseURL == "" {
+		if os.Getenv("CI") != "" {
+			t.Fatal("TEST_DATABASE_URL must be set in CI")
+		}
+		t.Skip("TEST_DATABASE_URL is not set, start a database with: docker compose --profile test up -d postgres-test")
+	}
+	return databaseURL
+}
@@ -0,0 +1,75 @@
+package database
+
+import (
+	"database/sql"
+	"embed"
+	"fmt"
+	"io/fs"
+	"log"
+	"sort"
+	"strings"
+)
+
+//go:embed migrations/*.sql
+var migrationFiles embed.FS
+
+// Migrate applies the migrations in migrations/ that have not been applied yet, in filename order.
+// init.sql creates the base schema; migrations build on top of it.
+func Migrate(db *sql.DB) error {
+	if _, err := db.Exec(`CREATE TABLE IF NOT EXISTS schema_migrations (
+		version VARCHAR(255) PRIMARY KEY,
+		applied_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+	)`); err != nil {
+		return fmt.Errorf("error creating schema_migrations table: %w", err)
+	}
+
+	names, err := fs.Glob(migrationFiles, "migrations/*.sql")
+	if err != nil {
+		return fmt.Errorf("error listing migrations: %w", err)
+	}
+	sort.Strings(names)
+
+	for _, name := range names {
+		version := strings.TrimSuffix(strings.TrimPrefix(name, "migrations/"), ".sql")
+		if err := applyMigration(db, version, name); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// applyMigration runs a single migration in a transaction, unless it was already applied
+func applyMigration(db *sql.DB, version, name string) error {
+	var applied bool
+	if err := db.QueryRow("SELECT EXISTS (SELECT 1 FROM schema_migrations WHERE version = $1)", version).Scan(&applied); err != nil {
+		return fmt.Errorf("error checking migration %s: %w", version, err)
+	}
+	if applied {
+		return nil
+	}
+
+	contents, err := migrationFiles.ReadFile(name)
+	if err != nil {
+		return fmt.Errorf("error reading migration %s: %w", version, err)
+	}
+
+	tx, err := db.Begin()
+	if err != nil {
+		return fmt.Errorf("error starting migration %s: %w", version, err)
+	}
+	defer tx.Rollback()
+
+	if _, err := tx.Exec(string(contents)); err != nil {
+		return fmt.Errorf("error applying migration %s: %w", version, err)
+	}
+	if _, err := tx.Exec("INSERT INTO schema_migrations (version) VALUES ($1)", version); err != nil {
+		return fmt.Errorf("error recording migration %s: %w", version, err)
+	}
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("error committing migration %s: %w", version, err)
+	}
+
+	log.Printf("Applied migration %s", version)
+	return nil
+}
@@ -0,0 +1,16 @@
+-- Full-text search over item titles/content and list titles.
+-- Titles are weighted above content so title matches rank first.
+ALTER TABLE items ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', coalesce(title, '')), 'A') ||
+        setweight(to_tsvector('english', coalesce(content, '')), 'B')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS items_search_vector_idx ON items USING GIN (search_vector);
+
+ALTER TABLE lists ADD COLUMN IF NOT EXISTS search_vector tsvector
+    GENERATED ALWAYS AS (
+        setweight(to_tsvector('english', coalesce(title, '')), 'A')
+    ) STORED;
+
+CREATE INDEX IF NOT EXISTS lists_search_vector_idx ON lists USING GIN (search_vector);
@@ -0,0 +1,15 @@
+-- Track whether an item is done, so it can be filtered on.
+ALTER TABLE items ADD COLUMN IF NOT EXISTS completed BOOLEAN NOT NULL DEFAULT FALSE;
+
+CREATE INDEX IF NOT EXISTS items_item_date_idx ON items (item_date);
+
+CREATE TABLE IF NOT EXISTS smart_lists (
+    id SERIAL PRIMARY KEY,            -- matches SmartList.ID in Go
+    owner VARCHAR(255) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    filter JSONB NOT NULL,            -- matches models.ItemFilterDefinition
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    updated_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS smart_lists_owner_idx ON smart_lists (owner);
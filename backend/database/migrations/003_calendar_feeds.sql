@@ -0,0 +1,8 @@
+-- Subscribable iCalendar feeds, authenticated by an unguessable token.
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    id SERIAL PRIMARY KEY,            -- matches CalendarFeed.ID in Go
+    owner VARCHAR(255) NOT NULL,
+    token VARCHAR(64) NOT NULL UNIQUE,
+    list_id INT REFERENCES lists(id) ON DELETE CASCADE,  -- NULL for a feed of every list
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
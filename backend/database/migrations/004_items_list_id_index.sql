@@ -0,0 +1,2 @@
+-- Lists are read joined with their items, so look items up by list.
+CREATE INDEX IF NOT EXISTS items_list_id_idx ON items (list_id);
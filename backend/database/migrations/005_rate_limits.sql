@@ -0,0 +1,7 @@
+-- Rate limit buckets shared by every backend replica, see the ratelimit package. Each
+-- bucket is kept as the time it will be full again. Losing them in a crash only resets
+-- the limits, so the table isn't worth writing ahead.
+CREATE UNLOGGED TABLE IF NOT EXISTS rate_limits (
+    key TEXT PRIMARY KEY,             -- the route's bucket and the client
+    full_at TIMESTAMPTZ NOT NULL
+);
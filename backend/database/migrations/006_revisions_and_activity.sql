@@ -0,0 +1,26 @@
+-- Change history of items and lists, and the activity log of who changed what.
+-- Databases created before these were migrations already have them.
+CREATE TABLE IF NOT EXISTS revisions (
+    id SERIAL PRIMARY KEY,            -- matches Revision.ID in Go
+    entity_type VARCHAR(16) NOT NULL, -- 'item' or 'list'
+    entity_id INT NOT NULL,
+    revision INT NOT NULL,            -- per-entity revision number, starting at 1
+    author VARCHAR(255) NOT NULL DEFAULT '',
+    old_values JSONB NOT NULL,
+    new_values JSONB NOT NULL,
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP,
+    UNIQUE (entity_type, entity_id, revision)
+);
+
+CREATE TABLE IF NOT EXISTS activity (
+    id SERIAL PRIMARY KEY,            -- matches Activity.ID in Go
+    user_name VARCHAR(255) NOT NULL,
+    action VARCHAR(32) NOT NULL,      -- create, update, delete, revert, import
+    entity_type VARCHAR(16) NOT NULL, -- 'item' or 'list'
+    entity_id INT NOT NULL,
+    list_id INT NOT NULL,             -- no foreign key so entries outlive deleted lists
+    created_at TIMESTAMP DEFAULT CURRENT_TIMESTAMP
+);
+
+CREATE INDEX IF NOT EXISTS activity_list_id_idx ON activity (list_id, created_at);
+CREATE INDEX IF NOT EXISTS activity_user_name_idx ON activity (user_name, created_at);
@@ -0,0 +1,4 @@
+-- Moves are logged with the list the item left as well, so they show in both lists' feeds.
+ALTER TABLE activity ADD COLUMN IF NOT EXISTS from_list_id INT; -- no foreign key, like list_id
+
+CREATE INDEX IF NOT EXISTS activity_from_list_id_idx ON activity (from_list_id, created_at);
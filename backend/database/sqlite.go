@@ -0,0 +1,65 @@
+package database
+
+import (
+	"database/sql"
+	_ "embed"
+	"fmt"
+
+	_ "modernc.org/sqlite" // SQLite driver, pure Go so the image still builds without cgo
+)
+
+//go:embed sqlite.sql
+var sqliteSchema string
+
+// ConnectSQLite opens the SQLite database at path, creating it and its schema when needed.
+// A path of ":memory:" gives a private database that disappears when closed.
+func ConnectSQLite(path string) (*sql.DB, error) {
+	// foreign keys are off by default in SQLite; they are needed for ON DELETE CASCADE.
+	// Times are written as UTC text in a single format, so comparing them as text works.
+	db, err := sql.Open("sqlite", "file:"+path+"?_pragma=foreign_keys(1)&_pragma=busy_timeout(5000)&_time_format=sqlite&_timezone=UTC")
+	if err != nil {
+		return nil, fmt.Errorf("error opening database: %w", err)
+	}
+
+	// SQLite allows a single writer, and every connection to ":memory:" would get its own database
+	db.SetMaxOpenConns(1)
+
+	if _, err := db.Exec(sqliteSchema); err != nil {
+		db.Close()
+		return nil, fmt.Errorf("error creating schema: %w", err)
+	}
+	if err := upgradeSQLite(db); err != nil {
+		db.Close()
+		return nil, err
+	}
+
+	return db, nil
+}
+
+// addedColumns are the columns added to sqlite.sql since it was first released, which
+// CREATE TABLE IF NOT EXISTS leaves out of databases created before, and the indexes on them
+var addedColumns = []struct {
+	table, column, definition string
+	index                     string
+}{
+	{"activity", "from_list_id", "INT", "CREATE INDEX IF NOT EXISTS activity_from_list_id_idx ON activity (from_list_id, created_at)"},
+}
+
+// upgradeSQLite adds any of addedColumns a database is missing
+func upgradeSQLite(db *sql.DB) error {
+	for _, c := range addedColumns {
+		var exists bool
+		if err := db.QueryRow("SELECT COUNT(*) > 0 FROM pragma_table_info(?) WHERE name = ?", c.table, c.column).Scan(&exists); err != nil {
+			return fmt.Errorf("error checking %s.%s: %w", c.table, c.column, err)
+		}
+		if !exists {
+			if _, err := db.Exec(fmt.Sprintf("ALTER TABLE %s ADD COLUMN %s %s", c.table, c.column, c.definition)); err != nil {
+				return fmt.Errorf("error adding %s.%s: %w", c.table, c.column, err)
+			}
+		}
+		if _, err := db.Exec(c.index); err != nil {
+			return fmt.Errorf("error indexing %s.%s: %w", c.table, c.column, err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,95 @@
+-- The SQLite schema, matching init.sql and every migration applied to Postgres.
+-- It is applied on every start, so it must stay safe to run again.
+-- Times are stored as UTC text in the format the driver writes, so that they compare correctly.
+CREATE TABLE IF NOT EXISTS lists (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,  -- AUTOINCREMENT so IDs aren't reused, like SERIAL
+    title VARCHAR(255) NOT NULL,
+    created_at TIMESTAMP DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TIMESTAMP DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE TABLE IF NOT EXISTS items (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    title VARCHAR(255) NOT NULL,
+    content TEXT,
+    item_date DATE,
+    list_id INT REFERENCES lists(id) ON DELETE CASCADE,
+    completed BOOLEAN NOT NULL DEFAULT FALSE,
+    created_at TIMESTAMP DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TIMESTAMP DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE INDEX IF NOT EXISTS items_list_id_idx ON items (list_id);
+CREATE INDEX IF NOT EXISTS items_item_date_idx ON items (item_date);
+
+CREATE TABLE IF NOT EXISTS revisions (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    entity_type VARCHAR(16) NOT NULL,
+    entity_id INT NOT NULL,
+    revision INT NOT NULL,
+    author VARCHAR(255) NOT NULL DEFAULT '',
+    old_values TEXT NOT NULL,          -- JSON
+    new_values TEXT NOT NULL,          -- JSON
+    created_at TIMESTAMP DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    UNIQUE (entity_type, entity_id, revision)
+);
+
+CREATE TABLE IF NOT EXISTS activity (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    user_name VARCHAR(255) NOT NULL,
+    action VARCHAR(32) NOT NULL,
+    entity_type VARCHAR(16) NOT NULL,
+    entity_id INT NOT NULL,
+    list_id INT NOT NULL,              -- no foreign key so entries outlive deleted lists
+    from_list_id INT,                  -- the list a moved item left
+    created_at TIMESTAMP DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE INDEX IF NOT EXISTS activity_list_id_idx ON activity (list_id, created_at);
+CREATE INDEX IF NOT EXISTS activity_user_name_idx ON activity (user_name, created_at);
+
+CREATE TABLE IF NOT EXISTS smart_lists (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    owner VARCHAR(255) NOT NULL,
+    title VARCHAR(255) NOT NULL,
+    filter TEXT NOT NULL,              -- JSON of models.ItemFilterDefinition
+    created_at TIMESTAMP DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')),
+    updated_at TIMESTAMP DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+CREATE INDEX IF NOT EXISTS smart_lists_owner_idx ON smart_lists (owner);
+
+CREATE TABLE IF NOT EXISTS calendar_feeds (
+    id INTEGER PRIMARY KEY AUTOINCREMENT,
+    owner VARCHAR(255) NOT NULL,
+    token VARCHAR(64) NOT NULL UNIQUE,
+    list_id INT REFERENCES lists(id) ON DELETE CASCADE,
+    created_at TIMESTAMP DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now'))
+);
+
+-- Full-text search indexes, kept in sync with their tables by triggers.
+-- Titles are weighted above content when ranking, as in Postgres.
+CREATE VIRTUAL TABLE IF NOT EXISTS items_fts USING fts5(title, content, content='items', content_rowid='id', tokenize='porter');
+CREATE VIRTUAL TABLE IF NOT EXISTS lists_fts USING fts5(title, content='lists', content_rowid='id', tokenize='porter');
+
+CREATE TRIGGER IF NOT EXISTS items_fts_insert AFTER INSERT ON items BEGIN
+    INSERT INTO items_fts (rowid, title, content) VALUES (new.id, new.title, coalesce(new.content, ''));
+END;
+CREATE TRIGGER IF NOT EXISTS items_fts_delete AFTER DELETE ON items BEGIN
+    INSERT INTO items_fts (items_fts, rowid, title, content) VALUES ('delete', old.id, old.title, coalesce(old.content, ''));
+END;
+CREATE TRIGGER IF NOT EXISTS items_fts_update AFTER UPDATE OF title, content ON items BEGIN
+    INSERT INTO items_fts (items_fts, rowid, title, content) VALUES ('delete', old.id, old.title, coalesce(old.content, ''));
+    INSERT INTO items_fts (rowid, title, content) VALUES (new.id, new.title, coalesce(new.content, ''));
+END;
+
+CREATE TRIGGER IF NOT EXISTS lists_fts_insert AFTER INSERT ON lists BEGIN
+    INSERT INTO lists_fts (rowid, title) VALUES (new.id, new.title);
+END;
+CREATE TRIGGER IF NOT EXISTS lists_fts_delete AFTER DELETE ON lists BEGIN
+    INSERT INTO lists_fts (lists_fts, rowid, title) VALUES ('delete', old.id, old.title);
+END;
+CREATE TRIGGER IF NOT EXISTS lists_fts_update AFTER UPDATE OF title ON lists BEGIN
+    INSERT INTO lists_fts (lists_fts, rowid, title) VALUES ('delete', old.id, old.title);
+    INSERT INTO lists_fts (rowid, title) VALUES (new.id, new.title);
+END;
@@ -0,0 +1,284 @@
+// dto package holds the v2 API's response bodies. They are kept apart from the models,
+// so the database can change without changing the API, and are consistently snake_case.
+// Dates without a time are YYYY-MM-DD strings and collections are never null.
+package dto
+
+import (
+	"time"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/transfer"
+)
+
+const dateLayout = "2006-01-02"
+
+type Item struct {
+	ID        int       `json:"id"`
+	ListID    int       `json:"list_id"`
+	Title     string    `json:"title"`
+	Content   string    `json:"content"`
+	ItemDate  string    `json:"item_date" format:"date"`
+	Completed bool      `json:"completed"`
+	CreatedAt time.Time `json:"created_at"`
+	UpdatedAt time.Time `json:"updated_at"`
+}
+
+func FromItem(item *models.Item) Item {
+	return Item{
+		ID:        item.ID,
+		ListID:    item.ListID,
+		Title:     item.Title,
+		Content:   item.Content,
+		ItemDate:  item.Date.Format(dateLayout),
+		Completed: item.Completed,
+		CreatedAt: item.CreatedAt,
+		UpdatedAt: item.UpdatedAt,
+	}
+}
+
+func FromItems(items []models.Item) []Item {
+	out := make([]Item, len(items))
+	for i := range items {
+		out[i] = FromItem(&items[i])
+	}
+	return out
+}
+
+type List struct {
+	ID        int64       `json:"id"`
+	Title     string      `json:"title"`
+	CreatedAt time.Time   `json:"created_at"`
+	UpdatedAt time.Time   `json:"updated_at"`
+	Items     []Item      `json:"items"`
+	Counts    *ListCounts `json:"counts,omitempty"` // only with include=counts
+}
+
+type ListCounts struct {
+	Total     int `json:"total"`
+	Completed int `json:"completed"`
+}
+
+func FromList(list *models.List) List {
+	out := List{
+		ID:        list.ID,
+		Title:     list.Title,
+		CreatedAt: list.CreatedAt,
+		UpdatedAt: list.UpdatedAt,
+		Items:     FromItems(list.Items),
+	}
+	if list.Counts != nil {
+		out.Counts = &ListCounts{Total: list.Counts.Total, Completed: list.Counts.Completed}
+	}
+	return out
+}
+
+func FromLists(lists []models.List) []List {
+	out := make([]List, len(lists))
+	for i := range lists {
+		out[i] = FromList(&lists[i])
+	}
+	return out
+}
+
+type FieldChange struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+type Revision struct {
+	ID         int                    `json:"id"`
+	EntityType string                 `json:"entity_type" enum:"item,list"`
+	EntityID   int                    `json:"entity_id"`
+	Revision   int                    `json:"revision"`
+	Author     string                 `json:"author"`
+	Old        map[string]string      `json:"old"`
+	New        map[string]string      `json:"new"`
+	Changes    map[string]FieldChange `json:"changes"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+func FromRevision(revision *models.Revision) Revision {
+	changes := make(map[string]FieldChange, len(revision.Changes))
+	for field, change := range revision.Changes {
+		changes[field] = FieldChange{Old: change.Old, New: change.New}
+	}
+	return Revision{
+		ID:         revision.ID,
+		EntityType: revision.EntityType,
+		EntityID:   revision.EntityID,
+		Revision:   revision.Revision,
+		Author:     revision.Author,
+		Old:        nonNil(revision.Old),
+		New:        nonNil(revision.New),
+		Changes:    changes,
+		CreatedAt:  revision.CreatedAt,
+	}
+}
+
+func FromRevisions(revisions []models.Revision) []Revision {
+	out := make([]Revision, len(revisions))
+	for i := range revisions {
+		out[i] = FromRevision(&revisions[i])
+	}
+	return out
+}
+
+type Activity struct {
+	ID         int       `json:"id"`
+	User       string    `json:"user"`
+	Action     string    `json:"action" enum:"create,update,delete,revert,import,move"`
+	EntityType string    `json:"entity_type" enum:"item,list"`
+	EntityID   int       `json:"entity_id"`
+	ListID     int       `json:"list_id"`
+	FromListID int       `json:"from_list_id,omitempty"`
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+func FromActivities(activity []models.Activity) []Activity {
+	out := make([]Activity, len(activity))
+	for i, a := range activity {
+		out[i] = Activity{
+			ID:         a.ID,
+			User:       a.User,
+			Action:     a.Action,
+			EntityType: a.EntityType,
+			EntityID:   a.EntityID,
+			ListID:     a.ListID,
+			FromListID: a.FromListID,
+			CreatedAt:  a.CreatedAt,
+		}
+	}
+	return out
+}
+
+// ItemFilter is a smart list's filter, the same as the filter a smart list is created with
+type ItemFilter = models.ItemFilterDefinition
+
+type SmartList struct {
+	ID        int        `json:"id"`
+	Owner     string     `json:"owner"`
+	Title     string     `json:"title"`
+	Filter    ItemFilter `json:"filter"`
+	CreatedAt time.Time  `json:"created_at"`
+	UpdatedAt time.Time  `json:"updated_at"`
+}
+
+func FromSmartList(list *models.SmartList) SmartList {
+	return SmartList{
+		ID:        list.ID,
+		Owner:     list.Owner,
+		Title:     list.Title,
+		Filter:    list.Filter,
+		CreatedAt: list.CreatedAt,
+		UpdatedAt: list.UpdatedAt,
+	}
+}
+
+func FromSmartLists(lists []models.SmartList) []SmartList {
+	out := make([]SmartList, len(lists))
+	for i := range lists {
+		out[i] = FromSmartList(&lists[i])
+	}
+	return out
+}
+
+type CalendarFeed struct {
+	ID        int       `json:"id"`
+	Owner     string    `json:"owner"`
+	Token     string    `json:"token"`
+	ListID    *int      `json:"list_id"` // null for a feed of every list
+	CreatedAt time.Time `json:"created_at"`
+}
+
+func FromCalendarFeed(feed *models.CalendarFeed) CalendarFeed {
+	return CalendarFeed{ID: feed.ID, Owner: feed.Owner, Token: feed.Token, ListID: feed.ListID, CreatedAt: feed.CreatedAt}
+}
+
+func FromCalendarFeeds(feeds []models.CalendarFeed) []CalendarFeed {
+	out := make([]CalendarFeed, len(feeds))
+	for i := range feeds {
+		out[i] = FromCalendarFeed(&feeds[i])
+	}
+	return out
+}
+
+type CalendarBucket struct {
+	Start string `json:"start" format:"date"`
+	End   string `json:"end" format:"date"`
+	Items []Item `json:"items"`
+}
+
+type Calendar struct {
+	From     string           `json:"from" format:"date"`
+	To       string           `json:"to" format:"date"`
+	TimeZone string           `json:"tz"`
+	Group    string           `json:"group" enum:"day,week,month"`
+	Buckets  []CalendarBucket `json:"buckets"`
+}
+
+func FromCalendar(calendar *models.Calendar) Calendar {
+	buckets := make([]CalendarBucket, len(calendar.Buckets))
+	for i, bucket := range calendar.Buckets {
+		buckets[i] = CalendarBucket{Start: bucket.Start, End: bucket.End, Items: FromItems(bucket.Items)}
+	}
+	return Calendar{From: calendar.From, To: calendar.To, TimeZone: calendar.TimeZone, Group: calendar.Group, Buckets: buckets}
+}
+
+type SearchResult struct {
+	Type     string  `json:"type" enum:"item,list"`
+	ID       int     `json:"id"`
+	ListID   int     `json:"list_id"`
+	Title    string  `json:"title"`
+	Snippet  string  `json:"snippet"` // matched text, escaped as HTML, with terms wrapped in <mark> tags
+	Rank     float64 `json:"rank"`
+	ItemDate *string `json:"item_date" format:"date"` // null for lists
+}
+
+func FromSearchResults(results []models.SearchResult) []SearchResult {
+	out := make([]SearchResult, len(results))
+	for i, result := range results {
+		out[i] = SearchResult{
+			Type:    result.Type,
+			ID:      result.ID,
+			ListID:  result.ListID,
+			Title:   result.Title,
+			Snippet: result.Snippet,
+			Rank:    result.Rank,
+		}
+		if result.ItemDate != nil {
+			date := result.ItemDate.Format(dateLayout)
+			out[i].ItemDate = &date
+		}
+	}
+	return out
+}
+
+type RowError struct {
+	Row   int    `json:"row"` // 0 for problems with the list itself
+	Error string `json:"error"`
+}
+
+type ImportResult struct {
+	List     *List      `json:"list"` // null when the import failed
+	Imported int        `json:"imported"`
+	Errors   []RowError `json:"errors"`
+}
+
+func FromImportResult(result *transfer.Result) ImportResult {
+	out := ImportResult{Imported: result.Imported, Errors: make([]RowError, len(result.Errors))}
+	if result.List != nil {
+		list := FromList(result.List)
+		out.List = &list
+	}
+	for i, rowError := range result.Errors {
+		out.Errors[i] = RowError{Row: rowError.Row, Error: rowError.Message}
+	}
+	return out
+}
+
+func nonNil(m map[string]string) map[string]string {
+	if m == nil {
+		return map[string]string{}
+	}
+	return m
+}
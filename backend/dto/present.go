@@ -0,0 +1,47 @@
+package dto
+
+import (
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/transfer"
+)
+
+// Present converts a model a handler responds with into its v2 body. Anything else,
+// such as error bodies, is returned as is.
+func Present(model any) any {
+	switch m := model.(type) {
+	case *models.Item:
+		return FromItem(m)
+	case models.Item:
+		return FromItem(&m)
+	case []models.Item:
+		return FromItems(m)
+	case *models.List:
+		return FromList(m)
+	case models.List:
+		return FromList(&m)
+	case []models.List:
+		return FromLists(m)
+	case *models.Revision:
+		return FromRevision(m)
+	case []models.Revision:
+		return FromRevisions(m)
+	case []models.Activity:
+		return FromActivities(m)
+	case *models.SmartList:
+		return FromSmartList(m)
+	case []models.SmartList:
+		return FromSmartLists(m)
+	case *models.CalendarFeed:
+		return FromCalendarFeed(m)
+	case []models.CalendarFeed:
+		return FromCalendarFeeds(m)
+	case *models.Calendar:
+		return FromCalendar(m)
+	case []models.SearchResult:
+		return FromSearchResults(m)
+	case *transfer.Result:
+		return FromImportResult(m)
+	default:
+		return model
+	}
+}
@@ -0,0 +1,72 @@
+package gql
+
+import "sync"
+
+// Loader batches loads by key, DataLoader style. Load only registers a key and returns
+// a thunk; the first thunk called fetches every key registered so far in one call.
+// graphql-go resolves thunks breadth first, so all siblings of a field are registered
+// before any of them is fetched. Results are cached for the loader's lifetime, which
+// is a single request.
+type Loader[K comparable, V any] struct {
+	fetch func(keys []K) (map[K]V, error)
+
+	mu      sync.Mutex
+	pending []K
+	results map[K]*loadResult[V]
+}
+
+type loadResult[V any] struct {
+	done  bool
+	value V
+	err   error
+}
+
+// NewLoader creates a Loader. fetch returns the values it found by key; missing keys
+// load as the zero value.
+func NewLoader[K comparable, V any](fetch func(keys []K) (map[K]V, error)) *Loader[K, V] {
+	return &Loader[K, V]{fetch: fetch, results: map[K]*loadResult[V]{}}
+}
+
+// Load registers key and returns a thunk resolving to its value
+func (l *Loader[K, V]) Load(key K) func() (V, error) {
+	l.mu.Lock()
+	if _, ok := l.results[key]; !ok {
+		l.results[key] = &loadResult[V]{}
+		l.pending = append(l.pending, key)
+	}
+	l.mu.Unlock()
+
+	return func() (V, error) {
+		l.mu.Lock()
+		defer l.mu.Unlock()
+
+		result := l.results[key]
+		if !result.done {
+			l.dispatch()
+		}
+		return result.value, result.err
+	}
+}
+
+// Prime caches a value that was already read some other way
+func (l *Loader[K, V]) Prime(key K, value V) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	if result, ok := l.results[key]; !ok || !result.done {
+		l.results[key] = &loadResult[V]{done: true, value: value}
+	}
+}
+
+// dispatch fetches every pending key, with l.mu held
+func (l *Loader[K, V]) dispatch() {
+	keys := l.pending
+	l.pending = nil
+
+	values, err := l.fetch(keys)
+	for _, key := range keys {
+		result := l.results[key]
+		result.done = true
+		result.value, result.err = values[key], err
+	}
+}
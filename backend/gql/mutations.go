@@ -0,0 +1,133 @@
+package gql
+
+import (
+	"errors"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+)
+
+var errInvalidDate = errors.New("invalid date format, expected YYYY-MM-DD")
+
+// mutations are the REST API's create, update, delete and revert operations
+func (s *Schema) mutations(listType, itemType *graphql.Object, idArgs graphql.FieldConfigArgument) *graphql.Object {
+	r := s.resolvers
+
+	revertArgs := graphql.FieldConfigArgument{
+		"id":       &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+		"revision": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int), Description: "the revision to undo"},
+	}
+
+	createItemInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name: "CreateItemInput",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"title":   &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"content": &graphql.InputObjectFieldConfig{Type: graphql.String, DefaultValue: ""},
+			"date":    &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String), Description: "YYYY-MM-DD"},
+			"listId":  &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.Int)},
+		},
+	})
+	updateItemInput := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "UpdateItemInput",
+		Description: "title, content and date are always replaced; completed and listId only when given",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"title":     &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String)},
+			"content":   &graphql.InputObjectFieldConfig{Type: graphql.String, DefaultValue: ""},
+			"date":      &graphql.InputObjectFieldConfig{Type: graphql.NewNonNull(graphql.String), Description: "YYYY-MM-DD"},
+			"completed": &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+			"listId":    &graphql.InputObjectFieldConfig{Type: graphql.Int, Description: "moves the item to another list"},
+		},
+	})
+
+	titleArgs := graphql.FieldConfigArgument{"title": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)}}
+	renameArgs := graphql.FieldConfigArgument{
+		"id":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+		"title": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)},
+	}
+
+	return graphql.NewObject(graphql.ObjectConfig{
+		Name: "Mutation",
+		Fields: graphql.Fields{
+			"createList": &graphql.Field{
+				Type: graphql.NewNonNull(listType),
+				Args: titleArgs,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return r.Lists.Create(stateFrom(p).user, p.Args["title"].(string))
+				},
+			},
+			"renameList": &graphql.Field{
+				Type: graphql.NewNonNull(listType),
+				Args: renameArgs,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return r.Lists.Rename(stateFrom(p).user, p.Args["id"].(int), p.Args["title"].(string))
+				},
+			},
+			"deleteList": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Args: idArgs,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					err := r.Lists.Delete(stateFrom(p).user, p.Args["id"].(int))
+					return err == nil, err
+				},
+			},
+			"revertList": &graphql.Field{
+				Type: graphql.NewNonNull(listType),
+				Args: revertArgs,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return r.Lists.Revert(stateFrom(p).user, p.Args["id"].(int), p.Args["revision"].(int))
+				},
+			},
+			"createItem": &graphql.Field{
+				Type: graphql.NewNonNull(itemType),
+				Args: graphql.FieldConfigArgument{"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(createItemInput)}},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					input := p.Args["input"].(map[string]any)
+					date, err := time.Parse(dateLayout, input["date"].(string))
+					if err != nil {
+						return nil, errInvalidDate
+					}
+					return r.Items.Create(stateFrom(p).user, input["title"].(string), date, input["content"].(string), input["listId"].(int))
+				},
+			},
+			"updateItem": &graphql.Field{
+				Type: graphql.NewNonNull(itemType),
+				Args: graphql.FieldConfigArgument{
+					"id":    &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)},
+					"input": &graphql.ArgumentConfig{Type: graphql.NewNonNull(updateItemInput)},
+				},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					input := p.Args["input"].(map[string]any)
+					date, err := time.Parse(dateLayout, input["date"].(string))
+					if err != nil {
+						return nil, errInvalidDate
+					}
+
+					changes := models.ItemChanges{Title: input["title"].(string), Content: input["content"].(string), Date: date}
+					if completed, ok := input["completed"].(bool); ok {
+						changes.Completed = &completed
+					}
+					if listID, ok := input["listId"].(int); ok {
+						changes.ListID = &listID
+					}
+					return r.Items.Update(stateFrom(p).user, p.Args["id"].(int), changes)
+				},
+			},
+			"deleteItem": &graphql.Field{
+				Type: graphql.NewNonNull(graphql.Boolean),
+				Args: idArgs,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					err := r.Items.Delete(stateFrom(p).user, p.Args["id"].(int))
+					return err == nil, err
+				},
+			},
+			"revertItem": &graphql.Field{
+				Type: graphql.NewNonNull(itemType),
+				Args: revertArgs,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return r.Items.Revert(stateFrom(p).user, p.Args["id"].(int), p.Args["revision"].(int))
+				},
+			},
+		},
+	})
+}
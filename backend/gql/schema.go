@@ -0,0 +1,449 @@
+// gql package serves the API as GraphQL. Fields resolve through the same services as
+// the REST handlers, and lists and items are loaded in batches to avoid N+1 queries.
+package gql
+
+import (
+	"context"
+	"errors"
+	"sort"
+	"time"
+
+	"github.com/graphql-go/graphql"
+	"github.com/graphql-go/graphql/language/ast"
+	"github.com/graphql-go/graphql/language/parser"
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+	"github.com/jennaborowy/fullstack-Go-Docker/service"
+)
+
+// Resolvers are what the schema's fields are resolved through
+type Resolvers struct {
+	Items      *service.ItemService
+	Lists      *service.ListService
+	Activity   repository.ActivityRepositoryInterface
+	SmartLists repository.SmartListRepositoryInterface
+}
+
+// Schema is the GraphQL schema of the API
+type Schema struct {
+	schema    graphql.Schema
+	resolvers Resolvers
+}
+
+// Request is a GraphQL request as sent over HTTP
+type Request struct {
+	Query         string         `json:"query"`
+	OperationName string         `json:"operationName,omitempty"`
+	Variables     map[string]any `json:"variables,omitempty"`
+}
+
+// IsMutation reports whether req runs a mutation, so GET requests can be limited to queries
+func (req Request) IsMutation() bool {
+	doc, err := parser.Parse(parser.ParseParams{Source: req.Query})
+	if err != nil {
+		// Execute reports the syntax error
+		return false
+	}
+	for _, definition := range doc.Definitions {
+		op, ok := definition.(*ast.OperationDefinition)
+		if ok && (req.OperationName == "" || op.Name != nil && op.Name.Value == req.OperationName) {
+			return op.Operation == ast.OperationTypeMutation
+		}
+	}
+	return false
+}
+
+// state is what resolvers share during one request
+type state struct {
+	user        string
+	itemsByList *Loader[int, []models.Item]
+	listsByID   *Loader[int, *models.List]
+}
+
+type stateKey struct{}
+
+func stateFrom(p graphql.ResolveParams) *state {
+	return p.Context.Value(stateKey{}).(*state)
+}
+
+// Execute runs a request on behalf of user
+func (s *Schema) Execute(ctx context.Context, user string, req Request) *graphql.Result {
+	st := &state{
+		user: user,
+		itemsByList: NewLoader(func(listIDs []int) (map[int][]models.Item, error) {
+			items, err := s.resolvers.Items.Find(models.ItemFilter{ListIDs: listIDs})
+			if err != nil {
+				return nil, err
+			}
+			byList := make(map[int][]models.Item, len(listIDs))
+			for _, item := range items {
+				byList[item.ListID] = append(byList[item.ListID], item)
+			}
+			return byList, nil
+		}),
+		listsByID: NewLoader(func(ids []int) (map[int]*models.List, error) {
+			// there are few lists, so reading them all is a single cheap query
+			lists, err := s.resolvers.Lists.All(models.ListInclude{}, nil)
+			if err != nil {
+				return nil, err
+			}
+			byID := make(map[int]*models.List, len(ids))
+			for i := range lists {
+				byID[int(lists[i].ID)] = &lists[i]
+			}
+			return byID, nil
+		}),
+	}
+
+	return graphql.Do(graphql.Params{
+		Schema:         s.schema,
+		RequestString:  req.Query,
+		OperationName:  req.OperationName,
+		VariableValues: req.Variables,
+		Context:        context.WithValue(ctx, stateKey{}, st),
+	})
+}
+
+// NewSchema builds the schema on top of r
+func NewSchema(r Resolvers) (*Schema, error) {
+	s := &Schema{resolvers: r}
+
+	userType := graphql.NewObject(graphql.ObjectConfig{
+		Name:        "User",
+		Description: "Someone who made changes, named by the X-User header",
+		Fields:      graphql.Fields{},
+	})
+	listType := graphql.NewObject(graphql.ObjectConfig{Name: "List", Fields: graphql.Fields{}})
+	itemType := graphql.NewObject(graphql.ObjectConfig{Name: "Item", Fields: graphql.Fields{}})
+
+	activityType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Activity",
+		Fields: graphql.Fields{
+			"id":         &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"action":     &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"entityType": &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: field(func(a *models.Activity) any { return a.EntityType })},
+			"entityId":   &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: field(func(a *models.Activity) any { return a.EntityID })},
+			"listId":     &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: field(func(a *models.Activity) any { return a.ListID })},
+			"fromListId": &graphql.Field{Type: graphql.Int, Resolve: field(func(a *models.Activity) any {
+				if a.FromListID == 0 {
+					return nil
+				}
+				return a.FromListID
+			})},
+			"createdAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime), Resolve: field(func(a *models.Activity) any { return a.CreatedAt })},
+			"user":      &graphql.Field{Type: graphql.NewNonNull(userType), Resolve: field(func(a *models.Activity) any { return a.User })},
+		},
+	})
+
+	fieldChangeType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "FieldChange",
+		Fields: graphql.Fields{
+			"field": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"old":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"new":   &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+		},
+	})
+	revisionType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Revision",
+		Fields: graphql.Fields{
+			"id":        &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"revision":  &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"createdAt": &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime), Resolve: field(func(r *models.Revision) any { return r.CreatedAt })},
+			"author":    &graphql.Field{Type: graphql.NewNonNull(userType), Resolve: field(func(r *models.Revision) any { return r.Author })},
+			"changes": &graphql.Field{
+				Type: nonNullList(fieldChangeType),
+				Resolve: field(func(r *models.Revision) any {
+					changes := make([]map[string]any, 0, len(r.Changes))
+					for name, change := range r.Changes {
+						changes = append(changes, map[string]any{"field": name, "old": change.Old, "new": change.New})
+					}
+					sort.Slice(changes, func(i, j int) bool { return changes[i]["field"].(string) < changes[j]["field"].(string) })
+					return changes
+				}),
+			},
+		},
+	})
+
+	smartListType := graphql.NewObject(graphql.ObjectConfig{
+		Name: "SmartList",
+		Fields: graphql.Fields{
+			"id":    &graphql.Field{Type: graphql.NewNonNull(graphql.Int)},
+			"title": &graphql.Field{Type: graphql.NewNonNull(graphql.String)},
+			"owner": &graphql.Field{Type: graphql.NewNonNull(userType), Resolve: field(func(l *models.SmartList) any { return l.Owner })},
+			"items": &graphql.Field{
+				Type:        nonNullList(itemType),
+				Description: "the items matching the smart list's filter now",
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					filter, err := p.Source.(*models.SmartList).Filter.Resolve(time.Now())
+					if err != nil {
+						return nil, err
+					}
+					return itemPointers(r.Items.Find(filter))
+				},
+			},
+		},
+	})
+
+	limitArgs := graphql.FieldConfigArgument{"limit": &graphql.ArgumentConfig{Type: graphql.Int, Description: "maximum number of entries"}}
+	activity := func(filter models.ActivityFilter, p graphql.ResolveParams) (any, error) {
+		filter.Limit, _ = p.Args["limit"].(int)
+		entries, err := r.Activity.GetActivity(filter)
+		if err != nil {
+			return nil, err
+		}
+		return pointers(entries), nil
+	}
+
+	userType.AddFieldConfig("name", &graphql.Field{
+		Type:    graphql.NewNonNull(graphql.String),
+		Resolve: func(p graphql.ResolveParams) (any, error) { return p.Source, nil },
+	})
+	userType.AddFieldConfig("activity", &graphql.Field{
+		Type:        nonNullList(activityType),
+		Description: "the user's changes, newest first",
+		Args:        limitArgs,
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			return activity(models.ActivityFilter{User: p.Source.(string)}, p)
+		},
+	})
+	userType.AddFieldConfig("smartLists", &graphql.Field{
+		Type: nonNullList(smartListType),
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			lists, err := r.SmartLists.GetSmartLists(p.Source.(string))
+			if err != nil {
+				return nil, err
+			}
+			return pointers(lists), nil
+		},
+	})
+
+	listItems := func(p graphql.ResolveParams) func() ([]models.Item, error) {
+		return stateFrom(p).itemsByList.Load(int(p.Source.(*models.List).ID))
+	}
+	listType.AddFieldConfig("id", &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: field(func(l *models.List) any { return l.ID })})
+	listType.AddFieldConfig("title", &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: field(func(l *models.List) any { return l.Title })})
+	listType.AddFieldConfig("createdAt", &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime), Resolve: field(func(l *models.List) any { return l.CreatedAt })})
+	listType.AddFieldConfig("updatedAt", &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime), Resolve: field(func(l *models.List) any { return l.UpdatedAt })})
+	listType.AddFieldConfig("items", &graphql.Field{
+		Type: nonNullList(itemType),
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			load := listItems(p)
+			return func() (any, error) { return itemPointers(load()) }, nil
+		},
+	})
+	listType.AddFieldConfig("itemCount", &graphql.Field{
+		Type: graphql.NewNonNull(graphql.Int),
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			load := listItems(p)
+			return func() (any, error) {
+				items, err := load()
+				return len(items), err
+			}, nil
+		},
+	})
+	listType.AddFieldConfig("completedCount", &graphql.Field{
+		Type: graphql.NewNonNull(graphql.Int),
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			load := listItems(p)
+			return func() (any, error) {
+				items, err := load()
+				completed := 0
+				for _, item := range items {
+					if item.Completed {
+						completed++
+					}
+				}
+				return completed, err
+			}, nil
+		},
+	})
+	listType.AddFieldConfig("revisions", &graphql.Field{
+		Type:        nonNullList(revisionType),
+		Description: "the title history, oldest first",
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			revisions, err := r.Lists.Revisions(int(p.Source.(*models.List).ID))
+			return pointers(revisions), err
+		},
+	})
+	listType.AddFieldConfig("activity", &graphql.Field{
+		Type:        nonNullList(activityType),
+		Description: "changes to the list and its items, newest first",
+		Args:        limitArgs,
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			return activity(models.ActivityFilter{ListID: int(p.Source.(*models.List).ID)}, p)
+		},
+	})
+
+	itemType.AddFieldConfig("id", &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: field(func(i *models.Item) any { return i.ID })})
+	itemType.AddFieldConfig("title", &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: field(func(i *models.Item) any { return i.Title })})
+	itemType.AddFieldConfig("content", &graphql.Field{Type: graphql.NewNonNull(graphql.String), Resolve: field(func(i *models.Item) any { return i.Content })})
+	itemType.AddFieldConfig("date", &graphql.Field{
+		Type:        graphql.NewNonNull(graphql.String),
+		Description: "YYYY-MM-DD",
+		Resolve:     field(func(i *models.Item) any { return i.Date.Format(dateLayout) }),
+	})
+	itemType.AddFieldConfig("completed", &graphql.Field{Type: graphql.NewNonNull(graphql.Boolean), Resolve: field(func(i *models.Item) any { return i.Completed })})
+	itemType.AddFieldConfig("listId", &graphql.Field{Type: graphql.NewNonNull(graphql.Int), Resolve: field(func(i *models.Item) any { return i.ListID })})
+	itemType.AddFieldConfig("createdAt", &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime), Resolve: field(func(i *models.Item) any { return i.CreatedAt })})
+	itemType.AddFieldConfig("updatedAt", &graphql.Field{Type: graphql.NewNonNull(graphql.DateTime), Resolve: field(func(i *models.Item) any { return i.UpdatedAt })})
+	itemType.AddFieldConfig("list", &graphql.Field{
+		Type: listType,
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			load := stateFrom(p).listsByID.Load(p.Source.(*models.Item).ListID)
+			return func() (any, error) {
+				list, err := load()
+				if list == nil {
+					return nil, err
+				}
+				return list, err
+			}, nil
+		},
+	})
+	itemType.AddFieldConfig("revisions", &graphql.Field{
+		Type:        nonNullList(revisionType),
+		Description: "the change history, oldest first",
+		Resolve: func(p graphql.ResolveParams) (any, error) {
+			revisions, err := r.Items.Revisions(p.Source.(*models.Item).ID)
+			return pointers(revisions), err
+		},
+	})
+
+	itemFilterType := graphql.NewInputObject(graphql.InputObjectConfig{
+		Name:        "ItemFilter",
+		Description: "the same filter smart lists save; every field is optional",
+		Fields: graphql.InputObjectConfigFieldMap{
+			"listIds":   &graphql.InputObjectFieldConfig{Type: graphql.NewList(graphql.NewNonNull(graphql.Int))},
+			"due":       &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "overdue, today, this_week, next_7_days or this_month"},
+			"from":      &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "YYYY-MM-DD"},
+			"to":        &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "YYYY-MM-DD"},
+			"completed": &graphql.InputObjectFieldConfig{Type: graphql.Boolean},
+			"q":         &graphql.InputObjectFieldConfig{Type: graphql.String, Description: "full text search on title and content"},
+		},
+	})
+
+	idArgs := graphql.FieldConfigArgument{"id": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.Int)}}
+
+	query := graphql.NewObject(graphql.ObjectConfig{
+		Name: "Query",
+		Fields: graphql.Fields{
+			"lists": &graphql.Field{
+				Type: nonNullList(listType),
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					lists, err := r.Lists.All(models.ListInclude{}, nil)
+					return pointers(lists), err
+				},
+			},
+			"list": &graphql.Field{
+				Type: listType,
+				Args: idArgs,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					list, err := r.Lists.Get(p.Args["id"].(int), nil)
+					if errors.Is(err, repository.ErrNotFound) {
+						return nil, nil
+					}
+					if err != nil {
+						return nil, err
+					}
+					// the list was read with its items, so they don't need loading again
+					stateFrom(p).itemsByList.Prime(int(list.ID), list.Items)
+					return list, nil
+				},
+			},
+			"items": &graphql.Field{
+				Type: nonNullList(itemType),
+				Args: graphql.FieldConfigArgument{"filter": &graphql.ArgumentConfig{Type: itemFilterType}},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					filter, err := filterDefinition(p.Args["filter"]).Resolve(time.Now())
+					if err != nil {
+						return nil, err
+					}
+					return itemPointers(r.Items.Find(filter))
+				},
+			},
+			"item": &graphql.Field{
+				Type: itemType,
+				Args: idArgs,
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					item, err := r.Items.Get(p.Args["id"].(int), nil)
+					if errors.Is(err, repository.ErrNotFound) {
+						return nil, nil
+					}
+					return item, err
+				},
+			},
+			"me": &graphql.Field{
+				Type:        graphql.NewNonNull(userType),
+				Description: "the user making the request",
+				Resolve:     func(p graphql.ResolveParams) (any, error) { return stateFrom(p).user, nil },
+			},
+			"user": &graphql.Field{
+				Type: graphql.NewNonNull(userType),
+				Args: graphql.FieldConfigArgument{"name": &graphql.ArgumentConfig{Type: graphql.NewNonNull(graphql.String)}},
+				Resolve: func(p graphql.ResolveParams) (any, error) {
+					return p.Args["name"], nil
+				},
+			},
+		},
+	})
+
+	mutation := s.mutations(listType, itemType, idArgs)
+
+	schema, err := graphql.NewSchema(graphql.SchemaConfig{Query: query, Mutation: mutation})
+	if err != nil {
+		return nil, err
+	}
+	s.schema = schema
+	return s, nil
+}
+
+const dateLayout = "2006-01-02"
+
+// field resolves a field from the source model with get
+func field[T any](get func(*T) any) graphql.FieldResolveFn {
+	return func(p graphql.ResolveParams) (any, error) {
+		return get(p.Source.(*T)), nil
+	}
+}
+
+func nonNullList(t graphql.Type) graphql.Type {
+	return graphql.NewNonNull(graphql.NewList(graphql.NewNonNull(t)))
+}
+
+// pointers returns pointers to the elements of values, the form field resolvers expect models in
+func pointers[T any](values []T) []*T {
+	out := make([]*T, len(values))
+	for i := range values {
+		out[i] = &values[i]
+	}
+	return out
+}
+
+func itemPointers(items []models.Item, err error) (any, error) {
+	if err != nil {
+		return nil, err
+	}
+	return pointers(items), nil
+}
+
+// filterDefinition reads an ItemFilter input object
+func filterDefinition(arg any) models.ItemFilterDefinition {
+	var definition models.ItemFilterDefinition
+	input, _ := arg.(map[string]any)
+	if input == nil {
+		return definition
+	}
+
+	if ids, ok := input["listIds"].([]any); ok {
+		for _, id := range ids {
+			definition.ListIDs = append(definition.ListIDs, id.(int))
+		}
+	}
+	definition.Due, _ = input["due"].(string)
+	definition.From, _ = input["from"].(string)
+	definition.To, _ = input["to"].(string)
+	definition.Query, _ = input["q"].(string)
+	if completed, ok := input["completed"].(bool); ok {
+		definition.Completed = &completed
+	}
+	return definition
+}
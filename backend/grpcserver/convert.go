@@ -0,0 +1,65 @@
+package grpcserver
+
+import (
+	"time"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	notesv1 "github.com/jennaborowy/fullstack-Go-Docker/proto/notes/v1"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+func toItem(item *models.Item) *notesv1.Item {
+	return &notesv1.Item{
+		Id:        int64(item.ID),
+		Title:     item.Title,
+		Content:   item.Content,
+		ItemDate:  item.Date.Format("2006-01-02"),
+		ListId:    int64(item.ListID),
+		Completed: item.Completed,
+		CreatedAt: timestamp(item.CreatedAt),
+		UpdatedAt: timestamp(item.UpdatedAt),
+	}
+}
+
+func toItems(items []models.Item) []*notesv1.Item {
+	converted := make([]*notesv1.Item, len(items))
+	for i := range items {
+		converted[i] = toItem(&items[i])
+	}
+	return converted
+}
+
+func toList(list *models.List) *notesv1.List {
+	return &notesv1.List{
+		Id:        list.ID,
+		Title:     list.Title,
+		CreatedAt: timestamp(list.CreatedAt),
+		UpdatedAt: timestamp(list.UpdatedAt),
+		Items:     toItems(list.Items),
+	}
+}
+
+func toLists(lists []models.List) []*notesv1.List {
+	converted := make([]*notesv1.List, len(lists))
+	for i := range lists {
+		converted[i] = toList(&lists[i])
+	}
+	return converted
+}
+
+// timestamp converts t, leaving it unset when t is zero, as it is for items
+// that were just changed and not read back
+func timestamp(t time.Time) *timestamppb.Timestamp {
+	if t.IsZero() {
+		return nil
+	}
+	return timestamppb.New(t)
+}
+
+func toInts(ids []int64) []int {
+	converted := make([]int, len(ids))
+	for i, id := range ids {
+		converted[i] = int(id)
+	}
+	return converted
+}
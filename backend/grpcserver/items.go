@@ -0,0 +1,94 @@
+package grpcserver
+
+import (
+	"context"
+	"time"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	notesv1 "github.com/jennaborowy/fullstack-Go-Docker/proto/notes/v1"
+	"github.com/jennaborowy/fullstack-Go-Docker/service"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/status"
+	"google.golang.org/protobuf/types/known/emptypb"
+)
+
+// itemServer implements notesv1.ItemServiceServer
+type itemServer struct {
+	notesv1.UnimplementedItemServiceServer
+	items *service.ItemService
+	now   func() time.Time // resolves relative due dates
+}
+
+func (s *itemServer) ListItems(ctx context.Context, req *notesv1.ListItemsRequest) (*notesv1.ListItemsResponse, error) {
+	definition := models.ItemFilterDefinition{
+		ListIDs:   toInts(req.ListIds),
+		Due:       req.Due,
+		From:      req.From,
+		To:        req.To,
+		Completed: req.Completed,
+		Query:     req.Q,
+	}
+
+	filter, err := definition.Resolve(s.now())
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, err.Error())
+	}
+
+	items, err := s.items.Find(filter)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &notesv1.ListItemsResponse{Items: toItems(items)}, nil
+}
+
+func (s *itemServer) GetItem(ctx context.Context, req *notesv1.GetItemRequest) (*notesv1.Item, error) {
+	item, err := s.items.Get(int(req.Id), nil)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toItem(item), nil
+}
+
+func (s *itemServer) CreateItem(ctx context.Context, req *notesv1.CreateItemRequest) (*notesv1.Item, error) {
+	date, err := time.Parse("2006-01-02", req.ItemDate)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid date format")
+	}
+
+	item, err := s.items.Create(currentUser(ctx), req.Title, date, req.Content, int(req.ListId))
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toItem(item), nil
+}
+
+func (s *itemServer) UpdateItem(ctx context.Context, req *notesv1.UpdateItemRequest) (*notesv1.Item, error) {
+	date, err := time.Parse("2006-01-02", req.ItemDate)
+	if err != nil {
+		return nil, status.Error(codes.InvalidArgument, "invalid date format")
+	}
+
+	changes := models.ItemChanges{
+		Title:     req.Title,
+		Content:   req.Content,
+		Date:      date,
+		Completed: req.Completed,
+	}
+	if req.ListId != nil {
+		listID := int(*req.ListId)
+		changes.ListID = &listID
+	}
+
+	item, err := s.items.Update(currentUser(ctx), int(req.Id), changes)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toItem(item), nil
+}
+
+func (s *itemServer) DeleteItem(ctx context.Context, req *notesv1.DeleteItemRequest) (*emptypb.Empty, error) {
+	if err := s.items.Delete(currentUser(ctx), int(req.Id)); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
@@ -0,0 +1,126 @@
+package grpcserver
+
+import (
+	"context"
+	"log"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	notesv1 "github.com/jennaborowy/fullstack-Go-Docker/proto/notes/v1"
+	"github.com/jennaborowy/fullstack-Go-Docker/service"
+	"google.golang.org/grpc"
+	"google.golang.org/protobuf/types/known/emptypb"
+	"google.golang.org/protobuf/types/known/timestamppb"
+)
+
+// listServer implements notesv1.ListServiceServer
+type listServer struct {
+	notesv1.UnimplementedListServiceServer
+	lists   *service.ListService
+	items   *service.ItemService
+	changes *service.Changes
+}
+
+func (s *listServer) ListLists(ctx context.Context, req *notesv1.ListListsRequest) (*notesv1.ListListsResponse, error) {
+	lists, err := s.lists.All(models.ListInclude{}, nil)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return &notesv1.ListListsResponse{Lists: toLists(lists)}, nil
+}
+
+func (s *listServer) GetList(ctx context.Context, req *notesv1.GetListRequest) (*notesv1.List, error) {
+	list, err := s.lists.Get(int(req.Id), nil)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toList(list), nil
+}
+
+func (s *listServer) CreateList(ctx context.Context, req *notesv1.CreateListRequest) (*notesv1.List, error) {
+	list, err := s.lists.Create(currentUser(ctx), req.Title)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toList(list), nil
+}
+
+func (s *listServer) RenameList(ctx context.Context, req *notesv1.RenameListRequest) (*notesv1.List, error) {
+	list, err := s.lists.Rename(currentUser(ctx), int(req.Id), req.Title)
+	if err != nil {
+		return nil, toStatus(err)
+	}
+	return toList(list), nil
+}
+
+func (s *listServer) DeleteList(ctx context.Context, req *notesv1.DeleteListRequest) (*emptypb.Empty, error) {
+	if err := s.lists.Delete(currentUser(ctx), int(req.Id)); err != nil {
+		return nil, toStatus(err)
+	}
+	return &emptypb.Empty{}, nil
+}
+
+// WatchList sends an event for every change to the list or its items, ending
+// once the list is deleted
+func (s *listServer) WatchList(req *notesv1.WatchListRequest, stream grpc.ServerStreamingServer[notesv1.ListEvent]) error {
+	id := int(req.Id)
+
+	// subscribe before checking the list exists, so no change in between is missed
+	changes, unsubscribe := s.changes.Subscribe()
+	defer unsubscribe()
+
+	if _, err := s.lists.Get(id, nil); err != nil {
+		return toStatus(err)
+	}
+
+	for {
+		select {
+		case <-stream.Context().Done():
+			return nil
+		case activity := <-changes:
+			if !activity.OnList(id) {
+				continue
+			}
+
+			if err := stream.Send(s.event(activity)); err != nil {
+				return err
+			}
+
+			if activity.EntityType == models.EntityList && activity.Action == models.ActionDelete {
+				return nil
+			}
+		}
+	}
+}
+
+// event describes a change, with the item or list as it is now
+func (s *listServer) event(activity models.Activity) *notesv1.ListEvent {
+	event := &notesv1.ListEvent{
+		Action:     activity.Action,
+		EntityType: activity.EntityType,
+		EntityId:   int64(activity.EntityID),
+		ListId:     int64(activity.ListID),
+		User:       activity.User,
+		Time:       timestamppb.New(activity.CreatedAt),
+	}
+	if activity.Action == models.ActionDelete {
+		return event
+	}
+
+	// the event is still worth sending if the item or list can't be read,
+	// for example when it was deleted again since
+	switch activity.EntityType {
+	case models.EntityItem:
+		if item, err := s.items.Get(activity.EntityID, nil); err == nil {
+			event.Item = toItem(item)
+		} else {
+			log.Printf("failed to get item %d for a list event: %v", activity.EntityID, err)
+		}
+	case models.EntityList:
+		if list, err := s.lists.Get(activity.EntityID, nil); err == nil {
+			event.List = toList(list)
+		} else {
+			log.Printf("failed to get list %d for a list event: %v", activity.EntityID, err)
+		}
+	}
+	return event
+}
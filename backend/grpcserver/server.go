@@ -0,0 +1,56 @@
+// grpcserver package serves the notes.v1 gRPC API through the same services as the REST handlers
+package grpcserver
+
+//go:generate protoc -I .. --go_out=.. --go_opt=paths=source_relative --go-grpc_out=.. --go-grpc_opt=paths=source_relative proto/notes/v1/notes.proto
+
+import (
+	"context"
+	"errors"
+	"time"
+
+	notesv1 "github.com/jennaborowy/fullstack-Go-Docker/proto/notes/v1"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+	"github.com/jennaborowy/fullstack-Go-Docker/service"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/metadata"
+	"google.golang.org/grpc/reflection"
+	"google.golang.org/grpc/status"
+)
+
+// UserMetadataKey is the metadata key identifying who is making a change,
+// the gRPC counterpart of the X-User header
+const UserMetadataKey = "x-user"
+
+const anonymousUser = "anonymous"
+
+// NewServer creates a gRPC server with the list and item services and server reflection.
+// changes must be the activity repository the services log to, for WatchList to see them.
+func NewServer(items *service.ItemService, lists *service.ListService, changes *service.Changes) *grpc.Server {
+	server := grpc.NewServer()
+	notesv1.RegisterListServiceServer(server, &listServer{lists: lists, items: items, changes: changes})
+	notesv1.RegisterItemServiceServer(server, &itemServer{items: items, now: time.Now})
+	reflection.Register(server)
+	return server
+}
+
+// currentUser returns the user making the request, or "anonymous" when no user was given
+func currentUser(ctx context.Context) string {
+	if values := metadata.ValueFromIncomingContext(ctx, UserMetadataKey); len(values) > 0 && values[0] != "" {
+		return values[0]
+	}
+	return anonymousUser
+}
+
+// toStatus turns a service error into a gRPC status, the way the handlers pick an HTTP status
+func toStatus(err error) error {
+	switch {
+	case errors.Is(err, repository.ErrListNotFound):
+		// checked first, as a missing list is also a not found error
+		return status.Error(codes.NotFound, "list not found")
+	case errors.Is(err, repository.ErrNotFound):
+		return status.Error(codes.NotFound, err.Error())
+	default:
+		return status.Error(codes.Internal, err.Error())
+	}
+}
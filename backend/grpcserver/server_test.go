@@ -0,0 +1,281 @@
+package grpcserver_test
+
+import (
+	"context"
+	"io"
+	"net"
+	"sort"
+	"testing"
+	"time"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/grpcserver"
+	"github.com/jennaborowy/fullstack-Go-Docker/mocks"
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	notesv1 "github.com/jennaborowy/fullstack-Go-Docker/proto/notes/v1"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+	"github.com/jennaborowy/fullstack-Go-Docker/service"
+	"go.uber.org/mock/gomock"
+	"google.golang.org/grpc"
+	"google.golang.org/grpc/codes"
+	"google.golang.org/grpc/credentials/insecure"
+	"google.golang.org/grpc/metadata"
+	reflectionpb "google.golang.org/grpc/reflection/grpc_reflection_v1"
+	"google.golang.org/grpc/status"
+	"google.golang.org/grpc/test/bufconn"
+)
+
+type grpcMocks struct {
+	items     *mocks.MockItemRepositoryInterface
+	lists     *mocks.MockListRepositoryInterface
+	revisions *mocks.MockRevisionRepositoryInterface
+	activity  *mocks.MockActivityRepositoryInterface
+}
+
+// startServer serves the gRPC API over an in-memory connection and returns a client connection to it
+func startServer(t *testing.T, m grpcMocks) *grpc.ClientConn {
+	changes := service.NewChanges(m.activity)
+	items := service.NewItemService(m.items, m.revisions, changes)
+	lists := service.NewListService(m.lists, m.revisions, changes)
+	server := grpcserver.NewServer(items, lists, changes)
+
+	lis := bufconn.Listen(1 << 20)
+	go server.Serve(lis)
+	t.Cleanup(server.Stop)
+
+	conn, err := grpc.NewClient("passthrough:///bufnet",
+		grpc.WithContextDialer(func(ctx context.Context, _ string) (net.Conn, error) { return lis.DialContext(ctx) }),
+		grpc.WithTransportCredentials(insecure.NewCredentials()),
+	)
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(func() { conn.Close() })
+	return conn
+}
+
+func newGRPCMocks(ctrl *gomock.Controller) grpcMocks {
+	return grpcMocks{
+		items:     mocks.NewMockItemRepositoryInterface(ctrl),
+		lists:     mocks.NewMockListRepositoryInterface(ctrl),
+		revisions: mocks.NewMockRevisionRepositoryInterface(ctrl),
+		activity:  mocks.NewMockActivityRepositoryInterface(ctrl),
+	}
+}
+
+func TestItemService(t *testing.T) {
+	date := time.Date(2025, 10, 10, 0, 0, 0, 0, time.UTC)
+	item := &models.Item{ID: 1, Title: "Milk", Date: date, ListID: 2}
+	moveTo := int64(9)
+
+	tests := []struct {
+		name         string
+		call         func(ctx context.Context, client notesv1.ItemServiceClient) (any, error)
+		setupMocks   func(m grpcMocks)
+		expectedCode codes.Code
+		expectedErr  string
+		expected     any
+	}{
+		{
+			name: "get item",
+			call: func(ctx context.Context, client notesv1.ItemServiceClient) (any, error) {
+				return client.GetItem(ctx, &notesv1.GetItemRequest{Id: 1})
+			},
+			setupMocks: func(m grpcMocks) {
+				m.items.EXPECT().GetByID(1, nil).Return(item, nil).Times(1)
+			},
+			expectedCode: codes.OK,
+			expected:     "Milk 2025-10-10",
+		},
+		{
+			name: "missing item",
+			call: func(ctx context.Context, client notesv1.ItemServiceClient) (any, error) {
+				return client.GetItem(ctx, &notesv1.GetItemRequest{Id: 5})
+			},
+			setupMocks: func(m grpcMocks) {
+				m.items.EXPECT().GetByID(5, nil).Return(nil, repository.ErrNotFound).Times(1)
+			},
+			expectedCode: codes.NotFound,
+			expectedErr:  "item not found",
+		},
+		{
+			name: "create item logs activity as the x-user",
+			call: func(ctx context.Context, client notesv1.ItemServiceClient) (any, error) {
+				ctx = metadata.AppendToOutgoingContext(ctx, grpcserver.UserMetadataKey, "alice")
+				return client.CreateItem(ctx, &notesv1.CreateItemRequest{Title: "Milk", ItemDate: "2025-10-10", ListId: 2})
+			},
+			setupMocks: func(m grpcMocks) {
+				m.items.EXPECT().CreateItem("Milk", date, "", 2).Return(item, nil).Times(1)
+				m.activity.EXPECT().LogActivity(models.NewActivity("alice", models.ActionCreate, models.EntityItem, 1, 2)).Return(nil).Times(1)
+			},
+			expectedCode: codes.OK,
+			expected:     "Milk 2025-10-10",
+		},
+		{
+			name: "invalid date",
+			call: func(ctx context.Context, client notesv1.ItemServiceClient) (any, error) {
+				return client.CreateItem(ctx, &notesv1.CreateItemRequest{Title: "Milk", ItemDate: "10/10/2025", ListId: 2})
+			},
+			setupMocks:   func(m grpcMocks) {},
+			expectedCode: codes.InvalidArgument,
+			expectedErr:  "invalid date format",
+		},
+		{
+			name: "moving to a missing list",
+			call: func(ctx context.Context, client notesv1.ItemServiceClient) (any, error) {
+				return client.UpdateItem(ctx, &notesv1.UpdateItemRequest{Id: 1, Title: "Milk", ItemDate: "2025-10-10", ListId: &moveTo})
+			},
+			setupMocks: func(m grpcMocks) {
+				m.items.EXPECT().GetByID(1, nil).Return(item, nil).Times(1)
+				listID := 9
+				m.items.EXPECT().ChangeItem(1, models.ItemChanges{Title: "Milk", Date: date, ListID: &listID}, gomock.Any()).Return(repository.ErrListNotFound).Times(1)
+			},
+			expectedCode: codes.NotFound,
+			expectedErr:  "list not found",
+		},
+		{
+			name: "invalid due filter",
+			call: func(ctx context.Context, client notesv1.ItemServiceClient) (any, error) {
+				return client.ListItems(ctx, &notesv1.ListItemsRequest{Due: "someday"})
+			},
+			setupMocks:   func(m grpcMocks) {},
+			expectedCode: codes.InvalidArgument,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			m := newGRPCMocks(ctrl)
+			tt.setupMocks(m)
+			client := notesv1.NewItemServiceClient(startServer(t, m))
+
+			resp, err := tt.call(context.Background(), client)
+			if code := status.Code(err); code != tt.expectedCode {
+				t.Fatalf("expected code %v, got %v (%v)", tt.expectedCode, code, err)
+			}
+			if tt.expectedErr != "" && status.Convert(err).Message() != tt.expectedErr {
+				t.Errorf("expected error %q, got %q", tt.expectedErr, status.Convert(err).Message())
+			}
+			if tt.expected != nil {
+				got := resp.(*notesv1.Item)
+				if summary := got.Title + " " + got.ItemDate; summary != tt.expected {
+					t.Errorf("expected %q, got %q", tt.expected, summary)
+				}
+			}
+		})
+	}
+}
+
+func TestWatchList(t *testing.T) {
+	date := time.Date(2025, 10, 10, 0, 0, 0, 0, time.UTC)
+	item := &models.Item{ID: 1, Title: "Milk", Date: date, ListID: 2}
+
+	ctrl := gomock.NewController(t)
+	m := newGRPCMocks(ctrl)
+	conn := startServer(t, m)
+	lists := notesv1.NewListServiceClient(conn)
+	items := notesv1.NewItemServiceClient(conn)
+
+	// the watch has subscribed by the time it checks the list exists
+	watching := make(chan struct{})
+	m.lists.EXPECT().GetList(2, nil).DoAndReturn(func(int, models.Fields) (*models.List, error) {
+		close(watching)
+		return &models.List{ID: 2, Title: "Groceries"}, nil
+	}).Times(1)
+	m.activity.EXPECT().LogActivity(gomock.Any()).Return(nil).AnyTimes()
+	m.items.EXPECT().CreateItem("Cheese", date, "", 3).Return(&models.Item{ID: 5, ListID: 3}, nil).Times(1)
+	m.items.EXPECT().CreateItem("Milk", date, "", 2).Return(item, nil).Times(1)
+	m.items.EXPECT().GetByID(1, nil).Return(item, nil).Times(1)
+	m.lists.EXPECT().DeleteList(2).Return(nil).Times(1)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	stream, err := lists.WatchList(ctx, &notesv1.WatchListRequest{Id: 2})
+	if err != nil {
+		t.Fatalf("failed to watch: %v", err)
+	}
+	<-watching
+
+	// a change to another list isn't sent
+	if _, err := items.CreateItem(ctx, &notesv1.CreateItemRequest{Title: "Cheese", ItemDate: "2025-10-10", ListId: 3}); err != nil {
+		t.Fatalf("failed to create item: %v", err)
+	}
+	if _, err := items.CreateItem(ctx, &notesv1.CreateItemRequest{Title: "Milk", ItemDate: "2025-10-10", ListId: 2}); err != nil {
+		t.Fatalf("failed to create item: %v", err)
+	}
+	if _, err := lists.DeleteList(ctx, &notesv1.DeleteListRequest{Id: 2}); err != nil {
+		t.Fatalf("failed to delete list: %v", err)
+	}
+
+	event, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("failed to receive: %v", err)
+	}
+	if event.Action != models.ActionCreate || event.EntityType != models.EntityItem || event.Item.GetTitle() != "Milk" {
+		t.Errorf("expected the created item, got %v", event)
+	}
+
+	event, err = stream.Recv()
+	if err != nil {
+		t.Fatalf("failed to receive: %v", err)
+	}
+	if event.Action != models.ActionDelete || event.EntityType != models.EntityList || event.List != nil {
+		t.Errorf("expected the deleted list, got %v", event)
+	}
+
+	// the stream ends once the list is deleted
+	if _, err := stream.Recv(); err != io.EOF {
+		t.Errorf("expected the stream to end, got %v", err)
+	}
+}
+
+func TestWatchMissingList(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	m := newGRPCMocks(ctrl)
+	m.lists.EXPECT().GetList(9, nil).Return(nil, repository.ErrListNotFound).Times(1)
+	client := notesv1.NewListServiceClient(startServer(t, m))
+
+	stream, err := client.WatchList(context.Background(), &notesv1.WatchListRequest{Id: 9})
+	if err == nil {
+		_, err = stream.Recv()
+	}
+	if status.Code(err) != codes.NotFound {
+		t.Errorf("expected NotFound, got %v", err)
+	}
+}
+
+func TestReflection(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	client := reflectionpb.NewServerReflectionClient(startServer(t, newGRPCMocks(ctrl)))
+
+	stream, err := client.ServerReflectionInfo(context.Background())
+	if err != nil {
+		t.Fatalf("failed to start reflection: %v", err)
+	}
+	err = stream.Send(&reflectionpb.ServerReflectionRequest{
+		MessageRequest: &reflectionpb.ServerReflectionRequest_ListServices{},
+	})
+	if err != nil {
+		t.Fatalf("failed to send: %v", err)
+	}
+	resp, err := stream.Recv()
+	if err != nil {
+		t.Fatalf("failed to receive: %v", err)
+	}
+
+	var services []string
+	for _, service := range resp.GetListServicesResponse().GetService() {
+		services = append(services, service.Name)
+	}
+	sort.Strings(services)
+	expected := []string{"grpc.reflection.v1.ServerReflection", "grpc.reflection.v1alpha.ServerReflection", "notes.v1.ItemService", "notes.v1.ListService"}
+	if len(services) != len(expected) {
+		t.Fatalf("expected services %v, got %v", expected, services)
+	}
+	for i := range expected {
+		if services[i] != expected[i] {
+			t.Errorf("expected services %v, got %v", expected, services)
+			break
+		}
+	}
+}
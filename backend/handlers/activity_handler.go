@@ -0,0 +1,124 @@
+// handlers package processes requests through the repositories
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+	"github.com/jennaborowy/fullstack-Go-Docker/service"
+)
+
+// ActivityHandler is used to query the activity log
+type ActivityHandler struct {
+	repo repository.ActivityRepositoryInterface
+}
+
+// NewActivityHandler creates a new ActivityHandler
+func NewActivityHandler(repo repository.ActivityRepositoryInterface) *ActivityHandler {
+	return &ActivityHandler{repo: repo}
+}
+
+// GetActivity returns activity across the API, filtered by the user, list_id, action,
+// from, to and limit query parameters
+func (h *ActivityHandler) GetActivity(c *gin.Context) {
+	filter, err := parseActivityFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.respond(c, filter)
+}
+
+// GetListActivity returns the activity feed of a single list
+func (h *ActivityHandler) GetListActivity(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid list ID"})
+		return
+	}
+
+	filter, err := parseActivityFilter(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	filter.ListID = id
+
+	h.respond(c, filter)
+}
+
+func (h *ActivityHandler) respond(c *gin.Context, filter models.ActivityFilter) {
+	activity, err := h.repo.GetActivity(filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, present(c, activity))
+}
+
+// activityFilterError is returned for a query parameter that cannot be parsed
+type activityFilterError struct {
+	param string
+}
+
+func (e *activityFilterError) Error() string {
+	return "invalid " + e.param
+}
+
+// parseActivityFilter reads activity filters from the query string.
+// from and to accept either RFC 3339 timestamps or YYYY-MM-DD dates.
+func parseActivityFilter(c *gin.Context) (models.ActivityFilter, error) {
+	filter := models.ActivityFilter{
+		User:   c.Query("user"),
+		Action: c.Query("action"),
+	}
+
+	if listID := c.Query("list_id"); listID != "" {
+		id, err := strconv.Atoi(listID)
+		if err != nil {
+			return filter, &activityFilterError{"list_id"}
+		}
+		filter.ListID = id
+	}
+
+	if limit := c.Query("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 0 {
+			return filter, &activityFilterError{"limit"}
+		}
+		filter.Limit = n
+	}
+
+	var err error
+	if filter.From, err = parseTimeParam(c.Query("from")); err != nil {
+		return filter, &activityFilterError{"from"}
+	}
+	if filter.To, err = parseTimeParam(c.Query("to")); err != nil {
+		return filter, &activityFilterError{"to"}
+	}
+
+	return filter, nil
+}
+
+// parseTimeParam parses an optional RFC 3339 timestamp or YYYY-MM-DD date
+func parseTimeParam(value string) (time.Time, error) {
+	if value == "" {
+		return time.Time{}, nil
+	}
+	if t, err := time.Parse(time.RFC3339, value); err == nil {
+		return t, nil
+	}
+	return time.Parse("2006-01-02", value)
+}
+
+// logActivity appends an entry to the activity log for the current user.
+// The action has already happened, so a failure here is logged rather than returned.
+func logActivity(repo repository.ActivityRepositoryInterface, c *gin.Context, action, entityType string, entityID, listID int) {
+	service.LogActivity(repo, currentUser(c), action, entityType, entityID, listID)
+}
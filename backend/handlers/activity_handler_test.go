@@ -0,0 +1,194 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jennaborowy/fullstack-Go-Docker/handlers"
+	"github.com/jennaborowy/fullstack-Go-Docker/mocks"
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"go.uber.org/mock/gomock"
+)
+
+// quietActivity returns an activity log mock that accepts any entries,
+// for tests that aren't checking what gets logged
+func quietActivity(ctrl *gomock.Controller) *mocks.MockActivityRepositoryInterface {
+	activity := mocks.NewMockActivityRepositoryInterface(ctrl)
+	activity.EXPECT().LogActivity(gomock.Any()).Return(nil).AnyTimes()
+	return activity
+}
+
+var sampleActivity = []models.Activity{
+	*models.NewActivity("jenna", models.ActionUpdate, models.EntityItem, 2, 1),
+	*models.NewActivity("jenna", models.ActionCreate, models.EntityItem, 2, 1),
+}
+
+func TestGetActivity(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMock      func(m *mocks.MockActivityRepositoryInterface)
+		query          string
+		expectedStatus int
+		checkResponse  func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "successfully get activity with filters",
+			setupMock: func(m *mocks.MockActivityRepositoryInterface) {
+				m.EXPECT().
+					GetActivity(models.ActivityFilter{
+						User:   "jenna",
+						Action: models.ActionUpdate,
+						ListID: 1,
+						From:   time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+						To:     time.Date(2025, 10, 8, 12, 0, 0, 0, time.UTC),
+						Limit:  10,
+					}).
+					Return(sampleActivity[:1], nil).
+					Times(1)
+			},
+			query:          "?user=jenna&action=update&list_id=1&from=2025-10-01&to=2025-10-08T12:00:00Z&limit=10",
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response []models.Activity
+				if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+					t.Fatalf("failed to unmarshal response: %v", err)
+				}
+
+				if len(response) != 1 || response[0].Action != models.ActionUpdate {
+					t.Errorf("expected a single update entry, got %+v", response)
+				}
+			},
+		},
+		{
+			name: "no filters",
+			setupMock: func(m *mocks.MockActivityRepositoryInterface) {
+				m.EXPECT().
+					GetActivity(models.ActivityFilter{}).
+					Return(sampleActivity, nil).
+					Times(1)
+			},
+			query:          "",
+			expectedStatus: http.StatusOK,
+			checkResponse:  nil,
+		},
+		{
+			name:           "invalid from date",
+			setupMock:      func(m *mocks.MockActivityRepositoryInterface) {},
+			query:          "?from=yesterday",
+			expectedStatus: http.StatusBadRequest,
+			checkResponse:  nil,
+		},
+		{
+			name:           "invalid list id",
+			setupMock:      func(m *mocks.MockActivityRepositoryInterface) {},
+			query:          "?list_id=abc",
+			expectedStatus: http.StatusBadRequest,
+			checkResponse:  nil,
+		},
+		{
+			name: "repository error",
+			setupMock: func(m *mocks.MockActivityRepositoryInterface) {
+				m.EXPECT().
+					GetActivity(gomock.Any()).
+					Return(nil, errors.New("database error")).
+					Times(1)
+			},
+			query:          "",
+			expectedStatus: http.StatusInternalServerError,
+			checkResponse:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			ctrl := gomock.NewController(t)
+
+			repo := mocks.NewMockActivityRepositoryInterface(ctrl)
+			handler := handlers.NewActivityHandler(repo)
+
+			tt.setupMock(repo)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			c.Request = httptest.NewRequest(http.MethodGet, "/activity"+tt.query, nil)
+
+			handler.GetActivity(c)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d. Response: %s",
+					tt.expectedStatus, w.Code, w.Body.String())
+			}
+
+			if tt.checkResponse != nil {
+				tt.checkResponse(t, w)
+			}
+		})
+	}
+}
+
+func TestGetListActivity(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMock      func(m *mocks.MockActivityRepositoryInterface)
+		id             string
+		query          string
+		expectedStatus int
+	}{
+		{
+			name: "list id from the path overrides the query",
+			setupMock: func(m *mocks.MockActivityRepositoryInterface) {
+				m.EXPECT().
+					GetActivity(models.ActivityFilter{ListID: 1, User: "jenna"}).
+					Return(sampleActivity, nil).
+					Times(1)
+			},
+			id:             "1",
+			query:          "?list_id=2&user=jenna",
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid id format",
+			setupMock:      func(m *mocks.MockActivityRepositoryInterface) {},
+			id:             "invalid",
+			query:          "",
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			ctrl := gomock.NewController(t)
+
+			repo := mocks.NewMockActivityRepositoryInterface(ctrl)
+			handler := handlers.NewActivityHandler(repo)
+
+			tt.setupMock(repo)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			c.Params = gin.Params{
+				{Key: "id", Value: tt.id},
+			}
+
+			c.Request = httptest.NewRequest(http.MethodGet, "/lists/"+tt.id+"/activity"+tt.query, nil)
+
+			handler.GetListActivity(c)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d. Response: %s",
+					tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
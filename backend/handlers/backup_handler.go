@@ -0,0 +1,104 @@
+// handlers package processes requests through the repositories
+package handlers
+
+import (
+	"errors"
+	"fmt"
+	"log"
+	"mime"
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jennaborowy/fullstack-Go-Docker/backup"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+)
+
+// maxRestoreBytes limits the size of an uploaded backup archive
+const maxRestoreBytes = 1 << 30
+
+// BackupHandler is used to back up and restore the whole database
+type BackupHandler struct {
+	repo repository.BackupRepositoryInterface
+}
+
+// NewBackupHandler creates a new BackupHandler. A nil repo means the storage doesn't
+// support backups, and every request is answered with 501 Not Implemented.
+func NewBackupHandler(repo repository.BackupRepositoryInterface) *BackupHandler {
+	return &BackupHandler{repo: repo}
+}
+
+// supported reports whether backups can be made, responding with an error if not
+func (h *BackupHandler) supported(c *gin.Context) bool {
+	if h.repo == nil {
+		c.JSON(http.StatusNotImplemented, gin.H{"error": "backups are not supported by this storage"})
+		return false
+	}
+	return true
+}
+
+// Backup downloads an archive of every table
+func (h *BackupHandler) Backup(c *gin.Context) {
+	if !h.supported(c) {
+		return
+	}
+	snapshot, err := h.repo.BeginBackup()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	now := time.Now()
+	filename := fmt.Sprintf("backup-%s.tar.gz", now.UTC().Format("20060102-150405"))
+	c.Header("Content-Type", "application/gzip")
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+
+	// Export reads every table before writing, so a database error can still be reported
+	if _, err := backup.Export(c.Writer, snapshot, now); err != nil {
+		if c.Writer.Written() {
+			log.Printf("Failed to send backup: %v", err)
+			return
+		}
+		c.Header("Content-Type", "")
+		c.Header("Content-Disposition", "")
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	}
+}
+
+// Restore restores an archive made by Backup, sent as the raw request body or as the
+// first file of a multipart form. The whole upload is read before the restore begins. The strategy query parameter decides what happens to
+// rows whose ID is already taken: fail (the default), skip, overwrite or copy.
+func (h *BackupHandler) Restore(c *gin.Context) {
+	if !h.supported(c) {
+		return
+	}
+	strategy := repository.ConflictStrategy(c.DefaultQuery("strategy", string(repository.ConflictFail)))
+	if !repository.ValidConflictStrategy(strategy) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid strategy, expected fail, skip, overwrite or copy"})
+		return
+	}
+
+	body, _, _, err := importUpload(c, maxRestoreBytes)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	report, err := backup.Restore(body, h.repo, strategy)
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		switch {
+		case errors.As(err, &tooLarge):
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "archive is too large"})
+		case errors.Is(err, backup.ErrInvalidArchive):
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		case errors.Is(err, repository.ErrRestoreConflict):
+			c.JSON(http.StatusConflict, gin.H{"error": err.Error()})
+		default:
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		}
+		return
+	}
+
+	c.JSON(http.StatusOK, report)
+}
@@ -0,0 +1,194 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jennaborowy/fullstack-Go-Docker/backup"
+	"github.com/jennaborowy/fullstack-Go-Docker/handlers"
+	"github.com/jennaborowy/fullstack-Go-Docker/mocks"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+	"go.uber.org/mock/gomock"
+)
+
+// expectSnapshot makes snapshot return a single list and no other rows
+func expectSnapshot(snapshot *mocks.MockBackupSnapshot) {
+	snapshot.EXPECT().SchemaVersion().Return("003_calendar_feeds", nil).AnyTimes()
+	snapshot.EXPECT().Rows(gomock.Any(), gomock.Any(), gomock.Any()).DoAndReturn(
+		func(table string, columns []string, fn func(json.RawMessage) error) error {
+			if table == "lists" {
+				return fn(json.RawMessage(`{"id": 1, "title": "Daily Tasks"}`))
+			}
+			return nil
+		}).AnyTimes()
+	snapshot.EXPECT().Close().Return(nil).AnyTimes()
+}
+
+func TestBackup(t *testing.T) {
+	tests := []struct {
+		name                string
+		setupMock           func(r *mocks.MockBackupRepositoryInterface, s *mocks.MockBackupSnapshot)
+		expectedStatus      int
+		expectedContentType string
+	}{
+		{
+			name: "download archive",
+			setupMock: func(r *mocks.MockBackupRepositoryInterface, s *mocks.MockBackupSnapshot) {
+				r.EXPECT().BeginBackup().Return(s, nil).Times(1)
+				expectSnapshot(s)
+			},
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/gzip",
+		},
+		{
+			name: "database error before anything is sent",
+			setupMock: func(r *mocks.MockBackupRepositoryInterface, s *mocks.MockBackupSnapshot) {
+				r.EXPECT().BeginBackup().Return(s, nil).Times(1)
+				s.EXPECT().SchemaVersion().Return("", errors.New("connection reset")).Times(1)
+				s.EXPECT().Close().Return(nil).Times(1)
+			},
+			expectedStatus:      http.StatusInternalServerError,
+			expectedContentType: "application/json; charset=utf-8",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			ctrl := gomock.NewController(t)
+
+			repo := mocks.NewMockBackupRepositoryInterface(ctrl)
+			snapshot := mocks.NewMockBackupSnapshot(ctrl)
+			handler := handlers.NewBackupHandler(repo)
+
+			tt.setupMock(repo, snapshot)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			c.Request = httptest.NewRequest(http.MethodGet, "/admin/backup", nil)
+
+			handler.Backup(c)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d. Response: %s",
+					tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if contentType := w.Header().Get("Content-Type"); contentType != tt.expectedContentType {
+				t.Errorf("expected content type %s, got %s", tt.expectedContentType, contentType)
+			}
+		})
+	}
+}
+
+func TestRestore(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// make a real archive to restore
+	ctrl := gomock.NewController(t)
+	snapshot := mocks.NewMockBackupSnapshot(ctrl)
+	expectSnapshot(snapshot)
+	var archive bytes.Buffer
+	if _, err := backup.Export(&archive, snapshot, time.Now()); err != nil {
+		t.Fatalf("failed to build archive: %v", err)
+	}
+
+	tests := []struct {
+		name           string
+		setupMock      func(r *mocks.MockBackupRepositoryInterface, rs *mocks.MockBackupRestorer)
+		query          string
+		body           []byte
+		expectedStatus int
+	}{
+		{
+			name: "restore archive",
+			setupMock: func(r *mocks.MockBackupRepositoryInterface, rs *mocks.MockBackupRestorer) {
+				r.EXPECT().BeginRestore().Return(rs, nil).Times(1)
+				rs.EXPECT().SchemaVersion().Return("003_calendar_feeds", nil).Times(1)
+				rs.EXPECT().RestoreRow("lists", []string{"id", "title"}, gomock.Any(), repository.ConflictSkip).Return(int64(1), true, nil).Times(1)
+				rs.EXPECT().Finish().Return(nil).Times(1)
+				rs.EXPECT().Abort().Return(nil).Times(1)
+			},
+			query:          "?strategy=skip",
+			body:           archive.Bytes(),
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "conflicting row",
+			setupMock: func(r *mocks.MockBackupRepositoryInterface, rs *mocks.MockBackupRestorer) {
+				r.EXPECT().BeginRestore().Return(rs, nil).Times(1)
+				rs.EXPECT().SchemaVersion().Return("003_calendar_feeds", nil).Times(1)
+				rs.EXPECT().RestoreRow("lists", gomock.Any(), gomock.Any(), repository.ConflictFail).
+					Return(int64(0), false, fmt.Errorf("%w: lists 1", repository.ErrRestoreConflict)).Times(1)
+				rs.EXPECT().Abort().Return(nil).Times(1)
+			},
+			query:          "",
+			body:           archive.Bytes(),
+			expectedStatus: http.StatusConflict,
+		},
+		{
+			name:           "invalid archive",
+			setupMock:      func(r *mocks.MockBackupRepositoryInterface, rs *mocks.MockBackupRestorer) {},
+			query:          "",
+			body:           []byte("not an archive"),
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid strategy",
+			setupMock:      func(r *mocks.MockBackupRepositoryInterface, rs *mocks.MockBackupRestorer) {},
+			query:          "?strategy=merge",
+			body:           archive.Bytes(),
+			expectedStatus: http.StatusBadRequest,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+
+			repo := mocks.NewMockBackupRepositoryInterface(ctrl)
+			restorer := mocks.NewMockBackupRestorer(ctrl)
+			handler := handlers.NewBackupHandler(repo)
+
+			tt.setupMock(repo, restorer)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			c.Request = httptest.NewRequest(http.MethodPost, "/admin/restore"+tt.query, bytes.NewReader(tt.body))
+			c.Request.Header.Set("Content-Type", "application/gzip")
+
+			handler.Restore(c)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d. Response: %s",
+					tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
+
+func TestBackupUnsupported(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	handler := handlers.NewBackupHandler(nil)
+
+	for _, action := range []gin.HandlerFunc{handler.Backup, handler.Restore} {
+		w := httptest.NewRecorder()
+		c, _ := gin.CreateTestContext(w)
+		c.Request = httptest.NewRequest(http.MethodPost, "/admin/restore", nil)
+
+		action(c)
+
+		if w.Code != http.StatusNotImplemented {
+			t.Errorf("expected status %d, got %d. Response: %s", http.StatusNotImplemented, w.Code, w.Body.String())
+		}
+	}
+}
@@ -0,0 +1,188 @@
+// handlers package processes requests through the repositories
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jennaborowy/fullstack-Go-Docker/ical"
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+)
+
+// allListsFeedName is the calendar name of a feed aggregating every list
+const allListsFeedName = "All lists"
+
+// CalendarFeedHandler is used to manage and serve iCalendar feeds
+type CalendarFeedHandler struct {
+	feeds repository.CalendarFeedRepositoryInterface
+	lists repository.ListRepositoryInterface
+	items repository.ItemRepositoryInterface
+}
+
+// NewCalendarFeedHandler creates a new CalendarFeedHandler
+func NewCalendarFeedHandler(feeds repository.CalendarFeedRepositoryInterface, lists repository.ListRepositoryInterface, items repository.ItemRepositoryInterface) *CalendarFeedHandler {
+	return &CalendarFeedHandler{feeds: feeds, lists: lists, items: items}
+}
+
+// GetFeeds returns the current user's calendar feeds
+func (h *CalendarFeedHandler) GetFeeds(c *gin.Context) {
+	feeds, err := h.feeds.GetFeeds(currentUser(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, present(c, feeds))
+}
+
+// CreateFeedRequest is the body of a request creating a calendar feed
+type CreateFeedRequest struct {
+	ListID *int `json:"list_id,omitempty"` // all lists when omitted
+}
+
+// CreateFeed creates a feed for one list, or for every list when no list_id is given
+func (h *CalendarFeedHandler) CreateFeed(c *gin.Context) {
+	var input CreateFeedRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	if input.ListID != nil {
+		if _, err := h.lists.GetList(*input.ListID, nil); err != nil {
+			if errors.Is(err, repository.ErrNotFound) {
+				c.JSON(http.StatusNotFound, gin.H{"error": "list not found"})
+				return
+			}
+
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+	}
+
+	feed, err := h.feeds.CreateFeed(currentUser(c), input.ListID)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, present(c, feed))
+}
+
+// DeleteFeed revokes a feed, after which its token no longer works
+func (h *CalendarFeedHandler) DeleteFeed(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	if err := h.feeds.DeleteFeed(id, currentUser(c)); err != nil {
+		if errors.Is(err, repository.ErrFeedNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "calendar feed not found"})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// GetListCalendar serves a single list as iCalendar data. The token query
+// parameter must belong to a feed for this list.
+func (h *CalendarFeedHandler) GetListCalendar(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid list ID"})
+		return
+	}
+
+	feed, ok := h.authorize(c)
+	if !ok {
+		return
+	}
+	if feed.ListID == nil || *feed.ListID != id {
+		c.JSON(http.StatusForbidden, gin.H{"error": "token is not valid for this list"})
+		return
+	}
+
+	list, err := h.lists.GetList(id, nil)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "list not found"})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.write(c, list.Title, list.Items)
+}
+
+// GetFeedCalendar serves the feed a token belongs to, which aggregates every
+// list unless the feed was created for a single list
+func (h *CalendarFeedHandler) GetFeedCalendar(c *gin.Context) {
+	feed, ok := h.authorize(c)
+	if !ok {
+		return
+	}
+
+	if feed.ListID != nil {
+		list, err := h.lists.GetList(*feed.ListID, nil)
+		if err != nil {
+			c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+			return
+		}
+		h.write(c, list.Title, list.Items)
+		return
+	}
+
+	items, err := h.items.GetAll()
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	h.write(c, allListsFeedName, items)
+}
+
+// authorize looks up the feed for the token query parameter, writing an
+// error response and returning false if there is none
+func (h *CalendarFeedHandler) authorize(c *gin.Context) (*models.CalendarFeed, bool) {
+	token := c.Query("token")
+	if token == "" {
+		c.JSON(http.StatusUnauthorized, gin.H{"error": "missing token"})
+		return nil, false
+	}
+
+	feed, err := h.feeds.GetFeedByToken(token)
+	if err != nil {
+		if errors.Is(err, repository.ErrFeedNotFound) {
+			c.JSON(http.StatusUnauthorized, gin.H{"error": "invalid token"})
+			return nil, false
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	return feed, true
+}
+
+// write renders items as iCalendar data, as events unless component=todo is requested
+func (h *CalendarFeedHandler) write(c *gin.Context, name string, items []models.Item) {
+	var buf bytes.Buffer
+	if err := ical.Write(&buf, name, items, c.DefaultQuery("component", ical.ComponentEvent), time.Now()); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.Data(http.StatusOK, "text/calendar; charset=utf-8", buf.Bytes())
+}
@@ -0,0 +1,288 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jennaborowy/fullstack-Go-Docker/handlers"
+	"github.com/jennaborowy/fullstack-Go-Docker/mocks"
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	feedListID = 1
+	listFeed   = &models.CalendarFeed{ID: 1, Owner: "jenna", Token: "list-token", ListID: &feedListID}
+	allFeed    = &models.CalendarFeed{ID: 2, Owner: "jenna", Token: "all-token"}
+)
+
+type feedMocks struct {
+	feeds *mocks.MockCalendarFeedRepositoryInterface
+	lists *mocks.MockListRepositoryInterface
+	items *mocks.MockItemRepositoryInterface
+}
+
+func TestGetListCalendar(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMock      func(m feedMocks)
+		id             string
+		query          string
+		expectedStatus int
+		checkResponse  func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "successfully serve list calendar",
+			setupMock: func(m feedMocks) {
+				m.feeds.EXPECT().
+					GetFeedByToken("list-token").
+					Return(listFeed, nil).
+					Times(1)
+
+				m.lists.EXPECT().
+					GetList(1, nil).
+					Return(validList, nil).
+					Times(1)
+			},
+			id:             "1",
+			query:          "?token=list-token&component=todo",
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				if contentType := w.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "text/calendar") {
+					t.Errorf("expected text/calendar, got %s", contentType)
+				}
+				if count := strings.Count(w.Body.String(), "BEGIN:VTODO"); count != len(validList.Items) {
+					t.Errorf("expected %d todos, got %d", len(validList.Items), count)
+				}
+			},
+		},
+		{
+			name:           "missing token",
+			setupMock:      func(m feedMocks) {},
+			id:             "1",
+			query:          "",
+			expectedStatus: http.StatusUnauthorized,
+			checkResponse:  nil,
+		},
+		{
+			name: "unknown token",
+			setupMock: func(m feedMocks) {
+				m.feeds.EXPECT().
+					GetFeedByToken("guess").
+					Return(nil, repository.ErrFeedNotFound).
+					Times(1)
+			},
+			id:             "1",
+			query:          "?token=guess",
+			expectedStatus: http.StatusUnauthorized,
+			checkResponse:  nil,
+		},
+		{
+			name: "token for another list",
+			setupMock: func(m feedMocks) {
+				m.feeds.EXPECT().
+					GetFeedByToken("list-token").
+					Return(listFeed, nil).
+					Times(1)
+			},
+			id:             "2",
+			query:          "?token=list-token",
+			expectedStatus: http.StatusForbidden,
+			checkResponse:  nil,
+		},
+		{
+			name: "list does not exist",
+			setupMock: func(m feedMocks) {
+				m.feeds.EXPECT().
+					GetFeedByToken("list-token").
+					Return(listFeed, nil).
+					Times(1)
+
+				m.lists.EXPECT().
+					GetList(1, nil).
+					Return(nil, repository.ErrListNotFound).
+					Times(1)
+			},
+			id:             "1",
+			query:          "?token=list-token",
+			expectedStatus: http.StatusNotFound,
+			checkResponse:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			ctrl := gomock.NewController(t)
+
+			m := feedMocks{
+				feeds: mocks.NewMockCalendarFeedRepositoryInterface(ctrl),
+				lists: mocks.NewMockListRepositoryInterface(ctrl),
+				items: mocks.NewMockItemRepositoryInterface(ctrl),
+			}
+			handler := handlers.NewCalendarFeedHandler(m.feeds, m.lists, m.items)
+
+			tt.setupMock(m)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			c.Params = gin.Params{
+				{Key: "id", Value: tt.id},
+			}
+
+			c.Request = httptest.NewRequest(http.MethodGet, "/lists/"+tt.id+"/calendar.ics"+tt.query, nil)
+
+			handler.GetListCalendar(c)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d. Response: %s",
+					tt.expectedStatus, w.Code, w.Body.String())
+			}
+
+			if tt.checkResponse != nil {
+				tt.checkResponse(t, w)
+			}
+		})
+	}
+}
+
+func TestGetFeedCalendar(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	ctrl := gomock.NewController(t)
+
+	m := feedMocks{
+		feeds: mocks.NewMockCalendarFeedRepositoryInterface(ctrl),
+		lists: mocks.NewMockListRepositoryInterface(ctrl),
+		items: mocks.NewMockItemRepositoryInterface(ctrl),
+	}
+	handler := handlers.NewCalendarFeedHandler(m.feeds, m.lists, m.items)
+
+	m.feeds.EXPECT().GetFeedByToken("all-token").Return(allFeed, nil).Times(1)
+	m.items.EXPECT().GetAll().Return(multipleItems, nil).Times(1)
+
+	w := httptest.NewRecorder()
+	c, _ := gin.CreateTestContext(w)
+	c.Request = httptest.NewRequest(http.MethodGet, "/calendar.ics?token=all-token", nil)
+
+	handler.GetFeedCalendar(c)
+
+	if w.Code != http.StatusOK {
+		t.Fatalf("expected status %d, got %d. Response: %s", http.StatusOK, w.Code, w.Body.String())
+	}
+	if count := strings.Count(w.Body.String(), "BEGIN:VEVENT"); count != len(multipleItems) {
+		t.Errorf("expected %d events, got %d", len(multipleItems), count)
+	}
+	if !strings.Contains(w.Body.String(), "X-WR-CALNAME:All lists") {
+		t.Errorf("expected the aggregated calendar name")
+	}
+}
+
+func TestCreateFeed(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMock      func(m feedMocks)
+		requestBody    string
+		expectedStatus int
+	}{
+		{
+			name: "feed for a list",
+			setupMock: func(m feedMocks) {
+				m.lists.EXPECT().
+					GetList(1, nil).
+					Return(validList, nil).
+					Times(1)
+
+				m.feeds.EXPECT().
+					CreateFeed("jenna", &feedListID).
+					Return(listFeed, nil).
+					Times(1)
+			},
+			requestBody:    `{"list_id": 1}`,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "feed for every list",
+			setupMock: func(m feedMocks) {
+				m.feeds.EXPECT().
+					CreateFeed("jenna", nil).
+					Return(allFeed, nil).
+					Times(1)
+			},
+			requestBody:    `{}`,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name: "list does not exist",
+			setupMock: func(m feedMocks) {
+				m.lists.EXPECT().
+					GetList(9, nil).
+					Return(nil, repository.ErrListNotFound).
+					Times(1)
+			},
+			requestBody:    `{"list_id": 9}`,
+			expectedStatus: http.StatusNotFound,
+		},
+		{
+			name: "repository error",
+			setupMock: func(m feedMocks) {
+				m.feeds.EXPECT().
+					CreateFeed(gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database error")).
+					Times(1)
+			},
+			requestBody:    `{}`,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			ctrl := gomock.NewController(t)
+
+			m := feedMocks{
+				feeds: mocks.NewMockCalendarFeedRepositoryInterface(ctrl),
+				lists: mocks.NewMockListRepositoryInterface(ctrl),
+				items: mocks.NewMockItemRepositoryInterface(ctrl),
+			}
+			handler := handlers.NewCalendarFeedHandler(m.feeds, m.lists, m.items)
+
+			tt.setupMock(m)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			c.Request = httptest.NewRequest(http.MethodPost, "/feeds", bytes.NewBufferString(tt.requestBody))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Request.Header.Set(handlers.UserHeader, "jenna")
+
+			handler.CreateFeed(c)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d. Response: %s",
+					tt.expectedStatus, w.Code, w.Body.String())
+			}
+
+			if w.Code == http.StatusCreated {
+				var response models.CalendarFeed
+				if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+					t.Fatalf("failed to unmarshal response: %v", err)
+				}
+				if response.Token == "" {
+					t.Errorf("expected the feed token in the response")
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,83 @@
+// handlers package processes requests through the repositories
+package handlers
+
+import (
+	"net/http"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+)
+
+// maxCalendarDays limits how large a range a single calendar request can cover
+const maxCalendarDays = 366
+
+// CalendarHandler is used to process requests for items grouped by date
+type CalendarHandler struct {
+	items repository.ItemRepositoryInterface
+}
+
+// NewCalendarHandler creates a new CalendarHandler
+func NewCalendarHandler(items repository.ItemRepositoryInterface) *CalendarHandler {
+	return &CalendarHandler{items: items}
+}
+
+// GetCalendar returns items between from and to bucketed by day, week or month (group).
+// It accepts the same filters as GetItems, and tz (an IANA zone name, default UTC)
+// decides what "today" is for relative ranges. Without a range the current month is used.
+func (h *CalendarHandler) GetCalendar(c *gin.Context) {
+	loc, err := time.LoadLocation(c.DefaultQuery("tz", "UTC"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid tz"})
+		return
+	}
+
+	group := c.DefaultQuery("group", models.GroupDay)
+	if !models.ValidCalendarGroup(group) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid group"})
+		return
+	}
+
+	definition, err := parseItemFilterDefinition(c)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	if definition.Due == "" && definition.From == "" && definition.To == "" {
+		definition.Due = models.DueThisMonth
+	}
+
+	filter, err := definition.Resolve(time.Now().In(loc))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	if filter.From.IsZero() || filter.To.IsZero() {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "calendar needs both from and to"})
+		return
+	}
+	if filter.To.Before(filter.From) {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "to is before from"})
+		return
+	}
+	if filter.To.Sub(filter.From) > maxCalendarDays*24*time.Hour {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "range is too large"})
+		return
+	}
+
+	items, err := h.items.GetFiltered(filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	calendar, err := models.NewCalendar(items, filter.From, filter.To, group, loc)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, present(c, calendar))
+}
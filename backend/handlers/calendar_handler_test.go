@@ -0,0 +1,155 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jennaborowy/fullstack-Go-Docker/handlers"
+	"github.com/jennaborowy/fullstack-Go-Docker/mocks"
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"go.uber.org/mock/gomock"
+)
+
+func TestGetCalendar(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMock      func(m *mocks.MockItemRepositoryInterface)
+		query          string
+		expectedStatus int
+		checkResponse  func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "items bucketed by day",
+			setupMock: func(m *mocks.MockItemRepositoryInterface) {
+				m.EXPECT().
+					GetFiltered(models.ItemFilter{
+						ListIDs: []int{1},
+						From:    time.Date(2025, 10, 8, 0, 0, 0, 0, time.UTC),
+						To:      time.Date(2025, 10, 12, 0, 0, 0, 0, time.UTC),
+					}).
+					Return(multipleItems, nil).
+					Times(1)
+			},
+			query:          "?from=2025-10-08&to=2025-10-12&list_id=1",
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response models.Calendar
+				if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+					t.Fatalf("failed to unmarshal response: %v", err)
+				}
+
+				if len(response.Buckets) != 5 {
+					t.Fatalf("expected 5 day buckets, got %d", len(response.Buckets))
+				}
+				if len(response.Buckets[0].Items) != 1 || response.Buckets[0].Items[0].Title != "Item 2" {
+					t.Errorf("expected Item 2 on 2025-10-08, got %+v", response.Buckets[0].Items)
+				}
+				if len(response.Buckets[1].Items) != 0 {
+					t.Errorf("expected no items on 2025-10-09, got %+v", response.Buckets[1].Items)
+				}
+			},
+		},
+		{
+			name: "items bucketed by week",
+			setupMock: func(m *mocks.MockItemRepositoryInterface) {
+				m.EXPECT().
+					GetFiltered(gomock.Any()).
+					Return(multipleItems, nil).
+					Times(1)
+			},
+			query:          "?from=2025-10-01&to=2025-10-31&group=week&tz=America/New_York",
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response models.Calendar
+				if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+					t.Fatalf("failed to unmarshal response: %v", err)
+				}
+
+				if response.TimeZone != "America/New_York" {
+					t.Errorf("expected tz America/New_York, got %s", response.TimeZone)
+				}
+				if response.Buckets[0].Start != "2025-09-29" {
+					t.Errorf("expected first week to start on monday 2025-09-29, got %s", response.Buckets[0].Start)
+				}
+				// items 2 and 3 fall in the week of 2025-10-06, item 4 on sunday 2025-10-12 too
+				if len(response.Buckets[1].Items) != 3 {
+					t.Errorf("expected 3 items in the week of 2025-10-06, got %d", len(response.Buckets[1].Items))
+				}
+			},
+		},
+		{
+			name:           "invalid time zone",
+			setupMock:      func(m *mocks.MockItemRepositoryInterface) {},
+			query:          "?tz=Mars/Olympus_Mons",
+			expectedStatus: http.StatusBadRequest,
+			checkResponse:  nil,
+		},
+		{
+			name:           "invalid group",
+			setupMock:      func(m *mocks.MockItemRepositoryInterface) {},
+			query:          "?group=year",
+			expectedStatus: http.StatusBadRequest,
+			checkResponse:  nil,
+		},
+		{
+			name:           "open ended range",
+			setupMock:      func(m *mocks.MockItemRepositoryInterface) {},
+			query:          "?from=2025-10-01",
+			expectedStatus: http.StatusBadRequest,
+			checkResponse:  nil,
+		},
+		{
+			name:           "range too large",
+			setupMock:      func(m *mocks.MockItemRepositoryInterface) {},
+			query:          "?from=2020-01-01&to=2025-01-01",
+			expectedStatus: http.StatusBadRequest,
+			checkResponse:  nil,
+		},
+		{
+			name: "repository error",
+			setupMock: func(m *mocks.MockItemRepositoryInterface) {
+				m.EXPECT().
+					GetFiltered(gomock.Any()).
+					Return(nil, errors.New("database error")).
+					Times(1)
+			},
+			query:          "",
+			expectedStatus: http.StatusInternalServerError,
+			checkResponse:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			ctrl := gomock.NewController(t)
+
+			repo := mocks.NewMockItemRepositoryInterface(ctrl)
+			handler := handlers.NewCalendarHandler(repo)
+
+			tt.setupMock(repo)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			c.Request = httptest.NewRequest(http.MethodGet, "/calendar"+tt.query, nil)
+
+			handler.GetCalendar(c)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d. Response: %s",
+					tt.expectedStatus, w.Code, w.Body.String())
+			}
+
+			if tt.checkResponse != nil {
+				tt.checkResponse(t, w)
+			}
+		})
+	}
+}
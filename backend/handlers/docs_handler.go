@@ -0,0 +1,39 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jennaborowy/fullstack-Go-Docker/openapi"
+)
+
+// DocsHandler serves the OpenAPI document and a page rendering it
+type DocsHandler struct {
+	spec []byte
+	page []byte
+}
+
+// NewDocsHandler creates a new DocsHandler. The document is encoded once, up front,
+// since it never changes while the server runs.
+func NewDocsHandler(doc *openapi.Document, specURL string) (*DocsHandler, error) {
+	spec, err := json.Marshal(doc)
+	if err != nil {
+		return nil, err
+	}
+	return &DocsHandler{spec: spec, page: openapi.DocsPage(specURL)}, nil
+}
+
+// GetSpec returns the OpenAPI document
+func (h *DocsHandler) GetSpec(c *gin.Context) {
+	c.Data(http.StatusOK, "application/json; charset=utf-8", h.spec)
+}
+
+// GetDocs returns the API reference page, relaxing the Content-Security-Policy if there
+// is one, so it can load Redoc
+func (h *DocsHandler) GetDocs(c *gin.Context) {
+	if c.Writer.Header().Get("Content-Security-Policy") != "" {
+		c.Header("Content-Security-Policy", openapi.DocsContentSecurityPolicy)
+	}
+	c.Data(http.StatusOK, "text/html; charset=utf-8", h.page)
+}
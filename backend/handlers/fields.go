@@ -0,0 +1,104 @@
+package handlers
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+)
+
+// respondFields responds with body limited to fields, keeping the order of its properties.
+// Without fields body is sent whole.
+func respondFields(c *gin.Context, status int, body any, fields models.Fields) {
+	if len(fields) == 0 {
+		c.JSON(status, body)
+		return
+	}
+
+	data, err := json.Marshal(body)
+	if err == nil {
+		data, err = selectFields(data, fields)
+	}
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.Data(status, "application/json; charset=utf-8", data)
+}
+
+// selectFields limits the JSON object in data, or each object of a JSON array, to the
+// properties named in fields. Names are matched ignoring case and underscores, so
+// created_at also selects the CreatedAt of a v1 list.
+func selectFields(data []byte, fields models.Fields) ([]byte, error) {
+	data = bytes.TrimSpace(data)
+	if len(data) == 0 {
+		return data, nil
+	}
+
+	switch data[0] {
+	case '[':
+		var elements []json.RawMessage
+		if err := json.Unmarshal(data, &elements); err != nil {
+			return nil, err
+		}
+		for i, element := range elements {
+			selected, err := selectFields(element, fields)
+			if err != nil {
+				return nil, err
+			}
+			elements[i] = selected
+		}
+		return json.Marshal(elements)
+	case '{':
+		return selectProperties(data, fields)
+	default:
+		return data, nil
+	}
+}
+
+// selectProperties copies the properties of a JSON object named in fields, in order
+func selectProperties(data []byte, fields models.Fields) ([]byte, error) {
+	wanted := make(map[string]bool, len(fields))
+	for _, field := range fields {
+		wanted[fieldKey(field)] = true
+	}
+
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	if _, err := decoder.Token(); err != nil { // {
+		return nil, err
+	}
+
+	var out bytes.Buffer
+	out.WriteByte('{')
+	for decoder.More() {
+		token, err := decoder.Token()
+		if err != nil {
+			return nil, err
+		}
+		name, _ := token.(string)
+		var value json.RawMessage
+		if err := decoder.Decode(&value); err != nil {
+			return nil, err
+		}
+		if !wanted[fieldKey(name)] {
+			continue
+		}
+
+		if out.Len() > 1 {
+			out.WriteByte(',')
+		}
+		key, _ := json.Marshal(name)
+		out.Write(key)
+		out.WriteByte(':')
+		out.Write(value)
+	}
+	out.WriteByte('}')
+	return out.Bytes(), nil
+}
+
+func fieldKey(name string) string {
+	return strings.ToLower(strings.ReplaceAll(name, "_", ""))
+}
@@ -0,0 +1,51 @@
+package handlers
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jennaborowy/fullstack-Go-Docker/gql"
+)
+
+// GraphQLHandler serves GraphQL requests
+type GraphQLHandler struct {
+	schema *gql.Schema
+}
+
+// NewGraphQLHandler creates a new GraphQLHandler
+func NewGraphQLHandler(schema *gql.Schema) *GraphQLHandler {
+	return &GraphQLHandler{schema: schema}
+}
+
+// Query runs a GraphQL request, sent as a JSON body or, for queries only, as the
+// query, operationName and variables query parameters. Errors in the request itself
+// are reported in the result's errors like any other, with a 200.
+func (h *GraphQLHandler) Query(c *gin.Context) {
+	var req gql.Request
+	if c.Request.Method == http.MethodGet {
+		req.Query = c.Query("query")
+		req.OperationName = c.Query("operationName")
+		if variables := c.Query("variables"); variables != "" {
+			if err := json.Unmarshal([]byte(variables), &req.Variables); err != nil {
+				c.JSON(http.StatusBadRequest, gin.H{"error": "invalid variables"})
+				return
+			}
+		}
+		if req.IsMutation() {
+			c.Header("Allow", http.MethodPost)
+			c.JSON(http.StatusMethodNotAllowed, gin.H{"error": "mutations must be sent with POST"})
+			return
+		}
+	} else if err := c.ShouldBindJSON(&req); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	if req.Query == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing query"})
+		return
+	}
+
+	c.JSON(http.StatusOK, h.schema.Execute(c.Request.Context(), currentUser(c), req))
+}
@@ -0,0 +1,195 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jennaborowy/fullstack-Go-Docker/gql"
+	"github.com/jennaborowy/fullstack-Go-Docker/handlers"
+	"github.com/jennaborowy/fullstack-Go-Docker/mocks"
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+	"github.com/jennaborowy/fullstack-Go-Docker/service"
+	"go.uber.org/mock/gomock"
+)
+
+type graphqlMocks struct {
+	items     *mocks.MockItemRepositoryInterface
+	lists     *mocks.MockListRepositoryInterface
+	revisions *mocks.MockRevisionRepositoryInterface
+	activity  *mocks.MockActivityRepositoryInterface
+}
+
+func TestGraphQL(t *testing.T) {
+	date := time.Date(2025, 10, 10, 0, 0, 0, 0, time.UTC)
+	lists := []models.List{{ID: 1, Title: "Groceries"}, {ID: 2, Title: "Chores"}, {ID: 3, Title: "Empty"}}
+	items := []models.Item{
+		{ID: 10, Title: "Milk", Date: date, ListID: 1, Completed: true},
+		{ID: 11, Title: "Eggs", Date: date, ListID: 1},
+		{ID: 12, Title: "Laundry", Date: date, ListID: 2},
+	}
+
+	tests := []struct {
+		name           string
+		method         string
+		body           string
+		setupMocks     func(m graphqlMocks)
+		expectedStatus int
+		expectedBody   string
+	}{
+		{
+			name: "items of every list are loaded in one batch",
+			body: `{"query": "{ lists { id title itemCount completedCount items { title } } }"}`,
+			setupMocks: func(m graphqlMocks) {
+				m.lists.EXPECT().GetAllLists(models.ListInclude{}, nil).Return(lists, nil).Times(1)
+				m.items.EXPECT().GetFiltered(models.ItemFilter{ListIDs: []int{1, 2, 3}}).Return(items, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":{"lists":[` +
+				`{"completedCount":1,"id":1,"itemCount":2,"items":[{"title":"Milk"},{"title":"Eggs"}],"title":"Groceries"},` +
+				`{"completedCount":0,"id":2,"itemCount":1,"items":[{"title":"Laundry"}],"title":"Chores"},` +
+				`{"completedCount":0,"id":3,"itemCount":0,"items":[],"title":"Empty"}]}}`,
+		},
+		{
+			name: "lists of items are loaded in one batch",
+			body: `{"query": "query Due($filter: ItemFilter) { items(filter: $filter) { title date list { title } } }", "variables": {"filter": {"due": "overdue"}}}`,
+			setupMocks: func(m graphqlMocks) {
+				m.items.EXPECT().GetFiltered(gomock.Any()).Return(items, nil).Times(1)
+				m.lists.EXPECT().GetAllLists(models.ListInclude{}, nil).Return(lists, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody: `{"data":{"items":[` +
+				`{"date":"2025-10-10","list":{"title":"Groceries"},"title":"Milk"},` +
+				`{"date":"2025-10-10","list":{"title":"Groceries"},"title":"Eggs"},` +
+				`{"date":"2025-10-10","list":{"title":"Chores"},"title":"Laundry"}]}}`,
+		},
+		{
+			name: "a list's own items are not loaded again",
+			body: `{"query": "{ list(id: 1) { title itemCount } missing: list(id: 9) { title } }"}`,
+			setupMocks: func(m graphqlMocks) {
+				m.lists.EXPECT().GetList(1, nil).Return(&models.List{ID: 1, Title: "Groceries", Items: items[:2]}, nil).Times(1)
+				m.lists.EXPECT().GetList(9, nil).Return(nil, repository.ErrListNotFound).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"list":{"itemCount":2,"title":"Groceries"},"missing":null}}`,
+		},
+		{
+			name: "update item records a revision and activity",
+			body: `{"query": "mutation { updateItem(id: 10, input: {title: \"Oat milk\", date: \"2025-10-10\", listId: 2}) { id title listId completed } }"}`,
+			setupMocks: func(m graphqlMocks) {
+				m.items.EXPECT().GetByID(10, nil).Return(&items[0], nil).Times(1)
+				listID := 2
+				m.items.EXPECT().ChangeItem(10, models.ItemChanges{Title: "Oat milk", Date: date, ListID: &listID}, gomock.Any()).
+					DoAndReturn(func(_ int, _ models.ItemChanges, rev *models.Revision) error {
+						if rev.Author != "jenna" || rev.Changes["title"].New != "Oat milk" {
+							t.Errorf("unexpected revision %+v", rev)
+						}
+						return nil
+					}).Times(1)
+				// retitled and moved, so logged as both
+				m.activity.EXPECT().LogActivity(gomock.Cond(func(a *models.Activity) bool {
+					return a.User == "jenna" && a.Action == models.ActionMove && a.ListID == 2 && a.FromListID == 1
+				})).Return(nil).Times(1)
+				m.activity.EXPECT().LogActivity(gomock.Cond(func(a *models.Activity) bool {
+					return a.User == "jenna" && a.Action == models.ActionUpdate && a.ListID == 2
+				})).Return(nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"updateItem":{"completed":true,"id":10,"listId":2,"title":"Oat milk"}}}`,
+		},
+		{
+			name: "mutation errors are reported",
+			body: `{"query": "mutation { updateItem(id: 10, input: {title: \"x\", date: \"2025-10-10\", listId: 9}) { id } }"}`,
+			setupMocks: func(m graphqlMocks) {
+				m.items.EXPECT().GetByID(10, nil).Return(&items[0], nil).Times(1)
+				listID := 9
+				m.items.EXPECT().ChangeItem(10, models.ItemChanges{Title: "x", Date: date, ListID: &listID}, gomock.Any()).Return(repository.ErrListNotFound).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":null,"errors":[{"message":"list not found","locations":[{"line":1,"column":12}],"path":["updateItem"]}]}`,
+		},
+		{
+			name:           "me is the X-User",
+			method:         http.MethodGet,
+			body:           "{ me { name } }",
+			expectedStatus: http.StatusOK,
+			expectedBody:   `{"data":{"me":{"name":"jenna"}}}`,
+		},
+		{
+			name:           "mutations can't be sent with GET",
+			method:         http.MethodGet,
+			body:           `mutation { deleteList(id: 1) }`,
+			expectedStatus: http.StatusMethodNotAllowed,
+			expectedBody:   `{"error":"mutations must be sent with POST"}`,
+		},
+		{
+			name:           "missing query",
+			body:           `{}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedBody:   `{"error":"missing query"}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+			ctrl := gomock.NewController(t)
+			m := graphqlMocks{
+				items:     mocks.NewMockItemRepositoryInterface(ctrl),
+				lists:     mocks.NewMockListRepositoryInterface(ctrl),
+				revisions: mocks.NewMockRevisionRepositoryInterface(ctrl),
+				activity:  mocks.NewMockActivityRepositoryInterface(ctrl),
+			}
+			if tt.setupMocks != nil {
+				tt.setupMocks(m)
+			}
+
+			schema, err := gql.NewSchema(gql.Resolvers{
+				Items:    service.NewItemService(m.items, m.revisions, m.activity),
+				Lists:    service.NewListService(m.lists, m.revisions, m.activity),
+				Activity: m.activity,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			handler := handlers.NewGraphQLHandler(schema)
+
+			router := gin.New()
+			router.GET("/graphql", handler.Query)
+			router.POST("/graphql", handler.Query)
+
+			var req *http.Request
+			if tt.method == http.MethodGet {
+				req = httptest.NewRequest(http.MethodGet, "/graphql?query="+url.QueryEscape(tt.body), nil)
+			} else {
+				req = httptest.NewRequest(http.MethodPost, "/graphql", bytes.NewBufferString(tt.body))
+				req.Header.Set("Content-Type", "application/json")
+			}
+			req.Header.Set(handlers.UserHeader, "jenna")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d", tt.expectedStatus, w.Code)
+			}
+
+			// compare re-encoded JSON, which sorts object keys, so field order doesn't matter
+			var expected, actual any
+			if err := json.Unmarshal(w.Body.Bytes(), &actual); err != nil {
+				t.Fatalf("invalid response %s: %v", w.Body.String(), err)
+			}
+			json.Unmarshal([]byte(tt.expectedBody), &expected)
+			expectedJSON, _ := json.Marshal(expected)
+			actualJSON, _ := json.Marshal(actual)
+			if string(expectedJSON) != string(actualJSON) {
+				t.Errorf("expected %s, got %s", expectedJSON, actualJSON)
+			}
+		})
+	}
+}
@@ -8,31 +8,49 @@ import (
 	"log"
 	"net/http"
 	"strconv"
+	"strings"
 	"time"
 
 	"github.com/gin-gonic/gin"
 	"github.com/jennaborowy/fullstack-Go-Docker/models"
 	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+	"github.com/jennaborowy/fullstack-Go-Docker/service"
 )
 
 // ItemHandler is used to process requests related to items
 type ItemHandler struct {
-	repo repository.ItemRepositoryInterface
+	items *service.ItemService
 }
 
 // NewItemHandler creates a new ItemHandler
-func NewItemHandler(repo repository.ItemRepositoryInterface) *ItemHandler {
-	return &ItemHandler{repo: repo}
+func NewItemHandler(repo repository.ItemRepositoryInterface, revisions repository.RevisionRepositoryInterface, activity repository.ActivityRepositoryInterface) *ItemHandler {
+	return &ItemHandler{items: service.NewItemService(repo, revisions, activity)}
 }
 
-// GetItems attempts to get all items
+// GetItems attempts to get all items, or only those matching the
+// list_id, due, from, to, completed and q query parameters when any are given
 func (h *ItemHandler) GetItems(c *gin.Context) {
-	items, err := h.repo.GetAll()
+	definition, err := parseItemFilterDefinition(c)
 	if err != nil {
-		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filter, err := definition.Resolve(time.Now())
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	filter.Fields, err = models.ParseFields(c.QueryArray("fields"), models.ItemFields)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, items)
+
+	streamItems(c, filter.Fields, func(fn func(item models.Item) error) error {
+		return h.items.Stream(filter, fn)
+	})
 }
 
 // GetItem attempts to get a single item by id
@@ -43,7 +61,13 @@ func (h *ItemHandler) GetItem(c *gin.Context) {
 		return
 	}
 
-	item, err := h.repo.GetByID(id)
+	fields, err := models.ParseFields(c.QueryArray("fields"), models.ItemFields)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	item, err := h.items.Get(id, fields)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "item not found"})
@@ -54,7 +78,7 @@ func (h *ItemHandler) GetItem(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, item)
+	respondFields(c, http.StatusOK, present(c, item), fields)
 }
 
 // DeleteItem attempts to delete an item by id and returns no content
@@ -65,22 +89,40 @@ func (h *ItemHandler) DeleteItem(c *gin.Context) {
 		return
 	}
 
-	err = h.repo.DeleteItemByID(id)
-	if err != nil {
+	if err := h.items.Delete(currentUser(c), id); err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "item not found"})
+			return
+		}
+
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
+
 	c.JSON(http.StatusNoContent, nil)
 }
 
+// CreateItemRequest is the body of a request creating an item
+type CreateItemRequest struct {
+	Title    string `json:"title"`
+	Content  string `json:"content,omitempty"`
+	ItemDate string `json:"item_date" format:"date"`
+	ListID   int    `json:"list_id"`
+}
+
+// UpdateItemRequest is the body of a request updating an item. Title, content and date
+// are always replaced; completed and list_id are only changed when given.
+type UpdateItemRequest struct {
+	Title     string `json:"title"`
+	Content   string `json:"content,omitempty"`
+	ItemDate  string `json:"item_date" format:"date"`
+	Completed *bool  `json:"completed,omitempty"`
+	ListID    *int   `json:"list_id,omitempty"` // moves the item to another list
+}
+
 // CreateItem attempts to create a new item and returns its ID
 func (h *ItemHandler) CreateItem(c *gin.Context) {
-	var input struct {
-		Title    string `json:"title"`
-		Content  string `json:"content"`
-		ItemDate string `json:"item_date"`
-		ListID   int    `json:"list_id"`
-	}
+	var input CreateItemRequest
 
 	if err := c.ShouldBindJSON(&input); err != nil {
 		log.Printf("Bind error: %v", err) // Add this
@@ -97,13 +139,13 @@ func (h *ItemHandler) CreateItem(c *gin.Context) {
 		return
 	}
 
-	item, err := h.repo.CreateItem(input.Title, itemDate, input.Content, input.ListID)
+	item, err := h.items.Create(currentUser(c), input.Title, itemDate, input.Content, input.ListID)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusCreated, item)
+	c.JSON(http.StatusCreated, present(c, item))
 }
 
 // UpdateItem attempts to edit an item's information and returns the updated item
@@ -115,11 +157,7 @@ func (h *ItemHandler) UpdateItem(c *gin.Context) {
 		return
 	}
 
-	var req struct {
-		Title    string `json:"title"`
-		Content  string `json:"content"`
-		ItemDate string `json:"item_date"`
-	}
+	var req UpdateItemRequest
 
 	if err := c.ShouldBindJSON(&req); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
@@ -132,9 +170,19 @@ func (h *ItemHandler) UpdateItem(c *gin.Context) {
 		return
 	}
 
-	// fetch item to get listID
-	existingItem, err := h.repo.GetByID(id)
+	updatedItem, err := h.items.Update(currentUser(c), id, models.ItemChanges{
+		Title:     req.Title,
+		Content:   req.Content,
+		Date:      date,
+		Completed: req.Completed,
+		ListID:    req.ListID,
+	})
 	if err != nil {
+		// checked first, as a missing list is also a not found error
+		if errors.Is(err, repository.ErrListNotFound) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "list not found"})
+			return
+		}
 		if errors.Is(err, repository.ErrNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "item not found"})
 			return
@@ -144,9 +192,75 @@ func (h *ItemHandler) UpdateItem(c *gin.Context) {
 		return
 	}
 
-	err = h.repo.UpdateItem(id, req.Title, date, req.Content)
+	c.JSON(http.StatusOK, present(c, updatedItem))
+}
+
+// GetItemRevisions returns the change history of an item, oldest first
+func (h *ItemHandler) GetItemRevisions(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
 	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	revisions, err := h.items.Revisions(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
 
+	c.JSON(http.StatusOK, present(c, revisions))
+}
+
+// GetItemRevision returns a single revision of an item along with the fields it changed
+func (h *ItemHandler) GetItemRevision(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	rev, err := strconv.Atoi(c.Param("rev"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid revision"})
+		return
+	}
+
+	revision, err := h.items.Revision(id, rev)
+	if err != nil {
+		if errors.Is(err, repository.ErrRevisionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "revision not found"})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, present(c, revision))
+}
+
+// RevertItem rolls an item back to how it was before the given revision.
+// The revert is itself recorded as a new revision.
+func (h *ItemHandler) RevertItem(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return
+	}
+
+	rev, err := strconv.Atoi(c.Param("rev"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid revision"})
+		return
+	}
+
+	revertedItem, err := h.items.Revert(currentUser(c), id, rev)
+	if err != nil {
+		if errors.Is(err, repository.ErrRevisionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "revision not found"})
+			return
+		}
 		if errors.Is(err, repository.ErrNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "item not found"})
 			return
@@ -156,8 +270,37 @@ func (h *ItemHandler) UpdateItem(c *gin.Context) {
 		return
 	}
 
-	updatedItem := models.NewItem(req.Title, date, req.Content, existingItem.ListID)
+	c.JSON(http.StatusOK, present(c, revertedItem))
+}
+
+// parseItemFilterDefinition reads an item filter from the query string, using the
+// same fields a smart list saves: list_id (repeatable or comma separated), due,
+// from, to, completed and q
+func parseItemFilterDefinition(c *gin.Context) (models.ItemFilterDefinition, error) {
+	definition := models.ItemFilterDefinition{
+		Due:   c.Query("due"),
+		From:  c.Query("from"),
+		To:    c.Query("to"),
+		Query: c.Query("q"),
+	}
+
+	for _, value := range c.QueryArray("list_id") {
+		for _, part := range strings.Split(value, ",") {
+			id, err := strconv.Atoi(strings.TrimSpace(part))
+			if err != nil {
+				return definition, errors.New("invalid list_id")
+			}
+			definition.ListIDs = append(definition.ListIDs, id)
+		}
+	}
 
-	c.JSON(http.StatusOK, updatedItem)
+	if completed := c.Query("completed"); completed != "" {
+		value, err := strconv.ParseBool(completed)
+		if err != nil {
+			return definition, errors.New("invalid completed")
+		}
+		definition.Completed = &value
+	}
 
+	return definition, nil
 }
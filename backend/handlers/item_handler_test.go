@@ -34,6 +34,7 @@ func TestGetItem(t *testing.T) {
 		name           string
 		setupMock      func(*mocks.MockItemRepositoryInterface)
 		requestedID    string
+		query          string
 		expectedStatus int
 		checkResponse  func(t *testing.T, w *httptest.ResponseRecorder)
 	}{
@@ -41,7 +42,7 @@ func TestGetItem(t *testing.T) {
 			name: "successful get",
 			setupMock: func(m *mocks.MockItemRepositoryInterface) {
 				m.EXPECT().
-					GetByID(1).
+					GetByID(1, nil).
 					Return(validItem, nil).
 					Times(1)
 			},
@@ -63,7 +64,7 @@ func TestGetItem(t *testing.T) {
 			name: "item does not exist",
 			setupMock: func(m *mocks.MockItemRepositoryInterface) {
 				m.EXPECT().
-					GetByID(5).
+					GetByID(5, nil).
 					Return(nil, repository.ErrNotFound).
 					Times(1)
 			},
@@ -71,6 +72,28 @@ func TestGetItem(t *testing.T) {
 			expectedStatus: http.StatusNotFound,
 			checkResponse:  nil,
 		},
+		{
+			name: "only some fields",
+			setupMock: func(m *mocks.MockItemRepositoryInterface) {
+				m.EXPECT().
+					GetByID(1, models.Fields{"id", "title"}).
+					Return(&models.Item{ID: 1, Title: validItem.Title}, nil).
+					Times(1)
+			},
+			requestedID:    "1",
+			query:          "?fields=id,title",
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response map[string]any
+				if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+					t.Fatalf("failed to unmarshal response: %v", err)
+				}
+
+				if len(response) != 2 || response["title"] != validItem.Title {
+					t.Errorf("expected only the id and title, got %v", response)
+				}
+			},
+		},
 		{
 			name:           "invalid id format",
 			setupMock:      func(m *mocks.MockItemRepositoryInterface) {},
@@ -87,7 +110,7 @@ func TestGetItem(t *testing.T) {
 			ctrl := gomock.NewController(t)
 
 			repo := mocks.NewMockItemRepositoryInterface(ctrl)
-			handler := handlers.NewItemHandler(repo)
+			handler := handlers.NewItemHandler(repo, mocks.NewMockRevisionRepositoryInterface(ctrl), quietActivity(ctrl))
 
 			tt.setupMock(repo)
 
@@ -98,7 +121,7 @@ func TestGetItem(t *testing.T) {
 				{Key: "id", Value: tt.requestedID},
 			}
 
-			c.Request = httptest.NewRequest(http.MethodGet, "/items/"+tt.requestedID, nil)
+			c.Request = httptest.NewRequest(http.MethodGet, "/items/"+tt.requestedID+tt.query, nil)
 
 			handler.GetItem(c)
 
@@ -116,10 +139,24 @@ func TestGetItem(t *testing.T) {
 
 }
 
+// streams makes a StreamFiltered mock call back with items, then return err
+func streams(items []models.Item, err error) func(models.ItemFilter, func(models.Item) error) error {
+	return func(_ models.ItemFilter, fn func(models.Item) error) error {
+		for _, item := range items {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+		return err
+	}
+}
+
 func TestGetItems(t *testing.T) {
 	tests := []struct {
 		name           string
 		setupMock      func(*mocks.MockItemRepositoryInterface)
+		query          string
+		accept         string
 		expectedStatus int
 		checkResponse  func(*testing.T, *httptest.ResponseRecorder)
 	}{
@@ -127,10 +164,11 @@ func TestGetItems(t *testing.T) {
 			name: "successfully fetch all items",
 			setupMock: func(m *mocks.MockItemRepositoryInterface) {
 				m.EXPECT().
-					GetAll().
-					Return(multipleItems, nil).
+					StreamFiltered(models.ItemFilter{}, gomock.Any()).
+					DoAndReturn(streams(multipleItems, nil)).
 					Times(1)
 			},
+			query:          "",
 			expectedStatus: http.StatusOK,
 			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
 				var response []models.Item
@@ -152,10 +190,11 @@ func TestGetItems(t *testing.T) {
 			name: "repository error",
 			setupMock: func(m *mocks.MockItemRepositoryInterface) {
 				m.EXPECT().
-					GetAll().
-					Return(nil, errors.New("database error")).
+					StreamFiltered(models.ItemFilter{}, gomock.Any()).
+					DoAndReturn(streams(nil, errors.New("database error"))).
 					Times(1)
 			},
+			query:          "",
 			expectedStatus: http.StatusInternalServerError,
 			checkResponse:  nil,
 		},
@@ -163,17 +202,153 @@ func TestGetItems(t *testing.T) {
 			name: "empty items list",
 			setupMock: func(m *mocks.MockItemRepositoryInterface) {
 				m.EXPECT().
-					GetAll().
-					Return([]models.Item{}, nil).
+					StreamFiltered(models.ItemFilter{}, gomock.Any()).
+					DoAndReturn(streams(nil, nil)).
 					Times(1)
 			},
+			query:          "",
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				if w.Body.String() != "[]" {
+					t.Errorf("expected [], got %s", w.Body.String())
+				}
+			},
+		},
+		{
+			name: "filtered by list, dates and completion",
+			setupMock: func(m *mocks.MockItemRepositoryInterface) {
+				notDone := false
+				m.EXPECT().
+					StreamFiltered(models.ItemFilter{
+						ListIDs:   []int{1, 2},
+						From:      time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+						To:        time.Date(2025, 10, 31, 0, 0, 0, 0, time.UTC),
+						Completed: &notDone,
+						Query:     "test",
+					}, gomock.Any()).
+					DoAndReturn(streams(multipleItems[:1], nil)).
+					Times(1)
+			},
+			query:          "?list_id=1,2&from=2025-10-01&to=2025-10-31&completed=false&q=test",
 			expectedStatus: http.StatusOK,
 			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
 				var response []models.Item
-				json.Unmarshal(w.Body.Bytes(), &response)
+				if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+					t.Fatalf("failed to unmarshal response: %v", err)
+				}
 
-				if len(response) != 0 {
-					t.Errorf("expected 0 items, got %d", len(response))
+				if len(response) != 1 {
+					t.Errorf("expected 1 item, got %d", len(response))
+				}
+			},
+		},
+		{
+			name:           "invalid due range",
+			setupMock:      func(m *mocks.MockItemRepositoryInterface) {},
+			query:          "?due=someday",
+			expectedStatus: http.StatusBadRequest,
+			checkResponse:  nil,
+		},
+		{
+			name:           "invalid completed value",
+			setupMock:      func(m *mocks.MockItemRepositoryInterface) {},
+			query:          "?completed=maybe",
+			expectedStatus: http.StatusBadRequest,
+			checkResponse:  nil,
+		},
+		{
+			name: "only some fields",
+			setupMock: func(m *mocks.MockItemRepositoryInterface) {
+				m.EXPECT().
+					StreamFiltered(models.ItemFilter{Fields: models.Fields{"title", "id"}}, gomock.Any()).
+					DoAndReturn(streams([]models.Item{{ID: 1, Title: "Item 1"}, {ID: 2, Title: "Item 2"}}, nil)).
+					Times(1)
+			},
+			query:          "?fields=title&fields=id",
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				expected := `[{"id":1,"title":"Item 1"},{"id":2,"title":"Item 2"}]`
+				if w.Body.String() != expected {
+					t.Errorf("expected %s, got %s", expected, w.Body.String())
+				}
+			},
+		},
+		{
+			name: "some fields of filtered items",
+			setupMock: func(m *mocks.MockItemRepositoryInterface) {
+				m.EXPECT().
+					StreamFiltered(models.ItemFilter{Query: "test", Fields: models.Fields{"content"}}, gomock.Any()).
+					DoAndReturn(streams(multipleItems[:1], nil)).
+					Times(1)
+			},
+			query:          "?q=test&fields=content",
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				expected := `[{"content":"test description dos"}]`
+				if w.Body.String() != expected {
+					t.Errorf("expected %s, got %s", expected, w.Body.String())
+				}
+			},
+		},
+		{
+			name:           "unknown field",
+			setupMock:      func(m *mocks.MockItemRepositoryInterface) {},
+			query:          "?fields=title&fields=owner",
+			expectedStatus: http.StatusBadRequest,
+			checkResponse:  nil,
+		},
+		{
+			name: "NDJSON when accepted",
+			setupMock: func(m *mocks.MockItemRepositoryInterface) {
+				m.EXPECT().
+					StreamFiltered(models.ItemFilter{Fields: models.Fields{"id", "title"}}, gomock.Any()).
+					DoAndReturn(streams([]models.Item{{ID: 1, Title: "Item 1"}, {ID: 2, Title: "Item 2"}}, nil)).
+					Times(1)
+			},
+			query:          "?fields=id,title",
+			accept:         "application/x-ndjson",
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				if contentType := w.Header().Get("Content-Type"); contentType != "application/x-ndjson; charset=utf-8" {
+					t.Errorf("expected NDJSON, got content type %s", contentType)
+				}
+				expected := "{\"id\":1,\"title\":\"Item 1\"}\n{\"id\":2,\"title\":\"Item 2\"}\n"
+				if w.Body.String() != expected {
+					t.Errorf("expected %q, got %q", expected, w.Body.String())
+				}
+			},
+		},
+		{
+			name: "failing part way leaves the array unclosed",
+			setupMock: func(m *mocks.MockItemRepositoryInterface) {
+				m.EXPECT().
+					StreamFiltered(models.ItemFilter{Fields: models.Fields{"id"}}, gomock.Any()).
+					DoAndReturn(streams([]models.Item{{ID: 1}}, errors.New("connection lost"))).
+					Times(1)
+			},
+			query:          "?fields=id",
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				if w.Body.String() != `[{"id":1}` {
+					t.Errorf("expected an unclosed array, got %s", w.Body.String())
+				}
+			},
+		},
+		{
+			name: "failing part way ends NDJSON with the error",
+			setupMock: func(m *mocks.MockItemRepositoryInterface) {
+				m.EXPECT().
+					StreamFiltered(models.ItemFilter{Fields: models.Fields{"id"}}, gomock.Any()).
+					DoAndReturn(streams([]models.Item{{ID: 1}}, errors.New("connection lost"))).
+					Times(1)
+			},
+			query:          "?fields=id",
+			accept:         "application/x-ndjson",
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				expected := "{\"id\":1}\n{\"error\":\"connection lost\"}\n"
+				if w.Body.String() != expected {
+					t.Errorf("expected %q, got %q", expected, w.Body.String())
 				}
 			},
 		},
@@ -187,7 +362,7 @@ func TestGetItems(t *testing.T) {
 			ctrl := gomock.NewController(t)
 
 			repo := mocks.NewMockItemRepositoryInterface(ctrl)
-			handler := handlers.NewItemHandler(repo)
+			handler := handlers.NewItemHandler(repo, mocks.NewMockRevisionRepositoryInterface(ctrl), quietActivity(ctrl))
 
 			// Setup mock expectations
 			tt.setupMock(repo)
@@ -195,7 +370,10 @@ func TestGetItems(t *testing.T) {
 			w := httptest.NewRecorder()
 			c, _ := gin.CreateTestContext(w)
 
-			c.Request = httptest.NewRequest(http.MethodGet, "/items", nil)
+			c.Request = httptest.NewRequest(http.MethodGet, "/items"+tt.query, nil)
+			if tt.accept != "" {
+				c.Request.Header.Set("Accept", tt.accept)
+			}
 
 			handler.GetItems(c)
 
@@ -273,7 +451,7 @@ func TestCreateItem(t *testing.T) {
 			defer ctrl.Finish()
 
 			repo := mocks.NewMockItemRepositoryInterface(ctrl)
-			handler := handlers.NewItemHandler(repo)
+			handler := handlers.NewItemHandler(repo, mocks.NewMockRevisionRepositoryInterface(ctrl), quietActivity(ctrl))
 
 			// Setup mock expectations
 			tt.setupMock(repo)
@@ -298,24 +476,55 @@ func TestCreateItem(t *testing.T) {
 func TestDeleteItem(t *testing.T) {
 	tests := []struct {
 		name           string
-		setupMock      func(m *mocks.MockItemRepositoryInterface)
+		setupMock      func(m *mocks.MockItemRepositoryInterface, a *mocks.MockActivityRepositoryInterface)
 		id             string
 		expectedStatus int
 	}{
 		{
 			name: "successful delete (item exits)",
-			setupMock: func(m *mocks.MockItemRepositoryInterface) {
+			setupMock: func(m *mocks.MockItemRepositoryInterface, a *mocks.MockActivityRepositoryInterface) {
+				m.EXPECT().
+					GetByID(1, nil).
+					Return(validItem, nil).
+					Times(1)
+
 				m.EXPECT().
 					DeleteItemByID(1).
 					Return(nil).
 					Times(1)
+
+				a.EXPECT().
+					LogActivity(gomock.Any()).
+					DoAndReturn(func(activity *models.Activity) error {
+						if activity.Action != models.ActionDelete || activity.EntityID != 1 || activity.ListID != validItem.ListID {
+							t.Errorf("unexpected activity %+v", activity)
+						}
+						return nil
+					}).
+					Times(1)
 			},
 			id:             "1",
 			expectedStatus: http.StatusNoContent,
 		},
+		{
+			name: "item not found",
+			setupMock: func(m *mocks.MockItemRepositoryInterface, a *mocks.MockActivityRepositoryInterface) {
+				m.EXPECT().
+					GetByID(999, nil).
+					Return(nil, repository.ErrNotFound).
+					Times(1)
+			},
+			id:             "999",
+			expectedStatus: http.StatusNotFound,
+		},
 		{
 			name: "repository error",
-			setupMock: func(m *mocks.MockItemRepositoryInterface) {
+			setupMock: func(m *mocks.MockItemRepositoryInterface, a *mocks.MockActivityRepositoryInterface) {
+				m.EXPECT().
+					GetByID(20, nil).
+					Return(validItem, nil).
+					Times(1)
+
 				m.EXPECT().
 					DeleteItemByID(20).
 					Return(errors.New("database error")).
@@ -333,9 +542,10 @@ func TestDeleteItem(t *testing.T) {
 			ctrl := gomock.NewController(t)
 
 			repo := mocks.NewMockItemRepositoryInterface(ctrl)
-			handler := handlers.NewItemHandler(repo)
+			activity := mocks.NewMockActivityRepositoryInterface(ctrl)
+			handler := handlers.NewItemHandler(repo, mocks.NewMockRevisionRepositoryInterface(ctrl), activity)
 
-			tt.setupMock(repo)
+			tt.setupMock(repo, activity)
 
 			w := httptest.NewRecorder()
 			c, _ := gin.CreateTestContext(w)
@@ -344,7 +554,7 @@ func TestDeleteItem(t *testing.T) {
 				{Key: "id", Value: tt.id},
 			}
 
-			http.NewRequest("DELETE", "/items/"+tt.id, nil)
+			c.Request = httptest.NewRequest(http.MethodDelete, "/items/"+tt.id, nil)
 
 			handler.DeleteItem(c)
 
@@ -358,9 +568,11 @@ func TestDeleteItem(t *testing.T) {
 }
 
 func TestUpdateItem(t *testing.T) {
+	newDate := time.Date(2025, 10, 23, 0, 0, 0, 0, time.UTC)
+
 	tests := []struct {
 		name           string
-		setupMock      func(m *mocks.MockItemRepositoryInterface)
+		setupMock      func(m *mocks.MockItemRepositoryInterface, r *mocks.MockRevisionRepositoryInterface)
 		id             string
 		requestBody    map[string]interface{}
 		expectedStatus int
@@ -368,15 +580,26 @@ func TestUpdateItem(t *testing.T) {
 	}{
 		{
 			name: "succesfully update item",
-			setupMock: func(m *mocks.MockItemRepositoryInterface) {
+			setupMock: func(m *mocks.MockItemRepositoryInterface, r *mocks.MockRevisionRepositoryInterface) {
 				m.EXPECT().
-					GetByID(1).
+					GetByID(1, nil).
 					Return(validItem, nil).
 					Times(1)
 
 				m.EXPECT().
-					UpdateItem(1, "new title", gomock.Any(), "new content").
-					Return(nil).
+					ChangeItem(1, models.ItemChanges{Title: "new title", Content: "new content", Date: newDate}, gomock.Any()).
+					DoAndReturn(func(_ int, _ models.ItemChanges, rev *models.Revision) error {
+						if rev.EntityType != models.EntityItem || rev.EntityID != 1 {
+							t.Errorf("expected revision for item 1, got %s %d", rev.EntityType, rev.EntityID)
+						}
+						if rev.Changes["title"].Old != validItem.Title || rev.Changes["title"].New != "new title" {
+							t.Errorf("unexpected title change %+v", rev.Changes["title"])
+						}
+						if rev.Author != "jenna" {
+							t.Errorf("expected author 'jenna', got '%s'", rev.Author)
+						}
+						return nil
+					}).
 					Times(1)
 			},
 			id: "1",
@@ -400,14 +623,14 @@ func TestUpdateItem(t *testing.T) {
 		},
 		{
 			name: "repository error",
-			setupMock: func(m *mocks.MockItemRepositoryInterface) {
+			setupMock: func(m *mocks.MockItemRepositoryInterface, r *mocks.MockRevisionRepositoryInterface) {
 				m.EXPECT().
-					GetByID(1).
+					GetByID(1, nil).
 					Return(validItem, nil).
 					Times(1)
 
 				m.EXPECT().
-					UpdateItem(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).
+					ChangeItem(gomock.Any(), gomock.Any(), gomock.Any()).
 					Return(errors.New("database error")).
 					Times(1)
 			},
@@ -420,12 +643,56 @@ func TestUpdateItem(t *testing.T) {
 			expectedStatus: http.StatusInternalServerError,
 			checkResponse:  nil,
 		},
+		{
+			name: "move item to another list",
+			setupMock: func(m *mocks.MockItemRepositoryInterface, r *mocks.MockRevisionRepositoryInterface) {
+				m.EXPECT().GetByID(1, nil).Return(validItem, nil).Times(1)
+				moveTo := 2
+				m.EXPECT().ChangeItem(1, models.ItemChanges{Title: "new title", Content: "new content", Date: newDate, ListID: &moveTo}, gomock.Not(gomock.Nil())).Return(nil).Times(1)
+			},
+			id: "1",
+			requestBody: map[string]interface{}{
+				"title":     "new title",
+				"content":   "new content",
+				"item_date": "2025-10-23",
+				"list_id":   2,
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response models.Item
+				if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+					t.Fatalf("failed to unmarshal response: %v", err)
+				}
+
+				if response.ID != 1 || response.ListID != 2 {
+					t.Errorf("expected item 1 in list 2, got item %d in list %d", response.ID, response.ListID)
+				}
+			},
+		},
+		{
+			name: "move item to a list that does not exist",
+			setupMock: func(m *mocks.MockItemRepositoryInterface, r *mocks.MockRevisionRepositoryInterface) {
+				m.EXPECT().GetByID(1, nil).Return(validItem, nil).Times(1)
+				// the move fails as a whole, so neither the title nor a revision is written
+				moveTo := 99
+				m.EXPECT().ChangeItem(1, models.ItemChanges{Title: "new title", Content: "new content", Date: newDate, ListID: &moveTo}, gomock.Any()).Return(repository.ErrListNotFound).Times(1)
+			},
+			id: "1",
+			requestBody: map[string]interface{}{
+				"title":     "new title",
+				"content":   "new content",
+				"item_date": "2025-10-23",
+				"list_id":   99,
+			},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse:  nil,
+		},
 		{
 			name: "item not found on GetByID",
-			setupMock: func(m *mocks.MockItemRepositoryInterface) {
+			setupMock: func(m *mocks.MockItemRepositoryInterface, r *mocks.MockRevisionRepositoryInterface) {
 				// GetByID fails - UpdateItem is never called
 				m.EXPECT().
-					GetByID(999).
+					GetByID(999, nil).
 					Return(nil, repository.ErrNotFound).
 					Times(1)
 			},
@@ -440,7 +707,7 @@ func TestUpdateItem(t *testing.T) {
 		},
 		{
 			name: "invalid ID format",
-			setupMock: func(m *mocks.MockItemRepositoryInterface) {
+			setupMock: func(m *mocks.MockItemRepositoryInterface, r *mocks.MockRevisionRepositoryInterface) {
 				// No expectations - should fail before reaching repo
 			},
 			id: "invalid",
@@ -454,7 +721,7 @@ func TestUpdateItem(t *testing.T) {
 		},
 		{
 			name: "invalid date format",
-			setupMock: func(m *mocks.MockItemRepositoryInterface) {
+			setupMock: func(m *mocks.MockItemRepositoryInterface, r *mocks.MockRevisionRepositoryInterface) {
 				// No expectations - should fail before reaching repo
 			},
 			id: "1",
@@ -468,7 +735,7 @@ func TestUpdateItem(t *testing.T) {
 		},
 		{
 			name: "missing required fields",
-			setupMock: func(m *mocks.MockItemRepositoryInterface) {
+			setupMock: func(m *mocks.MockItemRepositoryInterface, r *mocks.MockRevisionRepositoryInterface) {
 				// No expectations - should fail at binding
 			},
 			id: "1",
@@ -488,9 +755,10 @@ func TestUpdateItem(t *testing.T) {
 			ctrl := gomock.NewController(t)
 
 			repo := mocks.NewMockItemRepositoryInterface(ctrl)
-			handler := handlers.NewItemHandler(repo)
+			revisions := mocks.NewMockRevisionRepositoryInterface(ctrl)
+			handler := handlers.NewItemHandler(repo, revisions, quietActivity(ctrl))
 
-			tt.setupMock(repo)
+			tt.setupMock(repo, revisions)
 
 			w := httptest.NewRecorder()
 			c, _ := gin.CreateTestContext(w)
@@ -502,6 +770,7 @@ func TestUpdateItem(t *testing.T) {
 			body, _ := json.Marshal(tt.requestBody)
 			c.Request = httptest.NewRequest(http.MethodPut, "/items/"+tt.id, bytes.NewBuffer(body))
 			c.Request.Header.Set("Content-Type", "application/json")
+			c.Request.Header.Set(handlers.UserHeader, "jenna")
 
 			handler.UpdateItem(c)
 
@@ -516,3 +785,227 @@ func TestUpdateItem(t *testing.T) {
 		})
 	}
 }
+
+func TestGetItemRevisions(t *testing.T) {
+	revisions := []models.Revision{
+		*models.NewRevision(models.EntityItem, 1, "jenna",
+			models.ItemSnapshot("Item 1", validItem.Date, "old content"),
+			models.ItemSnapshot("Item 1", validItem.Date, "new content")),
+	}
+
+	tests := []struct {
+		name           string
+		setupMock      func(r *mocks.MockRevisionRepositoryInterface)
+		id             string
+		expectedStatus int
+		checkResponse  func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "successfully get revisions",
+			setupMock: func(r *mocks.MockRevisionRepositoryInterface) {
+				r.EXPECT().
+					GetRevisions(models.EntityItem, 1).
+					Return(revisions, nil).
+					Times(1)
+			},
+			id:             "1",
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response []models.Revision
+				if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+					t.Fatalf("failed to unmarshal response: %v", err)
+				}
+
+				if len(response) != 1 {
+					t.Fatalf("expected 1 revision, got %d", len(response))
+				}
+				if response[0].Changes["content"].New != "new content" {
+					t.Errorf("expected content change to 'new content', got %+v", response[0].Changes)
+				}
+				if _, ok := response[0].Changes["title"]; ok {
+					t.Errorf("expected unchanged title to be left out of changes")
+				}
+			},
+		},
+		{
+			name: "repository error",
+			setupMock: func(r *mocks.MockRevisionRepositoryInterface) {
+				r.EXPECT().
+					GetRevisions(models.EntityItem, 1).
+					Return(nil, errors.New("database error")).
+					Times(1)
+			},
+			id:             "1",
+			expectedStatus: http.StatusInternalServerError,
+			checkResponse:  nil,
+		},
+		{
+			name:           "invalid id format",
+			setupMock:      func(r *mocks.MockRevisionRepositoryInterface) {},
+			id:             "invalid",
+			expectedStatus: http.StatusBadRequest,
+			checkResponse:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			ctrl := gomock.NewController(t)
+
+			repo := mocks.NewMockItemRepositoryInterface(ctrl)
+			revisions := mocks.NewMockRevisionRepositoryInterface(ctrl)
+			handler := handlers.NewItemHandler(repo, revisions, quietActivity(ctrl))
+
+			tt.setupMock(revisions)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			c.Params = gin.Params{
+				{Key: "id", Value: tt.id},
+			}
+
+			c.Request = httptest.NewRequest(http.MethodGet, "/items/"+tt.id+"/revisions", nil)
+
+			handler.GetItemRevisions(c)
+
+			if tt.expectedStatus != w.Code {
+				t.Errorf("expected status %d, got %d. Response: %s",
+					tt.expectedStatus, w.Code, w.Body.String())
+			}
+
+			if tt.checkResponse != nil {
+				tt.checkResponse(t, w)
+			}
+		})
+	}
+}
+
+func TestRevertItem(t *testing.T) {
+	oldDate := time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC)
+	revision := models.NewRevision(models.EntityItem, 1, "jenna",
+		models.ItemSnapshot("old title", oldDate, "old content"),
+		models.ItemSnapshot(validItem.Title, validItem.Date, validItem.Content))
+	revision.Revision = 1
+
+	tests := []struct {
+		name           string
+		setupMock      func(m *mocks.MockItemRepositoryInterface, r *mocks.MockRevisionRepositoryInterface)
+		id             string
+		rev            string
+		expectedStatus int
+		checkResponse  func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "successfully revert item",
+			setupMock: func(m *mocks.MockItemRepositoryInterface, r *mocks.MockRevisionRepositoryInterface) {
+				r.EXPECT().
+					GetRevision(models.EntityItem, 1, 1).
+					Return(revision, nil).
+					Times(1)
+
+				m.EXPECT().
+					GetByID(1, nil).
+					Return(validItem, nil).
+					Times(1)
+
+				m.EXPECT().
+					ChangeItem(1, models.ItemChanges{Title: "old title", Date: oldDate, Content: "old content"}, gomock.Not(gomock.Nil())).
+					Return(nil).
+					Times(1)
+			},
+			id:             "1",
+			rev:            "1",
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response models.Item
+				if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+					t.Fatalf("failed to unmarshal response: %v", err)
+				}
+
+				if response.Title != "old title" {
+					t.Errorf("expected title 'old title', got '%s'", response.Title)
+				}
+				if response.ID != 1 {
+					t.Errorf("expected id 1, got %d", response.ID)
+				}
+			},
+		},
+		{
+			name: "revision not found",
+			setupMock: func(m *mocks.MockItemRepositoryInterface, r *mocks.MockRevisionRepositoryInterface) {
+				r.EXPECT().
+					GetRevision(models.EntityItem, 1, 7).
+					Return(nil, repository.ErrRevisionNotFound).
+					Times(1)
+			},
+			id:             "1",
+			rev:            "7",
+			expectedStatus: http.StatusNotFound,
+			checkResponse:  nil,
+		},
+		{
+			name: "item no longer exists",
+			setupMock: func(m *mocks.MockItemRepositoryInterface, r *mocks.MockRevisionRepositoryInterface) {
+				r.EXPECT().
+					GetRevision(models.EntityItem, 1, 1).
+					Return(revision, nil).
+					Times(1)
+
+				m.EXPECT().
+					GetByID(1, nil).
+					Return(nil, repository.ErrNotFound).
+					Times(1)
+			},
+			id:             "1",
+			rev:            "1",
+			expectedStatus: http.StatusNotFound,
+			checkResponse:  nil,
+		},
+		{
+			name:           "invalid revision format",
+			setupMock:      func(m *mocks.MockItemRepositoryInterface, r *mocks.MockRevisionRepositoryInterface) {},
+			id:             "1",
+			rev:            "latest",
+			expectedStatus: http.StatusBadRequest,
+			checkResponse:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			ctrl := gomock.NewController(t)
+
+			repo := mocks.NewMockItemRepositoryInterface(ctrl)
+			revisions := mocks.NewMockRevisionRepositoryInterface(ctrl)
+			handler := handlers.NewItemHandler(repo, revisions, quietActivity(ctrl))
+
+			tt.setupMock(repo, revisions)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			c.Params = gin.Params{
+				{Key: "id", Value: tt.id},
+				{Key: "rev", Value: tt.rev},
+			}
+
+			c.Request = httptest.NewRequest(http.MethodPost, "/items/"+tt.id+"/revisions/"+tt.rev+"/revert", nil)
+
+			handler.RevertItem(c)
+
+			if tt.expectedStatus != w.Code {
+				t.Errorf("expected status %d, got %d. Response: %s",
+					tt.expectedStatus, w.Code, w.Body.String())
+			}
+
+			if tt.checkResponse != nil {
+				tt.checkResponse(t, w)
+			}
+		})
+	}
+}
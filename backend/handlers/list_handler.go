@@ -7,27 +7,45 @@ import (
 	"strconv"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
 	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+	"github.com/jennaborowy/fullstack-Go-Docker/service"
 )
 
 // ListHandler is used to process requests related to lists
 type ListHandler struct {
-	repo repository.ListRepositoryInterface
+	lists *service.ListService
 }
 
 // NewListHandler creates and returns a new ListHandler
-func NewListHandler(repo repository.ListRepositoryInterface) *ListHandler {
-	return &ListHandler{repo: repo}
+func NewListHandler(repo repository.ListRepositoryInterface, revisions repository.RevisionRepositoryInterface, activity repository.ActivityRepositoryInterface) *ListHandler {
+	return &ListHandler{lists: service.NewListService(repo, revisions, activity)}
 }
 
-// GetLists gets every list without the individual items
+// GetLists gets every list, without the individual items unless include=items asks for
+// them. include=counts adds how many items each list has.
 func (h *ListHandler) GetLists(c *gin.Context) {
-	lists, err := h.repo.GetAllLists()
+	include, err := models.ParseListInclude(c.QueryArray("include"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	fields, err := models.ParseFields(c.QueryArray("fields"), models.ListFields)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+	// items and counts left out of the fields aren't worth reading
+	include.Items = include.Items && fields.Has("items")
+	include.Counts = include.Counts && fields.Has("counts")
+
+	lists, err := h.lists.All(include, fields)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
-	c.JSON(http.StatusOK, lists)
+	respondFields(c, http.StatusOK, present(c, lists), fields)
 }
 
 // GetList returns a single list with items fetched
@@ -41,7 +59,13 @@ func (h *ListHandler) GetList(c *gin.Context) {
 		return
 	}
 
-	list, err := h.repo.GetList(id)
+	fields, err := models.ParseFields(c.QueryArray("fields"), models.ListFields)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	list, err := h.lists.Get(id, fields)
 	if err != nil {
 		if errors.Is(err, repository.ErrNotFound) {
 			c.JSON(http.StatusNotFound, gin.H{"error": "item not found"})
@@ -52,27 +76,30 @@ func (h *ListHandler) GetList(c *gin.Context) {
 		return
 	}
 
-	c.JSON(http.StatusOK, list)
+	respondFields(c, http.StatusOK, present(c, list), fields)
+}
+
+// ListTitleRequest is the body of a request creating or renaming a list
+type ListTitleRequest struct {
+	Title string `json:"title"`
 }
 
 // CreateList creates a new list
 func (h *ListHandler) CreateList(c *gin.Context) {
-	var input struct {
-		Title string `json:"title"`
-	}
+	var input ListTitleRequest
 
 	if err := c.BindJSON(&input); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
 		return
 	}
 
-	list, err := h.repo.CreateList(input.Title)
+	list, err := h.lists.Create(currentUser(c), input.Title)
 	if err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusCreated, list)
+	c.JSON(http.StatusCreated, present(c, list))
 }
 
 // UpdateListTitle updates the title of an existing list
@@ -85,21 +112,24 @@ func (h *ListHandler) UpdateListTitle(c *gin.Context) {
 		return
 	}
 
-	var input struct {
-		Title string `json:"title"`
-	}
+	var input ListTitleRequest
 	if err := c.BindJSON(&input); err != nil {
 		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
 		return
 	}
 
-	updatedList, err := h.repo.UpdateTitle(id, input.Title)
+	updatedList, err := h.lists.Rename(currentUser(c), id, input.Title)
 	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "list not found"})
+			return
+		}
+
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
-	c.JSON(http.StatusOK, updatedList)
+	c.JSON(http.StatusOK, present(c, updatedList))
 }
 
 // DeleteList deletes a list by ID
@@ -111,10 +141,60 @@ func (h *ListHandler) DeleteList(c *gin.Context) {
 		return
 	}
 
-	if err := h.repo.DeleteList(id); err != nil {
+	if err := h.lists.Delete(currentUser(c), id); err != nil {
 		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
 		return
 	}
 
 	c.Status(http.StatusNoContent)
 }
+
+// GetListRevisions returns the title history of a list, oldest first
+func (h *ListHandler) GetListRevisions(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid list ID"})
+		return
+	}
+
+	revisions, err := h.lists.Revisions(id)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, present(c, revisions))
+}
+
+// RevertList rolls a list's title back to what it was before the given revision.
+// The revert is itself recorded as a new revision.
+func (h *ListHandler) RevertList(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid list ID"})
+		return
+	}
+
+	rev, err := strconv.Atoi(c.Param("rev"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid revision"})
+		return
+	}
+
+	updatedList, err := h.lists.Revert(currentUser(c), id, rev)
+	if err != nil {
+		if errors.Is(err, repository.ErrRevisionNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "revision not found"})
+			return
+		}
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "list not found"})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, present(c, updatedList))
+}
@@ -37,7 +37,7 @@ func TestGetList(t *testing.T) {
 			name: "successfully get list",
 			setupMock: func(m *mocks.MockListRepositoryInterface) {
 				m.EXPECT().
-					GetList(1).
+					GetList(1, nil).
 					Return(validList, nil).
 					Times(1)
 			},
@@ -60,7 +60,7 @@ func TestGetList(t *testing.T) {
 			name: "list does not exist",
 			setupMock: func(m *mocks.MockListRepositoryInterface) {
 				m.EXPECT().
-					GetList(5).
+					GetList(5, nil).
 					Return(nil, repository.ErrNotFound).
 					Times(1)
 			},
@@ -79,7 +79,7 @@ func TestGetList(t *testing.T) {
 			name: "repository error",
 			setupMock: func(m *mocks.MockListRepositoryInterface) {
 				m.EXPECT().
-					GetList(gomock.Any()).
+					GetList(gomock.Any(), nil).
 					Return(nil, errors.New("database error")).
 					Times(1)
 			},
@@ -91,7 +91,7 @@ func TestGetList(t *testing.T) {
 			name: "no items in list, should return",
 			setupMock: func(m *mocks.MockListRepositoryInterface) {
 				m.EXPECT().
-					GetList(3).
+					GetList(3, nil).
 					Return(emptyList, nil).
 					Times(1)
 			},
@@ -119,7 +119,7 @@ func TestGetList(t *testing.T) {
 			ctrl := gomock.NewController(t)
 
 			repo := mocks.NewMockListRepositoryInterface(ctrl)
-			handler := handlers.NewListHandler(repo)
+			handler := handlers.NewListHandler(repo, mocks.NewMockRevisionRepositoryInterface(ctrl), quietActivity(ctrl))
 
 			tt.setupMock(repo)
 
@@ -150,6 +150,7 @@ func TestGetList(t *testing.T) {
 func TestGetLists(t *testing.T) {
 	tests := []struct {
 		name           string
+		query          string
 		setupMock      func(m *mocks.MockListRepositoryInterface)
 		expectedStatus int
 		checkResponse  func(t *testing.T, w *httptest.ResponseRecorder)
@@ -158,7 +159,7 @@ func TestGetLists(t *testing.T) {
 			name: "successfully get all lists",
 			setupMock: func(m *mocks.MockListRepositoryInterface) {
 				m.EXPECT().
-					GetAllLists().
+					GetAllLists(models.ListInclude{}, nil).
 					Return(multipleLists, nil).
 					Times(1)
 			},
@@ -174,7 +175,7 @@ func TestGetLists(t *testing.T) {
 			name: "repository error",
 			setupMock: func(m *mocks.MockListRepositoryInterface) {
 				m.EXPECT().
-					GetAllLists().
+					GetAllLists(models.ListInclude{}, nil).
 					Return(nil, errors.New("database error")).
 					Times(1)
 			},
@@ -185,7 +186,7 @@ func TestGetLists(t *testing.T) {
 			name: "multiple empty lists",
 			setupMock: func(m *mocks.MockListRepositoryInterface) {
 				m.EXPECT().
-					GetAllLists().
+					GetAllLists(models.ListInclude{}, nil).
 					Return(multipleEmptyLists, nil).
 					Times(1)
 			},
@@ -197,11 +198,79 @@ func TestGetLists(t *testing.T) {
 				}
 			},
 		},
+		{
+			name:  "include items and counts",
+			query: "?include=items&include=counts",
+			setupMock: func(m *mocks.MockListRepositoryInterface) {
+				m.EXPECT().
+					GetAllLists(models.ListInclude{Items: true, Counts: true}, nil).
+					Return(multipleLists, nil).
+					Times(1)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:  "include counts",
+			query: "?include=counts",
+			setupMock: func(m *mocks.MockListRepositoryInterface) {
+				m.EXPECT().
+					GetAllLists(models.ListInclude{Counts: true}, nil).
+					Return(multipleLists, nil).
+					Times(1)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid include",
+			query:          "?include=revisions",
+			setupMock:      func(m *mocks.MockListRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:  "only some fields",
+			query: "?fields=id&fields=title",
+			setupMock: func(m *mocks.MockListRepositoryInterface) {
+				m.EXPECT().
+					GetAllLists(models.ListInclude{}, models.Fields{"id", "title"}).
+					Return([]models.List{{ID: 1, Title: "Groceries"}}, nil).
+					Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				expected := `[{"ID":1,"Title":"Groceries"}]`
+				if w.Body.String() != expected {
+					t.Errorf("expected %s, got %s", expected, w.Body.String())
+				}
+			},
+		},
+		{
+			name:  "included items left out of the fields aren't read",
+			query: "?include=items&include=counts&fields=title&fields=counts",
+			setupMock: func(m *mocks.MockListRepositoryInterface) {
+				m.EXPECT().
+					GetAllLists(models.ListInclude{Counts: true}, models.Fields{"title", "counts"}).
+					Return([]models.List{{ID: 1, Title: "Groceries", Counts: &models.ListCounts{Total: 2, Completed: 1}}}, nil).
+					Times(1)
+			},
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				expected := `[{"Title":"Groceries","Counts":{"Total":2,"Completed":1}}]`
+				if w.Body.String() != expected {
+					t.Errorf("expected %s, got %s", expected, w.Body.String())
+				}
+			},
+		},
+		{
+			name:           "unknown field",
+			query:          "?fields=content",
+			setupMock:      func(m *mocks.MockListRepositoryInterface) {},
+			expectedStatus: http.StatusBadRequest,
+		},
 		{
 			name: "no lists have been created",
 			setupMock: func(m *mocks.MockListRepositoryInterface) {
 				m.EXPECT().
-					GetAllLists().
+					GetAllLists(models.ListInclude{}, nil).
 					Return(noLists, nil).
 					Times(1)
 			},
@@ -222,17 +291,21 @@ func TestGetLists(t *testing.T) {
 			ctrl := gomock.NewController(t)
 
 			repo := mocks.NewMockListRepositoryInterface(ctrl)
-			handler := handlers.NewListHandler(repo)
+			handler := handlers.NewListHandler(repo, mocks.NewMockRevisionRepositoryInterface(ctrl), quietActivity(ctrl))
 
 			tt.setupMock(repo)
 
 			w := httptest.NewRecorder()
 			c, _ := gin.CreateTestContext(w)
 
-			c.Request = httptest.NewRequest(http.MethodGet, "/lists/", nil)
+			c.Request = httptest.NewRequest(http.MethodGet, "/lists/"+tt.query, nil)
 
 			handler.GetLists(c)
 
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d. Response: %s",
+					tt.expectedStatus, w.Code, w.Body.String())
+			}
 			if tt.checkResponse != nil {
 				tt.checkResponse(t, w)
 			}
@@ -330,7 +403,7 @@ func TestCreateList(t *testing.T) {
 			ctrl := gomock.NewController(t)
 
 			repo := mocks.NewMockListRepositoryInterface(ctrl)
-			handler := handlers.NewListHandler(repo)
+			handler := handlers.NewListHandler(repo, mocks.NewMockRevisionRepositoryInterface(ctrl), quietActivity(ctrl))
 
 			tt.setupMock(repo)
 
@@ -367,7 +440,7 @@ func TestCreateList(t *testing.T) {
 func TestUpdateList(t *testing.T) {
 	tests := []struct {
 		name           string
-		setupMock      func(m *mocks.MockListRepositoryInterface)
+		setupMock      func(m *mocks.MockListRepositoryInterface, r *mocks.MockRevisionRepositoryInterface)
 		id             string
 		requestBody    map[string]interface{}
 		expectedStatus int
@@ -375,14 +448,24 @@ func TestUpdateList(t *testing.T) {
 	}{
 		{
 			name: "successfully update list",
-			setupMock: func(m *mocks.MockListRepositoryInterface) {
+			setupMock: func(m *mocks.MockListRepositoryInterface, r *mocks.MockRevisionRepositoryInterface) {
 				m.EXPECT().
-					UpdateTitle(1, "Updated Title").
-					Return(&models.List{
-						ID:    1,
-						Title: "Updated Title",
-						Items: []models.Item{},
-					}, nil).
+					GetList(1, nil).
+					Return(validList, nil).
+					Times(1)
+
+				m.EXPECT().
+					UpdateTitle(1, "Updated Title", gomock.Any()).
+					DoAndReturn(func(_ int, _ string, rev *models.Revision) (*models.List, error) {
+						if rev.Changes["title"].Old != validList.Title || rev.Changes["title"].New != "Updated Title" {
+							t.Errorf("unexpected title change %+v", rev.Changes["title"])
+						}
+						return &models.List{
+							ID:    1,
+							Title: "Updated Title",
+							Items: []models.Item{},
+						}, nil
+					}).
 					Times(1)
 			},
 			id: "1",
@@ -403,9 +486,14 @@ func TestUpdateList(t *testing.T) {
 		},
 		{
 			name: "repository error on UpdateList",
-			setupMock: func(m *mocks.MockListRepositoryInterface) {
+			setupMock: func(m *mocks.MockListRepositoryInterface, r *mocks.MockRevisionRepositoryInterface) {
 				m.EXPECT().
-					UpdateTitle(1, "Updated Title").
+					GetList(1, nil).
+					Return(validList, nil).
+					Times(1)
+
+				m.EXPECT().
+					UpdateTitle(1, "Updated Title", gomock.Any()).
 					Return(nil, errors.New("database error")).
 					Times(1)
 			},
@@ -416,9 +504,24 @@ func TestUpdateList(t *testing.T) {
 			expectedStatus: http.StatusInternalServerError,
 			checkResponse:  nil,
 		},
+		{
+			name: "list not found",
+			setupMock: func(m *mocks.MockListRepositoryInterface, r *mocks.MockRevisionRepositoryInterface) {
+				m.EXPECT().
+					GetList(999, nil).
+					Return(nil, repository.ErrNotFound).
+					Times(1)
+			},
+			id: "999",
+			requestBody: map[string]interface{}{
+				"title": "Updated Title",
+			},
+			expectedStatus: http.StatusNotFound,
+			checkResponse:  nil,
+		},
 		{
 			name:      "invalid ID format",
-			setupMock: func(m *mocks.MockListRepositoryInterface) {},
+			setupMock: func(m *mocks.MockListRepositoryInterface, r *mocks.MockRevisionRepositoryInterface) {},
 			id:        "invalid",
 			requestBody: map[string]interface{}{
 				"title": "Updated Title",
@@ -428,9 +531,14 @@ func TestUpdateList(t *testing.T) {
 		},
 		{
 			name: "empty title",
-			setupMock: func(m *mocks.MockListRepositoryInterface) {
+			setupMock: func(m *mocks.MockListRepositoryInterface, r *mocks.MockRevisionRepositoryInterface) {
 				m.EXPECT().
-					UpdateTitle(1, "").
+					GetList(1, nil).
+					Return(validList, nil).
+					Times(1)
+
+				m.EXPECT().
+					UpdateTitle(1, "", gomock.Not(gomock.Nil())).
 					Return(&models.List{
 						ID:    1,
 						Title: "",
@@ -453,9 +561,10 @@ func TestUpdateList(t *testing.T) {
 			ctrl := gomock.NewController(t)
 
 			repo := mocks.NewMockListRepositoryInterface(ctrl)
-			handler := handlers.NewListHandler(repo)
+			revisions := mocks.NewMockRevisionRepositoryInterface(ctrl)
+			handler := handlers.NewListHandler(repo, revisions, quietActivity(ctrl))
 
-			tt.setupMock(repo)
+			tt.setupMock(repo, revisions)
 
 			w := httptest.NewRecorder()
 			c, _ := gin.CreateTestContext(w)
@@ -536,7 +645,7 @@ func TestDeleteList(t *testing.T) {
 			ctrl := gomock.NewController(t)
 
 			repo := mocks.NewMockListRepositoryInterface(ctrl)
-			handler := handlers.NewListHandler(repo)
+			handler := handlers.NewListHandler(repo, mocks.NewMockRevisionRepositoryInterface(ctrl), quietActivity(ctrl))
 
 			tt.setupMock(repo)
 
@@ -550,6 +659,7 @@ func TestDeleteList(t *testing.T) {
 			c.Request = httptest.NewRequest(http.MethodDelete, "/lists/"+tt.id, nil)
 
 			handler.DeleteList(c)
+			c.Writer.WriteHeaderNow() // as gin does once the handler returns, for c.Status
 
 			if tt.expectedStatus != w.Code {
 				t.Errorf("expected status %d, got %d. Response: %s",
@@ -0,0 +1,26 @@
+package handlers
+
+import "github.com/gin-gonic/gin"
+
+// Presenter turns the models a handler responds with into the response body.
+// API versions share handlers and differ only in how they present models.
+type Presenter func(model any) any
+
+const presenterKey = "presenter"
+
+// UsePresenter makes the handlers after it respond through present
+func UsePresenter(present Presenter) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Set(presenterKey, present)
+		c.Next()
+	}
+}
+
+// present converts model into the response body for the request's API version.
+// Without a presenter models are sent as they are, which is what v1 does.
+func present(c *gin.Context, model any) any {
+	if presenter, ok := c.Get(presenterKey); ok {
+		return presenter.(Presenter)(model)
+	}
+	return model
+}
@@ -0,0 +1,77 @@
+// handlers package processes requests through the repositories
+package handlers
+
+import (
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+)
+
+// SearchHandler is used to process full-text search requests
+type SearchHandler struct {
+	repo repository.SearchRepositoryInterface
+}
+
+// NewSearchHandler creates a new SearchHandler
+func NewSearchHandler(repo repository.SearchRepositoryInterface) *SearchHandler {
+	return &SearchHandler{repo: repo}
+}
+
+// Search finds items and lists matching q, optionally filtered by type, list_id,
+// from and to (item dates, YYYY-MM-DD) and limit
+func (h *SearchHandler) Search(c *gin.Context) {
+	query := models.SearchQuery{
+		Query: strings.TrimSpace(c.Query("q")),
+		Type:  c.Query("type"),
+	}
+
+	if query.Query == "" {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "missing search query"})
+		return
+	}
+
+	if query.Type != "" && query.Type != models.EntityItem && query.Type != models.EntityList {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid type"})
+		return
+	}
+
+	if listID := c.Query("list_id"); listID != "" {
+		id, err := strconv.Atoi(listID)
+		if err != nil {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid list_id"})
+			return
+		}
+		query.ListID = id
+	}
+
+	if limit := c.Query("limit"); limit != "" {
+		n, err := strconv.Atoi(limit)
+		if err != nil || n < 0 {
+			c.JSON(http.StatusBadRequest, gin.H{"error": "invalid limit"})
+			return
+		}
+		query.Limit = n
+	}
+
+	var err error
+	if query.From, err = parseTimeParam(c.Query("from")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid from"})
+		return
+	}
+	if query.To, err = parseTimeParam(c.Query("to")); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid to"})
+		return
+	}
+
+	results, err := h.repo.Search(query)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, present(c, results))
+}
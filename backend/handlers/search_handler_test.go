@@ -0,0 +1,118 @@
+package handlers_test
+
+import (
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jennaborowy/fullstack-Go-Docker/handlers"
+	"github.com/jennaborowy/fullstack-Go-Docker/mocks"
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"go.uber.org/mock/gomock"
+)
+
+func TestSearch(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMock      func(m *mocks.MockSearchRepositoryInterface)
+		query          string
+		expectedStatus int
+		checkResponse  func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "successful search with filters",
+			setupMock: func(m *mocks.MockSearchRepositoryInterface) {
+				m.EXPECT().
+					Search(models.SearchQuery{
+						Query:  "groceries",
+						Type:   models.EntityItem,
+						ListID: 1,
+						From:   time.Date(2025, 10, 1, 0, 0, 0, 0, time.UTC),
+						To:     time.Date(2025, 10, 31, 0, 0, 0, 0, time.UTC),
+					}).
+					Return([]models.SearchResult{
+						{Type: models.EntityItem, ID: 3, ListID: 1, Title: "Buy groceries", Snippet: "Buy <mark>groceries</mark>", Rank: 0.6},
+					}, nil).
+					Times(1)
+			},
+			query:          "?q=groceries&type=item&list_id=1&from=2025-10-01&to=2025-10-31",
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response []models.SearchResult
+				if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+					t.Fatalf("failed to unmarshal response: %v", err)
+				}
+
+				if len(response) != 1 || response[0].Snippet != "Buy <mark>groceries</mark>" {
+					t.Errorf("unexpected results %+v", response)
+				}
+			},
+		},
+		{
+			name:           "missing query",
+			setupMock:      func(m *mocks.MockSearchRepositoryInterface) {},
+			query:          "?q=%20",
+			expectedStatus: http.StatusBadRequest,
+			checkResponse:  nil,
+		},
+		{
+			name:           "invalid type",
+			setupMock:      func(m *mocks.MockSearchRepositoryInterface) {},
+			query:          "?q=groceries&type=tag",
+			expectedStatus: http.StatusBadRequest,
+			checkResponse:  nil,
+		},
+		{
+			name:           "invalid date",
+			setupMock:      func(m *mocks.MockSearchRepositoryInterface) {},
+			query:          "?q=groceries&to=soon",
+			expectedStatus: http.StatusBadRequest,
+			checkResponse:  nil,
+		},
+		{
+			name: "repository error",
+			setupMock: func(m *mocks.MockSearchRepositoryInterface) {
+				m.EXPECT().
+					Search(gomock.Any()).
+					Return(nil, errors.New("database error")).
+					Times(1)
+			},
+			query:          "?q=groceries",
+			expectedStatus: http.StatusInternalServerError,
+			checkResponse:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			ctrl := gomock.NewController(t)
+
+			repo := mocks.NewMockSearchRepositoryInterface(ctrl)
+			handler := handlers.NewSearchHandler(repo)
+
+			tt.setupMock(repo)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			c.Request = httptest.NewRequest(http.MethodGet, "/search"+tt.query, nil)
+
+			handler.Search(c)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d. Response: %s",
+					tt.expectedStatus, w.Code, w.Body.String())
+			}
+
+			if tt.checkResponse != nil {
+				tt.checkResponse(t, w)
+			}
+		})
+	}
+}
@@ -0,0 +1,164 @@
+// handlers package processes requests through the repositories
+package handlers
+
+import (
+	"errors"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+)
+
+// SmartListHandler is used to process requests related to smart lists,
+// saved item filters that are evaluated whenever they are read
+type SmartListHandler struct {
+	repo  repository.SmartListRepositoryInterface
+	items repository.ItemRepositoryInterface
+}
+
+// NewSmartListHandler creates a new SmartListHandler
+func NewSmartListHandler(repo repository.SmartListRepositoryInterface, items repository.ItemRepositoryInterface) *SmartListHandler {
+	return &SmartListHandler{repo: repo, items: items}
+}
+
+// SmartListRequest is the body of a request creating or updating a smart list
+type SmartListRequest struct {
+	Title  string                      `json:"title"`
+	Filter models.ItemFilterDefinition `json:"filter"`
+}
+
+// GetSmartLists returns the current user's smart lists
+func (h *SmartListHandler) GetSmartLists(c *gin.Context) {
+	lists, err := h.repo.GetSmartLists(currentUser(c))
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+	c.JSON(http.StatusOK, present(c, lists))
+}
+
+// GetSmartList returns a single smart list definition
+func (h *SmartListHandler) GetSmartList(c *gin.Context) {
+	list, ok := h.fetch(c)
+	if !ok {
+		return
+	}
+	c.JSON(http.StatusOK, present(c, list))
+}
+
+// CreateSmartList saves a new filter for the current user
+func (h *SmartListHandler) CreateSmartList(c *gin.Context) {
+	var input SmartListRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	if _, err := input.Filter.Resolve(time.Now()); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	list, err := h.repo.CreateSmartList(currentUser(c), input.Title, input.Filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusCreated, present(c, list))
+}
+
+// UpdateSmartList replaces the title and filter of a smart list
+func (h *SmartListHandler) UpdateSmartList(c *gin.Context) {
+	list, ok := h.fetch(c)
+	if !ok {
+		return
+	}
+
+	var input SmartListRequest
+	if err := c.ShouldBindJSON(&input); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid request body"})
+		return
+	}
+
+	if _, err := input.Filter.Resolve(time.Now()); err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	updated, err := h.repo.UpdateSmartList(list.ID, input.Title, input.Filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, present(c, updated))
+}
+
+// DeleteSmartList deletes a smart list
+func (h *SmartListHandler) DeleteSmartList(c *gin.Context) {
+	list, ok := h.fetch(c)
+	if !ok {
+		return
+	}
+
+	if err := h.repo.DeleteSmartList(list.ID); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusNoContent, nil)
+}
+
+// GetSmartListItems evaluates a smart list and returns the items it currently matches
+func (h *SmartListHandler) GetSmartListItems(c *gin.Context) {
+	list, ok := h.fetch(c)
+	if !ok {
+		return
+	}
+
+	filter, err := list.Filter.Resolve(time.Now())
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	items, err := h.items.GetFiltered(filter)
+	if err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	c.JSON(http.StatusOK, present(c, items))
+}
+
+// fetch loads the smart list named in the path, writing an error response and
+// returning false if it doesn't exist or belongs to someone else
+func (h *SmartListHandler) fetch(c *gin.Context) (*models.SmartList, bool) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid id"})
+		return nil, false
+	}
+
+	list, err := h.repo.GetSmartList(id)
+	if err != nil {
+		if errors.Is(err, repository.ErrSmartListNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "smart list not found"})
+			return nil, false
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return nil, false
+	}
+
+	if list.Owner != currentUser(c) {
+		c.JSON(http.StatusNotFound, gin.H{"error": "smart list not found"})
+		return nil, false
+	}
+
+	return list, true
+}
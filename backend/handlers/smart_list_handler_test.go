@@ -0,0 +1,220 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jennaborowy/fullstack-Go-Docker/handlers"
+	"github.com/jennaborowy/fullstack-Go-Docker/mocks"
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+	"go.uber.org/mock/gomock"
+)
+
+var (
+	notDone        = false
+	dueThisWeek    = models.ItemFilterDefinition{Due: models.DueThisWeek, Completed: &notDone}
+	validSmartList = &models.SmartList{ID: 1, Owner: "jenna", Title: "This week", Filter: dueThisWeek}
+)
+
+func TestGetSmartListItems(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMock      func(s *mocks.MockSmartListRepositoryInterface, i *mocks.MockItemRepositoryInterface)
+		id             string
+		user           string
+		expectedStatus int
+		checkResponse  func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "successfully evaluate smart list",
+			setupMock: func(s *mocks.MockSmartListRepositoryInterface, i *mocks.MockItemRepositoryInterface) {
+				s.EXPECT().
+					GetSmartList(1).
+					Return(validSmartList, nil).
+					Times(1)
+
+				i.EXPECT().
+					GetFiltered(gomock.Any()).
+					DoAndReturn(func(filter models.ItemFilter) ([]models.Item, error) {
+						if filter.From.IsZero() || filter.To.Sub(filter.From).Hours() != 6*24 {
+							t.Errorf("expected a week long date range, got %v to %v", filter.From, filter.To)
+						}
+						if filter.Completed == nil || *filter.Completed {
+							t.Errorf("expected only items that are not done")
+						}
+						return multipleItems, nil
+					}).
+					Times(1)
+			},
+			id:             "1",
+			user:           "jenna",
+			expectedStatus: http.StatusOK,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response []models.Item
+				if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+					t.Fatalf("failed to unmarshal response: %v", err)
+				}
+
+				if len(response) != len(multipleItems) {
+					t.Errorf("expected %d items, got %d", len(multipleItems), len(response))
+				}
+			},
+		},
+		{
+			name: "smart list belongs to someone else",
+			setupMock: func(s *mocks.MockSmartListRepositoryInterface, i *mocks.MockItemRepositoryInterface) {
+				s.EXPECT().
+					GetSmartList(1).
+					Return(validSmartList, nil).
+					Times(1)
+			},
+			id:             "1",
+			user:           "someone-else",
+			expectedStatus: http.StatusNotFound,
+			checkResponse:  nil,
+		},
+		{
+			name: "smart list does not exist",
+			setupMock: func(s *mocks.MockSmartListRepositoryInterface, i *mocks.MockItemRepositoryInterface) {
+				s.EXPECT().
+					GetSmartList(5).
+					Return(nil, repository.ErrSmartListNotFound).
+					Times(1)
+			},
+			id:             "5",
+			user:           "jenna",
+			expectedStatus: http.StatusNotFound,
+			checkResponse:  nil,
+		},
+		{
+			name: "repository error",
+			setupMock: func(s *mocks.MockSmartListRepositoryInterface, i *mocks.MockItemRepositoryInterface) {
+				s.EXPECT().
+					GetSmartList(1).
+					Return(validSmartList, nil).
+					Times(1)
+
+				i.EXPECT().
+					GetFiltered(gomock.Any()).
+					Return(nil, errors.New("database error")).
+					Times(1)
+			},
+			id:             "1",
+			user:           "jenna",
+			expectedStatus: http.StatusInternalServerError,
+			checkResponse:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			ctrl := gomock.NewController(t)
+
+			repo := mocks.NewMockSmartListRepositoryInterface(ctrl)
+			items := mocks.NewMockItemRepositoryInterface(ctrl)
+			handler := handlers.NewSmartListHandler(repo, items)
+
+			tt.setupMock(repo, items)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			c.Params = gin.Params{
+				{Key: "id", Value: tt.id},
+			}
+
+			c.Request = httptest.NewRequest(http.MethodGet, "/smart-lists/"+tt.id+"/items", nil)
+			c.Request.Header.Set(handlers.UserHeader, tt.user)
+
+			handler.GetSmartListItems(c)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d. Response: %s",
+					tt.expectedStatus, w.Code, w.Body.String())
+			}
+
+			if tt.checkResponse != nil {
+				tt.checkResponse(t, w)
+			}
+		})
+	}
+}
+
+func TestCreateSmartList(t *testing.T) {
+	tests := []struct {
+		name           string
+		setupMock      func(s *mocks.MockSmartListRepositoryInterface)
+		requestBody    string
+		expectedStatus int
+	}{
+		{
+			name: "successfully create smart list",
+			setupMock: func(s *mocks.MockSmartListRepositoryInterface) {
+				s.EXPECT().
+					CreateSmartList("jenna", "This week", dueThisWeek).
+					Return(validSmartList, nil).
+					Times(1)
+			},
+			requestBody:    `{"title": "This week", "filter": {"due": "this_week", "completed": false}}`,
+			expectedStatus: http.StatusCreated,
+		},
+		{
+			name:           "invalid filter",
+			setupMock:      func(s *mocks.MockSmartListRepositoryInterface) {},
+			requestBody:    `{"title": "Later", "filter": {"due": "eventually"}}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name:           "invalid JSON",
+			setupMock:      func(s *mocks.MockSmartListRepositoryInterface) {},
+			requestBody:    `{invalid json}`,
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "repository error",
+			setupMock: func(s *mocks.MockSmartListRepositoryInterface) {
+				s.EXPECT().
+					CreateSmartList(gomock.Any(), gomock.Any(), gomock.Any()).
+					Return(nil, errors.New("database error")).
+					Times(1)
+			},
+			requestBody:    `{"title": "This week", "filter": {"due": "this_week"}}`,
+			expectedStatus: http.StatusInternalServerError,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			ctrl := gomock.NewController(t)
+
+			repo := mocks.NewMockSmartListRepositoryInterface(ctrl)
+			handler := handlers.NewSmartListHandler(repo, mocks.NewMockItemRepositoryInterface(ctrl))
+
+			tt.setupMock(repo)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			c.Request = httptest.NewRequest(http.MethodPost, "/smart-lists", bytes.NewBufferString(tt.requestBody))
+			c.Request.Header.Set("Content-Type", "application/json")
+			c.Request.Header.Set(handlers.UserHeader, "jenna")
+
+			handler.CreateSmartList(c)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d. Response: %s",
+					tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
@@ -0,0 +1,76 @@
+package handlers
+
+import (
+	"encoding/json"
+	"log"
+	"net/http"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+)
+
+// NDJSONContentType is newline-delimited JSON: one value per line
+const NDJSONContentType = "application/x-ndjson"
+
+// streamItems responds with the items stream calls back with, encoding each as it comes
+// rather than holding them all: as a JSON array, or as NDJSON when the client accepts it.
+// Until the first item the response can still be an error. After it a failure can only
+// cut the response short: a JSON array is left unclosed, and NDJSON ends with an
+// {"error": "..."} line.
+func streamItems(c *gin.Context, fields models.Fields, stream func(fn func(item models.Item) error) error) {
+	ndjson := c.NegotiateFormat(gin.MIMEJSON, NDJSONContentType) == NDJSONContentType
+	started := false
+
+	write := func(data []byte) error {
+		_, err := c.Writer.Write(data)
+		return err
+	}
+	err := stream(func(item models.Item) error {
+		data, err := json.Marshal(present(c, item))
+		if err == nil && len(fields) > 0 {
+			data, err = selectFields(data, fields)
+		}
+		if err != nil {
+			return err
+		}
+
+		switch {
+		case ndjson:
+			data = append(data, '\n')
+		case !started:
+			data = append([]byte{'['}, data...)
+		default:
+			data = append([]byte{','}, data...)
+		}
+		if !started {
+			started = true
+			c.Header("Content-Type", contentType(ndjson))
+			c.Status(http.StatusOK)
+		}
+		return write(data)
+	})
+
+	switch {
+	case err != nil && !started:
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+	case err != nil:
+		log.Printf("%s %s: response cut short: %v", c.Request.Method, c.Request.URL.Path, err)
+		if ndjson {
+			line, _ := json.Marshal(gin.H{"error": err.Error()})
+			write(append(line, '\n'))
+		}
+	case !started && ndjson:
+		c.Data(http.StatusOK, contentType(ndjson), nil)
+	case !started:
+		c.Data(http.StatusOK, contentType(ndjson), []byte("[]"))
+	case !ndjson:
+		write([]byte{']'})
+	}
+}
+
+func contentType(ndjson bool) string {
+	if ndjson {
+		return NDJSONContentType + "; charset=utf-8"
+	}
+	return "application/json; charset=utf-8"
+}
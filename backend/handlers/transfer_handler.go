@@ -0,0 +1,149 @@
+// handlers package processes requests through the repositories
+package handlers
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"mime"
+	"net/http"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+	"github.com/jennaborowy/fullstack-Go-Docker/transfer"
+)
+
+// maxImportBytes limits the size of an uploaded import
+const maxImportBytes = 32 << 20
+
+// TransferHandler is used to import and export whole lists
+type TransferHandler struct {
+	lists    repository.ListRepositoryInterface
+	imports  repository.ImportRepositoryInterface
+	activity repository.ActivityRepositoryInterface
+}
+
+// NewTransferHandler creates a new TransferHandler
+func NewTransferHandler(lists repository.ListRepositoryInterface, imports repository.ImportRepositoryInterface, activity repository.ActivityRepositoryInterface) *TransferHandler {
+	return &TransferHandler{lists: lists, imports: imports, activity: activity}
+}
+
+// ExportList downloads a list and its items in the format given by the format query parameter
+func (h *TransferHandler) ExportList(c *gin.Context) {
+	id, err := strconv.Atoi(c.Param("id"))
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "invalid list ID"})
+		return
+	}
+
+	format, ok := transfer.Lookup(c.DefaultQuery("format", "json"))
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unsupported format, expected one of " + strings.Join(transfer.Names(), ", ")})
+		return
+	}
+
+	list, err := h.lists.GetList(id, nil)
+	if err != nil {
+		if errors.Is(err, repository.ErrNotFound) {
+			c.JSON(http.StatusNotFound, gin.H{"error": "list not found"})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	var buf bytes.Buffer
+	if err := format.Write(&buf, list); err != nil {
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	filename := fmt.Sprintf("list-%d%s", id, format.Extension)
+	c.Header("Content-Disposition", mime.FormatMediaType("attachment", map[string]string{"filename": filename}))
+	c.Data(http.StatusOK, format.ContentType+"; charset=utf-8", buf.Bytes())
+}
+
+// ImportList creates a new list from an uploaded file, sent either as the raw request
+// body or as the first file of a multipart form. The format comes from the format query
+// parameter, or else the upload's content type or file name. Every row is read and validated
+// before a single transaction inserts them; if any row is invalid nothing is imported and
+// every row error is reported.
+func (h *TransferHandler) ImportList(c *gin.Context) {
+	body, contentType, filename, err := importUpload(c, maxImportBytes)
+	if err != nil {
+		c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+		return
+	}
+
+	var format transfer.Format
+	var ok bool
+	if name := c.Query("format"); name != "" {
+		format, ok = transfer.Lookup(name)
+	} else {
+		format, ok = transfer.LookupByContentType(contentType, filename)
+	}
+	if !ok {
+		c.JSON(http.StatusBadRequest, gin.H{"error": "unsupported format, expected one of " + strings.Join(transfer.Names(), ", ")})
+		return
+	}
+
+	result, err := transfer.Import(format.NewReader(body), h.imports, c.Query("title"))
+	if err != nil {
+		var tooLarge *http.MaxBytesError
+		if errors.As(err, &tooLarge) {
+			c.JSON(http.StatusRequestEntityTooLarge, gin.H{"error": "upload is too large"})
+			return
+		}
+		if errors.Is(err, transfer.ErrMalformed) {
+			c.JSON(http.StatusBadRequest, gin.H{"error": err.Error()})
+			return
+		}
+
+		c.JSON(http.StatusInternalServerError, gin.H{"error": err.Error()})
+		return
+	}
+
+	if len(result.Errors) > 0 {
+		c.JSON(http.StatusUnprocessableEntity, present(c, result))
+		return
+	}
+
+	logActivity(h.activity, c, models.ActionImport, models.EntityList, int(result.List.ID), int(result.List.ID))
+
+	c.JSON(http.StatusCreated, present(c, result))
+}
+
+// importUpload returns a stream of the uploaded file along with its content type and file name,
+// failing with an *http.MaxBytesError once more than limit bytes have been read
+func importUpload(c *gin.Context, limit int64) (io.Reader, string, string, error) {
+	c.Request.Body = http.MaxBytesReader(c.Writer, c.Request.Body, limit)
+
+	contentType := c.GetHeader("Content-Type")
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if mediaType != "multipart/form-data" {
+		return c.Request.Body, contentType, "", nil
+	}
+
+	reader, err := c.Request.MultipartReader()
+	if err != nil {
+		return nil, "", "", errors.New("invalid multipart upload")
+	}
+
+	for {
+		part, err := reader.NextPart()
+		if errors.Is(err, io.EOF) {
+			return nil, "", "", errors.New("no file in upload")
+		}
+		if err != nil {
+			return nil, "", "", errors.New("invalid multipart upload")
+		}
+		if part.FileName() != "" {
+			return part, part.Header.Get("Content-Type"), part.FileName(), nil
+		}
+	}
+}
@@ -0,0 +1,233 @@
+package handlers_test
+
+import (
+	"bytes"
+	"encoding/json"
+	"mime/multipart"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jennaborowy/fullstack-Go-Docker/handlers"
+	"github.com/jennaborowy/fullstack-Go-Docker/mocks"
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+	"github.com/jennaborowy/fullstack-Go-Docker/transfer"
+	"go.uber.org/mock/gomock"
+)
+
+func TestExportList(t *testing.T) {
+	tests := []struct {
+		name                string
+		setupMock           func(m *mocks.MockListRepositoryInterface)
+		id                  string
+		query               string
+		expectedStatus      int
+		expectedContentType string
+	}{
+		{
+			name: "export as csv",
+			setupMock: func(m *mocks.MockListRepositoryInterface) {
+				m.EXPECT().GetList(1, nil).Return(validList, nil).Times(1)
+			},
+			id:                  "1",
+			query:               "?format=csv",
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "text/csv; charset=utf-8",
+		},
+		{
+			name: "export as json by default",
+			setupMock: func(m *mocks.MockListRepositoryInterface) {
+				m.EXPECT().GetList(1, nil).Return(validList, nil).Times(1)
+			},
+			id:                  "1",
+			query:               "",
+			expectedStatus:      http.StatusOK,
+			expectedContentType: "application/json; charset=utf-8",
+		},
+		{
+			name:           "unsupported format",
+			setupMock:      func(m *mocks.MockListRepositoryInterface) {},
+			id:             "1",
+			query:          "?format=xlsx",
+			expectedStatus: http.StatusBadRequest,
+		},
+		{
+			name: "list does not exist",
+			setupMock: func(m *mocks.MockListRepositoryInterface) {
+				m.EXPECT().GetList(9, nil).Return(nil, repository.ErrListNotFound).Times(1)
+			},
+			id:             "9",
+			query:          "?format=csv",
+			expectedStatus: http.StatusNotFound,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			ctrl := gomock.NewController(t)
+
+			lists := mocks.NewMockListRepositoryInterface(ctrl)
+			handler := handlers.NewTransferHandler(lists, mocks.NewMockImportRepositoryInterface(ctrl), quietActivity(ctrl))
+
+			tt.setupMock(lists)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			c.Params = gin.Params{
+				{Key: "id", Value: tt.id},
+			}
+
+			c.Request = httptest.NewRequest(http.MethodGet, "/lists/"+tt.id+"/export"+tt.query, nil)
+
+			handler.ExportList(c)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d. Response: %s",
+					tt.expectedStatus, w.Code, w.Body.String())
+			}
+
+			if tt.expectedContentType != "" {
+				if contentType := w.Header().Get("Content-Type"); contentType != tt.expectedContentType {
+					t.Errorf("expected content type %s, got %s", tt.expectedContentType, contentType)
+				}
+				if !strings.Contains(w.Body.String(), validList.Items[0].Title) {
+					t.Errorf("expected export to contain the list's items")
+				}
+			}
+		})
+	}
+}
+
+func TestImportList(t *testing.T) {
+	validCSV := "list_title,title,item_date\nGroceries,Milk,2025-10-01\nGroceries,Eggs,2025-10-02\n"
+
+	tests := []struct {
+		name           string
+		setupMock      func(r *mocks.MockImportRepositoryInterface, i *mocks.MockListImporter)
+		buildRequest   func() *http.Request
+		expectedStatus int
+		checkResponse  func(t *testing.T, w *httptest.ResponseRecorder)
+	}{
+		{
+			name: "import csv body",
+			setupMock: func(r *mocks.MockImportRepositoryInterface, i *mocks.MockListImporter) {
+				r.EXPECT().BeginListImport().Return(i, nil).Times(1)
+				i.EXPECT().AddItem(gomock.Any()).Return(nil).Times(2)
+				i.EXPECT().Finish(gomock.Any()).Return(&models.List{ID: 7, Title: "Groceries"}, nil).Times(1)
+				i.EXPECT().Abort().Return(nil).Times(1)
+			},
+			buildRequest: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/lists/import", strings.NewReader(validCSV))
+				req.Header.Set("Content-Type", "text/csv")
+				return req
+			},
+			expectedStatus: http.StatusCreated,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response transfer.Result
+				if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+					t.Fatalf("failed to unmarshal response: %v", err)
+				}
+				if response.Imported != 2 || response.List.ID != 7 {
+					t.Errorf("unexpected result %+v", response)
+				}
+			},
+		},
+		{
+			name: "import multipart upload",
+			setupMock: func(r *mocks.MockImportRepositoryInterface, i *mocks.MockListImporter) {
+				r.EXPECT().BeginListImport().Return(i, nil).Times(1)
+				i.EXPECT().AddItem(gomock.Any()).Return(nil).Times(2)
+				i.EXPECT().Finish(gomock.Any()).Return(&models.List{ID: 7, Title: "Groceries"}, nil).Times(1)
+				i.EXPECT().Abort().Return(nil).Times(1)
+			},
+			buildRequest: func() *http.Request {
+				var body bytes.Buffer
+				form := multipart.NewWriter(&body)
+				form.WriteField("note", "ignored")
+				file, _ := form.CreateFormFile("file", "groceries.csv")
+				file.Write([]byte(validCSV))
+				form.Close()
+
+				req := httptest.NewRequest(http.MethodPost, "/lists/import", &body)
+				req.Header.Set("Content-Type", form.FormDataContentType())
+				return req
+			},
+			expectedStatus: http.StatusCreated,
+			checkResponse:  nil,
+		},
+		{
+			name:      "invalid rows are reported and nothing is imported",
+			setupMock: func(r *mocks.MockImportRepositoryInterface, i *mocks.MockListImporter) {},
+			buildRequest: func() *http.Request {
+				body := `{"title": "Groceries", "items": [{"title": "", "item_date": "2025-10-01"}]}`
+				return httptest.NewRequest(http.MethodPost, "/lists/import?format=json", strings.NewReader(body))
+			},
+			expectedStatus: http.StatusUnprocessableEntity,
+			checkResponse: func(t *testing.T, w *httptest.ResponseRecorder) {
+				var response transfer.Result
+				if err := json.Unmarshal(w.Body.Bytes(), &response); err != nil {
+					t.Fatalf("failed to unmarshal response: %v", err)
+				}
+				if len(response.Errors) != 1 || response.Errors[0].Row != 1 {
+					t.Errorf("expected an error for row 1, got %+v", response.Errors)
+				}
+			},
+		},
+		{
+			name:      "malformed file",
+			setupMock: func(r *mocks.MockImportRepositoryInterface, i *mocks.MockListImporter) {},
+			buildRequest: func() *http.Request {
+				return httptest.NewRequest(http.MethodPost, "/lists/import?format=json", strings.NewReader(`{"items": [`))
+			},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse:  nil,
+		},
+		{
+			name:      "unknown format",
+			setupMock: func(r *mocks.MockImportRepositoryInterface, i *mocks.MockListImporter) {},
+			buildRequest: func() *http.Request {
+				req := httptest.NewRequest(http.MethodPost, "/lists/import", strings.NewReader("data"))
+				req.Header.Set("Content-Type", "application/octet-stream")
+				return req
+			},
+			expectedStatus: http.StatusBadRequest,
+			checkResponse:  nil,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			gin.SetMode(gin.TestMode)
+
+			ctrl := gomock.NewController(t)
+
+			imports := mocks.NewMockImportRepositoryInterface(ctrl)
+			importer := mocks.NewMockListImporter(ctrl)
+			handler := handlers.NewTransferHandler(mocks.NewMockListRepositoryInterface(ctrl), imports, quietActivity(ctrl))
+
+			tt.setupMock(imports, importer)
+
+			w := httptest.NewRecorder()
+			c, _ := gin.CreateTestContext(w)
+
+			c.Request = tt.buildRequest()
+
+			handler.ImportList(c)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d. Response: %s",
+					tt.expectedStatus, w.Code, w.Body.String())
+			}
+
+			if tt.checkResponse != nil {
+				tt.checkResponse(t, w)
+			}
+		})
+	}
+}
@@ -0,0 +1,18 @@
+// handlers package processes requests through the repositories
+package handlers
+
+import "github.com/gin-gonic/gin"
+
+// UserHeader is the request header identifying who is making a change.
+// There is no authentication yet, so it is trusted as-is.
+const UserHeader = "X-User"
+
+const anonymousUser = "anonymous"
+
+// currentUser returns the user making the request, or "anonymous" when no user was given
+func currentUser(c *gin.Context) string {
+	if user := c.GetHeader(UserHeader); user != "" {
+		return user
+	}
+	return anonymousUser
+}
@@ -0,0 +1,155 @@
+// ical package writes items as RFC 5545 iCalendar data
+package ical
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strings"
+	"time"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+)
+
+// Components that items can be written as
+const (
+	ComponentEvent = "event" // all-day VEVENT on the item date
+	ComponentTodo  = "todo"  // VTODO due on the item date
+)
+
+// ProductID identifies this app in the PRODID property
+const ProductID = "-//fullstack-Go-Docker//Lists//EN"
+
+// uidDomain makes item UIDs globally unique
+const uidDomain = "fullstack-go-docker"
+
+// maxLineOctets is the longest a content line may be before it has to be folded
+const maxLineOctets = 75
+
+// Write writes a VCALENDAR with one component per item.
+// Completed items are marked COMPLETED as todos, and prefixed with a check mark as events.
+func Write(w io.Writer, name string, items []models.Item, component string, now time.Time) error {
+	if component != ComponentEvent && component != ComponentTodo {
+		return fmt.Errorf("invalid component %q", component)
+	}
+
+	cw := &contentWriter{w: bufio.NewWriter(w)}
+	stamp := formatDateTime(now)
+
+	cw.line("BEGIN", "VCALENDAR")
+	cw.line("VERSION", "2.0")
+	cw.line("PRODID", ProductID)
+	cw.line("CALSCALE", "GREGORIAN")
+	cw.line("X-WR-CALNAME", escapeText(name))
+
+	for _, item := range items {
+		uid := fmt.Sprintf("item-%d@%s", item.ID, uidDomain)
+		date := item.Date.Format("20060102")
+
+		if component == ComponentTodo {
+			cw.line("BEGIN", "VTODO")
+			cw.line("UID", uid)
+			cw.line("DTSTAMP", stamp)
+			cw.line("DUE;VALUE=DATE", date)
+			cw.line("SUMMARY", escapeText(item.Title))
+			if item.Content != "" {
+				cw.line("DESCRIPTION", escapeText(item.Content))
+			}
+			if item.Completed {
+				cw.line("STATUS", "COMPLETED")
+				cw.line("COMPLETED", formatDateTime(item.UpdatedAt))
+			} else {
+				cw.line("STATUS", "NEEDS-ACTION")
+			}
+			writeTimestamps(cw, item)
+			cw.line("END", "VTODO")
+			continue
+		}
+
+		summary := item.Title
+		if item.Completed {
+			summary = "✓ " + summary
+		}
+
+		cw.line("BEGIN", "VEVENT")
+		cw.line("UID", uid)
+		cw.line("DTSTAMP", stamp)
+		cw.line("DTSTART;VALUE=DATE", date)
+		cw.line("DTEND;VALUE=DATE", item.Date.AddDate(0, 0, 1).Format("20060102"))
+		cw.line("SUMMARY", escapeText(summary))
+		if item.Content != "" {
+			cw.line("DESCRIPTION", escapeText(item.Content))
+		}
+		cw.line("TRANSP", "TRANSPARENT")
+		writeTimestamps(cw, item)
+		cw.line("END", "VEVENT")
+	}
+
+	cw.line("END", "VCALENDAR")
+
+	if cw.err != nil {
+		return cw.err
+	}
+	return cw.w.Flush()
+}
+
+func writeTimestamps(cw *contentWriter, item models.Item) {
+	if !item.CreatedAt.IsZero() {
+		cw.line("CREATED", formatDateTime(item.CreatedAt))
+	}
+	if !item.UpdatedAt.IsZero() {
+		cw.line("LAST-MODIFIED", formatDateTime(item.UpdatedAt))
+	}
+}
+
+// contentWriter writes folded, CRLF terminated content lines, keeping the first error
+type contentWriter struct {
+	w   *bufio.Writer
+	err error
+}
+
+func (cw *contentWriter) line(name, value string) {
+	if cw.err != nil {
+		return
+	}
+	_, cw.err = cw.w.WriteString(fold(name+":"+value) + "\r\n")
+}
+
+// fold splits a content line into lines of at most 75 octets, continuing each with a space.
+// It never splits a UTF-8 character.
+func fold(line string) string {
+	if len(line) <= maxLineOctets {
+		return line
+	}
+
+	var b strings.Builder
+	limit := maxLineOctets
+	octets := 0
+	for _, r := range line {
+		size := len(string(r))
+		if octets+size > limit {
+			b.WriteString("\r\n ")
+			// the leading space counts towards the continuation line's length
+			limit = maxLineOctets - 1
+			octets = 0
+		}
+		b.WriteRune(r)
+		octets += size
+	}
+	return b.String()
+}
+
+// escapeText escapes a TEXT property value
+func escapeText(s string) string {
+	return strings.NewReplacer(
+		`\`, `\\`,
+		";", `\;`,
+		",", `\,`,
+		"\r\n", `\n`,
+		"\n", `\n`,
+	).Replace(s)
+}
+
+func formatDateTime(t time.Time) string {
+	return t.UTC().Format("20060102T150405Z")
+}
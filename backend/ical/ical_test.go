@@ -0,0 +1,94 @@
+package ical_test
+
+import (
+	"bytes"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/ical"
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+)
+
+var now = time.Date(2025, 10, 8, 12, 0, 0, 0, time.UTC)
+
+func TestWrite(t *testing.T) {
+	items := []models.Item{
+		{ID: 1, Title: "Pay rent, today", Date: time.Date(2025, 10, 31, 0, 0, 0, 0, time.UTC), Content: "line one\nline two; done"},
+		{ID: 2, Title: "Water plants", Date: time.Date(2025, 10, 9, 0, 0, 0, 0, time.UTC), Completed: true, UpdatedAt: now},
+	}
+
+	tests := []struct {
+		name      string
+		component string
+		expected  []string
+	}{
+		{
+			name:      "events",
+			component: ical.ComponentEvent,
+			expected: []string{
+				"BEGIN:VCALENDAR\r\n",
+				"X-WR-CALNAME:Daily Tasks\r\n",
+				"UID:item-1@fullstack-go-docker\r\n",
+				"DTSTART;VALUE=DATE:20251031\r\n",
+				"DTEND;VALUE=DATE:20251101\r\n",
+				"SUMMARY:Pay rent\\, today\r\n",
+				"DESCRIPTION:line one\\nline two\\; done\r\n",
+				"SUMMARY:✓ Water plants\r\n",
+				"END:VCALENDAR\r\n",
+			},
+		},
+		{
+			name:      "todos",
+			component: ical.ComponentTodo,
+			expected: []string{
+				"BEGIN:VTODO\r\n",
+				"DUE;VALUE=DATE:20251031\r\n",
+				"STATUS:NEEDS-ACTION\r\n",
+				"STATUS:COMPLETED\r\n",
+				"COMPLETED:20251008T120000Z\r\n",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var buf bytes.Buffer
+			if err := ical.Write(&buf, "Daily Tasks", items, tt.component, now); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			for _, expected := range tt.expected {
+				if !strings.Contains(buf.String(), expected) {
+					t.Errorf("expected output to contain %q, got:\n%s", expected, buf.String())
+				}
+			}
+		})
+	}
+}
+
+func TestWriteFoldsLongLines(t *testing.T) {
+	items := []models.Item{
+		{ID: 1, Title: strings.Repeat("é", 100), Date: now},
+	}
+
+	var buf bytes.Buffer
+	if err := ical.Write(&buf, "Long", items, ical.ComponentEvent, now); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+
+	for _, line := range strings.Split(buf.String(), "\r\n") {
+		if len(line) > 75 {
+			t.Errorf("line is %d octets long: %q", len(line), line)
+		}
+	}
+	if !strings.Contains(buf.String(), "\r\n é") {
+		t.Errorf("expected a folded continuation line")
+	}
+}
+
+func TestWriteRejectsUnknownComponent(t *testing.T) {
+	if err := ical.Write(&bytes.Buffer{}, "x", nil, "journal", now); err == nil {
+		t.Errorf("expected an error")
+	}
+}
@@ -1,29 +1,91 @@
 package main
 
 import (
+	"errors"
+	"flag"
+	"fmt"
 	"log"
+	"log/slog"
+	"net"
+	"net/http"
+	"os"
+	_ "time/tzdata" // embed time zones, the alpine image doesn't ship them
 
+	"github.com/gin-gonic/gin"
 	"github.com/jennaborowy/fullstack-Go-Docker/config"
-	"github.com/jennaborowy/fullstack-Go-Docker/database"
+	"github.com/jennaborowy/fullstack-Go-Docker/grpcserver"
 	"github.com/jennaborowy/fullstack-Go-Docker/routes"
+	"github.com/jennaborowy/fullstack-Go-Docker/service"
 )
 
 func main() {
-	// load config from .env file
-	cfg := config.Load()
-	log.Println("Connecting to DB with URL:", cfg.DatabaseURL)
+	// load config from defaults, a config file, the environment and flags
+	cfg, args, err := config.Load(os.Args[1:])
+	if errors.Is(err, flag.ErrHelp) {
+		return
+	}
+	if err != nil {
+		log.Fatal(err)
+	}
+	setupLogging(cfg.Log)
 
-	// Connect to database
-	db, err := database.Connect(cfg.DatabaseURL)
+	// Print the configuration, secrets redacted, rather than run anything
+	if len(args) > 0 && args[0] == "config" {
+		fmt.Print(cfg)
+		return
+	}
+
+	// Connect to the storage backend and apply any pending schema migrations
+	repos, db, err := openStorage(cfg)
 	if err != nil {
-		log.Fatal("Failed to connect to database:", err)
+		log.Fatal("Failed to open storage:", err)
+	}
+	if db != nil {
+		defer db.Close()
+	}
+
+	// the cache is set up for commands too, so a restore clears the entries in Redis
+	repos, err = cacheRepositories(repos, cfg)
+	if err != nil {
+		log.Fatal("Failed to set up the cache:", err)
+	}
+
+	// Run a maintenance command such as backup or restore instead of the server
+	if len(args) > 0 {
+		if cfg.Database.Storage != config.StoragePostgres {
+			log.Fatalf("%s needs STORAGE=%s", args[0], config.StoragePostgres)
+		}
+		if err := runCommand(args[0], args[1:], repos.Backup); err != nil {
+			log.Fatalf("%s failed: %v", args[0], err)
+		}
+		return
 	}
-	defer db.Close()
 
-	log.Println("Database connected successfully")
+	repos = rateLimitStore(repos, db, cfg)
+
+	// every change is logged as activity, which gRPC clients can watch
+	changes := service.NewChanges(repos.Activity)
+	repos.Activity = changes
 
 	// create a new gin engine
-	r := routes.SetupRoutes(db)
+	r := routes.NewRouter(repos, cfg)
+
+	// Start the gRPC server on its own port, sharing the repositories with the REST API
+	if cfg.Features.GRPC {
+		lis, err := net.Listen("tcp", ":"+cfg.Server.GRPCPort)
+		if err != nil {
+			log.Fatal("Failed to listen for gRPC:", err)
+		}
+		items := service.NewItemService(repos.Items, repos.Revisions, repos.Activity)
+		lists := service.NewListService(repos.Lists, repos.Revisions, repos.Activity)
+		grpcServer := grpcserver.NewServer(items, lists, changes)
+		go func() {
+			log.Printf("gRPC server starting on port %s", cfg.Server.GRPCPort)
+			if err := grpcServer.Serve(lis); err != nil {
+				log.Fatal("Failed to start gRPC server:", err)
+			}
+		}()
+	}
 
 	//define routes
 	// r.GET("/", func(c *gin.Context) {
@@ -33,9 +95,35 @@ func main() {
 	// })
 
 	// Start server
-	log.Printf("Server starting on port %s", cfg.Port)
-	if err := r.Run(":" + cfg.Port); err != nil {
+	server := &http.Server{
+		Addr:              ":" + cfg.Server.Port,
+		Handler:           r,
+		ReadHeaderTimeout: cfg.Server.ReadHeaderTimeout,
+		ReadTimeout:       cfg.Server.ReadTimeout,
+		WriteTimeout:      cfg.Server.WriteTimeout,
+		IdleTimeout:       cfg.Server.IdleTimeout,
+	}
+	log.Printf("Server starting on port %s", cfg.Server.Port)
+	if err := server.ListenAndServe(); err != nil {
 		log.Fatal("Failed to start server:", err)
 	}
 
 }
+
+// setupLogging sends everything logged, through log or slog, to stderr at the configured
+// level and format. Gin only prints its debug output at the debug level.
+func setupLogging(cfg config.LogConfig) {
+	var level slog.Level
+	level.UnmarshalText([]byte(cfg.Level)) // already validated
+	options := &slog.HandlerOptions{Level: level}
+
+	var handler slog.Handler = slog.NewTextHandler(os.Stderr, options)
+	if cfg.Format == config.LogJSON {
+		handler = slog.NewJSONHandler(os.Stderr, options)
+	}
+	slog.SetDefault(slog.New(handler))
+
+	if level > slog.LevelDebug {
+		gin.SetMode(gin.ReleaseMode)
+	}
+}
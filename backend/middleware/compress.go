@@ -0,0 +1,207 @@
+package middleware
+
+import (
+	"compress/gzip"
+	"io"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+	"sync"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+	"github.com/klauspost/compress/zstd"
+)
+
+// compressMinSize is the smallest response worth compressing. Anything smaller is sent
+// as it is, since compressing it saves next to nothing.
+const compressMinSize = 1024
+
+// encoder compresses a response body
+type encoder interface {
+	io.WriteCloser
+	Flush() error
+	Reset(w io.Writer)
+}
+
+// encodings are the content encodings responses can be compressed with, best first.
+// Encoders are pooled, since zstd and brotli allocate a lot to set one up.
+var encodings = []struct {
+	name string
+	pool *sync.Pool
+}{
+	{"zstd", &sync.Pool{New: func() any {
+		w, _ := zstd.NewWriter(nil, zstd.WithEncoderLevel(zstd.SpeedDefault), zstd.WithEncoderConcurrency(1), zstd.WithLowerEncoderMem(true))
+		return &zstdEncoder{w}
+	}}},
+	{"br", &sync.Pool{New: func() any { return brotli.NewWriterLevel(nil, 5) }}},
+	{"gzip", &sync.Pool{New: func() any { return gzip.NewWriter(nil) }}},
+}
+
+// zstdEncoder adapts zstd.Encoder, whose Reset returns nothing
+type zstdEncoder struct{ *zstd.Encoder }
+
+func (e *zstdEncoder) Reset(w io.Writer) { e.Encoder.Reset(w) }
+
+// CompressionMiddleware compresses text and JSON responses with the best encoding the
+// request's Accept-Encoding allows: zstd, br or gzip. Small responses, responses that
+// already have a Content-Encoding and partial content are sent as they are. Streamed
+// responses stay streamed: each flush is passed on compressed.
+func CompressionMiddleware() gin.HandlerFunc {
+	return func(c *gin.Context) {
+		c.Writer.Header().Add("Vary", "Accept-Encoding")
+		encoding := negotiateEncoding(c.GetHeader("Accept-Encoding"))
+		if encoding < 0 || c.Request.Method == http.MethodHead {
+			c.Next()
+			return
+		}
+
+		writer := &compressWriter{ResponseWriter: c.Writer, encoding: encoding}
+		c.Writer = writer
+		c.Next()
+		// not deferred: after a panic what was held back is dropped, and the recovery
+		// middleware's 500 goes through as it is
+		writer.close()
+		c.Writer = writer.ResponseWriter
+	}
+}
+
+// negotiateEncoding returns the index in encodings of the encoding accept prefers, or -1
+// for none. Encodings accept weighs the same are picked in the order of encodings.
+func negotiateEncoding(accept string) int {
+	weights := map[string]float64{}
+	for _, part := range strings.Split(accept, ",") {
+		name, params, _ := strings.Cut(strings.TrimSpace(part), ";")
+		weight := 1.0
+		for _, param := range strings.Split(params, ";") {
+			key, value, _ := strings.Cut(strings.TrimSpace(param), "=")
+			if strings.EqualFold(key, "q") {
+				if q, err := strconv.ParseFloat(value, 64); err == nil {
+					weight = q
+				}
+			}
+		}
+		if name != "" {
+			weights[strings.ToLower(name)] = weight
+		}
+	}
+
+	best, bestWeight := -1, 0.0
+	for i, encoding := range encodings {
+		weight, ok := weights[encoding.name]
+		if !ok {
+			weight, ok = weights["*"]
+		}
+		if ok && weight > bestWeight {
+			best, bestWeight = i, weight
+		}
+	}
+	return best
+}
+
+// compressible reports whether a content type is text worth compressing
+func compressible(contentType string) bool {
+	mediaType, _, err := mime.ParseMediaType(contentType)
+	if err != nil {
+		return false
+	}
+	return strings.HasPrefix(mediaType, "text/") ||
+		strings.HasSuffix(mediaType, "+json") || strings.HasSuffix(mediaType, "+xml") ||
+		slices.Contains([]string{"application/json", "application/x-ndjson", "application/javascript", "application/xml", "application/yaml"}, mediaType)
+}
+
+// compressWriter holds back the start of a response until it knows whether it is worth
+// compressing: once compressMinSize bytes have been written, the response is flushed or
+// the handler returns
+type compressWriter struct {
+	gin.ResponseWriter
+	encoding int
+	buffer   []byte
+	decided  bool
+	encoder  encoder // nil unless compressing
+}
+
+func (w *compressWriter) Write(data []byte) (int, error) {
+	if !w.decided {
+		w.buffer = append(w.buffer, data...)
+		if len(w.buffer) < compressMinSize {
+			return len(data), nil
+		}
+		if err := w.decide(false); err != nil {
+			return 0, err
+		}
+		return len(data), nil
+	}
+	if w.encoder != nil {
+		return w.encoder.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *compressWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *compressWriter) Written() bool {
+	return len(w.buffer) > 0 || w.ResponseWriter.Written()
+}
+
+// Flush sends what has been written so far. A response flushed is a stream, so it is
+// compressed whatever its size so far.
+func (w *compressWriter) Flush() {
+	if err := w.decide(true); err != nil {
+		return
+	}
+	if w.encoder != nil {
+		w.encoder.Flush()
+	}
+	w.ResponseWriter.Flush()
+}
+
+// decide starts compressing if the response is worth it, then writes what was held back
+func (w *compressWriter) decide(streaming bool) error {
+	if w.decided {
+		return nil
+	}
+	w.decided = true
+
+	header := w.Header()
+	if (streaming || len(w.buffer) >= compressMinSize) && !w.ResponseWriter.Written() &&
+		header.Get("Content-Encoding") == "" && w.Status() != http.StatusPartialContent &&
+		compressible(header.Get("Content-Type")) {
+		header.Set("Content-Encoding", encodings[w.encoding].name)
+		header.Del("Content-Length")
+		if etag := header.Get("ETag"); etag != "" && !strings.HasPrefix(etag, "W/") {
+			// the compressed bytes differ from those the strong ETag stands for
+			header.Set("ETag", "W/"+etag)
+		}
+		w.encoder = encodings[w.encoding].pool.Get().(encoder)
+		w.encoder.Reset(w.ResponseWriter)
+	}
+
+	buffer := w.buffer
+	w.buffer = nil
+	if len(buffer) == 0 {
+		return nil
+	}
+	var err error
+	if w.encoder != nil {
+		_, err = w.encoder.Write(buffer)
+	} else {
+		_, err = w.ResponseWriter.Write(buffer)
+	}
+	return err
+}
+
+// close writes out the rest of the response once the handler has returned
+func (w *compressWriter) close() {
+	w.decide(false)
+	if w.encoder != nil {
+		w.encoder.Close()
+		w.encoder.Reset(nil)
+		encodings[w.encoding].pool.Put(w.encoder)
+		w.encoder = nil
+	}
+}
@@ -0,0 +1,22 @@
+package middleware
+
+import (
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+)
+
+// DeprecationMiddleware marks responses as coming from a deprecated API version with the
+// Deprecation header (RFC 9745), and links the same path under successorPrefix as the
+// successor version
+func DeprecationMiddleware(since time.Time, prefix, successorPrefix string) gin.HandlerFunc {
+	deprecation := fmt.Sprintf("@%d", since.Unix())
+	return func(c *gin.Context) {
+		successor := successorPrefix + strings.TrimPrefix(c.Request.URL.Path, prefix)
+		c.Header("Deprecation", deprecation)
+		c.Header("Link", fmt.Sprintf("<%s>; rel=\"successor-version\"", successor))
+		c.Next()
+	}
+}
@@ -0,0 +1,260 @@
+package middleware
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"io"
+	"log"
+	"mime"
+	"net/http"
+	"slices"
+	"strconv"
+	"strings"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jennaborowy/fullstack-Go-Docker/openapi"
+)
+
+// ValidationMode decides what OpenAPIValidationMiddleware checks
+type ValidationMode string
+
+const (
+	// ValidateOff turns validation off
+	ValidateOff ValidationMode = "off"
+	// ValidateRequests rejects requests that don't match the document
+	ValidateRequests ValidationMode = "requests"
+	// ValidateAll also checks JSON responses, which are buffered to do so. Meant for
+	// development and tests: a response that doesn't match is replaced by a 500.
+	ValidateAll ValidationMode = "all"
+)
+
+// Problem is an RFC 9457 problem details body. Error repeats Detail, so clients reading
+// the {"error": "..."} body the rest of the API returns still get a message.
+type Problem struct {
+	Type   string   `json:"type"`
+	Title  string   `json:"title"`
+	Status int      `json:"status"`
+	Detail string   `json:"detail"`
+	Errors []string `json:"errors,omitempty"`
+	Error  string   `json:"error"`
+}
+
+const problemContentType = "application/problem+json"
+
+// OpenAPIValidationMiddleware checks requests to documented routes against doc: path and
+// query parameters, and JSON bodies. Requests that don't match are rejected with a 400
+// problem. In ValidateAll mode JSON responses are checked too.
+func OpenAPIValidationMiddleware(doc *openapi.Document, mode ValidationMode) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		op := doc.Operation(c.Request.Method, c.FullPath())
+		if mode == ValidateOff || op == nil {
+			c.Next()
+			return
+		}
+
+		if err := validateRequest(c, doc, op); err != nil {
+			writeProblem(c, http.StatusBadRequest, "Invalid request", err)
+			c.Abort()
+			return
+		}
+
+		if mode != ValidateAll {
+			c.Next()
+			return
+		}
+
+		writer := &validatingWriter{ResponseWriter: c.Writer}
+		c.Writer = writer
+		c.Next()
+		c.Writer = writer.ResponseWriter
+
+		if !writer.decided && writer.status != 0 {
+			// nothing was written, only a status
+			writer.ResponseWriter.WriteHeader(writer.status)
+		}
+		if !writer.buffering {
+			return
+		}
+		partial := sparse(c, op) && writer.status < 300
+		if err := validateResponse(doc, op, writer.status, writer.Header().Get("Content-Type"), writer.body.Bytes(), partial); err != nil {
+			log.Printf("%s %s: response does not match the OpenAPI document: %v", c.Request.Method, c.FullPath(), err)
+			writeProblem(c, http.StatusInternalServerError, "Invalid response", err)
+			return
+		}
+		writer.flush()
+	}
+}
+
+func validateRequest(c *gin.Context, doc *openapi.Document, op *openapi.Operation) error {
+	var problems []string
+	add := func(err error) {
+		var invalid *openapi.ValidationError
+		if errors.As(err, &invalid) {
+			problems = append(problems, invalid.Problems...)
+		} else if err != nil {
+			problems = append(problems, err.Error())
+		}
+	}
+
+	query := c.Request.URL.Query()
+	for _, param := range op.Parameters {
+		switch param.In {
+		case "path":
+			add(doc.ValidateParameter(param, []string{c.Param(param.Name)}))
+		case "query":
+			add(doc.ValidateParameter(param, query[param.Name]))
+		}
+	}
+
+	if body := op.RequestBody; body != nil && body.Content["application/json"] != nil {
+		data, err := io.ReadAll(c.Request.Body)
+		if err != nil {
+			var tooLarge *http.MaxBytesError
+			if errors.As(err, &tooLarge) {
+				return err
+			}
+			return fmt.Errorf("could not read request body: %w", err)
+		}
+		c.Request.Body = io.NopCloser(bytes.NewReader(data))
+
+		if len(bytes.TrimSpace(data)) == 0 {
+			if body.Required {
+				problems = append(problems, "body is required")
+			}
+		} else {
+			add(doc.ValidateJSON(body.Content["application/json"].Schema, data))
+		}
+	}
+
+	if len(problems) > 0 {
+		return &openapi.ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// fieldsParameter is the query parameter limiting a response to some of its fields
+const fieldsParameter = "fields"
+
+// sparse reports whether the request asks op for a sparse fieldset, so its successful
+// response may leave out required properties
+func sparse(c *gin.Context, op *openapi.Operation) bool {
+	if _, ok := c.Request.URL.Query()[fieldsParameter]; !ok {
+		return false
+	}
+	return slices.ContainsFunc(op.Parameters, func(param *openapi.Parameter) bool {
+		return param.In == "query" && param.Name == fieldsParameter
+	})
+}
+
+// validateResponse checks a response against op, leaving out required properties if partial
+func validateResponse(doc *openapi.Document, op *openapi.Operation, status int, contentType string, body []byte, partial bool) error {
+	response, ok := op.Responses[strconv.Itoa(status)]
+	if !ok {
+		return fmt.Errorf("status %d is not documented", status)
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(contentType)
+	if len(body) == 0 || mediaType == "" {
+		if len(response.Content) > 0 && len(body) > 0 {
+			return errors.New("response has no content type")
+		}
+		return nil
+	}
+
+	content, ok := response.Content[mediaType]
+	if !ok {
+		return fmt.Errorf("content type %s is not documented for status %d", mediaType, status)
+	}
+	if partial {
+		return doc.ValidatePartialJSON(content.Schema, body)
+	}
+	return doc.ValidateJSON(content.Schema, body)
+}
+
+func writeProblem(c *gin.Context, status int, title string, err error) {
+	problem := Problem{Type: "about:blank", Title: title, Status: status, Detail: err.Error(), Error: err.Error()}
+	var invalid *openapi.ValidationError
+	if errors.As(err, &invalid) {
+		problem.Errors = invalid.Problems
+	}
+	// c.JSON keeps a content type that is already set
+	c.Header("Content-Type", problemContentType)
+	c.JSON(status, problem)
+}
+
+// validatingWriter holds back JSON responses until they have been validated. Other
+// responses, such as file downloads, are passed straight through.
+type validatingWriter struct {
+	gin.ResponseWriter
+	status    int
+	body      bytes.Buffer
+	decided   bool
+	buffering bool
+}
+
+func (w *validatingWriter) WriteHeader(status int) {
+	if w.status == 0 {
+		w.status = status
+	}
+}
+
+func (w *validatingWriter) WriteHeaderNow() {
+	w.decide()
+	if !w.buffering {
+		w.ResponseWriter.WriteHeaderNow()
+	}
+}
+
+func (w *validatingWriter) Write(data []byte) (int, error) {
+	w.decide()
+	if w.buffering {
+		return w.body.Write(data)
+	}
+	return w.ResponseWriter.Write(data)
+}
+
+func (w *validatingWriter) WriteString(s string) (int, error) {
+	return w.Write([]byte(s))
+}
+
+func (w *validatingWriter) Status() int {
+	if w.status != 0 {
+		return w.status
+	}
+	return w.ResponseWriter.Status()
+}
+
+func (w *validatingWriter) Written() bool {
+	return w.decided || w.ResponseWriter.Written()
+}
+
+func (w *validatingWriter) Size() int {
+	if w.buffering {
+		return w.body.Len()
+	}
+	return w.ResponseWriter.Size()
+}
+
+// decide picks buffering or passing through once the handler starts writing, when
+// its content type is known
+func (w *validatingWriter) decide() {
+	if w.decided {
+		return
+	}
+	w.decided = true
+	if w.status == 0 {
+		w.status = http.StatusOK
+	}
+
+	mediaType, _, _ := mime.ParseMediaType(w.Header().Get("Content-Type"))
+	w.buffering = mediaType == "application/json" || strings.HasSuffix(mediaType, "+json")
+	if !w.buffering {
+		w.ResponseWriter.WriteHeader(w.status)
+	}
+}
+
+func (w *validatingWriter) flush() {
+	w.ResponseWriter.WriteHeader(w.status)
+	w.ResponseWriter.Write(w.body.Bytes())
+}
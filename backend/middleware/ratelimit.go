@@ -0,0 +1,86 @@
+package middleware
+
+import (
+	"crypto/sha256"
+	"encoding/hex"
+	"fmt"
+	"log"
+	"math"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jennaborowy/fullstack-Go-Docker/ratelimit"
+)
+
+// RateLimitKey decides who RateLimitMiddleware counts a request against
+type RateLimitKey string
+
+const (
+	// RateLimitByIP counts requests against the client's IP address
+	RateLimitByIP RateLimitKey = "ip"
+	// RateLimitByUser counts requests against the X-User header, or the IP address
+	// without one. X-User isn't authenticated, so a client can dodge this by changing it.
+	RateLimitByUser RateLimitKey = "user"
+	// RateLimitByAPIKey counts requests against the "Authorization: Bearer" token, or the
+	// IP address without one
+	RateLimitByAPIKey RateLimitKey = "api_key"
+)
+
+// RateLimitMiddleware refuses requests over limiter's limits with a 429 and a
+// Retry-After header. Limited responses carry RateLimit-Limit, RateLimit-Remaining,
+// RateLimit-Reset and RateLimit-Policy headers, as drafted by the IETF. Routes are
+// matched to limiter's rules without prefix, so a rule applies to every API version, and
+// the versions share its bucket. When the store fails requests are let through.
+func RateLimitMiddleware(limiter *ratelimit.Limiter, key RateLimitKey, prefix string) gin.HandlerFunc {
+	return func(c *gin.Context) {
+		route := strings.TrimPrefix(c.FullPath(), prefix)
+		result, err := limiter.Allow(c.Request.Method, route, rateLimitClient(c, key))
+		if err != nil {
+			log.Printf("rate limit not applied to %s %s: %v", c.Request.Method, c.Request.URL.Path, err)
+			c.Next()
+			return
+		}
+		if result.Limit.Unlimited() {
+			c.Next()
+			return
+		}
+
+		c.Header("RateLimit-Limit", strconv.Itoa(result.Limit.Requests))
+		c.Header("RateLimit-Remaining", strconv.Itoa(result.Remaining))
+		c.Header("RateLimit-Reset", strconv.Itoa(seconds(result.Reset)))
+		c.Header("RateLimit-Policy", fmt.Sprintf("%d;w=%d", result.Limit.Requests, seconds(result.Limit.Period)))
+		if !result.Allowed {
+			retry := max(seconds(result.RetryAfter), 1)
+			c.Header("Retry-After", strconv.Itoa(retry))
+			c.AbortWithStatusJSON(http.StatusTooManyRequests, gin.H{"error": fmt.Sprintf("rate limit of %d requests per %v exceeded, retry in %ds", result.Limit.Requests, result.Limit.Period, retry)})
+			return
+		}
+
+		c.Next()
+	}
+}
+
+// rateLimitClient identifies who made the request. Tokens are hashed, so they aren't
+// kept in the store.
+func rateLimitClient(c *gin.Context, key RateLimitKey) string {
+	switch key {
+	case RateLimitByUser:
+		if user := c.GetHeader("X-User"); user != "" {
+			return "user:" + user
+		}
+	case RateLimitByAPIKey:
+		if token, ok := strings.CutPrefix(c.GetHeader("Authorization"), "Bearer "); ok && token != "" {
+			sum := sha256.Sum256([]byte(token))
+			return "key:" + hex.EncodeToString(sum[:16])
+		}
+	}
+	return "ip:" + c.ClientIP()
+}
+
+// seconds rounds d up to whole seconds
+func seconds(d time.Duration) int {
+	return int(math.Ceil(d.Seconds()))
+}
@@ -0,0 +1,34 @@
+package middleware
+
+import (
+	"fmt"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jennaborowy/fullstack-Go-Docker/config"
+)
+
+// SecurityHeadersMiddleware sends the security headers cfg sets with every response,
+// and X-Content-Type-Options: nosniff. Strict-Transport-Security is only sent over
+// HTTPS, directly or behind a proxy setting X-Forwarded-Proto, since browsers ignore it
+// over plain HTTP. Handlers can replace the Content-Security-Policy, as the docs page does.
+func SecurityHeadersMiddleware(cfg config.SecurityConfig) gin.HandlerFunc {
+	hsts := fmt.Sprintf("max-age=%d; includeSubDomains", int(cfg.HSTSMaxAge.Seconds()))
+	return func(c *gin.Context) {
+		c.Header("X-Content-Type-Options", "nosniff")
+		if configured(cfg.FrameOptions) {
+			c.Header("X-Frame-Options", cfg.FrameOptions)
+		}
+		if configured(cfg.ContentSecurityPolicy) {
+			c.Header("Content-Security-Policy", cfg.ContentSecurityPolicy)
+		}
+		if cfg.HSTSMaxAge > 0 && (c.Request.TLS != nil || c.GetHeader("X-Forwarded-Proto") == "https") {
+			c.Header("Strict-Transport-Security", hsts)
+		}
+		c.Next()
+	}
+}
+
+// configured reports whether a header's value is configured, rather than empty or off
+func configured(value string) bool {
+	return value != "" && value != "off"
+}
@@ -0,0 +1,70 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: .\repository\activity_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source .\repository\activity_repository.go -destination .\mocks\mock_activity_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/jennaborowy/fullstack-Go-Docker/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockActivityRepositoryInterface is a mock of ActivityRepositoryInterface interface.
+type MockActivityRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockActivityRepositoryInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockActivityRepositoryInterfaceMockRecorder is the mock recorder for MockActivityRepositoryInterface.
+type MockActivityRepositoryInterfaceMockRecorder struct {
+	mock *MockActivityRepositoryInterface
+}
+
+// NewMockActivityRepositoryInterface creates a new mock instance.
+func NewMockActivityRepositoryInterface(ctrl *gomock.Controller) *MockActivityRepositoryInterface {
+	mock := &MockActivityRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockActivityRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockActivityRepositoryInterface) EXPECT() *MockActivityRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// GetActivity mocks base method.
+func (m *MockActivityRepositoryInterface) GetActivity(filter models.ActivityFilter) ([]models.Activity, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetActivity", filter)
+	ret0, _ := ret[0].([]models.Activity)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetActivity indicates an expected call of GetActivity.
+func (mr *MockActivityRepositoryInterfaceMockRecorder) GetActivity(filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetActivity", reflect.TypeOf((*MockActivityRepositoryInterface)(nil).GetActivity), filter)
+}
+
+// LogActivity mocks base method.
+func (m *MockActivityRepositoryInterface) LogActivity(activity *models.Activity) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "LogActivity", activity)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// LogActivity indicates an expected call of LogActivity.
+func (mr *MockActivityRepositoryInterfaceMockRecorder) LogActivity(activity any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "LogActivity", reflect.TypeOf((*MockActivityRepositoryInterface)(nil).LogActivity), activity)
+}
@@ -0,0 +1,222 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: .\repository\backup_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source .\repository\backup_repository.go -destination .\mocks\mock_backup_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	json "encoding/json"
+	reflect "reflect"
+
+	repository "github.com/jennaborowy/fullstack-Go-Docker/repository"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockBackupRepositoryInterface is a mock of BackupRepositoryInterface interface.
+type MockBackupRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockBackupRepositoryInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockBackupRepositoryInterfaceMockRecorder is the mock recorder for MockBackupRepositoryInterface.
+type MockBackupRepositoryInterfaceMockRecorder struct {
+	mock *MockBackupRepositoryInterface
+}
+
+// NewMockBackupRepositoryInterface creates a new mock instance.
+func NewMockBackupRepositoryInterface(ctrl *gomock.Controller) *MockBackupRepositoryInterface {
+	mock := &MockBackupRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockBackupRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBackupRepositoryInterface) EXPECT() *MockBackupRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// BeginBackup mocks base method.
+func (m *MockBackupRepositoryInterface) BeginBackup() (repository.BackupSnapshot, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginBackup")
+	ret0, _ := ret[0].(repository.BackupSnapshot)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginBackup indicates an expected call of BeginBackup.
+func (mr *MockBackupRepositoryInterfaceMockRecorder) BeginBackup() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginBackup", reflect.TypeOf((*MockBackupRepositoryInterface)(nil).BeginBackup))
+}
+
+// BeginRestore mocks base method.
+func (m *MockBackupRepositoryInterface) BeginRestore() (repository.BackupRestorer, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginRestore")
+	ret0, _ := ret[0].(repository.BackupRestorer)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginRestore indicates an expected call of BeginRestore.
+func (mr *MockBackupRepositoryInterfaceMockRecorder) BeginRestore() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginRestore", reflect.TypeOf((*MockBackupRepositoryInterface)(nil).BeginRestore))
+}
+
+// MockBackupSnapshot is a mock of BackupSnapshot interface.
+type MockBackupSnapshot struct {
+	ctrl     *gomock.Controller
+	recorder *MockBackupSnapshotMockRecorder
+	isgomock struct{}
+}
+
+// MockBackupSnapshotMockRecorder is the mock recorder for MockBackupSnapshot.
+type MockBackupSnapshotMockRecorder struct {
+	mock *MockBackupSnapshot
+}
+
+// NewMockBackupSnapshot creates a new mock instance.
+func NewMockBackupSnapshot(ctrl *gomock.Controller) *MockBackupSnapshot {
+	mock := &MockBackupSnapshot{ctrl: ctrl}
+	mock.recorder = &MockBackupSnapshotMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBackupSnapshot) EXPECT() *MockBackupSnapshotMockRecorder {
+	return m.recorder
+}
+
+// Close mocks base method.
+func (m *MockBackupSnapshot) Close() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Close")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Close indicates an expected call of Close.
+func (mr *MockBackupSnapshotMockRecorder) Close() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Close", reflect.TypeOf((*MockBackupSnapshot)(nil).Close))
+}
+
+// Rows mocks base method.
+func (m *MockBackupSnapshot) Rows(table string, columns []string, fn func(json.RawMessage) error) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Rows", table, columns, fn)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Rows indicates an expected call of Rows.
+func (mr *MockBackupSnapshotMockRecorder) Rows(table, columns, fn any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Rows", reflect.TypeOf((*MockBackupSnapshot)(nil).Rows), table, columns, fn)
+}
+
+// SchemaVersion mocks base method.
+func (m *MockBackupSnapshot) SchemaVersion() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchemaVersion")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SchemaVersion indicates an expected call of SchemaVersion.
+func (mr *MockBackupSnapshotMockRecorder) SchemaVersion() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchemaVersion", reflect.TypeOf((*MockBackupSnapshot)(nil).SchemaVersion))
+}
+
+// MockBackupRestorer is a mock of BackupRestorer interface.
+type MockBackupRestorer struct {
+	ctrl     *gomock.Controller
+	recorder *MockBackupRestorerMockRecorder
+	isgomock struct{}
+}
+
+// MockBackupRestorerMockRecorder is the mock recorder for MockBackupRestorer.
+type MockBackupRestorerMockRecorder struct {
+	mock *MockBackupRestorer
+}
+
+// NewMockBackupRestorer creates a new mock instance.
+func NewMockBackupRestorer(ctrl *gomock.Controller) *MockBackupRestorer {
+	mock := &MockBackupRestorer{ctrl: ctrl}
+	mock.recorder = &MockBackupRestorerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockBackupRestorer) EXPECT() *MockBackupRestorerMockRecorder {
+	return m.recorder
+}
+
+// Abort mocks base method.
+func (m *MockBackupRestorer) Abort() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Abort")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Abort indicates an expected call of Abort.
+func (mr *MockBackupRestorerMockRecorder) Abort() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Abort", reflect.TypeOf((*MockBackupRestorer)(nil).Abort))
+}
+
+// Finish mocks base method.
+func (m *MockBackupRestorer) Finish() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Finish")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Finish indicates an expected call of Finish.
+func (mr *MockBackupRestorerMockRecorder) Finish() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finish", reflect.TypeOf((*MockBackupRestorer)(nil).Finish))
+}
+
+// RestoreRow mocks base method.
+func (m *MockBackupRestorer) RestoreRow(table string, columns []string, row json.RawMessage, strategy repository.ConflictStrategy) (int64, bool, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RestoreRow", table, columns, row, strategy)
+	ret0, _ := ret[0].(int64)
+	ret1, _ := ret[1].(bool)
+	ret2, _ := ret[2].(error)
+	return ret0, ret1, ret2
+}
+
+// RestoreRow indicates an expected call of RestoreRow.
+func (mr *MockBackupRestorerMockRecorder) RestoreRow(table, columns, row, strategy any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RestoreRow", reflect.TypeOf((*MockBackupRestorer)(nil).RestoreRow), table, columns, row, strategy)
+}
+
+// SchemaVersion mocks base method.
+func (m *MockBackupRestorer) SchemaVersion() (string, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SchemaVersion")
+	ret0, _ := ret[0].(string)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// SchemaVersion indicates an expected call of SchemaVersion.
+func (mr *MockBackupRestorerMockRecorder) SchemaVersion() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SchemaVersion", reflect.TypeOf((*MockBackupRestorer)(nil).SchemaVersion))
+}
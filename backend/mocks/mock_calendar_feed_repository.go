@@ -0,0 +1,100 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: .\repository\calendar_feed_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source .\repository\calendar_feed_repository.go -destination .\mocks\mock_calendar_feed_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/jennaborowy/fullstack-Go-Docker/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockCalendarFeedRepositoryInterface is a mock of CalendarFeedRepositoryInterface interface.
+type MockCalendarFeedRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockCalendarFeedRepositoryInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockCalendarFeedRepositoryInterfaceMockRecorder is the mock recorder for MockCalendarFeedRepositoryInterface.
+type MockCalendarFeedRepositoryInterfaceMockRecorder struct {
+	mock *MockCalendarFeedRepositoryInterface
+}
+
+// NewMockCalendarFeedRepositoryInterface creates a new mock instance.
+func NewMockCalendarFeedRepositoryInterface(ctrl *gomock.Controller) *MockCalendarFeedRepositoryInterface {
+	mock := &MockCalendarFeedRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockCalendarFeedRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockCalendarFeedRepositoryInterface) EXPECT() *MockCalendarFeedRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateFeed mocks base method.
+func (m *MockCalendarFeedRepositoryInterface) CreateFeed(owner string, listID *int) (*models.CalendarFeed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateFeed", owner, listID)
+	ret0, _ := ret[0].(*models.CalendarFeed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateFeed indicates an expected call of CreateFeed.
+func (mr *MockCalendarFeedRepositoryInterfaceMockRecorder) CreateFeed(owner, listID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateFeed", reflect.TypeOf((*MockCalendarFeedRepositoryInterface)(nil).CreateFeed), owner, listID)
+}
+
+// DeleteFeed mocks base method.
+func (m *MockCalendarFeedRepositoryInterface) DeleteFeed(id int, owner string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteFeed", id, owner)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteFeed indicates an expected call of DeleteFeed.
+func (mr *MockCalendarFeedRepositoryInterfaceMockRecorder) DeleteFeed(id, owner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteFeed", reflect.TypeOf((*MockCalendarFeedRepositoryInterface)(nil).DeleteFeed), id, owner)
+}
+
+// GetFeedByToken mocks base method.
+func (m *MockCalendarFeedRepositoryInterface) GetFeedByToken(token string) (*models.CalendarFeed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeedByToken", token)
+	ret0, _ := ret[0].(*models.CalendarFeed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeedByToken indicates an expected call of GetFeedByToken.
+func (mr *MockCalendarFeedRepositoryInterfaceMockRecorder) GetFeedByToken(token any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeedByToken", reflect.TypeOf((*MockCalendarFeedRepositoryInterface)(nil).GetFeedByToken), token)
+}
+
+// GetFeeds mocks base method.
+func (m *MockCalendarFeedRepositoryInterface) GetFeeds(owner string) ([]models.CalendarFeed, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFeeds", owner)
+	ret0, _ := ret[0].([]models.CalendarFeed)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFeeds indicates an expected call of GetFeeds.
+func (mr *MockCalendarFeedRepositoryInterfaceMockRecorder) GetFeeds(owner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFeeds", reflect.TypeOf((*MockCalendarFeedRepositoryInterface)(nil).GetFeeds), owner)
+}
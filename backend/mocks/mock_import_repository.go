@@ -0,0 +1,124 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: .\repository\import_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source .\repository\import_repository.go -destination .\mocks\mock_import_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/jennaborowy/fullstack-Go-Docker/models"
+	repository "github.com/jennaborowy/fullstack-Go-Docker/repository"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockImportRepositoryInterface is a mock of ImportRepositoryInterface interface.
+type MockImportRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockImportRepositoryInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockImportRepositoryInterfaceMockRecorder is the mock recorder for MockImportRepositoryInterface.
+type MockImportRepositoryInterfaceMockRecorder struct {
+	mock *MockImportRepositoryInterface
+}
+
+// NewMockImportRepositoryInterface creates a new mock instance.
+func NewMockImportRepositoryInterface(ctrl *gomock.Controller) *MockImportRepositoryInterface {
+	mock := &MockImportRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockImportRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockImportRepositoryInterface) EXPECT() *MockImportRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// BeginListImport mocks base method.
+func (m *MockImportRepositoryInterface) BeginListImport() (repository.ListImporter, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "BeginListImport")
+	ret0, _ := ret[0].(repository.ListImporter)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// BeginListImport indicates an expected call of BeginListImport.
+func (mr *MockImportRepositoryInterfaceMockRecorder) BeginListImport() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "BeginListImport", reflect.TypeOf((*MockImportRepositoryInterface)(nil).BeginListImport))
+}
+
+// MockListImporter is a mock of ListImporter interface.
+type MockListImporter struct {
+	ctrl     *gomock.Controller
+	recorder *MockListImporterMockRecorder
+	isgomock struct{}
+}
+
+// MockListImporterMockRecorder is the mock recorder for MockListImporter.
+type MockListImporterMockRecorder struct {
+	mock *MockListImporter
+}
+
+// NewMockListImporter creates a new mock instance.
+func NewMockListImporter(ctrl *gomock.Controller) *MockListImporter {
+	mock := &MockListImporter{ctrl: ctrl}
+	mock.recorder = &MockListImporterMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockListImporter) EXPECT() *MockListImporterMockRecorder {
+	return m.recorder
+}
+
+// Abort mocks base method.
+func (m *MockListImporter) Abort() error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Abort")
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Abort indicates an expected call of Abort.
+func (mr *MockListImporterMockRecorder) Abort() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Abort", reflect.TypeOf((*MockListImporter)(nil).Abort))
+}
+
+// AddItem mocks base method.
+func (m *MockListImporter) AddItem(item models.Item) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "AddItem", item)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// AddItem indicates an expected call of AddItem.
+func (mr *MockListImporterMockRecorder) AddItem(item any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "AddItem", reflect.TypeOf((*MockListImporter)(nil).AddItem), item)
+}
+
+// Finish mocks base method.
+func (m *MockListImporter) Finish(list models.List) (*models.List, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Finish", list)
+	ret0, _ := ret[0].(*models.List)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Finish indicates an expected call of Finish.
+func (mr *MockListImporterMockRecorder) Finish(list any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Finish", reflect.TypeOf((*MockListImporter)(nil).Finish), list)
+}
@@ -41,6 +41,20 @@ func (m *MockItemRepositoryInterface) EXPECT() *MockItemRepositoryInterfaceMockR
 	return m.recorder
 }
 
+// ChangeItem mocks base method.
+func (m *MockItemRepositoryInterface) ChangeItem(id int, changes models.ItemChanges, revision *models.Revision) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ChangeItem", id, changes, revision)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// ChangeItem indicates an expected call of ChangeItem.
+func (mr *MockItemRepositoryInterfaceMockRecorder) ChangeItem(id, changes, revision any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ChangeItem", reflect.TypeOf((*MockItemRepositoryInterface)(nil).ChangeItem), id, changes, revision)
+}
+
 // CreateItem mocks base method.
 func (m *MockItemRepositoryInterface) CreateItem(title string, date time.Time, content string, listID int) (*models.Item, error) {
 	m.ctrl.T.Helper()
@@ -86,30 +100,45 @@ func (mr *MockItemRepositoryInterfaceMockRecorder) GetAll() *gomock.Call {
 }
 
 // GetByID mocks base method.
-func (m *MockItemRepositoryInterface) GetByID(id int) (*models.Item, error) {
+func (m *MockItemRepositoryInterface) GetByID(id int, fields models.Fields) (*models.Item, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetByID", id)
+	ret := m.ctrl.Call(m, "GetByID", id, fields)
 	ret0, _ := ret[0].(*models.Item)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetByID indicates an expected call of GetByID.
-func (mr *MockItemRepositoryInterfaceMockRecorder) GetByID(id any) *gomock.Call {
+func (mr *MockItemRepositoryInterfaceMockRecorder) GetByID(id, fields any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockItemRepositoryInterface)(nil).GetByID), id, fields)
+}
+
+// GetFiltered mocks base method.
+func (m *MockItemRepositoryInterface) GetFiltered(filter models.ItemFilter) ([]models.Item, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetFiltered", filter)
+	ret0, _ := ret[0].([]models.Item)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetFiltered indicates an expected call of GetFiltered.
+func (mr *MockItemRepositoryInterfaceMockRecorder) GetFiltered(filter any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetByID", reflect.TypeOf((*MockItemRepositoryInterface)(nil).GetByID), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetFiltered", reflect.TypeOf((*MockItemRepositoryInterface)(nil).GetFiltered), filter)
 }
 
-// UpdateItem mocks base method.
-func (m *MockItemRepositoryInterface) UpdateItem(id int, title string, date time.Time, content string) error {
+// StreamFiltered mocks base method.
+func (m *MockItemRepositoryInterface) StreamFiltered(filter models.ItemFilter, fn func(models.Item) error) error {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateItem", id, title, date, content)
+	ret := m.ctrl.Call(m, "StreamFiltered", filter, fn)
 	ret0, _ := ret[0].(error)
 	return ret0
 }
 
-// UpdateItem indicates an expected call of UpdateItem.
-func (mr *MockItemRepositoryInterfaceMockRecorder) UpdateItem(id, title, date, content any) *gomock.Call {
+// StreamFiltered indicates an expected call of StreamFiltered.
+func (mr *MockItemRepositoryInterfaceMockRecorder) StreamFiltered(filter, fn any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateItem", reflect.TypeOf((*MockItemRepositoryInterface)(nil).UpdateItem), id, title, date, content)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StreamFiltered", reflect.TypeOf((*MockItemRepositoryInterface)(nil).StreamFiltered), filter, fn)
 }
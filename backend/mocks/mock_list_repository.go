@@ -70,46 +70,46 @@ func (mr *MockListRepositoryInterfaceMockRecorder) DeleteList(id any) *gomock.Ca
 }
 
 // GetAllLists mocks base method.
-func (m *MockListRepositoryInterface) GetAllLists() ([]models.List, error) {
+func (m *MockListRepositoryInterface) GetAllLists(include models.ListInclude, fields models.Fields) ([]models.List, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetAllLists")
+	ret := m.ctrl.Call(m, "GetAllLists", include, fields)
 	ret0, _ := ret[0].([]models.List)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetAllLists indicates an expected call of GetAllLists.
-func (mr *MockListRepositoryInterfaceMockRecorder) GetAllLists() *gomock.Call {
+func (mr *MockListRepositoryInterfaceMockRecorder) GetAllLists(include, fields any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllLists", reflect.TypeOf((*MockListRepositoryInterface)(nil).GetAllLists))
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetAllLists", reflect.TypeOf((*MockListRepositoryInterface)(nil).GetAllLists), include, fields)
 }
 
 // GetList mocks base method.
-func (m *MockListRepositoryInterface) GetList(id int) (*models.List, error) {
+func (m *MockListRepositoryInterface) GetList(id int, fields models.Fields) (*models.List, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "GetList", id)
+	ret := m.ctrl.Call(m, "GetList", id, fields)
 	ret0, _ := ret[0].(*models.List)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // GetList indicates an expected call of GetList.
-func (mr *MockListRepositoryInterfaceMockRecorder) GetList(id any) *gomock.Call {
+func (mr *MockListRepositoryInterfaceMockRecorder) GetList(id, fields any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockListRepositoryInterface)(nil).GetList), id)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetList", reflect.TypeOf((*MockListRepositoryInterface)(nil).GetList), id, fields)
 }
 
 // UpdateTitle mocks base method.
-func (m *MockListRepositoryInterface) UpdateTitle(id int, title string) (*models.List, error) {
+func (m *MockListRepositoryInterface) UpdateTitle(id int, title string, revision *models.Revision) (*models.List, error) {
 	m.ctrl.T.Helper()
-	ret := m.ctrl.Call(m, "UpdateTitle", id, title)
+	ret := m.ctrl.Call(m, "UpdateTitle", id, title, revision)
 	ret0, _ := ret[0].(*models.List)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
 
 // UpdateTitle indicates an expected call of UpdateTitle.
-func (mr *MockListRepositoryInterfaceMockRecorder) UpdateTitle(id, title any) *gomock.Call {
+func (mr *MockListRepositoryInterfaceMockRecorder) UpdateTitle(id, title, revision any) *gomock.Call {
 	mr.mock.ctrl.T.Helper()
-	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTitle", reflect.TypeOf((*MockListRepositoryInterface)(nil).UpdateTitle), id, title)
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateTitle", reflect.TypeOf((*MockListRepositoryInterface)(nil).UpdateTitle), id, title, revision)
 }
@@ -0,0 +1,128 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: .\repository\revision_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source .\repository\revision_repository.go -destination .\mocks\mock_revision_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/jennaborowy/fullstack-Go-Docker/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockRevisionRepositoryInterface is a mock of RevisionRepositoryInterface interface.
+type MockRevisionRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockRevisionRepositoryInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockRevisionRepositoryInterfaceMockRecorder is the mock recorder for MockRevisionRepositoryInterface.
+type MockRevisionRepositoryInterfaceMockRecorder struct {
+	mock *MockRevisionRepositoryInterface
+}
+
+// NewMockRevisionRepositoryInterface creates a new mock instance.
+func NewMockRevisionRepositoryInterface(ctrl *gomock.Controller) *MockRevisionRepositoryInterface {
+	mock := &MockRevisionRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockRevisionRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockRevisionRepositoryInterface) EXPECT() *MockRevisionRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateRevision mocks base method.
+func (m *MockRevisionRepositoryInterface) CreateRevision(rev *models.Revision) (*models.Revision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateRevision", rev)
+	ret0, _ := ret[0].(*models.Revision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateRevision indicates an expected call of CreateRevision.
+func (mr *MockRevisionRepositoryInterfaceMockRecorder) CreateRevision(rev any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateRevision", reflect.TypeOf((*MockRevisionRepositoryInterface)(nil).CreateRevision), rev)
+}
+
+// GetRevision mocks base method.
+func (m *MockRevisionRepositoryInterface) GetRevision(entityType string, entityID, revision int) (*models.Revision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevision", entityType, entityID, revision)
+	ret0, _ := ret[0].(*models.Revision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevision indicates an expected call of GetRevision.
+func (mr *MockRevisionRepositoryInterfaceMockRecorder) GetRevision(entityType, entityID, revision any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevision", reflect.TypeOf((*MockRevisionRepositoryInterface)(nil).GetRevision), entityType, entityID, revision)
+}
+
+// GetRevisions mocks base method.
+func (m *MockRevisionRepositoryInterface) GetRevisions(entityType string, entityID int) ([]models.Revision, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetRevisions", entityType, entityID)
+	ret0, _ := ret[0].([]models.Revision)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetRevisions indicates an expected call of GetRevisions.
+func (mr *MockRevisionRepositoryInterfaceMockRecorder) GetRevisions(entityType, entityID any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetRevisions", reflect.TypeOf((*MockRevisionRepositoryInterface)(nil).GetRevisions), entityType, entityID)
+}
+
+// Mockscanner is a mock of scanner interface.
+type Mockscanner struct {
+	ctrl     *gomock.Controller
+	recorder *MockscannerMockRecorder
+	isgomock struct{}
+}
+
+// MockscannerMockRecorder is the mock recorder for Mockscanner.
+type MockscannerMockRecorder struct {
+	mock *Mockscanner
+}
+
+// NewMockscanner creates a new mock instance.
+func NewMockscanner(ctrl *gomock.Controller) *Mockscanner {
+	mock := &Mockscanner{ctrl: ctrl}
+	mock.recorder = &MockscannerMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *Mockscanner) EXPECT() *MockscannerMockRecorder {
+	return m.recorder
+}
+
+// Scan mocks base method.
+func (m *Mockscanner) Scan(dest ...any) error {
+	m.ctrl.T.Helper()
+	varargs := []any{}
+	for _, a := range dest {
+		varargs = append(varargs, a)
+	}
+	ret := m.ctrl.Call(m, "Scan", varargs...)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// Scan indicates an expected call of Scan.
+func (mr *MockscannerMockRecorder) Scan(dest ...any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Scan", reflect.TypeOf((*Mockscanner)(nil).Scan), dest...)
+}
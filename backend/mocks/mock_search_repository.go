@@ -0,0 +1,56 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: .\repository\search_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source .\repository\search_repository.go -destination .\mocks\mock_search_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/jennaborowy/fullstack-Go-Docker/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSearchRepositoryInterface is a mock of SearchRepositoryInterface interface.
+type MockSearchRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockSearchRepositoryInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockSearchRepositoryInterfaceMockRecorder is the mock recorder for MockSearchRepositoryInterface.
+type MockSearchRepositoryInterfaceMockRecorder struct {
+	mock *MockSearchRepositoryInterface
+}
+
+// NewMockSearchRepositoryInterface creates a new mock instance.
+func NewMockSearchRepositoryInterface(ctrl *gomock.Controller) *MockSearchRepositoryInterface {
+	mock := &MockSearchRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockSearchRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSearchRepositoryInterface) EXPECT() *MockSearchRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// Search mocks base method.
+func (m *MockSearchRepositoryInterface) Search(query models.SearchQuery) ([]models.SearchResult, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Search", query)
+	ret0, _ := ret[0].([]models.SearchResult)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Search indicates an expected call of Search.
+func (mr *MockSearchRepositoryInterfaceMockRecorder) Search(query any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Search", reflect.TypeOf((*MockSearchRepositoryInterface)(nil).Search), query)
+}
@@ -0,0 +1,115 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: .\repository\smart_list_repository.go
+//
+// Generated by this command:
+//
+//	mockgen -source .\repository\smart_list_repository.go -destination .\mocks\mock_smart_list_repository.go -package=mocks
+//
+
+// Package mocks is a generated GoMock package.
+package mocks
+
+import (
+	reflect "reflect"
+
+	models "github.com/jennaborowy/fullstack-Go-Docker/models"
+	gomock "go.uber.org/mock/gomock"
+)
+
+// MockSmartListRepositoryInterface is a mock of SmartListRepositoryInterface interface.
+type MockSmartListRepositoryInterface struct {
+	ctrl     *gomock.Controller
+	recorder *MockSmartListRepositoryInterfaceMockRecorder
+	isgomock struct{}
+}
+
+// MockSmartListRepositoryInterfaceMockRecorder is the mock recorder for MockSmartListRepositoryInterface.
+type MockSmartListRepositoryInterfaceMockRecorder struct {
+	mock *MockSmartListRepositoryInterface
+}
+
+// NewMockSmartListRepositoryInterface creates a new mock instance.
+func NewMockSmartListRepositoryInterface(ctrl *gomock.Controller) *MockSmartListRepositoryInterface {
+	mock := &MockSmartListRepositoryInterface{ctrl: ctrl}
+	mock.recorder = &MockSmartListRepositoryInterfaceMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use.
+func (m *MockSmartListRepositoryInterface) EXPECT() *MockSmartListRepositoryInterfaceMockRecorder {
+	return m.recorder
+}
+
+// CreateSmartList mocks base method.
+func (m *MockSmartListRepositoryInterface) CreateSmartList(owner, title string, filter models.ItemFilterDefinition) (*models.SmartList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "CreateSmartList", owner, title, filter)
+	ret0, _ := ret[0].(*models.SmartList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// CreateSmartList indicates an expected call of CreateSmartList.
+func (mr *MockSmartListRepositoryInterfaceMockRecorder) CreateSmartList(owner, title, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "CreateSmartList", reflect.TypeOf((*MockSmartListRepositoryInterface)(nil).CreateSmartList), owner, title, filter)
+}
+
+// DeleteSmartList mocks base method.
+func (m *MockSmartListRepositoryInterface) DeleteSmartList(id int) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "DeleteSmartList", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// DeleteSmartList indicates an expected call of DeleteSmartList.
+func (mr *MockSmartListRepositoryInterfaceMockRecorder) DeleteSmartList(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "DeleteSmartList", reflect.TypeOf((*MockSmartListRepositoryInterface)(nil).DeleteSmartList), id)
+}
+
+// GetSmartList mocks base method.
+func (m *MockSmartListRepositoryInterface) GetSmartList(id int) (*models.SmartList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSmartList", id)
+	ret0, _ := ret[0].(*models.SmartList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSmartList indicates an expected call of GetSmartList.
+func (mr *MockSmartListRepositoryInterfaceMockRecorder) GetSmartList(id any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSmartList", reflect.TypeOf((*MockSmartListRepositoryInterface)(nil).GetSmartList), id)
+}
+
+// GetSmartLists mocks base method.
+func (m *MockSmartListRepositoryInterface) GetSmartLists(owner string) ([]models.SmartList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "GetSmartLists", owner)
+	ret0, _ := ret[0].([]models.SmartList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// GetSmartLists indicates an expected call of GetSmartLists.
+func (mr *MockSmartListRepositoryInterfaceMockRecorder) GetSmartLists(owner any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "GetSmartLists", reflect.TypeOf((*MockSmartListRepositoryInterface)(nil).GetSmartLists), owner)
+}
+
+// UpdateSmartList mocks base method.
+func (m *MockSmartListRepositoryInterface) UpdateSmartList(id int, title string, filter models.ItemFilterDefinition) (*models.SmartList, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateSmartList", id, title, filter)
+	ret0, _ := ret[0].(*models.SmartList)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// UpdateSmartList indicates an expected call of UpdateSmartList.
+func (mr *MockSmartListRepositoryInterfaceMockRecorder) UpdateSmartList(id, title, filter any) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateSmartList", reflect.TypeOf((*MockSmartListRepositoryInterface)(nil).UpdateSmartList), id, title, filter)
+}
@@ -0,0 +1,53 @@
+package models
+
+import "time"
+
+// Activity is a single entry in the append-only activity log
+type Activity struct {
+	ID         int       `json:"id"`
+	User       string    `json:"user"`
+	Action     string    `json:"action"`
+	EntityType string    `json:"entity_type"`
+	EntityID   int       `json:"entity_id"`
+	ListID     int       `json:"list_id"`
+	FromListID int       `json:"from_list_id,omitempty"` // the list a moved item left
+	CreatedAt  time.Time `json:"created_at"`
+}
+
+// Actions recorded in the activity log
+const (
+	ActionCreate = "create"
+	ActionUpdate = "update"
+	ActionDelete = "delete"
+	ActionRevert = "revert"
+	ActionImport = "import"
+	ActionMove   = "move"
+)
+
+// OnList reports whether the entry belongs in the feed of a list: it happened on the
+// list, or a moved item left it
+func (a Activity) OnList(listID int) bool {
+	return a.ListID == listID || a.FromListID != 0 && a.FromListID == listID
+}
+
+// ActivityFilter narrows down which activity entries are returned.
+// Zero values are ignored.
+type ActivityFilter struct {
+	User   string
+	Action string
+	ListID int // entries on the list, or moving an item off it
+	From   time.Time
+	To     time.Time
+	Limit  int
+}
+
+// NewActivity creates a new activity entry
+func NewActivity(user, action, entityType string, entityID, listID int) *Activity {
+	return &Activity{
+		User:       user,
+		Action:     action,
+		EntityType: entityType,
+		EntityID:   entityID,
+		ListID:     listID,
+	}
+}
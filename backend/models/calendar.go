@@ -0,0 +1,109 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// Calendar groupings
+const (
+	GroupDay   = "day"
+	GroupWeek  = "week"
+	GroupMonth = "month"
+)
+
+// CalendarBucket holds the items falling within one day, week or month.
+// Start and End are inclusive YYYY-MM-DD dates.
+type CalendarBucket struct {
+	Start string `json:"start"`
+	End   string `json:"end"`
+	Items []Item `json:"items"`
+}
+
+// Calendar is a date range of items grouped into buckets
+type Calendar struct {
+	From     string           `json:"from"`
+	To       string           `json:"to"`
+	TimeZone string           `json:"tz"`
+	Group    string           `json:"group"`
+	Buckets  []CalendarBucket `json:"buckets"`
+}
+
+// NewCalendar buckets items by their date between from and to (inclusive), including empty buckets.
+// Item dates are calendar dates without a time, so they are never shifted between time zones;
+// the time zone only decides what "today" is when resolving the range.
+func NewCalendar(items []Item, from, to time.Time, group string, loc *time.Location) (*Calendar, error) {
+	if !ValidCalendarGroup(group) {
+		return nil, fmt.Errorf("invalid group %q", group)
+	}
+
+	from = truncateDate(from)
+	to = truncateDate(to)
+
+	calendar := &Calendar{
+		From:     from.Format("2006-01-02"),
+		To:       to.Format("2006-01-02"),
+		TimeZone: loc.String(),
+		Group:    group,
+		Buckets:  []CalendarBucket{},
+	}
+
+	index := map[string]int{}
+	for start := bucketStart(from, group); !start.After(to); start = nextBucket(start, group) {
+		end := nextBucket(start, group).AddDate(0, 0, -1)
+		index[start.Format("2006-01-02")] = len(calendar.Buckets)
+		calendar.Buckets = append(calendar.Buckets, CalendarBucket{
+			Start: start.Format("2006-01-02"),
+			End:   end.Format("2006-01-02"),
+			Items: []Item{},
+		})
+	}
+
+	for _, item := range items {
+		date := truncateDate(item.Date)
+		if date.Before(from) || date.After(to) {
+			continue
+		}
+		i, ok := index[bucketStart(date, group).Format("2006-01-02")]
+		if !ok {
+			continue
+		}
+		calendar.Buckets[i].Items = append(calendar.Buckets[i].Items, item)
+	}
+
+	return calendar, nil
+}
+
+// ValidCalendarGroup reports whether group is one of the supported groupings
+func ValidCalendarGroup(group string) bool {
+	return group == GroupDay || group == GroupWeek || group == GroupMonth
+}
+
+// truncateDate drops the time of day, keeping the calendar date as written
+func truncateDate(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// bucketStart returns the first date of the bucket containing date. Weeks start on Monday.
+func bucketStart(date time.Time, group string) time.Time {
+	switch group {
+	case GroupWeek:
+		return date.AddDate(0, 0, -((int(date.Weekday()) + 6) % 7))
+	case GroupMonth:
+		return time.Date(date.Year(), date.Month(), 1, 0, 0, 0, 0, time.UTC)
+	default:
+		return date
+	}
+}
+
+// nextBucket returns the first date of the bucket after the one starting at start
+func nextBucket(start time.Time, group string) time.Time {
+	switch group {
+	case GroupWeek:
+		return start.AddDate(0, 0, 7)
+	case GroupMonth:
+		return start.AddDate(0, 1, 0)
+	default:
+		return start.AddDate(0, 0, 1)
+	}
+}
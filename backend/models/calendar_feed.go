@@ -0,0 +1,14 @@
+package models
+
+import "time"
+
+// CalendarFeed is a subscribable iCalendar feed, either for a single list or,
+// when ListID is nil, aggregating every list. The token authenticates
+// calendar clients, which can't use session auth.
+type CalendarFeed struct {
+	ID        int       `json:"id"`
+	Owner     string    `json:"owner"`
+	Token     string    `json:"token"`
+	ListID    *int      `json:"list_id"`
+	CreatedAt time.Time `json:"created_at"`
+}
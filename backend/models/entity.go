@@ -0,0 +1,7 @@
+package models
+
+// Entity types that history such as revisions and activity is recorded for
+const (
+	EntityItem = "item"
+	EntityList = "list"
+)
@@ -0,0 +1,38 @@
+package models
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+)
+
+// Fields is a sparse fieldset: the properties a response is limited to. Empty means all of them.
+type Fields []string
+
+// Has reports whether the fieldset includes name
+func (f Fields) Has(name string) bool {
+	return len(f) == 0 || slices.Contains(f, name)
+}
+
+// The fields items and lists can be limited to, named as in the JSON of API v2
+var (
+	ItemFields = Fields{"id", "title", "item_date", "content", "list_id", "completed", "created_at", "updated_at"}
+	ListFields = Fields{"id", "title", "created_at", "updated_at", "items", "counts"}
+)
+
+// ParseFields reads the fields query parameter, comma separated or repeated for each field:
+// fields=id,title or fields=id&fields=title. Every field must be one of known.
+func ParseFields(values []string, known Fields) (Fields, error) {
+	var fields Fields
+	for _, value := range values {
+		for field := range strings.SplitSeq(value, ",") {
+			if !slices.Contains(known, field) {
+				return nil, fmt.Errorf("invalid field %q, expected one of %s", field, strings.Join(known, ", "))
+			}
+			if !slices.Contains(fields, field) {
+				fields = append(fields, field)
+			}
+		}
+	}
+	return fields, nil
+}
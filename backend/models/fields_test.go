@@ -0,0 +1,50 @@
+package models_test
+
+import (
+	"slices"
+	"testing"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+)
+
+func TestParseFields(t *testing.T) {
+	tests := []struct {
+		name        string
+		values      []string
+		expected    models.Fields
+		expectError bool
+	}{
+		{name: "nothing", values: nil},
+		{name: "some", values: []string{"id", "title"}, expected: models.Fields{"id", "title"}},
+		{name: "repeated", values: []string{"title", "title"}, expected: models.Fields{"title"}},
+		{name: "comma separated", values: []string{"id,title", "content"}, expected: models.Fields{"id", "title", "content"}},
+		{name: "repeated across commas", values: []string{"id,title,id"}, expected: models.Fields{"id", "title"}},
+		{name: "unknown after a comma", values: []string{"id,owner"}, expectError: true},
+		{name: "trailing comma", values: []string{"id,"}, expectError: true},
+		{name: "unknown", values: []string{"owner"}, expectError: true},
+		{name: "list field", values: []string{"items"}, expectError: true},
+		{name: "empty", values: []string{""}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			fields, err := models.ParseFields(tt.values, models.ItemFields)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("expected error: %v, got %v", tt.expectError, err)
+			}
+			if !tt.expectError && !slices.Equal(fields, tt.expected) {
+				t.Errorf("expected %v, got %v", tt.expected, fields)
+			}
+		})
+	}
+}
+
+func TestFieldsHas(t *testing.T) {
+	if !(models.Fields{}).Has("content") {
+		t.Error("expected no fields to have every field")
+	}
+	fields := models.Fields{"id", "title"}
+	if !fields.Has("title") || fields.Has("content") {
+		t.Errorf("expected %v to have title and not content", fields)
+	}
+}
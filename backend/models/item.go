@@ -8,6 +8,7 @@ type Item struct {
 	Date      time.Time `json:"item_date" time_format:"2006-01-02"`
 	Content   string    `json:"content"`
 	ListID    int       `json:"list_id"`
+	Completed bool      `json:"completed"`
 	CreatedAt time.Time `json:"created_at" time_format:"2006-01-02"`
 	UpdatedAt time.Time `json:"updated_at" time_format:"2006-01-02"`
 }
@@ -21,3 +22,13 @@ func NewItem(title string, date time.Time, content string, listID int) *Item {
 		ListID:  listID,
 	}
 }
+
+// ItemChanges are the changes made to an item by an update. Title, content and date
+// are always replaced; Completed and ListID only when set.
+type ItemChanges struct {
+	Title     string
+	Content   string
+	Date      time.Time
+	Completed *bool
+	ListID    *int // moves the item to another list
+}
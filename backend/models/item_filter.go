@@ -0,0 +1,99 @@
+package models
+
+import (
+	"fmt"
+	"time"
+)
+
+// ItemFilter narrows down which items are returned. Zero values are ignored,
+// and From/To are inclusive item dates.
+type ItemFilter struct {
+	ListIDs   []int
+	From      time.Time
+	To        time.Time
+	Completed *bool
+	Query     string
+
+	// Fields limits what is read of each item. Repositories may leave the other
+	// fields zero, but always read the ID.
+	Fields Fields
+}
+
+// IsEmpty reports whether the filter would match every item, whatever its Fields
+func (f ItemFilter) IsEmpty() bool {
+	return len(f.ListIDs) == 0 && f.From.IsZero() && f.To.IsZero() && f.Completed == nil && f.Query == ""
+}
+
+// Relative due date ranges that an ItemFilterDefinition can use.
+// They are resolved against the current date every time the filter is evaluated.
+const (
+	DueOverdue   = "overdue"
+	DueToday     = "today"
+	DueThisWeek  = "this_week"
+	DueNext7Days = "next_7_days"
+	DueThisMonth = "this_month"
+)
+
+// ItemFilterDefinition is an item filter as given in a request or saved in a smart list.
+// Unlike ItemFilter it can use relative dates such as "this_week".
+type ItemFilterDefinition struct {
+	ListIDs   []int  `json:"list_ids,omitempty"`
+	Due       string `json:"due,omitempty" enum:"overdue,today,this_week,next_7_days,this_month"`
+	From      string `json:"from,omitempty" format:"date"` // YYYY-MM-DD
+	To        string `json:"to,omitempty" format:"date"`   // YYYY-MM-DD
+	Completed *bool  `json:"completed,omitempty"`
+	Query     string `json:"q,omitempty"`
+}
+
+// Resolve turns the definition into a concrete ItemFilter for the given moment.
+// When both Due and From/To are given, From/To narrow the due range further.
+func (d ItemFilterDefinition) Resolve(now time.Time) (ItemFilter, error) {
+	filter := ItemFilter{
+		ListIDs:   d.ListIDs,
+		Completed: d.Completed,
+		Query:     d.Query,
+	}
+
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+	switch d.Due {
+	case "":
+	case DueOverdue:
+		filter.To = today.AddDate(0, 0, -1)
+	case DueToday:
+		filter.From, filter.To = today, today
+	case DueThisWeek:
+		// weeks start on Monday
+		offset := (int(today.Weekday()) + 6) % 7
+		filter.From = today.AddDate(0, 0, -offset)
+		filter.To = filter.From.AddDate(0, 0, 6)
+	case DueNext7Days:
+		filter.From, filter.To = today, today.AddDate(0, 0, 6)
+	case DueThisMonth:
+		filter.From = time.Date(today.Year(), today.Month(), 1, 0, 0, 0, 0, time.UTC)
+		filter.To = filter.From.AddDate(0, 1, -1)
+	default:
+		return filter, fmt.Errorf("invalid due range %q", d.Due)
+	}
+
+	if d.From != "" {
+		from, err := time.Parse("2006-01-02", d.From)
+		if err != nil {
+			return filter, fmt.Errorf("invalid from date %q", d.From)
+		}
+		if from.After(filter.From) {
+			filter.From = from
+		}
+	}
+
+	if d.To != "" {
+		to, err := time.Parse("2006-01-02", d.To)
+		if err != nil {
+			return filter, fmt.Errorf("invalid to date %q", d.To)
+		}
+		if filter.To.IsZero() || to.Before(filter.To) {
+			filter.To = to
+		}
+	}
+
+	return filter, nil
+}
@@ -0,0 +1,57 @@
+package models_test
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+)
+
+func TestItemFilterDefinitionResolve(t *testing.T) {
+	// a Wednesday afternoon
+	now := time.Date(2025, 10, 8, 15, 30, 0, 0, time.UTC)
+	day := func(month time.Month, d int) time.Time {
+		return time.Date(2025, month, d, 0, 0, 0, 0, time.UTC)
+	}
+
+	tests := []struct {
+		name         string
+		definition   models.ItemFilterDefinition
+		expectedFrom time.Time
+		expectedTo   time.Time
+		expectError  bool
+	}{
+		{name: "no dates", definition: models.ItemFilterDefinition{}},
+		{name: "overdue", definition: models.ItemFilterDefinition{Due: models.DueOverdue}, expectedTo: day(10, 7)},
+		{name: "today", definition: models.ItemFilterDefinition{Due: models.DueToday}, expectedFrom: day(10, 8), expectedTo: day(10, 8)},
+		{name: "this week starts on monday", definition: models.ItemFilterDefinition{Due: models.DueThisWeek}, expectedFrom: day(10, 6), expectedTo: day(10, 12)},
+		{name: "next 7 days", definition: models.ItemFilterDefinition{Due: models.DueNext7Days}, expectedFrom: day(10, 8), expectedTo: day(10, 14)},
+		{name: "this month", definition: models.ItemFilterDefinition{Due: models.DueThisMonth}, expectedFrom: day(10, 1), expectedTo: day(10, 31)},
+		{name: "absolute dates", definition: models.ItemFilterDefinition{From: "2025-01-01", To: "2025-01-31"}, expectedFrom: day(1, 1), expectedTo: day(1, 31)},
+		{name: "absolute dates narrow a relative range", definition: models.ItemFilterDefinition{Due: models.DueThisMonth, From: "2025-10-15"}, expectedFrom: day(10, 15), expectedTo: day(10, 31)},
+		{name: "unknown due range", definition: models.ItemFilterDefinition{Due: "someday"}, expectError: true},
+		{name: "invalid date", definition: models.ItemFilterDefinition{To: "10/31/2025"}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			filter, err := tt.definition.Resolve(now)
+			if tt.expectError {
+				if err == nil {
+					t.Fatalf("expected an error")
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if !filter.From.Equal(tt.expectedFrom) {
+				t.Errorf("expected from %v, got %v", tt.expectedFrom, filter.From)
+			}
+			if !filter.To.Equal(tt.expectedTo) {
+				t.Errorf("expected to %v, got %v", tt.expectedTo, filter.To)
+			}
+		})
+	}
+}
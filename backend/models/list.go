@@ -1,6 +1,10 @@
 package models
 
-import "time"
+import (
+	"fmt"
+	"strings"
+	"time"
+)
 
 type List struct {
 	ID        int64
@@ -8,8 +12,57 @@ type List struct {
 	CreatedAt time.Time
 	UpdatedAt time.Time
 	Items     []Item
+	Counts    *ListCounts `json:",omitempty"` // only when asked for
+}
+
+// ListCounts counts the items of a list
+type ListCounts struct {
+	Total     int
+	Completed int
+}
+
+// CountItems counts items and how many of them are completed
+func CountItems(items []Item) *ListCounts {
+	counts := &ListCounts{Total: len(items)}
+	for _, item := range items {
+		if item.Completed {
+			counts.Completed++
+		}
+	}
+	return counts
 }
 
 func NewList(title string, items []Item) *List {
 	return &List{Title: title, Items: items}
 }
+
+// What a list of lists can include besides the lists themselves
+const (
+	IncludeItems  = "items"
+	IncludeCounts = "counts"
+)
+
+// ListInclude says what to read along with each list
+type ListInclude struct {
+	Items  bool
+	Counts bool
+}
+
+// ParseListInclude reads the include query parameter, comma separated or repeated for both:
+// include=items,counts or include=items&include=counts
+func ParseListInclude(values []string) (ListInclude, error) {
+	var include ListInclude
+	for _, value := range values {
+		for part := range strings.SplitSeq(value, ",") {
+			switch part {
+			case IncludeItems:
+				include.Items = true
+			case IncludeCounts:
+				include.Counts = true
+			default:
+				return include, fmt.Errorf("invalid include %q, expected %s or %s", part, IncludeItems, IncludeCounts)
+			}
+		}
+	}
+	return include, nil
+}
@@ -0,0 +1,39 @@
+package models_test
+
+import (
+	"testing"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+)
+
+func TestParseListInclude(t *testing.T) {
+	tests := []struct {
+		name        string
+		values      []string
+		expected    models.ListInclude
+		expectError bool
+	}{
+		{name: "nothing", values: nil},
+		{name: "items", values: []string{"items"}, expected: models.ListInclude{Items: true}},
+		{name: "counts", values: []string{"counts"}, expected: models.ListInclude{Counts: true}},
+		{name: "both", values: []string{"counts", "items"}, expected: models.ListInclude{Items: true, Counts: true}},
+		{name: "repeated", values: []string{"items", "items"}, expected: models.ListInclude{Items: true}},
+		{name: "comma separated", values: []string{"items,counts"}, expected: models.ListInclude{Items: true, Counts: true}},
+		{name: "unknown after a comma", values: []string{"items,revisions"}, expectError: true},
+		{name: "trailing comma", values: []string{"items,"}, expectError: true},
+		{name: "unknown", values: []string{"revisions"}, expectError: true},
+		{name: "empty", values: []string{""}, expectError: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			include, err := models.ParseListInclude(tt.values)
+			if (err != nil) != tt.expectError {
+				t.Fatalf("expected error: %v, got %v", tt.expectError, err)
+			}
+			if !tt.expectError && include != tt.expected {
+				t.Errorf("expected %+v, got %+v", tt.expected, include)
+			}
+		})
+	}
+}
@@ -0,0 +1,65 @@
+package models
+
+import "time"
+
+// Revision records a single change made to an item or list, keeping the
+// editable fields as they were before and after the change
+type Revision struct {
+	ID         int                    `json:"id"`
+	EntityType string                 `json:"entity_type"`
+	EntityID   int                    `json:"entity_id"`
+	Revision   int                    `json:"revision"`
+	Author     string                 `json:"author"`
+	Old        map[string]string      `json:"old"`
+	New        map[string]string      `json:"new"`
+	Changes    map[string]FieldChange `json:"changes"`
+	CreatedAt  time.Time              `json:"created_at"`
+}
+
+// FieldChange holds the old and new value of a single changed field
+type FieldChange struct {
+	Old string `json:"old"`
+	New string `json:"new"`
+}
+
+// NewRevision creates a new revision and works out which fields changed
+func NewRevision(entityType string, entityID int, author string, oldValues, newValues map[string]string) *Revision {
+	return &Revision{
+		EntityType: entityType,
+		EntityID:   entityID,
+		Author:     author,
+		Old:        oldValues,
+		New:        newValues,
+		Changes:    DiffFields(oldValues, newValues),
+	}
+}
+
+// DiffFields returns the fields whose values differ between old and new
+func DiffFields(oldValues, newValues map[string]string) map[string]FieldChange {
+	changes := map[string]FieldChange{}
+	for field, newValue := range newValues {
+		if oldValue := oldValues[field]; oldValue != newValue {
+			changes[field] = FieldChange{Old: oldValue, New: newValue}
+		}
+	}
+	for field, oldValue := range oldValues {
+		if _, ok := newValues[field]; !ok {
+			changes[field] = FieldChange{Old: oldValue}
+		}
+	}
+	return changes
+}
+
+// ItemSnapshot returns the editable fields of an item for recording a revision
+func ItemSnapshot(title string, date time.Time, content string) map[string]string {
+	return map[string]string{
+		"title":     title,
+		"item_date": date.Format("2006-01-02"),
+		"content":   content,
+	}
+}
+
+// ListSnapshot returns the editable fields of a list for recording a revision
+func ListSnapshot(title string) map[string]string {
+	return map[string]string{"title": title}
+}
@@ -0,0 +1,25 @@
+package models
+
+import "time"
+
+// SearchResult is a single item or list matching a full-text search
+type SearchResult struct {
+	Type     string     `json:"type"` // EntityItem or EntityList
+	ID       int        `json:"id"`
+	ListID   int        `json:"list_id"`
+	Title    string     `json:"title"`
+	Snippet  string     `json:"snippet"` // matched text, escaped as HTML, with terms wrapped in <mark> tags
+	Rank     float64    `json:"rank"`
+	ItemDate *time.Time `json:"item_date,omitempty" time_format:"2006-01-02"`
+}
+
+// SearchQuery describes a full-text search and the filters combined with it.
+// Zero values are ignored.
+type SearchQuery struct {
+	Query  string
+	Type   string // restrict results to EntityItem or EntityList
+	ListID int
+	From   time.Time
+	To     time.Time
+	Limit  int
+}
@@ -0,0 +1,18 @@
+package models
+
+import "time"
+
+// SmartList is a saved item filter that is evaluated into a virtual list whenever it is read
+type SmartList struct {
+	ID        int                  `json:"id"`
+	Owner     string               `json:"owner"`
+	Title     string               `json:"title"`
+	Filter    ItemFilterDefinition `json:"filter"`
+	CreatedAt time.Time            `json:"created_at"`
+	UpdatedAt time.Time            `json:"updated_at"`
+}
+
+// NewSmartList creates a new smart list
+func NewSmartList(owner, title string, filter ItemFilterDefinition) *SmartList {
+	return &SmartList{Owner: owner, Title: title, Filter: filter}
+}
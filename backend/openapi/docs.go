@@ -0,0 +1,20 @@
+package openapi
+
+import (
+	_ "embed"
+	"html"
+	"strings"
+)
+
+//go:embed docs.html
+var docsPage string
+
+// DocsContentSecurityPolicy lets the docs page load Redoc, which styles the page inline
+// and searches in a worker, and fetch the document
+const DocsContentSecurityPolicy = "default-src 'none'; script-src https://cdn.redoc.ly; style-src 'unsafe-inline'; " +
+	"img-src data: https:; font-src data: https:; connect-src 'self'; worker-src blob:; frame-ancestors 'none'"
+
+// DocsPage returns an HTML page rendering the document served at specURL with Redoc
+func DocsPage(specURL string) []byte {
+	return []byte(strings.Replace(docsPage, "{{SPEC_URL}}", html.EscapeString(specURL), 1))
+}
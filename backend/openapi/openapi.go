@@ -0,0 +1,329 @@
+// openapi package builds the OpenAPI 3 document describing the API
+package openapi
+
+import (
+	"fmt"
+	"net/http"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// Version is the OpenAPI version documents are written in
+const Version = "3.0.3"
+
+// Document is an OpenAPI 3 document. Only the parts this API uses are modelled.
+type Document struct {
+	OpenAPI    string               `json:"openapi"`
+	Info       Info                 `json:"info"`
+	Servers    []Server             `json:"servers,omitempty"`
+	Paths      map[string]*PathItem `json:"paths"`
+	Components Components           `json:"components"`
+
+	types map[string]string // component names already taken, by Go type
+}
+
+type Info struct {
+	Title       string `json:"title"`
+	Version     string `json:"version"`
+	Description string `json:"description,omitempty"`
+}
+
+// Server is where the API is served. Paths are relative to its URL.
+type Server struct {
+	URL string `json:"url"`
+}
+
+// PathItem holds the operations of a path, keyed by lower case HTTP method
+type PathItem map[string]*Operation
+
+type Operation struct {
+	OperationID string                `json:"operationId"`
+	Summary     string                `json:"summary,omitempty"`
+	Tags        []string              `json:"tags,omitempty"`
+	Deprecated  bool                  `json:"deprecated,omitempty"`
+	Parameters  []*Parameter          `json:"parameters,omitempty"`
+	RequestBody *RequestBody          `json:"requestBody,omitempty"`
+	Responses   map[string]*Response  `json:"responses"`
+	Security    []map[string][]string `json:"security,omitempty"`
+}
+
+type Parameter struct {
+	Name        string  `json:"name"`
+	In          string  `json:"in"` // path, query or header
+	Description string  `json:"description,omitempty"`
+	Required    bool    `json:"required,omitempty"`
+	Explode     *bool   `json:"explode,omitempty"`
+	Schema      *Schema `json:"schema"`
+
+	commas bool // an array whose values may also be comma separated
+}
+
+type RequestBody struct {
+	Required bool                  `json:"required,omitempty"`
+	Content  map[string]*MediaType `json:"content"`
+}
+
+type Response struct {
+	Description string                `json:"description"`
+	Headers     map[string]*Header    `json:"headers,omitempty"`
+	Content     map[string]*MediaType `json:"content,omitempty"`
+}
+
+type Header struct {
+	Description string  `json:"description,omitempty"`
+	Schema      *Schema `json:"schema"`
+}
+
+type MediaType struct {
+	Schema *Schema `json:"schema"`
+}
+
+type Components struct {
+	Schemas         map[string]*Schema         `json:"schemas"`
+	SecuritySchemes map[string]*SecurityScheme `json:"securitySchemes,omitempty"`
+}
+
+type SecurityScheme struct {
+	Type   string `json:"type"`
+	Scheme string `json:"scheme,omitempty"`
+}
+
+// New creates an empty document
+func New(info Info) *Document {
+	return &Document{
+		OpenAPI:    Version,
+		Info:       info,
+		Paths:      map[string]*PathItem{},
+		Components: Components{Schemas: map[string]*Schema{}},
+		types:      map[string]string{},
+	}
+}
+
+var ginParam = regexp.MustCompile(`[:*]([A-Za-z_]+)`)
+
+// Path turns a gin route path such as /api/items/:id into the OpenAPI form /api/items/{id}
+func Path(ginPath string) string {
+	return ginParam.ReplaceAllString(ginPath, "{$1}")
+}
+
+// BasePath returns the path of the first server, which document paths are relative to
+func (d *Document) BasePath() string {
+	if len(d.Servers) == 0 {
+		return ""
+	}
+	return strings.TrimSuffix(d.Servers[0].URL, "/")
+}
+
+// Operation returns the operation for a method and full gin route path, including the
+// base path, or nil if it isn't documented
+func (d *Document) Operation(method, ginPath string) *Operation {
+	path, ok := strings.CutPrefix(ginPath, d.BasePath())
+	if !ok {
+		return nil
+	}
+	item := d.Paths[Path(path)]
+	if item == nil {
+		return nil
+	}
+	return (*item)[strings.ToLower(method)]
+}
+
+// Route documents the operation for a method and gin route path, relative to the base path. Path parameters are
+// added as integers, which every path parameter of this API is.
+func (d *Document) Route(method, ginPath, operationID, summary string) *OperationBuilder {
+	path := Path(ginPath)
+	item := d.Paths[path]
+	if item == nil {
+		item = &PathItem{}
+		d.Paths[path] = item
+	}
+
+	op := &Operation{OperationID: operationID, Summary: summary, Responses: map[string]*Response{}}
+	(*item)[strings.ToLower(method)] = op
+
+	for _, match := range ginParam.FindAllStringSubmatch(ginPath, -1) {
+		op.Parameters = append(op.Parameters, &Parameter{Name: match[1], In: "path", Required: true, Schema: &Schema{Type: "integer"}})
+	}
+	return &OperationBuilder{doc: d, op: op}
+}
+
+// OperationBuilder fills in an operation
+type OperationBuilder struct {
+	doc *Document
+	op  *Operation
+}
+
+// Tags sets the operation's tags
+func (b *OperationBuilder) Tags(tags ...string) *OperationBuilder {
+	b.op.Tags = tags
+	return b
+}
+
+// Deprecated marks the operation as deprecated
+func (b *OperationBuilder) Deprecated() *OperationBuilder {
+	b.op.Deprecated = true
+	return b
+}
+
+// Query adds a query parameter. schema is usually one of the helpers such as String().
+func (b *OperationBuilder) Query(name string, schema *Schema, description string) *OperationBuilder {
+	param := &Parameter{Name: name, In: "query", Description: description, Schema: schema}
+	if schema.Type == "array" {
+		explode := true
+		param.Explode = &explode
+	}
+	b.op.Parameters = append(b.op.Parameters, param)
+	return b
+}
+
+// ListQuery adds a query parameter taking an array of items, repeated or comma separated.
+// OpenAPI can only describe one of the two, so the document says repeated.
+func (b *OperationBuilder) ListQuery(name string, items *Schema, description string) *OperationBuilder {
+	b.Query(name, ArrayOf(items), description)
+	b.op.Parameters[len(b.op.Parameters)-1].commas = true
+	return b
+}
+
+// RequiredQuery adds a required query parameter
+func (b *OperationBuilder) RequiredQuery(name string, schema *Schema, description string) *OperationBuilder {
+	b.Query(name, schema, description)
+	b.op.Parameters[len(b.op.Parameters)-1].Required = true
+	return b
+}
+
+// Header adds an optional request header
+func (b *OperationBuilder) Header(name, description string) *OperationBuilder {
+	b.op.Parameters = append(b.op.Parameters, &Parameter{Name: name, In: "header", Description: description, Schema: String()})
+	return b
+}
+
+// Body sets a required JSON request body with the schema of v's type
+func (b *OperationBuilder) Body(v any) *OperationBuilder {
+	b.op.RequestBody = &RequestBody{Required: true, Content: map[string]*MediaType{"application/json": {Schema: b.doc.SchemaOf(v)}}}
+	return b
+}
+
+// Upload sets a required request body that is a file of one of the given content types,
+// sent as is or as a multipart form
+func (b *OperationBuilder) Upload(contentTypes ...string) *OperationBuilder {
+	content := map[string]*MediaType{
+		"multipart/form-data": {Schema: &Schema{Type: "object", Properties: map[string]*Schema{"file": {Type: "string", Format: "binary"}}}},
+	}
+	for _, contentType := range contentTypes {
+		content[contentType] = &MediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+	}
+	b.op.RequestBody = &RequestBody{Required: true, Content: content}
+	return b
+}
+
+// JSON documents a JSON response with the schema of v's type
+func (b *OperationBuilder) JSON(status int, v any) *OperationBuilder {
+	b.op.Responses[strconv.Itoa(status)] = &Response{
+		Description: http.StatusText(status),
+		Content:     map[string]*MediaType{"application/json": {Schema: b.doc.SchemaOf(v)}},
+	}
+	return b
+}
+
+// NDJSON documents that a response can also be newline-delimited JSON, one value with the
+// schema of v's type per line
+func (b *OperationBuilder) NDJSON(status int, v any) *OperationBuilder {
+	response, ok := b.op.Responses[strconv.Itoa(status)]
+	if !ok {
+		response = &Response{Description: http.StatusText(status), Content: map[string]*MediaType{}}
+		b.op.Responses[strconv.Itoa(status)] = response
+	}
+	response.Content["application/x-ndjson"] = &MediaType{Schema: b.doc.SchemaOf(v)}
+	return b
+}
+
+// File documents a response that is a file of one of the given content types
+func (b *OperationBuilder) File(status int, contentTypes ...string) *OperationBuilder {
+	content := map[string]*MediaType{}
+	for _, contentType := range contentTypes {
+		content[contentType] = &MediaType{Schema: &Schema{Type: "string", Format: "binary"}}
+	}
+	b.op.Responses[strconv.Itoa(status)] = &Response{Description: http.StatusText(status), Content: content}
+	return b
+}
+
+// NoContent documents a response without a body
+func (b *OperationBuilder) NoContent(status int) *OperationBuilder {
+	b.op.Responses[strconv.Itoa(status)] = &Response{Description: http.StatusText(status)}
+	return b
+}
+
+// Errors documents error responses, which all have the {"error": "..."} body
+func (b *OperationBuilder) Errors(statuses ...int) *OperationBuilder {
+	for _, status := range statuses {
+		b.JSON(status, ErrorResponse{})
+	}
+	return b
+}
+
+// Security requires the named security scheme, which is added to the document's components
+func (b *OperationBuilder) Security(name string, scheme *SecurityScheme) *OperationBuilder {
+	if b.doc.Components.SecuritySchemes == nil {
+		b.doc.Components.SecuritySchemes = map[string]*SecurityScheme{}
+	}
+	b.doc.Components.SecuritySchemes[name] = scheme
+	b.op.Security = append(b.op.Security, map[string][]string{name: {}})
+	return b
+}
+
+// ErrorResponse is the body of every error response
+type ErrorResponse struct {
+	Error string `json:"error"`
+}
+
+// Operations calls fn for every documented operation, in path and method order
+func (d *Document) Operations(fn func(method, path string, op *Operation)) {
+	paths := make([]string, 0, len(d.Paths))
+	for path := range d.Paths {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+
+	for _, path := range paths {
+		item := *d.Paths[path]
+		methods := make([]string, 0, len(item))
+		for method := range item {
+			methods = append(methods, method)
+		}
+		sort.Strings(methods)
+		for _, method := range methods {
+			fn(strings.ToUpper(method), path, item[method])
+		}
+	}
+}
+
+// Validate checks that the document is self-consistent: operation IDs are unique and
+// every $ref points at a schema in the components
+func (d *Document) Validate() error {
+	ids := map[string]string{}
+	var errs []string
+	d.Operations(func(method, path string, op *Operation) {
+		if other, ok := ids[op.OperationID]; ok {
+			errs = append(errs, fmt.Sprintf("operationId %s is used by %s and %s %s", op.OperationID, other, method, path))
+		}
+		ids[op.OperationID] = method + " " + path
+	})
+
+	for name, schema := range d.Components.Schemas {
+		schema.walk(func(s *Schema) {
+			if s.Ref != "" {
+				if _, ok := d.Components.Schemas[strings.TrimPrefix(s.Ref, "#/components/schemas/")]; !ok {
+					errs = append(errs, fmt.Sprintf("schema %s refers to missing %s", name, s.Ref))
+				}
+			}
+		})
+	}
+
+	if len(errs) > 0 {
+		sort.Strings(errs)
+		return fmt.Errorf("invalid OpenAPI document: %s", strings.Join(errs, "; "))
+	}
+	return nil
+}
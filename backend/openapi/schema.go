@@ -0,0 +1,202 @@
+package openapi
+
+import (
+	"encoding/json"
+	"reflect"
+	"strings"
+	"time"
+)
+
+// Schema is an OpenAPI 3.0 schema object
+type Schema struct {
+	Ref                  string             `json:"$ref,omitempty"`
+	Type                 string             `json:"type,omitempty"`
+	Format               string             `json:"format,omitempty"`
+	Description          string             `json:"description,omitempty"`
+	Nullable             bool               `json:"nullable,omitempty"`
+	Enum                 []string           `json:"enum,omitempty"`
+	Items                *Schema            `json:"items,omitempty"`
+	Properties           map[string]*Schema `json:"properties,omitempty"`
+	Required             []string           `json:"required,omitempty"`
+	AdditionalProperties *Schema            `json:"additionalProperties,omitempty"`
+	AllOf                []*Schema          `json:"allOf,omitempty"`
+}
+
+// String returns a string schema, with an optional format such as "date"
+func String(format ...string) *Schema {
+	s := &Schema{Type: "string"}
+	if len(format) > 0 {
+		s.Format = format[0]
+	}
+	return s
+}
+
+// Integer returns an integer schema
+func Integer() *Schema { return &Schema{Type: "integer"} }
+
+// Boolean returns a boolean schema
+func Boolean() *Schema { return &Schema{Type: "boolean"} }
+
+// Enum returns a string schema limited to values
+func Enum(values ...string) *Schema { return &Schema{Type: "string", Enum: values} }
+
+// ArrayOf returns an array schema of items
+func ArrayOf(items *Schema) *Schema { return &Schema{Type: "array", Items: items} }
+
+func (s *Schema) walk(fn func(*Schema)) {
+	if s == nil {
+		return
+	}
+	fn(s)
+	s.Items.walk(fn)
+	s.AdditionalProperties.walk(fn)
+	for _, property := range s.Properties {
+		property.walk(fn)
+	}
+	for _, sub := range s.AllOf {
+		sub.walk(fn)
+	}
+}
+
+var (
+	timeType       = reflect.TypeFor[time.Time]()
+	rawMessageType = reflect.TypeFor[json.RawMessage]()
+)
+
+// SchemaOf returns the schema of the JSON encoding of v's type. Named struct types are
+// added to the components and referred to by $ref.
+//
+// Struct fields follow encoding/json: the json tag names them, and fields tagged
+// omitempty are optional while every other field is required. Slices, maps and pointers
+// are nullable, as encoding/json writes their zero value as null. A format tag sets the
+// schema's format, e.g. format:"date" for a YYYY-MM-DD string, and an enum tag lists
+// the allowed values of a string, separated by commas.
+func (d *Document) SchemaOf(v any) *Schema {
+	return d.schema(reflect.TypeOf(v))
+}
+
+func (d *Document) schema(t reflect.Type) *Schema {
+	switch {
+	case t == nil:
+		return &Schema{}
+	case t == timeType:
+		return String("date-time")
+	case t == rawMessageType:
+		return &Schema{}
+	}
+
+	switch t.Kind() {
+	case reflect.Pointer:
+		return nullable(d.schema(t.Elem()))
+	case reflect.Bool:
+		return Boolean()
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+		return Integer()
+	case reflect.Int64, reflect.Uint64:
+		return &Schema{Type: "integer", Format: "int64"}
+	case reflect.Float32, reflect.Float64:
+		return &Schema{Type: "number"}
+	case reflect.String:
+		return String()
+	case reflect.Slice, reflect.Array:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return String("byte")
+		}
+		return &Schema{Type: "array", Items: d.schema(t.Elem()), Nullable: t.Kind() == reflect.Slice}
+	case reflect.Map:
+		return &Schema{Type: "object", AdditionalProperties: d.schema(t.Elem()), Nullable: true}
+	case reflect.Struct:
+		if t.Name() == "" {
+			return d.structSchema(t)
+		}
+		return d.ref(t)
+	default:
+		// interfaces can hold anything
+		return &Schema{}
+	}
+}
+
+// ref adds a named struct type to the components, once, and refers to it
+func (d *Document) ref(t reflect.Type) *Schema {
+	key := t.PkgPath() + "." + t.Name()
+	name, ok := d.types[key]
+	if !ok {
+		name = t.Name()
+		if _, taken := d.Components.Schemas[name]; taken {
+			name = t.PkgPath()[strings.LastIndex(t.PkgPath(), "/")+1:] + "." + t.Name()
+		}
+		d.types[key] = name
+
+		// reserve the name first, so recursive types refer to it instead of recursing
+		d.Components.Schemas[name] = &Schema{}
+		*d.Components.Schemas[name] = *d.structSchema(t)
+	}
+	return &Schema{Ref: "#/components/schemas/" + name}
+}
+
+func (d *Document) structSchema(t reflect.Type) *Schema {
+	s := &Schema{Type: "object", Properties: map[string]*Schema{}}
+	d.addFields(s, t)
+	return s
+}
+
+func (d *Document) addFields(s *Schema, t reflect.Type) {
+	for i := 0; i < t.NumField(); i++ {
+		field := t.Field(i)
+		tag := field.Tag.Get("json")
+		if tag == "-" {
+			continue
+		}
+		name, options, _ := strings.Cut(tag, ",")
+
+		// embedded structs without a name have their fields promoted
+		if field.Anonymous && name == "" {
+			embedded := field.Type
+			if embedded.Kind() == reflect.Pointer {
+				embedded = embedded.Elem()
+			}
+			if embedded.Kind() == reflect.Struct {
+				d.addFields(s, embedded)
+				continue
+			}
+		}
+		if !field.IsExported() {
+			continue
+		}
+		if name == "" {
+			name = field.Name
+		}
+
+		property := d.schema(field.Type)
+		if format := field.Tag.Get("format"); format != "" {
+			property = withFormat(property, format)
+		}
+		if enum := field.Tag.Get("enum"); enum != "" {
+			property.Enum = strings.Split(enum, ",")
+		}
+		if strings.Contains(options, "string") {
+			property = String()
+		}
+		s.Properties[name] = property
+
+		if !strings.Contains(options, "omitempty") {
+			s.Required = append(s.Required, name)
+		}
+	}
+}
+
+// nullable marks a schema as nullable, wrapping references, which can't have siblings
+func nullable(s *Schema) *Schema {
+	if s.Ref != "" {
+		return &Schema{AllOf: []*Schema{s}, Nullable: true}
+	}
+	copied := *s
+	copied.Nullable = true
+	return &copied
+}
+
+func withFormat(s *Schema, format string) *Schema {
+	copied := *s
+	copied.Format = format
+	return &copied
+}
@@ -0,0 +1,49 @@
+package openapi_test
+
+import (
+	"encoding/json"
+	"testing"
+	"time"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/openapi"
+)
+
+type node struct {
+	Name     string    `json:"name"`
+	Parent   *node     `json:"parent,omitempty"`
+	Children []node    `json:"children"`
+	Seen     time.Time `json:"seen"`
+	Kind     string    `json:"kind" enum:"a,b"`
+	secret   string
+	Ignored  string `json:"-"`
+}
+
+func TestSchemaOf(t *testing.T) {
+	doc := openapi.New(openapi.Info{Title: "test", Version: "1"})
+
+	ref := doc.SchemaOf([]node{})
+	if ref.Type != "array" || ref.Items.Ref != "#/components/schemas/node" {
+		t.Fatalf("unexpected schema %+v", ref)
+	}
+	if err := doc.Validate(); err != nil {
+		t.Fatal(err)
+	}
+
+	got, _ := json.Marshal(doc.Components.Schemas["node"])
+	expected := `{"type":"object","properties":{` +
+		`"children":{"type":"array","nullable":true,"items":{"$ref":"#/components/schemas/node"}},` +
+		`"kind":{"type":"string","enum":["a","b"]},` +
+		`"name":{"type":"string"},` +
+		`"parent":{"nullable":true,"allOf":[{"$ref":"#/components/schemas/node"}]},` +
+		`"seen":{"type":"string","format":"date-time"}},` +
+		`"required":["name","children","seen","kind"]}`
+	if string(got) != expected {
+		t.Errorf("expected %s, got %s", expected, got)
+	}
+}
+
+func TestPath(t *testing.T) {
+	if got := openapi.Path("/api/items/:id/revisions/:rev"); got != "/api/items/{id}/revisions/{rev}" {
+		t.Errorf("unexpected path %s", got)
+	}
+}
@@ -0,0 +1,232 @@
+package openapi
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// ValidationError lists every way a value differs from its schema
+type ValidationError struct {
+	Problems []string
+}
+
+func (e *ValidationError) Error() string {
+	return strings.Join(e.Problems, "; ")
+}
+
+// ValidateJSON checks that data is JSON matching schema. Properties the schema doesn't
+// mention are allowed, as encoding/json ignores them too.
+func (d *Document) ValidateJSON(schema *Schema, data []byte) error {
+	decoder := json.NewDecoder(bytes.NewReader(data))
+	decoder.UseNumber()
+
+	var value any
+	if err := decoder.Decode(&value); err != nil {
+		return &ValidationError{Problems: []string{"invalid JSON: " + err.Error()}}
+	}
+	if decoder.More() {
+		return &ValidationError{Problems: []string{"invalid JSON: unexpected data after the value"}}
+	}
+
+	var problems []string
+	d.validate(schema, value, "", &problems)
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// ValidatePartialJSON is ValidateJSON for a sparse fieldset, a response limited to some of
+// its properties: the object in data, or each object of an array, may leave out required ones
+func (d *Document) ValidatePartialJSON(schema *Schema, data []byte) error {
+	return d.ValidateJSON(d.partial(schema), data)
+}
+
+// partial returns a copy of schema requiring no properties, nor do the items of an array
+func (d *Document) partial(schema *Schema) *Schema {
+	resolved := d.resolve(schema)
+	copied := *resolved
+	copied.Required = nil
+	if resolved.Items != nil {
+		copied.Items = d.partial(resolved.Items)
+	}
+	if len(resolved.AllOf) > 0 {
+		copied.AllOf = make([]*Schema, len(resolved.AllOf))
+		for i, sub := range resolved.AllOf {
+			copied.AllOf[i] = d.partial(sub)
+		}
+	}
+	return &copied
+}
+
+// ValidateParameter checks the values a query or path parameter was given as strings
+func (d *Document) ValidateParameter(param *Parameter, values []string) error {
+	var problems []string
+	name := param.In + " parameter " + param.Name
+
+	switch {
+	case len(values) == 0:
+		if param.Required {
+			problems = append(problems, name+" is required")
+		}
+	case param.Schema.Type == "array":
+		for _, value := range values {
+			if !param.commas {
+				d.validateString(param.Schema.Items, value, name, &problems)
+				continue
+			}
+			for item := range strings.SplitSeq(value, ",") {
+				d.validateString(param.Schema.Items, item, name, &problems)
+			}
+		}
+	case len(values) > 1:
+		problems = append(problems, name+" must only be given once")
+	default:
+		d.validateString(param.Schema, values[0], name, &problems)
+	}
+
+	if len(problems) > 0 {
+		return &ValidationError{Problems: problems}
+	}
+	return nil
+}
+
+// validateString checks a parameter value, which is a string whatever its schema's type
+func (d *Document) validateString(schema *Schema, value, name string, problems *[]string) {
+	schema = d.resolve(schema)
+	switch schema.Type {
+	case "integer":
+		if _, err := strconv.ParseInt(value, 10, 64); err != nil {
+			*problems = append(*problems, name+" must be an integer")
+		}
+	case "number":
+		if _, err := strconv.ParseFloat(value, 64); err != nil {
+			*problems = append(*problems, name+" must be a number")
+		}
+	case "boolean":
+		if _, err := strconv.ParseBool(value); err != nil {
+			*problems = append(*problems, name+" must be true or false")
+		}
+	default:
+		d.validate(schema, value, name, problems)
+	}
+}
+
+// resolve follows a schema's $ref
+func (d *Document) resolve(schema *Schema) *Schema {
+	for schema.Ref != "" {
+		resolved, ok := d.Components.Schemas[strings.TrimPrefix(schema.Ref, "#/components/schemas/")]
+		if !ok {
+			break
+		}
+		schema = resolved
+	}
+	return schema
+}
+
+func (d *Document) validate(schema *Schema, value any, path string, problems *[]string) {
+	fail := func(format string, args ...any) {
+		name := path
+		if name == "" {
+			name = "body"
+		}
+		*problems = append(*problems, name+" "+fmt.Sprintf(format, args...))
+	}
+
+	if value == nil {
+		if !schema.Nullable && (schema.Ref != "" || schema.Type != "" || len(schema.AllOf) > 0) {
+			fail("must not be null")
+		}
+		return
+	}
+
+	if schema.Ref != "" {
+		d.validate(d.resolve(schema), value, path, problems)
+		return
+	}
+	for _, sub := range schema.AllOf {
+		d.validate(sub, value, path, problems)
+	}
+
+	switch schema.Type {
+	case "object":
+		object, ok := value.(map[string]any)
+		if !ok {
+			fail("must be an object")
+			return
+		}
+		for _, name := range schema.Required {
+			if _, ok := object[name]; !ok {
+				fail("is missing required property %s", name)
+			}
+		}
+		names := make([]string, 0, len(object))
+		for name := range object {
+			names = append(names, name)
+		}
+		sort.Strings(names)
+		for _, name := range names {
+			property, ok := schema.Properties[name]
+			if !ok {
+				property = schema.AdditionalProperties
+			}
+			if property != nil {
+				d.validate(property, object[name], join(path, name), problems)
+			}
+		}
+	case "array":
+		array, ok := value.([]any)
+		if !ok {
+			fail("must be an array")
+			return
+		}
+		for i, element := range array {
+			d.validate(schema.Items, element, fmt.Sprintf("%s[%d]", path, i), problems)
+		}
+	case "string":
+		s, ok := value.(string)
+		if !ok {
+			fail("must be a string")
+			return
+		}
+		if len(schema.Enum) > 0 && !slices.Contains(schema.Enum, s) {
+			fail("must be one of %s", strings.Join(schema.Enum, ", "))
+		}
+		switch schema.Format {
+		case "date":
+			if _, err := time.Parse(time.DateOnly, s); err != nil {
+				fail("must be a YYYY-MM-DD date")
+			}
+		case "date-time":
+			if _, err := time.Parse(time.RFC3339, s); err != nil {
+				fail("must be an RFC 3339 timestamp")
+			}
+		}
+	case "integer":
+		n, ok := value.(json.Number)
+		if _, err := n.Int64(); !ok || err != nil {
+			fail("must be an integer")
+		}
+	case "number":
+		if _, ok := value.(json.Number); !ok {
+			fail("must be a number")
+		}
+	case "boolean":
+		if _, ok := value.(bool); !ok {
+			fail("must be a boolean")
+		}
+	}
+}
+
+func join(path, name string) string {
+	if path == "" {
+		return name
+	}
+	return path + "." + name
+}
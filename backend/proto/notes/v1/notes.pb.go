@@ -0,0 +1,1189 @@
+// Code generated by protoc-gen-go. DO NOT EDIT.
+// versions:
+// 	protoc-gen-go v1.36.12
+// 	protoc        v5.29.3
+// source: proto/notes/v1/notes.proto
+
+// notes.v1 is the gRPC API for lists and items. It shares its business rules
+// with the REST API: changes record revisions and activity the same way.
+
+package notesv1
+
+import (
+	protoreflect "google.golang.org/protobuf/reflect/protoreflect"
+	protoimpl "google.golang.org/protobuf/runtime/protoimpl"
+	emptypb "google.golang.org/protobuf/types/known/emptypb"
+	timestamppb "google.golang.org/protobuf/types/known/timestamppb"
+	reflect "reflect"
+	sync "sync"
+	unsafe "unsafe"
+)
+
+const (
+	// Verify that this generated code is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(20 - protoimpl.MinVersion)
+	// Verify that runtime/protoimpl is sufficiently up-to-date.
+	_ = protoimpl.EnforceVersion(protoimpl.MaxVersion - 20)
+)
+
+type Item struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	Id      int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title   string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Content string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	// item_date is the day the item is for, as YYYY-MM-DD
+	ItemDate      string                 `protobuf:"bytes,4,opt,name=item_date,json=itemDate,proto3" json:"item_date,omitempty"`
+	ListId        int64                  `protobuf:"varint,5,opt,name=list_id,json=listId,proto3" json:"list_id,omitempty"`
+	Completed     bool                   `protobuf:"varint,6,opt,name=completed,proto3" json:"completed,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,7,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,8,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *Item) Reset() {
+	*x = Item{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[0]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *Item) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*Item) ProtoMessage() {}
+
+func (x *Item) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[0]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use Item.ProtoReflect.Descriptor instead.
+func (*Item) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{0}
+}
+
+func (x *Item) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *Item) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *Item) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *Item) GetItemDate() string {
+	if x != nil {
+		return x.ItemDate
+	}
+	return ""
+}
+
+func (x *Item) GetListId() int64 {
+	if x != nil {
+		return x.ListId
+	}
+	return 0
+}
+
+func (x *Item) GetCompleted() bool {
+	if x != nil {
+		return x.Completed
+	}
+	return false
+}
+
+func (x *Item) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *Item) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+type List struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	CreatedAt     *timestamppb.Timestamp `protobuf:"bytes,3,opt,name=created_at,json=createdAt,proto3" json:"created_at,omitempty"`
+	UpdatedAt     *timestamppb.Timestamp `protobuf:"bytes,4,opt,name=updated_at,json=updatedAt,proto3" json:"updated_at,omitempty"`
+	Items         []*Item                `protobuf:"bytes,5,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *List) Reset() {
+	*x = List{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[1]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *List) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*List) ProtoMessage() {}
+
+func (x *List) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[1]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use List.ProtoReflect.Descriptor instead.
+func (*List) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{1}
+}
+
+func (x *List) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *List) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *List) GetCreatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.CreatedAt
+	}
+	return nil
+}
+
+func (x *List) GetUpdatedAt() *timestamppb.Timestamp {
+	if x != nil {
+		return x.UpdatedAt
+	}
+	return nil
+}
+
+func (x *List) GetItems() []*Item {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+// ListEvent is a change to a watched list, taken from the activity log
+type ListEvent struct {
+	state protoimpl.MessageState `protogen:"open.v1"`
+	// action is create, update, delete, revert, import or move. Moves are sent to the
+	// watchers of both lists, with list_id the list the item moved to.
+	Action string `protobuf:"bytes,1,opt,name=action,proto3" json:"action,omitempty"`
+	// entity_type is item or list
+	EntityType string                 `protobuf:"bytes,2,opt,name=entity_type,json=entityType,proto3" json:"entity_type,omitempty"`
+	EntityId   int64                  `protobuf:"varint,3,opt,name=entity_id,json=entityId,proto3" json:"entity_id,omitempty"`
+	ListId     int64                  `protobuf:"varint,4,opt,name=list_id,json=listId,proto3" json:"list_id,omitempty"`
+	User       string                 `protobuf:"bytes,5,opt,name=user,proto3" json:"user,omitempty"`
+	Time       *timestamppb.Timestamp `protobuf:"bytes,6,opt,name=time,proto3" json:"time,omitempty"`
+	// item is the changed item as it is now, unless it was deleted
+	Item *Item `protobuf:"bytes,7,opt,name=item,proto3" json:"item,omitempty"`
+	// list is the changed list as it is now, with its items, unless it was deleted
+	List          *List `protobuf:"bytes,8,opt,name=list,proto3" json:"list,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListEvent) Reset() {
+	*x = ListEvent{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[2]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListEvent) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListEvent) ProtoMessage() {}
+
+func (x *ListEvent) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[2]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListEvent.ProtoReflect.Descriptor instead.
+func (*ListEvent) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{2}
+}
+
+func (x *ListEvent) GetAction() string {
+	if x != nil {
+		return x.Action
+	}
+	return ""
+}
+
+func (x *ListEvent) GetEntityType() string {
+	if x != nil {
+		return x.EntityType
+	}
+	return ""
+}
+
+func (x *ListEvent) GetEntityId() int64 {
+	if x != nil {
+		return x.EntityId
+	}
+	return 0
+}
+
+func (x *ListEvent) GetListId() int64 {
+	if x != nil {
+		return x.ListId
+	}
+	return 0
+}
+
+func (x *ListEvent) GetUser() string {
+	if x != nil {
+		return x.User
+	}
+	return ""
+}
+
+func (x *ListEvent) GetTime() *timestamppb.Timestamp {
+	if x != nil {
+		return x.Time
+	}
+	return nil
+}
+
+func (x *ListEvent) GetItem() *Item {
+	if x != nil {
+		return x.Item
+	}
+	return nil
+}
+
+func (x *ListEvent) GetList() *List {
+	if x != nil {
+		return x.List
+	}
+	return nil
+}
+
+type ListListsRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListListsRequest) Reset() {
+	*x = ListListsRequest{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[3]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListListsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListListsRequest) ProtoMessage() {}
+
+func (x *ListListsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[3]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListListsRequest.ProtoReflect.Descriptor instead.
+func (*ListListsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{3}
+}
+
+type ListListsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Lists         []*List                `protobuf:"bytes,1,rep,name=lists,proto3" json:"lists,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListListsResponse) Reset() {
+	*x = ListListsResponse{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[4]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListListsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListListsResponse) ProtoMessage() {}
+
+func (x *ListListsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[4]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListListsResponse.ProtoReflect.Descriptor instead.
+func (*ListListsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{4}
+}
+
+func (x *ListListsResponse) GetLists() []*List {
+	if x != nil {
+		return x.Lists
+	}
+	return nil
+}
+
+type GetListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetListRequest) Reset() {
+	*x = GetListRequest{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[5]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetListRequest) ProtoMessage() {}
+
+func (x *GetListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[5]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetListRequest.ProtoReflect.Descriptor instead.
+func (*GetListRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{5}
+}
+
+func (x *GetListRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type CreateListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateListRequest) Reset() {
+	*x = CreateListRequest{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[6]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateListRequest) ProtoMessage() {}
+
+func (x *CreateListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[6]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateListRequest.ProtoReflect.Descriptor instead.
+func (*CreateListRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{6}
+}
+
+func (x *CreateListRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+type RenameListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title         string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *RenameListRequest) Reset() {
+	*x = RenameListRequest{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[7]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *RenameListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*RenameListRequest) ProtoMessage() {}
+
+func (x *RenameListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[7]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use RenameListRequest.ProtoReflect.Descriptor instead.
+func (*RenameListRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{7}
+}
+
+func (x *RenameListRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *RenameListRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+type DeleteListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteListRequest) Reset() {
+	*x = DeleteListRequest{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[8]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteListRequest) ProtoMessage() {}
+
+func (x *DeleteListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[8]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteListRequest.ProtoReflect.Descriptor instead.
+func (*DeleteListRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{8}
+}
+
+func (x *DeleteListRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type WatchListRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *WatchListRequest) Reset() {
+	*x = WatchListRequest{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[9]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *WatchListRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*WatchListRequest) ProtoMessage() {}
+
+func (x *WatchListRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[9]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use WatchListRequest.ProtoReflect.Descriptor instead.
+func (*WatchListRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{9}
+}
+
+func (x *WatchListRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+// ListItemsRequest filters items like the query parameters of GET /api/items
+type ListItemsRequest struct {
+	state   protoimpl.MessageState `protogen:"open.v1"`
+	ListIds []int64                `protobuf:"varint,1,rep,packed,name=list_ids,json=listIds,proto3" json:"list_ids,omitempty"`
+	// due is a relative date range: overdue, today, this_week, next_7_days or this_month
+	Due string `protobuf:"bytes,2,opt,name=due,proto3" json:"due,omitempty"`
+	// from and to are inclusive YYYY-MM-DD item dates
+	From      string `protobuf:"bytes,3,opt,name=from,proto3" json:"from,omitempty"`
+	To        string `protobuf:"bytes,4,opt,name=to,proto3" json:"to,omitempty"`
+	Completed *bool  `protobuf:"varint,5,opt,name=completed,proto3,oneof" json:"completed,omitempty"`
+	// q is a full-text search of titles and content
+	Q             string `protobuf:"bytes,6,opt,name=q,proto3" json:"q,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListItemsRequest) Reset() {
+	*x = ListItemsRequest{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[10]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListItemsRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListItemsRequest) ProtoMessage() {}
+
+func (x *ListItemsRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[10]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListItemsRequest.ProtoReflect.Descriptor instead.
+func (*ListItemsRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{10}
+}
+
+func (x *ListItemsRequest) GetListIds() []int64 {
+	if x != nil {
+		return x.ListIds
+	}
+	return nil
+}
+
+func (x *ListItemsRequest) GetDue() string {
+	if x != nil {
+		return x.Due
+	}
+	return ""
+}
+
+func (x *ListItemsRequest) GetFrom() string {
+	if x != nil {
+		return x.From
+	}
+	return ""
+}
+
+func (x *ListItemsRequest) GetTo() string {
+	if x != nil {
+		return x.To
+	}
+	return ""
+}
+
+func (x *ListItemsRequest) GetCompleted() bool {
+	if x != nil && x.Completed != nil {
+		return *x.Completed
+	}
+	return false
+}
+
+func (x *ListItemsRequest) GetQ() string {
+	if x != nil {
+		return x.Q
+	}
+	return ""
+}
+
+type ListItemsResponse struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Items         []*Item                `protobuf:"bytes,1,rep,name=items,proto3" json:"items,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *ListItemsResponse) Reset() {
+	*x = ListItemsResponse{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[11]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *ListItemsResponse) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*ListItemsResponse) ProtoMessage() {}
+
+func (x *ListItemsResponse) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[11]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use ListItemsResponse.ProtoReflect.Descriptor instead.
+func (*ListItemsResponse) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{11}
+}
+
+func (x *ListItemsResponse) GetItems() []*Item {
+	if x != nil {
+		return x.Items
+	}
+	return nil
+}
+
+type GetItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *GetItemRequest) Reset() {
+	*x = GetItemRequest{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[12]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *GetItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*GetItemRequest) ProtoMessage() {}
+
+func (x *GetItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[12]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use GetItemRequest.ProtoReflect.Descriptor instead.
+func (*GetItemRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{12}
+}
+
+func (x *GetItemRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+type CreateItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Title         string                 `protobuf:"bytes,1,opt,name=title,proto3" json:"title,omitempty"`
+	Content       string                 `protobuf:"bytes,2,opt,name=content,proto3" json:"content,omitempty"`
+	ItemDate      string                 `protobuf:"bytes,3,opt,name=item_date,json=itemDate,proto3" json:"item_date,omitempty"`
+	ListId        int64                  `protobuf:"varint,4,opt,name=list_id,json=listId,proto3" json:"list_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *CreateItemRequest) Reset() {
+	*x = CreateItemRequest{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[13]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *CreateItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*CreateItemRequest) ProtoMessage() {}
+
+func (x *CreateItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[13]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use CreateItemRequest.ProtoReflect.Descriptor instead.
+func (*CreateItemRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{13}
+}
+
+func (x *CreateItemRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *CreateItemRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *CreateItemRequest) GetItemDate() string {
+	if x != nil {
+		return x.ItemDate
+	}
+	return ""
+}
+
+func (x *CreateItemRequest) GetListId() int64 {
+	if x != nil {
+		return x.ListId
+	}
+	return 0
+}
+
+type UpdateItemRequest struct {
+	state     protoimpl.MessageState `protogen:"open.v1"`
+	Id        int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	Title     string                 `protobuf:"bytes,2,opt,name=title,proto3" json:"title,omitempty"`
+	Content   string                 `protobuf:"bytes,3,opt,name=content,proto3" json:"content,omitempty"`
+	ItemDate  string                 `protobuf:"bytes,4,opt,name=item_date,json=itemDate,proto3" json:"item_date,omitempty"`
+	Completed *bool                  `protobuf:"varint,5,opt,name=completed,proto3,oneof" json:"completed,omitempty"`
+	// list_id moves the item to another list
+	ListId        *int64 `protobuf:"varint,6,opt,name=list_id,json=listId,proto3,oneof" json:"list_id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *UpdateItemRequest) Reset() {
+	*x = UpdateItemRequest{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[14]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *UpdateItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*UpdateItemRequest) ProtoMessage() {}
+
+func (x *UpdateItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[14]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use UpdateItemRequest.ProtoReflect.Descriptor instead.
+func (*UpdateItemRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{14}
+}
+
+func (x *UpdateItemRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+func (x *UpdateItemRequest) GetTitle() string {
+	if x != nil {
+		return x.Title
+	}
+	return ""
+}
+
+func (x *UpdateItemRequest) GetContent() string {
+	if x != nil {
+		return x.Content
+	}
+	return ""
+}
+
+func (x *UpdateItemRequest) GetItemDate() string {
+	if x != nil {
+		return x.ItemDate
+	}
+	return ""
+}
+
+func (x *UpdateItemRequest) GetCompleted() bool {
+	if x != nil && x.Completed != nil {
+		return *x.Completed
+	}
+	return false
+}
+
+func (x *UpdateItemRequest) GetListId() int64 {
+	if x != nil && x.ListId != nil {
+		return *x.ListId
+	}
+	return 0
+}
+
+type DeleteItemRequest struct {
+	state         protoimpl.MessageState `protogen:"open.v1"`
+	Id            int64                  `protobuf:"varint,1,opt,name=id,proto3" json:"id,omitempty"`
+	unknownFields protoimpl.UnknownFields
+	sizeCache     protoimpl.SizeCache
+}
+
+func (x *DeleteItemRequest) Reset() {
+	*x = DeleteItemRequest{}
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[15]
+	ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+	ms.StoreMessageInfo(mi)
+}
+
+func (x *DeleteItemRequest) String() string {
+	return protoimpl.X.MessageStringOf(x)
+}
+
+func (*DeleteItemRequest) ProtoMessage() {}
+
+func (x *DeleteItemRequest) ProtoReflect() protoreflect.Message {
+	mi := &file_proto_notes_v1_notes_proto_msgTypes[15]
+	if x != nil {
+		ms := protoimpl.X.MessageStateOf(protoimpl.Pointer(x))
+		if ms.LoadMessageInfo() == nil {
+			ms.StoreMessageInfo(mi)
+		}
+		return ms
+	}
+	return mi.MessageOf(x)
+}
+
+// Deprecated: Use DeleteItemRequest.ProtoReflect.Descriptor instead.
+func (*DeleteItemRequest) Descriptor() ([]byte, []int) {
+	return file_proto_notes_v1_notes_proto_rawDescGZIP(), []int{15}
+}
+
+func (x *DeleteItemRequest) GetId() int64 {
+	if x != nil {
+		return x.Id
+	}
+	return 0
+}
+
+var File_proto_notes_v1_notes_proto protoreflect.FileDescriptor
+
+const file_proto_notes_v1_notes_proto_rawDesc = "" +
+	"\n" +
+	"\x1aproto/notes/v1/notes.proto\x12\bnotes.v1\x1a\x1bgoogle/protobuf/empty.proto\x1a\x1fgoogle/protobuf/timestamp.proto\"\x90\x02\n" +
+	"\x04Item\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x12\x1b\n" +
+	"\titem_date\x18\x04 \x01(\tR\bitemDate\x12\x17\n" +
+	"\alist_id\x18\x05 \x01(\x03R\x06listId\x12\x1c\n" +
+	"\tcompleted\x18\x06 \x01(\bR\tcompleted\x129\n" +
+	"\n" +
+	"created_at\x18\a \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\b \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\"\xc8\x01\n" +
+	"\x04List\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x129\n" +
+	"\n" +
+	"created_at\x18\x03 \x01(\v2\x1a.google.protobuf.TimestampR\tcreatedAt\x129\n" +
+	"\n" +
+	"updated_at\x18\x04 \x01(\v2\x1a.google.protobuf.TimestampR\tupdatedAt\x12$\n" +
+	"\x05items\x18\x05 \x03(\v2\x0e.notes.v1.ItemR\x05items\"\x86\x02\n" +
+	"\tListEvent\x12\x16\n" +
+	"\x06action\x18\x01 \x01(\tR\x06action\x12\x1f\n" +
+	"\ventity_type\x18\x02 \x01(\tR\n" +
+	"entityType\x12\x1b\n" +
+	"\tentity_id\x18\x03 \x01(\x03R\bentityId\x12\x17\n" +
+	"\alist_id\x18\x04 \x01(\x03R\x06listId\x12\x12\n" +
+	"\x04user\x18\x05 \x01(\tR\x04user\x12.\n" +
+	"\x04time\x18\x06 \x01(\v2\x1a.google.protobuf.TimestampR\x04time\x12\"\n" +
+	"\x04item\x18\a \x01(\v2\x0e.notes.v1.ItemR\x04item\x12\"\n" +
+	"\x04list\x18\b \x01(\v2\x0e.notes.v1.ListR\x04list\"\x12\n" +
+	"\x10ListListsRequest\"9\n" +
+	"\x11ListListsResponse\x12$\n" +
+	"\x05lists\x18\x01 \x03(\v2\x0e.notes.v1.ListR\x05lists\" \n" +
+	"\x0eGetListRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\")\n" +
+	"\x11CreateListRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\"9\n" +
+	"\x11RenameListRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\"#\n" +
+	"\x11DeleteListRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\"\n" +
+	"\x10WatchListRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"\xa2\x01\n" +
+	"\x10ListItemsRequest\x12\x19\n" +
+	"\blist_ids\x18\x01 \x03(\x03R\alistIds\x12\x10\n" +
+	"\x03due\x18\x02 \x01(\tR\x03due\x12\x12\n" +
+	"\x04from\x18\x03 \x01(\tR\x04from\x12\x0e\n" +
+	"\x02to\x18\x04 \x01(\tR\x02to\x12!\n" +
+	"\tcompleted\x18\x05 \x01(\bH\x00R\tcompleted\x88\x01\x01\x12\f\n" +
+	"\x01q\x18\x06 \x01(\tR\x01qB\f\n" +
+	"\n" +
+	"_completed\"9\n" +
+	"\x11ListItemsResponse\x12$\n" +
+	"\x05items\x18\x01 \x03(\v2\x0e.notes.v1.ItemR\x05items\" \n" +
+	"\x0eGetItemRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\"y\n" +
+	"\x11CreateItemRequest\x12\x14\n" +
+	"\x05title\x18\x01 \x01(\tR\x05title\x12\x18\n" +
+	"\acontent\x18\x02 \x01(\tR\acontent\x12\x1b\n" +
+	"\titem_date\x18\x03 \x01(\tR\bitemDate\x12\x17\n" +
+	"\alist_id\x18\x04 \x01(\x03R\x06listId\"\xcb\x01\n" +
+	"\x11UpdateItemRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id\x12\x14\n" +
+	"\x05title\x18\x02 \x01(\tR\x05title\x12\x18\n" +
+	"\acontent\x18\x03 \x01(\tR\acontent\x12\x1b\n" +
+	"\titem_date\x18\x04 \x01(\tR\bitemDate\x12!\n" +
+	"\tcompleted\x18\x05 \x01(\bH\x00R\tcompleted\x88\x01\x01\x12\x1c\n" +
+	"\alist_id\x18\x06 \x01(\x03H\x01R\x06listId\x88\x01\x01B\f\n" +
+	"\n" +
+	"_completedB\n" +
+	"\n" +
+	"\b_list_id\"#\n" +
+	"\x11DeleteItemRequest\x12\x0e\n" +
+	"\x02id\x18\x01 \x01(\x03R\x02id2\x81\x03\n" +
+	"\vListService\x12D\n" +
+	"\tListLists\x12\x1a.notes.v1.ListListsRequest\x1a\x1b.notes.v1.ListListsResponse\x123\n" +
+	"\aGetList\x12\x18.notes.v1.GetListRequest\x1a\x0e.notes.v1.List\x129\n" +
+	"\n" +
+	"CreateList\x12\x1b.notes.v1.CreateListRequest\x1a\x0e.notes.v1.List\x129\n" +
+	"\n" +
+	"RenameList\x12\x1b.notes.v1.RenameListRequest\x1a\x0e.notes.v1.List\x12A\n" +
+	"\n" +
+	"DeleteList\x12\x1b.notes.v1.DeleteListRequest\x1a\x16.google.protobuf.Empty\x12>\n" +
+	"\tWatchList\x12\x1a.notes.v1.WatchListRequest\x1a\x13.notes.v1.ListEvent0\x012\xc1\x02\n" +
+	"\vItemService\x12D\n" +
+	"\tListItems\x12\x1a.notes.v1.ListItemsRequest\x1a\x1b.notes.v1.ListItemsResponse\x123\n" +
+	"\aGetItem\x12\x18.notes.v1.GetItemRequest\x1a\x0e.notes.v1.Item\x129\n" +
+	"\n" +
+	"CreateItem\x12\x1b.notes.v1.CreateItemRequest\x1a\x0e.notes.v1.Item\x129\n" +
+	"\n" +
+	"UpdateItem\x12\x1b.notes.v1.UpdateItemRequest\x1a\x0e.notes.v1.Item\x12A\n" +
+	"\n" +
+	"DeleteItem\x12\x1b.notes.v1.DeleteItemRequest\x1a\x16.google.protobuf.EmptyBCZAgithub.com/jennaborowy/fullstack-Go-Docker/proto/notes/v1;notesv1b\x06proto3"
+
+var (
+	file_proto_notes_v1_notes_proto_rawDescOnce sync.Once
+	file_proto_notes_v1_notes_proto_rawDescData []byte
+)
+
+func file_proto_notes_v1_notes_proto_rawDescGZIP() []byte {
+	file_proto_notes_v1_notes_proto_rawDescOnce.Do(func() {
+		file_proto_notes_v1_notes_proto_rawDescData = protoimpl.X.CompressGZIP(unsafe.Slice(unsafe.StringData(file_proto_notes_v1_notes_proto_rawDesc), len(file_proto_notes_v1_notes_proto_rawDesc)))
+	})
+	return file_proto_notes_v1_notes_proto_rawDescData
+}
+
+var file_proto_notes_v1_notes_proto_msgTypes = make([]protoimpl.MessageInfo, 16)
+var file_proto_notes_v1_notes_proto_goTypes = []any{
+	(*Item)(nil),                  // 0: notes.v1.Item
+	(*List)(nil),                  // 1: notes.v1.List
+	(*ListEvent)(nil),             // 2: notes.v1.ListEvent
+	(*ListListsRequest)(nil),      // 3: notes.v1.ListListsRequest
+	(*ListListsResponse)(nil),     // 4: notes.v1.ListListsResponse
+	(*GetListRequest)(nil),        // 5: notes.v1.GetListRequest
+	(*CreateListRequest)(nil),     // 6: notes.v1.CreateListRequest
+	(*RenameListRequest)(nil),     // 7: notes.v1.RenameListRequest
+	(*DeleteListRequest)(nil),     // 8: notes.v1.DeleteListRequest
+	(*WatchListRequest)(nil),      // 9: notes.v1.WatchListRequest
+	(*ListItemsRequest)(nil),      // 10: notes.v1.ListItemsRequest
+	(*ListItemsResponse)(nil),     // 11: notes.v1.ListItemsResponse
+	(*GetItemRequest)(nil),        // 12: notes.v1.GetItemRequest
+	(*CreateItemRequest)(nil),     // 13: notes.v1.CreateItemRequest
+	(*UpdateItemRequest)(nil),     // 14: notes.v1.UpdateItemRequest
+	(*DeleteItemRequest)(nil),     // 15: notes.v1.DeleteItemRequest
+	(*timestamppb.Timestamp)(nil), // 16: google.protobuf.Timestamp
+	(*emptypb.Empty)(nil),         // 17: google.protobuf.Empty
+}
+var file_proto_notes_v1_notes_proto_depIdxs = []int32{
+	16, // 0: notes.v1.Item.created_at:type_name -> google.protobuf.Timestamp
+	16, // 1: notes.v1.Item.updated_at:type_name -> google.protobuf.Timestamp
+	16, // 2: notes.v1.List.created_at:type_name -> google.protobuf.Timestamp
+	16, // 3: notes.v1.List.updated_at:type_name -> google.protobuf.Timestamp
+	0,  // 4: notes.v1.List.items:type_name -> notes.v1.Item
+	16, // 5: notes.v1.ListEvent.time:type_name -> google.protobuf.Timestamp
+	0,  // 6: notes.v1.ListEvent.item:type_name -> notes.v1.Item
+	1,  // 7: notes.v1.ListEvent.list:type_name -> notes.v1.List
+	1,  // 8: notes.v1.ListListsResponse.lists:type_name -> notes.v1.List
+	0,  // 9: notes.v1.ListItemsResponse.items:type_name -> notes.v1.Item
+	3,  // 10: notes.v1.ListService.ListLists:input_type -> notes.v1.ListListsRequest
+	5,  // 11: notes.v1.ListService.GetList:input_type -> notes.v1.GetListRequest
+	6,  // 12: notes.v1.ListService.CreateList:input_type -> notes.v1.CreateListRequest
+	7,  // 13: notes.v1.ListService.RenameList:input_type -> notes.v1.RenameListRequest
+	8,  // 14: notes.v1.ListService.DeleteList:input_type -> notes.v1.DeleteListRequest
+	9,  // 15: notes.v1.ListService.WatchList:input_type -> notes.v1.WatchListRequest
+	10, // 16: notes.v1.ItemService.ListItems:input_type -> notes.v1.ListItemsRequest
+	12, // 17: notes.v1.ItemService.GetItem:input_type -> notes.v1.GetItemRequest
+	13, // 18: notes.v1.ItemService.CreateItem:input_type -> notes.v1.CreateItemRequest
+	14, // 19: notes.v1.ItemService.UpdateItem:input_type -> notes.v1.UpdateItemRequest
+	15, // 20: notes.v1.ItemService.DeleteItem:input_type -> notes.v1.DeleteItemRequest
+	4,  // 21: notes.v1.ListService.ListLists:output_type -> notes.v1.ListListsResponse
+	1,  // 22: notes.v1.ListService.GetList:output_type -> notes.v1.List
+	1,  // 23: notes.v1.ListService.CreateList:output_type -> notes.v1.List
+	1,  // 24: notes.v1.ListService.RenameList:output_type -> notes.v1.List
+	17, // 25: notes.v1.ListService.DeleteList:output_type -> google.protobuf.Empty
+	2,  // 26: notes.v1.ListService.WatchList:output_type -> notes.v1.ListEvent
+	11, // 27: notes.v1.ItemService.ListItems:output_type -> notes.v1.ListItemsResponse
+	0,  // 28: notes.v1.ItemService.GetItem:output_type -> notes.v1.Item
+	0,  // 29: notes.v1.ItemService.CreateItem:output_type -> notes.v1.Item
+	0,  // 30: notes.v1.ItemService.UpdateItem:output_type -> notes.v1.Item
+	17, // 31: notes.v1.ItemService.DeleteItem:output_type -> google.protobuf.Empty
+	21, // [21:32] is the sub-list for method output_type
+	10, // [10:21] is the sub-list for method input_type
+	10, // [10:10] is the sub-list for extension type_name
+	10, // [10:10] is the sub-list for extension extendee
+	0,  // [0:10] is the sub-list for field type_name
+}
+
+func init() { file_proto_notes_v1_notes_proto_init() }
+func file_proto_notes_v1_notes_proto_init() {
+	if File_proto_notes_v1_notes_proto != nil {
+		return
+	}
+	file_proto_notes_v1_notes_proto_msgTypes[10].OneofWrappers = []any{}
+	file_proto_notes_v1_notes_proto_msgTypes[14].OneofWrappers = []any{}
+	type x struct{}
+	out := protoimpl.TypeBuilder{
+		File: protoimpl.DescBuilder{
+			GoPackagePath: reflect.TypeOf(x{}).PkgPath(),
+			RawDescriptor: unsafe.Slice(unsafe.StringData(file_proto_notes_v1_notes_proto_rawDesc), len(file_proto_notes_v1_notes_proto_rawDesc)),
+			NumEnums:      0,
+			NumMessages:   16,
+			NumExtensions: 0,
+			NumServices:   2,
+		},
+		GoTypes:           file_proto_notes_v1_notes_proto_goTypes,
+		DependencyIndexes: file_proto_notes_v1_notes_proto_depIdxs,
+		MessageInfos:      file_proto_notes_v1_notes_proto_msgTypes,
+	}.Build()
+	File_proto_notes_v1_notes_proto = out.File
+	file_proto_notes_v1_notes_proto_goTypes = nil
+	file_proto_notes_v1_notes_proto_depIdxs = nil
+}
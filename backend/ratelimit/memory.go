@@ -0,0 +1,48 @@
+package ratelimit
+
+import (
+	"sync"
+	"time"
+)
+
+// sweepEvery is how often stores drop the buckets that are full again
+const sweepEvery = time.Minute
+
+// Memory is an in-process Store. Each replica keeps buckets of its own, so behind a
+// load balancer a client gets the limit from every replica.
+type Memory struct {
+	mu      sync.Mutex
+	buckets map[string]time.Time // when each bucket will be full again
+	swept   time.Time
+	now     func() time.Time
+}
+
+// NewMemory creates an empty Memory store
+func NewMemory() *Memory {
+	return &Memory{buckets: make(map[string]time.Time), now: time.Now}
+}
+
+func (m *Memory) Take(key string, interval, period time.Duration) (Bucket, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := m.now()
+	if now.Sub(m.swept) >= sweepEvery {
+		for k, full := range m.buckets {
+			if !full.After(now) {
+				delete(m.buckets, k)
+			}
+		}
+		m.swept = now
+	}
+
+	full := m.buckets[key]
+	if full.Before(now) {
+		full = now
+	}
+	if next := full.Add(interval); next.Sub(now) <= period {
+		m.buckets[key] = next
+		return Bucket{Now: now, Full: next, Taken: true}, nil
+	}
+	return Bucket{Now: now, Full: full}, nil
+}
@@ -0,0 +1,68 @@
+package ratelimit
+
+import (
+	"database/sql"
+	"errors"
+	"fmt"
+	"log"
+	"sync"
+	"time"
+)
+
+// Postgres is a Store kept in the rate_limits table, so every replica sharing the
+// database shares the buckets too. Times come from the database's clock rather than the
+// replicas', which may disagree.
+type Postgres struct {
+	db *sql.DB
+
+	mu    sync.Mutex
+	swept time.Time
+}
+
+// NewPostgres creates a store in the rate_limits table of db
+func NewPostgres(db *sql.DB) *Postgres {
+	return &Postgres{db: db}
+}
+
+// takeQuery takes a token in one statement: the update only happens when the bucket
+// isn't empty, and returns no row when it is
+const takeQuery = `
+	INSERT INTO rate_limits AS b (key, full_at) VALUES ($1, now() + $2::float8 * INTERVAL '1 second')
+	ON CONFLICT (key) DO UPDATE SET full_at = GREATEST(b.full_at, now()) + $2::float8 * INTERVAL '1 second'
+	WHERE GREATEST(b.full_at, now()) + $2::float8 * INTERVAL '1 second' <= now() + $3::float8 * INTERVAL '1 second'
+	RETURNING full_at, now()`
+
+func (p *Postgres) Take(key string, interval, period time.Duration) (Bucket, error) {
+	p.sweep()
+
+	var b Bucket
+	err := p.db.QueryRow(takeQuery, key, interval.Seconds(), period.Seconds()).Scan(&b.Full, &b.Now)
+	if err == nil {
+		b.Taken = true
+		return b, nil
+	}
+	if !errors.Is(err, sql.ErrNoRows) {
+		return Bucket{}, fmt.Errorf("failed to take a token: %w", err)
+	}
+
+	// the bucket is empty
+	if err := p.db.QueryRow("SELECT full_at, now() FROM rate_limits WHERE key = $1", key).Scan(&b.Full, &b.Now); err != nil {
+		return Bucket{}, fmt.Errorf("failed to read bucket: %w", err)
+	}
+	return b, nil
+}
+
+// sweep deletes the buckets that are full again, at most every sweepEvery
+func (p *Postgres) sweep() {
+	p.mu.Lock()
+	if time.Since(p.swept) < sweepEvery {
+		p.mu.Unlock()
+		return
+	}
+	p.swept = time.Now()
+	p.mu.Unlock()
+
+	if _, err := p.db.Exec("DELETE FROM rate_limits WHERE full_at <= now()"); err != nil {
+		log.Printf("failed to sweep rate limits: %v", err)
+	}
+}
@@ -0,0 +1,49 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/database"
+	"github.com/jennaborowy/fullstack-Go-Docker/database/databasetest"
+)
+
+// TestPostgres runs against the Postgres database at TEST_DATABASE_URL, in a temporary
+// table that goes with the connection
+func TestPostgres(t *testing.T) {
+	db, err := database.Connect(databasetest.URL(t), database.Pool{})
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	defer db.Close()
+	db.SetMaxOpenConns(1) // temporary tables belong to one connection
+	if _, err := db.Exec("CREATE TEMPORARY TABLE rate_limits (key TEXT PRIMARY KEY, full_at TIMESTAMPTZ NOT NULL)"); err != nil {
+		t.Fatalf("failed to create table: %v", err)
+	}
+
+	// two replicas sharing the buckets
+	replicas := []*Limiter{
+		New(NewPostgres(db), Limit{Requests: 2, Period: time.Hour}, nil),
+		New(NewPostgres(db), Limit{Requests: 2, Period: time.Hour}, nil),
+	}
+	for i, expected := range []bool{true, true, false} {
+		result, err := replicas[i%2].Allow("GET", "/items", "a")
+		if err != nil {
+			t.Fatalf("Allow: %v", err)
+		}
+		if result.Allowed != expected {
+			t.Fatalf("request %d: expected allowed %v, got %+v", i, expected, result)
+		}
+		if !expected && (result.RetryAfter <= 29*time.Minute || result.RetryAfter > 30*time.Minute) {
+			t.Errorf("expected a retry after about 30m, got %v", result.RetryAfter)
+		}
+	}
+
+	result, err := replicas[0].Allow("GET", "/items", "b")
+	if err != nil {
+		t.Fatalf("Allow: %v", err)
+	}
+	if !result.Allowed || result.Remaining != 1 {
+		t.Errorf("expected another client to have a bucket of its own, got %+v", result)
+	}
+}
@@ -0,0 +1,175 @@
+// Package ratelimit limits how often each client may call the API, with token buckets.
+//
+// A bucket holds up to Limit.Requests tokens and refills at Limit.Requests per
+// Limit.Period; every request takes a token, and a request finding the bucket empty is
+// refused. Rather than a token count, a bucket is kept as the time it will be full again,
+// so a store can take a token with a single compare-and-set, and a bucket full again is
+// the same as no bucket at all.
+package ratelimit
+
+import (
+	"fmt"
+	"net/http"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// Limit is how many requests a client may make per period. The zero Limit is no limit.
+type Limit struct {
+	Requests int
+	Period   time.Duration
+}
+
+// Unlimited reports whether l doesn't limit anything
+func (l Limit) Unlimited() bool {
+	return l.Requests == 0
+}
+
+// interval is how long the bucket takes to refill one token
+func (l Limit) interval() time.Duration {
+	return l.Period / time.Duration(l.Requests)
+}
+
+func (l Limit) String() string {
+	if l.Unlimited() {
+		return "off"
+	}
+	return fmt.Sprintf("%d/%v", l.Requests, l.Period)
+}
+
+// ParseLimit reads a limit written as requests/period, such as 100/1m or 10/s, or off
+// for no limit
+func ParseLimit(s string) (Limit, error) {
+	s = strings.TrimSpace(s)
+	if s == "" || s == "off" {
+		return Limit{}, nil
+	}
+
+	requests, period, ok := strings.Cut(s, "/")
+	n, err := strconv.Atoi(strings.TrimSpace(requests))
+	if !ok || err != nil || n < 1 {
+		return Limit{}, fmt.Errorf("invalid rate limit %q, expected requests/period such as 100/1m, or off", s)
+	}
+	period = strings.TrimSpace(period)
+	if period != "" && (period[0] < '0' || period[0] > '9') {
+		period = "1" + period // 10/s is 10/1s
+	}
+	d, err := time.ParseDuration(period)
+	if err != nil || d < time.Duration(n) {
+		return Limit{}, fmt.Errorf("invalid rate limit %q, expected requests/period such as 100/1m, or off", s)
+	}
+	return Limit{Requests: n, Period: d}, nil
+}
+
+// Rule overrides the limit of one route, given as a method and a gin route pattern such
+// as POST /items or DELETE /lists/:id
+type Rule struct {
+	Method string
+	Path   string
+	Limit  Limit
+}
+
+// ParseRules reads comma-separated rules written as "METHOD /path=limit", such as
+// "POST /items=10/1m, DELETE /lists/:id=off"
+func ParseRules(s string) ([]Rule, error) {
+	var rules []Rule
+	for _, part := range strings.Split(s, ",") {
+		part = strings.TrimSpace(part)
+		if part == "" {
+			continue
+		}
+
+		route, limit, ok := strings.Cut(part, "=")
+		method, path, _ := strings.Cut(strings.TrimSpace(route), " ")
+		method, path = strings.ToUpper(method), strings.TrimSpace(path)
+		if !ok || !knownMethod(method) || !strings.HasPrefix(path, "/") {
+			return nil, fmt.Errorf("invalid rate limit rule %q, expected METHOD /path=limit such as POST /items=10/1m", part)
+		}
+		l, err := ParseLimit(limit)
+		if err != nil {
+			return nil, fmt.Errorf("invalid rate limit rule %q: %w", part, err)
+		}
+		rules = append(rules, Rule{Method: method, Path: path, Limit: l})
+	}
+	return rules, nil
+}
+
+func knownMethod(method string) bool {
+	switch method {
+	case http.MethodGet, http.MethodHead, http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete, http.MethodOptions:
+		return true
+	}
+	return false
+}
+
+// Bucket is a bucket as Store.Take left it
+type Bucket struct {
+	Now   time.Time // the store's clock, which Full is relative to
+	Full  time.Time // when the bucket will be full again
+	Taken bool      // whether a token was taken
+}
+
+// Store keeps the buckets. Take must be atomic: two requests can't take the same token,
+// however many limiters share the store.
+type Store interface {
+	// Take takes a token from key's bucket, unless it is empty: it moves when the bucket
+	// will be full again interval later, unless that would be more than period from now.
+	// A missing bucket is full.
+	Take(key string, interval, period time.Duration) (Bucket, error)
+}
+
+// Result is what the limiter decided about a request
+type Result struct {
+	Allowed   bool
+	Limit     Limit         // the limit applied, Unlimited when none is
+	Remaining int           // the requests left right now
+	Reset     time.Duration // until the bucket is full again
+	// RetryAfter is how long until a refused request may be retried
+	RetryAfter time.Duration
+}
+
+// Limiter applies a default limit to every route, apart from those with a rule of their
+// own. A route with a rule gets a bucket of its own; all the others share one.
+type Limiter struct {
+	store Store
+	limit Limit
+	rules []Rule
+}
+
+// New creates a limiter keeping its buckets in store
+func New(store Store, limit Limit, rules []Rule) *Limiter {
+	return &Limiter{store: store, limit: limit, rules: rules}
+}
+
+// Allow takes a token for a request from client to the route pattern path
+func (l *Limiter) Allow(method, path, client string) (Result, error) {
+	limit, bucket := l.limit, "*"
+	for _, rule := range l.rules {
+		if rule.Method == method && rule.Path == path {
+			limit, bucket = rule.Limit, method+" "+path
+			break
+		}
+	}
+	if limit.Unlimited() {
+		return Result{Allowed: true}, nil
+	}
+
+	interval := limit.interval()
+	b, err := l.store.Take(bucket+" "+client, interval, limit.Period)
+	if err != nil {
+		return Result{}, err
+	}
+
+	wait := max(b.Full.Sub(b.Now), 0)
+	result := Result{
+		Allowed:   b.Taken,
+		Limit:     limit,
+		Remaining: max(int((limit.Period-wait)/interval), 0),
+		Reset:     wait,
+	}
+	if !b.Taken {
+		result.RetryAfter = max(wait-(limit.Period-interval), 0)
+	}
+	return result, nil
+}
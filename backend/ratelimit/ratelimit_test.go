@@ -0,0 +1,115 @@
+package ratelimit
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseLimit(t *testing.T) {
+	tests := []struct {
+		in       string
+		expected Limit
+		wantErr  bool
+	}{
+		{in: "100/1m", expected: Limit{Requests: 100, Period: time.Minute}},
+		{in: "10/s", expected: Limit{Requests: 10, Period: time.Second}},
+		{in: " 5 / 2h ", expected: Limit{Requests: 5, Period: 2 * time.Hour}},
+		{in: "off"},
+		{in: ""},
+		{in: "100", wantErr: true},
+		{in: "0/1m", wantErr: true},
+		{in: "-1/1m", wantErr: true},
+		{in: "ten/1m", wantErr: true},
+		{in: "10/fortnight", wantErr: true},
+		{in: "10/5ns", wantErr: true},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.in, func(t *testing.T) {
+			limit, err := ParseLimit(tt.in)
+			if (err != nil) != tt.wantErr {
+				t.Fatalf("expected error %v, got %v", tt.wantErr, err)
+			}
+			if limit != tt.expected {
+				t.Errorf("expected %v, got %v", tt.expected, limit)
+			}
+		})
+	}
+}
+
+func TestParseRules(t *testing.T) {
+	rules, err := ParseRules("post /items=10/1m, DELETE /lists/:id=off,")
+	if err != nil {
+		t.Fatalf("ParseRules: %v", err)
+	}
+	expected := []Rule{
+		{Method: "POST", Path: "/items", Limit: Limit{Requests: 10, Period: time.Minute}},
+		{Method: "DELETE", Path: "/lists/:id"},
+	}
+	if len(rules) != len(expected) {
+		t.Fatalf("expected %v, got %v", expected, rules)
+	}
+	for i := range expected {
+		if rules[i] != expected[i] {
+			t.Errorf("rule %d: expected %v, got %v", i, expected[i], rules[i])
+		}
+	}
+
+	for _, invalid := range []string{"POST /items", "/items=10/1m", "FETCH /items=10/1m", "POST items=10/1m", "POST /items=lots"} {
+		if _, err := ParseRules(invalid); err == nil {
+			t.Errorf("ParseRules(%q): expected an error", invalid)
+		}
+	}
+}
+
+func TestLimiter(t *testing.T) {
+	now := time.Date(2025, 10, 10, 12, 0, 0, 0, time.UTC)
+	store := NewMemory()
+	store.now = func() time.Time { return now }
+
+	limiter := New(store, Limit{Requests: 3, Period: time.Minute}, []Rule{
+		{Method: "POST", Path: "/items", Limit: Limit{Requests: 1, Period: time.Minute}},
+		{Method: "GET", Path: "/search"},
+	})
+
+	allow := func(method, path, client string) Result {
+		t.Helper()
+		result, err := limiter.Allow(method, path, client)
+		if err != nil {
+			t.Fatalf("Allow: %v", err)
+		}
+		return result
+	}
+	expect := func(result Result, allowed bool, remaining int, reset, retryAfter time.Duration) {
+		t.Helper()
+		if result.Allowed != allowed || result.Remaining != remaining || result.Reset != reset || result.RetryAfter != retryAfter {
+			t.Errorf("expected allowed %v, %d remaining, reset in %v, retry after %v, got %+v", allowed, remaining, reset, retryAfter, result)
+		}
+	}
+
+	// the whole limit can be spent at once
+	expect(allow("GET", "/items", "a"), true, 2, 20*time.Second, 0)
+	expect(allow("GET", "/lists", "a"), true, 1, 40*time.Second, 0)
+	expect(allow("GET", "/items", "a"), true, 0, time.Minute, 0)
+	expect(allow("GET", "/items", "a"), false, 0, time.Minute, 20*time.Second)
+
+	// other clients and routes with a rule have buckets of their own
+	expect(allow("GET", "/items", "b"), true, 2, 20*time.Second, 0)
+	expect(allow("POST", "/items", "a"), true, 0, time.Minute, 0)
+	expect(allow("POST", "/items", "a"), false, 0, time.Minute, time.Minute)
+	if result := allow("GET", "/search", "a"); !result.Allowed || !result.Limit.Unlimited() {
+		t.Errorf("expected an unlimited route, got %+v", result)
+	}
+
+	// a token comes back every 20 seconds
+	now = now.Add(25 * time.Second)
+	expect(allow("GET", "/items", "a"), true, 0, 55*time.Second, 0)
+	expect(allow("GET", "/items", "a"), false, 0, 55*time.Second, 15*time.Second)
+
+	// full again, and swept
+	now = now.Add(2 * time.Minute)
+	expect(allow("GET", "/items", "a"), true, 2, 20*time.Second, 0)
+	if len(store.buckets) != 1 {
+		t.Errorf("expected the full buckets to be swept, got %v", store.buckets)
+	}
+}
@@ -0,0 +1,100 @@
+// repository package provides data access logic
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+)
+
+// DefaultActivityLimit caps how many activity entries are returned when no limit is given
+const DefaultActivityLimit = 100
+
+type ActivityRepositoryInterface interface {
+	LogActivity(activity *models.Activity) error
+	GetActivity(filter models.ActivityFilter) ([]models.Activity, error)
+}
+
+// ActivityRepository appends to and queries the activity log.
+// Entries are never updated or deleted.
+type ActivityRepository struct {
+	db *sql.DB
+}
+
+// NewActivityRepository creates a new ActivityRepository
+func NewActivityRepository(db *sql.DB) *ActivityRepository {
+	return &ActivityRepository{db: db}
+}
+
+// LogActivity appends an entry to the activity log
+func (r *ActivityRepository) LogActivity(activity *models.Activity) error {
+	err := r.db.QueryRow(
+		"INSERT INTO activity (user_name, action, entity_type, entity_id, list_id, from_list_id) VALUES ($1, $2, $3, $4, $5, $6) RETURNING id, created_at",
+		activity.User, activity.Action, activity.EntityType, activity.EntityID, activity.ListID, nullInt(activity.FromListID),
+	).Scan(&activity.ID, &activity.CreatedAt)
+	if err != nil {
+		return fmt.Errorf("could not log activity: %w", err)
+	}
+	return nil
+}
+
+// GetActivity retrieves activity entries matching the filter, newest first
+func (r *ActivityRepository) GetActivity(filter models.ActivityFilter) ([]models.Activity, error) {
+	var conditions []string
+	var args []any
+	addCondition := func(condition string, arg any) {
+		args = append(args, arg)
+		conditions = append(conditions, fmt.Sprintf(condition, len(args)))
+	}
+
+	if filter.User != "" {
+		addCondition("user_name = $%d", filter.User)
+	}
+	if filter.Action != "" {
+		addCondition("action = $%d", filter.Action)
+	}
+	if filter.ListID != 0 {
+		addCondition("(list_id = $%[1]d OR from_list_id = $%[1]d)", filter.ListID)
+	}
+	if !filter.From.IsZero() {
+		addCondition("created_at >= $%d", filter.From)
+	}
+	if !filter.To.IsZero() {
+		addCondition("created_at < $%d", filter.To)
+	}
+
+	query := "SELECT id, user_name, action, entity_type, entity_id, list_id, COALESCE(from_list_id, 0), created_at FROM activity"
+	if len(conditions) > 0 {
+		query += " WHERE " + strings.Join(conditions, " AND ")
+	}
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = DefaultActivityLimit
+	}
+	args = append(args, limit)
+	query += fmt.Sprintf(" ORDER BY created_at DESC, id DESC LIMIT $%d", len(args))
+
+	rows, err := r.db.Query(query, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query activity: %w", err)
+	}
+	defer rows.Close()
+
+	activity := []models.Activity{}
+	for rows.Next() {
+		var a models.Activity
+		if err := rows.Scan(&a.ID, &a.User, &a.Action, &a.EntityType, &a.EntityID, &a.ListID, &a.FromListID, &a.CreatedAt); err != nil {
+			return nil, fmt.Errorf("failed to scan activity: %w", err)
+		}
+		activity = append(activity, a)
+	}
+	return activity, rows.Err()
+}
+
+// nullInt turns a zero ID into NULL, for columns that only sometimes refer to a row
+func nullInt(id int) sql.NullInt64 {
+	return sql.NullInt64{Int64: int64(id), Valid: id != 0}
+}
@@ -0,0 +1,237 @@
+// repository package provides data access logic
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	"github.com/lib/pq"
+)
+
+// ConflictStrategy decides what a restore does with a row whose ID is already taken
+type ConflictStrategy string
+
+const (
+	// ConflictFail aborts the restore, so it only succeeds into an empty database
+	ConflictFail ConflictStrategy = "fail"
+	// ConflictSkip keeps the existing row and drops the restored one
+	ConflictSkip ConflictStrategy = "skip"
+	// ConflictOverwrite replaces the existing row with the restored one
+	ConflictOverwrite ConflictStrategy = "overwrite"
+	// ConflictCopy inserts the restored row under a new ID
+	ConflictCopy ConflictStrategy = "copy"
+)
+
+// ValidConflictStrategy reports whether strategy is one of the known conflict strategies
+func ValidConflictStrategy(strategy ConflictStrategy) bool {
+	switch strategy {
+	case ConflictFail, ConflictSkip, ConflictOverwrite, ConflictCopy:
+		return true
+	}
+	return false
+}
+
+// ErrRestoreConflict is returned by a ConflictFail restore when a row already exists
+var ErrRestoreConflict = errors.New("restored row conflicts with an existing row")
+
+type BackupRepositoryInterface interface {
+	BeginBackup() (BackupSnapshot, error)
+	BeginRestore() (BackupRestorer, error)
+}
+
+// BackupSnapshot reads whole tables from a consistent snapshot of the database
+type BackupSnapshot interface {
+	// SchemaVersion returns the latest applied migration
+	SchemaVersion() (string, error)
+	// Rows calls fn with every row of table as a JSON object of the given columns, ordered by id
+	Rows(table string, columns []string, fn func(row json.RawMessage) error) error
+	Close() error
+}
+
+// BackupRestorer inserts backed up rows in a single transaction
+type BackupRestorer interface {
+	SchemaVersion() (string, error)
+	// RestoreRow inserts a row given as a JSON object of the given columns, the first of
+	// which must be id. It returns the row's ID after the restore, and false when the
+	// row was skipped.
+	RestoreRow(table string, columns []string, row json.RawMessage, strategy ConflictStrategy) (int64, bool, error)
+	// Finish moves the ID sequences of restored tables past the restored IDs and commits
+	Finish() error
+	Abort() error
+}
+
+// BackupRepository reads and restores every table for backups. Table and column names
+// are never user input; they come from the backup package's fixed list of tables.
+type BackupRepository struct {
+	db *sql.DB
+}
+
+// NewBackupRepository creates a new BackupRepository
+func NewBackupRepository(db *sql.DB) *BackupRepository {
+	return &BackupRepository{db: db}
+}
+
+// BeginBackup starts a read-only transaction, so every table is read as of the same moment
+func (r *BackupRepository) BeginBackup() (BackupSnapshot, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("could not start backup: %w", err)
+	}
+	if _, err := tx.Exec("SET TRANSACTION ISOLATION LEVEL REPEATABLE READ, READ ONLY"); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("could not start backup: %w", err)
+	}
+	return &backupSnapshot{tx: tx}, nil
+}
+
+// BeginRestore starts the transaction a restore runs in
+func (r *BackupRepository) BeginRestore() (BackupRestorer, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("could not start restore: %w", err)
+	}
+	return &backupRestorer{tx: tx, tables: map[string]bool{}}, nil
+}
+
+type backupSnapshot struct {
+	tx *sql.Tx
+}
+
+func (s *backupSnapshot) SchemaVersion() (string, error) {
+	return schemaVersion(s.tx)
+}
+
+func (s *backupSnapshot) Rows(table string, columns []string, fn func(row json.RawMessage) error) error {
+	query := fmt.Sprintf("SELECT row_to_json(r) FROM (SELECT %s FROM %s ORDER BY id) r",
+		strings.Join(columns, ", "), pq.QuoteIdentifier(table))
+
+	rows, err := s.tx.Query(query)
+	if err != nil {
+		return fmt.Errorf("could not read %s: %w", table, err)
+	}
+	defer rows.Close()
+
+	for rows.Next() {
+		var row []byte
+		if err := rows.Scan(&row); err != nil {
+			return fmt.Errorf("could not read %s: %w", table, err)
+		}
+		if err := fn(row); err != nil {
+			return err
+		}
+	}
+	return rows.Err()
+}
+
+func (s *backupSnapshot) Close() error {
+	return s.tx.Rollback()
+}
+
+type backupRestorer struct {
+	tx     *sql.Tx
+	tables map[string]bool
+	done   bool
+}
+
+func (r *backupRestorer) SchemaVersion() (string, error) {
+	return schemaVersion(r.tx)
+}
+
+func (r *backupRestorer) RestoreRow(table string, columns []string, row json.RawMessage, strategy ConflictStrategy) (int64, bool, error) {
+	if len(columns) == 0 || columns[0] != "id" {
+		return 0, false, fmt.Errorf("restoring %s: id must be the first column", table)
+	}
+	r.tables[table] = true
+
+	var id int64
+	if err := json.Unmarshal(row, &struct {
+		ID *int64 `json:"id"`
+	}{&id}); err != nil {
+		return 0, false, fmt.Errorf("restoring %s: invalid row: %w", table, err)
+	}
+
+	// json_populate_record turns the JSON object into a row of the table's own column types
+	insert := func(columns []string, onConflict string) (int64, bool, error) {
+		list := strings.Join(columns, ", ")
+		query := fmt.Sprintf("INSERT INTO %s (%s) SELECT %s FROM json_populate_record(NULL::%s, $1::json) %s RETURNING id",
+			pq.QuoteIdentifier(table), list, list, pq.QuoteIdentifier(table), onConflict)
+
+		var restoredID int64
+		err := r.tx.QueryRow(query, string(row)).Scan(&restoredID)
+		if errors.Is(err, sql.ErrNoRows) {
+			return 0, false, nil
+		}
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23505" {
+			return 0, false, fmt.Errorf("%w: %s %d: %s", ErrRestoreConflict, table, id, pqErr.Detail)
+		}
+		if err != nil {
+			return 0, false, fmt.Errorf("could not restore %s %d: %w", table, id, err)
+		}
+		return restoredID, true, nil
+	}
+
+	switch strategy {
+	case ConflictFail:
+		return insert(columns, "")
+	case ConflictSkip:
+		restoredID, inserted, err := insert(columns, "ON CONFLICT DO NOTHING")
+		if err == nil && !inserted {
+			// references to the skipped row now point at the existing one
+			restoredID = id
+		}
+		return restoredID, inserted, err
+	case ConflictOverwrite:
+		updates := make([]string, 0, len(columns)-1)
+		for _, column := range columns[1:] {
+			updates = append(updates, fmt.Sprintf("%s = EXCLUDED.%s", column, column))
+		}
+		return insert(columns, "ON CONFLICT (id) DO UPDATE SET "+strings.Join(updates, ", "))
+	case ConflictCopy:
+		restoredID, inserted, err := insert(columns, "ON CONFLICT DO NOTHING")
+		if err != nil || inserted {
+			return restoredID, inserted, err
+		}
+		// the ID is taken, so let the sequence pick a new one
+		return insert(columns[1:], "ON CONFLICT DO NOTHING")
+	default:
+		return 0, false, fmt.Errorf("unknown conflict strategy %q", strategy)
+	}
+}
+
+func (r *backupRestorer) Finish() error {
+	for table := range r.tables {
+		query := fmt.Sprintf("SELECT setval(pg_get_serial_sequence($1, 'id'), COALESCE(MAX(id), 0) + 1, false) FROM %s",
+			pq.QuoteIdentifier(table))
+		if _, err := r.tx.Exec(query, table); err != nil {
+			return fmt.Errorf("could not reset %s id sequence: %w", table, err)
+		}
+	}
+
+	if err := r.tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit restore: %w", err)
+	}
+	r.done = true
+	return nil
+}
+
+// Abort rolls the restore back. It does nothing once Finish has committed.
+func (r *backupRestorer) Abort() error {
+	if r.done {
+		return nil
+	}
+	r.done = true
+	return r.tx.Rollback()
+}
+
+// schemaVersion returns the latest migration applied to the database
+func schemaVersion(tx *sql.Tx) (string, error) {
+	var version sql.NullString
+	if err := tx.QueryRow("SELECT MAX(version) FROM schema_migrations").Scan(&version); err != nil {
+		return "", fmt.Errorf("could not read schema version: %w", err)
+	}
+	return version.String, nil
+}
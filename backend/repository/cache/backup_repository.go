@@ -0,0 +1,44 @@
+package cache
+
+import "github.com/jennaborowy/fullstack-Go-Docker/repository"
+
+// BackupRepository clears the cache once a restore is committed. A restore can insert,
+// overwrite or renumber any list or item, so every cached read may have changed.
+type BackupRepository struct {
+	repo  repository.BackupRepositoryInterface
+	cache *Cache
+}
+
+// NewBackupRepository clears cache after the restores of repo, which should share a
+// database with the list and item repositories cache is in front of
+func NewBackupRepository(repo repository.BackupRepositoryInterface, cache *Cache) *BackupRepository {
+	return &BackupRepository{repo: repo, cache: cache}
+}
+
+// BeginBackup starts a backup, which only reads
+func (r *BackupRepository) BeginBackup() (repository.BackupSnapshot, error) {
+	return r.repo.BeginBackup()
+}
+
+// BeginRestore starts a restore
+func (r *BackupRepository) BeginRestore() (repository.BackupRestorer, error) {
+	restorer, err := r.repo.BeginRestore()
+	if err != nil {
+		return nil, err
+	}
+	return &backupRestorer{BackupRestorer: restorer, cache: r.cache}, nil
+}
+
+type backupRestorer struct {
+	repository.BackupRestorer
+	cache *Cache
+}
+
+// Finish commits the restore, then clears the cache
+func (r *backupRestorer) Finish() error {
+	err := r.BackupRestorer.Finish()
+	if err == nil {
+		r.cache.clear()
+	}
+	return err
+}
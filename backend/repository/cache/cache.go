@@ -0,0 +1,124 @@
+// cache package is a read-through cache in front of the list and item repositories of
+// any storage backend. Writes made through it invalidate exactly the entries they change,
+// and so do imports and restores made through its import and backup repositories; writes
+// made around it, such as the restore command, are only seen once entries expire.
+package cache
+
+import (
+	"encoding/json"
+	"fmt"
+	"log"
+	"sync/atomic"
+	"time"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+)
+
+// Store keeps cached values. Values are JSON, so callers never share what they read.
+type Store interface {
+	// Get returns the value stored under key, and false when there is none
+	Get(key string) ([]byte, bool, error)
+	// Set stores value under key, forgetting it after ttl
+	Set(key string, value []byte, ttl time.Duration) error
+	// Delete forgets the values stored under keys, if any
+	Delete(keys ...string) error
+	// Clear forgets every value
+	Clear() error
+}
+
+// Stats counts how the cache has been used since it was created
+type Stats struct {
+	Hits   int64 `json:"hits"`
+	Misses int64 `json:"misses"`
+	Errors int64 `json:"errors"` // store failures, answered from the repository instead
+}
+
+// Cache reads through a Store, counting hits and misses
+type Cache struct {
+	store Store
+	ttl   time.Duration
+
+	hits   atomic.Int64
+	misses atomic.Int64
+	errors atomic.Int64
+}
+
+// New creates a Cache keeping entries in store for ttl
+func New(store Store, ttl time.Duration) *Cache {
+	return &Cache{store: store, ttl: ttl}
+}
+
+// Stats returns the counts so far
+func (c *Cache) Stats() Stats {
+	return Stats{Hits: c.hits.Load(), Misses: c.misses.Load(), Errors: c.errors.Load()}
+}
+
+// fetch returns the value cached under key, or loads and caches it. Errors are never
+// cached, and a failing store only costs the cache, never the read.
+func fetch[T any](c *Cache, key string, load func() (T, error)) (T, error) {
+	data, ok, err := c.store.Get(key)
+	if err != nil {
+		c.failed("read", key, err)
+	} else if ok {
+		var value T
+		if err := json.Unmarshal(data, &value); err == nil {
+			c.hits.Add(1)
+			return value, nil
+		}
+		c.failed("decode", key, err)
+	}
+
+	c.misses.Add(1)
+	value, err := load()
+	if err != nil {
+		return value, err
+	}
+	data, err = json.Marshal(value)
+	if err != nil {
+		c.failed("encode", key, err)
+		return value, nil
+	}
+	if err := c.store.Set(key, data, c.ttl); err != nil {
+		c.failed("write", key, err)
+	}
+	return value, nil
+}
+
+// invalidate forgets keys after a write. The write has already happened, so a failure is
+// only logged; the stale entries expire with their TTL.
+func (c *Cache) invalidate(keys ...string) {
+	if err := c.store.Delete(keys...); err != nil {
+		c.failed("invalidate", fmt.Sprint(keys), err)
+	}
+}
+
+// clear forgets everything after a write that may have changed any entry
+func (c *Cache) clear() {
+	if err := c.store.Clear(); err != nil {
+		c.failed("clear", "every key", err)
+	}
+}
+
+func (c *Cache) failed(action, key string, err error) {
+	c.errors.Add(1)
+	log.Printf("cache: failed to %s %s: %v", action, key, err)
+}
+
+// keys of the cached reads, all starting with keyPrefix
+const keyPrefix = "notes:"
+
+// allListsKeys holds the key of every variant of GetAllLists
+var allListsKeys = []string{
+	allListsKey(models.ListInclude{}),
+	allListsKey(models.ListInclude{Items: true}),
+	allListsKey(models.ListInclude{Counts: true}),
+	allListsKey(models.ListInclude{Items: true, Counts: true}),
+}
+
+func allListsKey(include models.ListInclude) string {
+	return fmt.Sprintf(keyPrefix+"lists:items=%t:counts=%t", include.Items, include.Counts)
+}
+
+func listKey(id int) string { return fmt.Sprintf(keyPrefix+"list:%d", id) }
+
+func itemKey(id int) string { return fmt.Sprintf(keyPrefix+"item:%d", id) }
@@ -0,0 +1,369 @@
+package cache_test
+
+import (
+	"errors"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/alicebob/miniredis/v2"
+	"github.com/jennaborowy/fullstack-Go-Docker/mocks"
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository/cache"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository/memory"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository/repositorytest"
+	"go.uber.org/mock/gomock"
+)
+
+// TestConformance checks the cache doesn't change what the repositories return,
+// which it would if a write left a stale entry behind
+func TestConformance(t *testing.T) {
+	stores := []struct {
+		name string
+		open func(t *testing.T) cache.Store
+	}{
+		{"lru", func(t *testing.T) cache.Store { return cache.NewLRU(100) }},
+		{"redis", func(t *testing.T) cache.Store { return newRedis(t, miniredis.RunT(t)) }},
+	}
+
+	for _, s := range stores {
+		t.Run(s.name, func(t *testing.T) {
+			repositorytest.Run(t, func(t *testing.T) repositorytest.Repositories {
+				db := memory.NewDB()
+				c := cache.New(s.open(t), time.Minute)
+				return repositorytest.Repositories{
+					Items: cache.NewItemRepository(memory.NewItemRepository(db), c),
+					Lists: cache.NewListRepository(memory.NewListRepository(db), c),
+				}
+			})
+		})
+	}
+}
+
+func newRedis(t *testing.T, server *miniredis.Miniredis) *cache.Redis {
+	t.Helper()
+	store, err := cache.NewRedis("redis://" + server.Addr())
+	if err != nil {
+		t.Fatalf("NewRedis: %v", err)
+	}
+	t.Cleanup(func() { store.Close() })
+	return store
+}
+
+type cacheMocks struct {
+	items *mocks.MockItemRepositoryInterface
+	lists *mocks.MockListRepositoryInterface
+}
+
+func TestInvalidation(t *testing.T) {
+	day := time.Date(2025, 10, 10, 0, 0, 0, 0, time.UTC)
+	milk := &models.Item{ID: 1, Title: "Milk", ListID: 2}
+	groceries := &models.List{ID: 2, Title: "Groceries", Items: []models.Item{*milk}}
+	chores := &models.List{ID: 3, Title: "Chores"}
+	moveTo := 3
+	completed := true
+
+	// every test reads item 1, list 2 and list 3 through the cache, makes a write,
+	// then reads them again: the reads expected of the repository the second time
+	// are the ones the write invalidated
+	tests := []struct {
+		name        string
+		write       func(items repository.ItemRepositoryInterface, lists repository.ListRepositoryInterface) error
+		setupMocks  func(m cacheMocks)
+		reloadItem  bool
+		reloadLists []int
+	}{
+		{
+			name: "changing an item reloads it and its list",
+			write: func(items repository.ItemRepositoryInterface, _ repository.ListRepositoryInterface) error {
+				return items.ChangeItem(1, models.ItemChanges{Title: "Oat milk", Date: day}, nil)
+			},
+			setupMocks: func(m cacheMocks) {
+				m.items.EXPECT().ChangeItem(1, models.ItemChanges{Title: "Oat milk", Date: day}, nil).Return(nil).Times(1)
+			},
+			reloadItem:  true,
+			reloadLists: []int{2},
+		},
+		{
+			name: "a failed write still invalidates",
+			write: func(items repository.ItemRepositoryInterface, _ repository.ListRepositoryInterface) error {
+				return items.ChangeItem(1, models.ItemChanges{Title: "Milk", Date: day, Completed: &completed}, nil)
+			},
+			setupMocks: func(m cacheMocks) {
+				m.items.EXPECT().ChangeItem(1, models.ItemChanges{Title: "Milk", Date: day, Completed: &completed}, nil).
+					Return(errors.New("connection reset")).Times(1)
+			},
+			reloadItem:  true,
+			reloadLists: []int{2},
+		},
+		{
+			name: "changing and moving an item reloads both lists",
+			write: func(items repository.ItemRepositoryInterface, _ repository.ListRepositoryInterface) error {
+				return items.ChangeItem(1, models.ItemChanges{Title: "Oat milk", Date: day, ListID: &moveTo}, nil)
+			},
+			setupMocks: func(m cacheMocks) {
+				m.items.EXPECT().ChangeItem(1, models.ItemChanges{Title: "Oat milk", Date: day, ListID: &moveTo}, nil).Return(nil).Times(1)
+			},
+			reloadItem:  true,
+			reloadLists: []int{2, 3},
+		},
+		{
+			name: "creating an item reloads its list only",
+			write: func(items repository.ItemRepositoryInterface, _ repository.ListRepositoryInterface) error {
+				_, err := items.CreateItem("Laundry", day, "", 3)
+				return err
+			},
+			setupMocks: func(m cacheMocks) {
+				m.items.EXPECT().CreateItem("Laundry", day, "", 3).Return(&models.Item{ID: 4}, nil).Times(1)
+			},
+			reloadLists: []int{3},
+		},
+		{
+			name: "renaming a list reloads it only",
+			write: func(_ repository.ItemRepositoryInterface, lists repository.ListRepositoryInterface) error {
+				_, err := lists.UpdateTitle(3, "Housework", nil)
+				return err
+			},
+			setupMocks: func(m cacheMocks) {
+				m.lists.EXPECT().UpdateTitle(3, "Housework", nil).Return(chores, nil).Times(1)
+			},
+			reloadLists: []int{3},
+		},
+		{
+			name: "deleting a list reloads it and its items",
+			write: func(_ repository.ItemRepositoryInterface, lists repository.ListRepositoryInterface) error {
+				return lists.DeleteList(2)
+			},
+			setupMocks: func(m cacheMocks) {
+				m.lists.EXPECT().DeleteList(2).Return(nil).Times(1)
+			},
+			reloadItem:  true,
+			reloadLists: []int{2},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			m := cacheMocks{items: mocks.NewMockItemRepositoryInterface(ctrl), lists: mocks.NewMockListRepositoryInterface(ctrl)}
+			c := cache.New(cache.NewLRU(100), time.Minute)
+			items := cache.NewItemRepository(m.items, c)
+			lists := cache.NewListRepository(m.lists, c)
+
+			loads := map[int]int{2: 1, 3: 1}
+			for _, id := range tt.reloadLists {
+				loads[id]++
+			}
+			itemLoads := 1
+			if tt.reloadItem {
+				itemLoads++
+			}
+			m.items.EXPECT().GetByID(1, nil).Return(milk, nil).Times(itemLoads)
+			m.lists.EXPECT().GetList(2, nil).Return(groceries, nil).Times(loads[2])
+			m.lists.EXPECT().GetList(3, nil).Return(chores, nil).Times(loads[3])
+			tt.setupMocks(m)
+
+			read := func() {
+				items.GetByID(1, nil)
+				lists.GetList(2, nil)
+				lists.GetList(3, nil)
+			}
+			read()
+			tt.write(items, lists)
+			read()
+		})
+	}
+}
+
+func TestStats(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockListRepositoryInterface(ctrl)
+	repo.EXPECT().GetList(2, nil).Return(&models.List{ID: 2, Title: "Groceries"}, nil).Times(1)
+	repo.EXPECT().GetList(9, nil).Return(nil, repository.ErrListNotFound).Times(2)
+
+	c := cache.New(cache.NewLRU(100), time.Minute)
+	lists := cache.NewListRepository(repo, c)
+
+	for range 3 {
+		list, err := lists.GetList(2, nil)
+		if err != nil || list.Title != "Groceries" {
+			t.Fatalf("expected the list, got %+v, %v", list, err)
+		}
+	}
+	// errors aren't cached
+	for range 2 {
+		if _, err := lists.GetList(9, nil); !errors.Is(err, repository.ErrListNotFound) {
+			t.Fatalf("expected ErrListNotFound, got %v", err)
+		}
+	}
+
+	expected := cache.Stats{Hits: 2, Misses: 3}
+	if stats := c.Stats(); stats != expected {
+		t.Errorf("expected %+v, got %+v", expected, stats)
+	}
+}
+
+func TestStoreDown(t *testing.T) {
+	server := miniredis.RunT(t)
+	store := newRedis(t, server)
+	server.Close()
+
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockListRepositoryInterface(ctrl)
+	repo.EXPECT().GetList(2, nil).Return(&models.List{ID: 2, Title: "Groceries"}, nil).Times(2)
+
+	c := cache.New(store, time.Minute)
+	lists := cache.NewListRepository(repo, c)
+
+	// reads go to the repository while the store is down
+	for range 2 {
+		if list, err := lists.GetList(2, nil); err != nil || list.Title != "Groceries" {
+			t.Fatalf("expected the list, got %+v, %v", list, err)
+		}
+	}
+	if stats := c.Stats(); stats.Misses != 2 || stats.Errors != 4 {
+		t.Errorf("expected 2 misses and 4 errors, got %+v", stats)
+	}
+}
+
+func TestIncludeInvalidation(t *testing.T) {
+	db := memory.NewDB()
+	c := cache.New(cache.NewLRU(100), time.Minute)
+	items := cache.NewItemRepository(memory.NewItemRepository(db), c)
+	lists := cache.NewListRepository(memory.NewListRepository(db), c)
+
+	list, err := lists.CreateList("Groceries")
+	if err != nil {
+		t.Fatalf("CreateList: %v", err)
+	}
+	counts := func() models.ListCounts {
+		all, err := lists.GetAllLists(models.ListInclude{Counts: true}, nil)
+		if err != nil || len(all) != 1 {
+			t.Fatalf("expected one list, got %+v, %v", all, err)
+		}
+		return *all[0].Counts
+	}
+
+	if got := counts(); got != (models.ListCounts{}) {
+		t.Errorf("expected no items, got %+v", got)
+	}
+	item, err := items.CreateItem("Milk", time.Date(2025, 10, 10, 0, 0, 0, 0, time.UTC), "", int(list.ID))
+	if err != nil {
+		t.Fatalf("CreateItem: %v", err)
+	}
+	if got := counts(); got != (models.ListCounts{Total: 1}) {
+		t.Errorf("expected the new item counted, got %+v", got)
+	}
+	completed := true
+	if err := items.ChangeItem(item.ID, models.ItemChanges{Title: item.Title, Date: item.Date, Completed: &completed}, nil); err != nil {
+		t.Fatalf("ChangeItem: %v", err)
+	}
+	if got := counts(); got != (models.ListCounts{Total: 1, Completed: 1}) {
+		t.Errorf("expected the item counted as completed, got %+v", got)
+	}
+
+	// reading the plain lists again doesn't go to the repository
+	lists.GetAllLists(models.ListInclude{}, nil)
+	before := c.Stats()
+	lists.GetAllLists(models.ListInclude{}, nil)
+	if after := c.Stats(); after.Hits != before.Hits+1 {
+		t.Errorf("expected a hit, got %+v then %+v", before, after)
+	}
+}
+
+func TestStreamBypassesCache(t *testing.T) {
+	ctrl := gomock.NewController(t)
+	repo := mocks.NewMockItemRepositoryInterface(ctrl)
+	filters := []models.ItemFilter{{}, {Fields: models.Fields{"id", "title"}}}
+	for _, filter := range filters {
+		repo.EXPECT().StreamFiltered(filter, gomock.Any()).Return(nil).Times(2)
+	}
+
+	c := cache.New(cache.NewLRU(100), time.Minute)
+	items := cache.NewItemRepository(repo, c)
+
+	// every stream reads the repository, even of every item, and nothing is cached
+	for range 2 {
+		for _, filter := range filters {
+			if err := items.StreamFiltered(filter, func(models.Item) error { return nil }); err != nil {
+				t.Fatalf("StreamFiltered: %v", err)
+			}
+		}
+	}
+	if stats := c.Stats(); stats != (cache.Stats{}) {
+		t.Errorf("expected the cache unused, got %+v", stats)
+	}
+}
+
+// TestImportAndRestore checks that lists imported or restored around the list
+// repository are read afterwards, rather than the lists cached before
+func TestImportAndRestore(t *testing.T) {
+	stores := []struct {
+		name string
+		open func(t *testing.T) cache.Store
+	}{
+		{"lru", func(t *testing.T) cache.Store { return cache.NewLRU(100) }},
+		{"redis", func(t *testing.T) cache.Store { return newRedis(t, miniredis.RunT(t)) }},
+	}
+
+	for _, s := range stores {
+		t.Run(s.name, func(t *testing.T) {
+			db := memory.NewDB()
+			c := cache.New(s.open(t), time.Minute)
+			lists := cache.NewListRepository(memory.NewListRepository(db), c)
+			imports := cache.NewImportRepository(memory.NewImportRepository(db), c)
+
+			ctrl := gomock.NewController(t)
+			backup := mocks.NewMockBackupRepositoryInterface(ctrl)
+			restorer := mocks.NewMockBackupRestorer(ctrl)
+			backup.EXPECT().BeginRestore().Return(restorer, nil)
+			restorer.EXPECT().Finish().Return(nil)
+			restores := cache.NewBackupRepository(backup, c)
+
+			titles := func() []string {
+				t.Helper()
+				all, err := lists.GetAllLists(models.ListInclude{}, nil)
+				if err != nil {
+					t.Fatalf("GetAllLists: %v", err)
+				}
+				var titles []string
+				for _, list := range all {
+					titles = append(titles, list.Title)
+				}
+				return titles
+			}
+
+			if _, err := lists.CreateList("Groceries"); err != nil {
+				t.Fatalf("CreateList: %v", err)
+			}
+			titles() // cached
+
+			importer, err := imports.BeginListImport()
+			if err != nil {
+				t.Fatalf("BeginListImport: %v", err)
+			}
+			if _, err := importer.Finish(models.List{Title: "Chores"}); err != nil {
+				t.Fatalf("Finish: %v", err)
+			}
+			if got := titles(); !slices.Equal(got, []string{"Groceries", "Chores"}) {
+				t.Errorf("after the import, expected Groceries and Chores, got %v", got)
+			}
+
+			// the restore renames a list without the list repository knowing
+			restore, err := restores.BeginRestore()
+			if err != nil {
+				t.Fatalf("BeginRestore: %v", err)
+			}
+			if _, err := memory.NewListRepository(db).UpdateTitle(1, "Shopping", nil); err != nil {
+				t.Fatalf("UpdateTitle: %v", err)
+			}
+			if err := restore.Finish(); err != nil {
+				t.Fatalf("Finish: %v", err)
+			}
+			if got := titles(); !slices.Equal(got, []string{"Shopping", "Chores"}) {
+				t.Errorf("after the restore, expected Shopping and Chores, got %v", got)
+			}
+		})
+	}
+}
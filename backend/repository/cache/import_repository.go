@@ -0,0 +1,43 @@
+package cache
+
+import (
+	"slices"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+)
+
+// ImportRepository invalidates the lists read through the cache once an import is committed
+type ImportRepository struct {
+	repo  repository.ImportRepositoryInterface
+	cache *Cache
+}
+
+// NewImportRepository invalidates cache after the imports of repo, which should share a
+// database with the list and item repositories cache is in front of
+func NewImportRepository(repo repository.ImportRepositoryInterface, cache *Cache) *ImportRepository {
+	return &ImportRepository{repo: repo, cache: cache}
+}
+
+// BeginListImport starts an import
+func (r *ImportRepository) BeginListImport() (repository.ListImporter, error) {
+	importer, err := r.repo.BeginListImport()
+	if err != nil {
+		return nil, err
+	}
+	return &listImporter{ListImporter: importer, cache: r.cache}, nil
+}
+
+type listImporter struct {
+	repository.ListImporter
+	cache *Cache
+}
+
+// Finish commits the import. The new list only changes the reads of every list.
+func (i *listImporter) Finish(list models.List) (*models.List, error) {
+	imported, err := i.ListImporter.Finish(list)
+	if err == nil {
+		i.cache.invalidate(slices.Concat(allListsKeys, []string{listKey(int(imported.ID))})...)
+	}
+	return imported, err
+}
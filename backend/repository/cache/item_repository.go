@@ -0,0 +1,88 @@
+package cache
+
+import (
+	"slices"
+	"time"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+)
+
+// ItemRepository caches the items read from another item repository. Lists hold their
+// items, so item writes also invalidate the lists they touch.
+type ItemRepository struct {
+	repo  repository.ItemRepositoryInterface
+	cache *Cache
+}
+
+// NewItemRepository caches the reads of repo in cache, which should be shared with the
+// list repository
+func NewItemRepository(repo repository.ItemRepositoryInterface, cache *Cache) *ItemRepository {
+	return &ItemRepository{repo: repo, cache: cache}
+}
+
+// itemListKeys are the GetAllLists variants an item write changes
+var itemListKeys = []string{
+	allListsKey(models.ListInclude{Items: true}),
+	allListsKey(models.ListInclude{Counts: true}),
+	allListsKey(models.ListInclude{Items: true, Counts: true}),
+}
+
+// GetAll retrieves all existing items. The whole table is too big to hold as one entry,
+// so it always reads the repository.
+func (r *ItemRepository) GetAll() ([]models.Item, error) {
+	return r.repo.GetAll()
+}
+
+// GetByID retrieves a single item by its ID. The cache holds whole items, so they are
+// read whole whatever fields.
+func (r *ItemRepository) GetByID(id int, fields models.Fields) (*models.Item, error) {
+	return fetch(r.cache, itemKey(id), func() (*models.Item, error) { return r.repo.GetByID(id, nil) })
+}
+
+// GetFiltered retrieves the items matching a filter. Filters vary too much to be worth
+// caching, so it always reads the repository.
+func (r *ItemRepository) GetFiltered(filter models.ItemFilter) ([]models.Item, error) {
+	return r.repo.GetFiltered(filter)
+}
+
+// StreamFiltered streams the items matching a filter straight from the repository, so
+// they are never all held in memory and only the fields asked for are read
+func (r *ItemRepository) StreamFiltered(filter models.ItemFilter, fn func(item models.Item) error) error {
+	return r.repo.StreamFiltered(filter, fn)
+}
+
+// CreateItem creates a new item
+func (r *ItemRepository) CreateItem(title string, date time.Time, content string, listID int) (*models.Item, error) {
+	item, err := r.repo.CreateItem(title, date, content, listID)
+	r.cache.invalidate(slices.Concat(itemListKeys, []string{listKey(listID)})...)
+	return item, err
+}
+
+// DeleteItemByID deletes an item by ID
+func (r *ItemRepository) DeleteItemByID(id int) error {
+	return r.change(id, func() error { return r.repo.DeleteItemByID(id) })
+}
+
+// ChangeItem makes every change of an update to an item at once
+func (r *ItemRepository) ChangeItem(id int, changes models.ItemChanges, revision *models.Revision) error {
+	var keys []string
+	if changes.ListID != nil {
+		keys = append(keys, listKey(*changes.ListID))
+	}
+	return r.change(id, func() error { return r.repo.ChangeItem(id, changes, revision) }, keys...)
+}
+
+// change makes a write to an item, then invalidates the item, the list it was on and
+// any other keys
+func (r *ItemRepository) change(id int, write func() error, keys ...string) error {
+	keys = append(keys, itemKey(id))
+	keys = append(keys, itemListKeys...)
+	if item, err := r.GetByID(id, nil); err == nil {
+		keys = append(keys, listKey(item.ListID))
+	}
+
+	err := write()
+	r.cache.invalidate(keys...)
+	return err
+}
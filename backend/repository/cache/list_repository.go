@@ -0,0 +1,59 @@
+package cache
+
+import (
+	"slices"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+)
+
+// ListRepository caches the lists read from another list repository
+type ListRepository struct {
+	repo  repository.ListRepositoryInterface
+	cache *Cache
+}
+
+// NewListRepository caches the reads of repo in cache
+func NewListRepository(repo repository.ListRepositoryInterface, cache *Cache) *ListRepository {
+	return &ListRepository{repo: repo, cache: cache}
+}
+
+// CreateList creates a new list
+func (r *ListRepository) CreateList(title string) (*models.List, error) {
+	list, err := r.repo.CreateList(title)
+	r.cache.invalidate(allListsKeys...)
+	return list, err
+}
+
+// GetList retrieves a list with its items. The cache holds whole lists, so they are
+// read whole whatever fields.
+func (r *ListRepository) GetList(id int, fields models.Fields) (*models.List, error) {
+	return fetch(r.cache, listKey(id), func() (*models.List, error) { return r.repo.GetList(id, nil) })
+}
+
+// GetAllLists retrieves all lists, with their items and item counts if asked, read whole
+// whatever fields
+func (r *ListRepository) GetAllLists(include models.ListInclude, fields models.Fields) ([]models.List, error) {
+	return fetch(r.cache, allListsKey(include), func() ([]models.List, error) { return r.repo.GetAllLists(include, nil) })
+}
+
+// UpdateTitle updates the title of a list
+func (r *ListRepository) UpdateTitle(id int, title string, revision *models.Revision) (*models.List, error) {
+	list, err := r.repo.UpdateTitle(id, title, revision)
+	r.cache.invalidate(slices.Concat(allListsKeys, []string{listKey(id)})...)
+	return list, err
+}
+
+// DeleteList deletes a list and its items
+func (r *ListRepository) DeleteList(id int) error {
+	keys := slices.Concat(allListsKeys, []string{listKey(id)})
+	if list, err := r.GetList(id, nil); err == nil {
+		for _, item := range list.Items {
+			keys = append(keys, itemKey(item.ID))
+		}
+	}
+
+	err := r.repo.DeleteList(id)
+	r.cache.invalidate(keys...)
+	return err
+}
@@ -0,0 +1,109 @@
+package cache
+
+import (
+	"container/list"
+	"sync"
+	"time"
+)
+
+// LRU is an in-process Store holding up to a fixed number of entries, dropping the
+// least recently used one to make room
+type LRU struct {
+	mu       sync.Mutex
+	capacity int
+	order    *list.List // most recently used first
+	entries  map[string]*list.Element
+	now      func() time.Time
+}
+
+type lruEntry struct {
+	key     string
+	value   []byte
+	expires time.Time
+}
+
+// NewLRU creates an LRU holding up to capacity entries
+func NewLRU(capacity int) *LRU {
+	return &LRU{
+		capacity: capacity,
+		order:    list.New(),
+		entries:  make(map[string]*list.Element),
+		now:      time.Now,
+	}
+}
+
+// Get returns the value stored under key, unless it has expired
+func (l *LRU) Get(key string) ([]byte, bool, error) {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	element, ok := l.entries[key]
+	if !ok {
+		return nil, false, nil
+	}
+	entry := element.Value.(*lruEntry)
+	if !l.now().Before(entry.expires) {
+		l.remove(element)
+		return nil, false, nil
+	}
+	l.order.MoveToFront(element)
+	return entry.value, true, nil
+}
+
+// Set stores value under key for ttl, evicting the least recently used entry when full
+func (l *LRU) Set(key string, value []byte, ttl time.Duration) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	expires := l.now().Add(ttl)
+	if element, ok := l.entries[key]; ok {
+		entry := element.Value.(*lruEntry)
+		entry.value, entry.expires = value, expires
+		l.order.MoveToFront(element)
+		return nil
+	}
+
+	if l.order.Len() >= l.capacity {
+		if oldest := l.order.Back(); oldest != nil {
+			l.remove(oldest)
+		}
+	}
+	l.entries[key] = l.order.PushFront(&lruEntry{key: key, value: value, expires: expires})
+	return nil
+}
+
+// Delete forgets the values stored under keys
+func (l *LRU) Delete(keys ...string) error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	for _, key := range keys {
+		if element, ok := l.entries[key]; ok {
+			l.remove(element)
+		}
+	}
+	return nil
+}
+
+// Clear forgets every value
+func (l *LRU) Clear() error {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+
+	l.order.Init()
+	clear(l.entries)
+	return nil
+}
+
+// Len returns how many entries are held, including expired ones not yet dropped
+func (l *LRU) Len() int {
+	l.mu.Lock()
+	defer l.mu.Unlock()
+	return l.order.Len()
+}
+
+// remove drops an entry. It must be called with the lock held.
+func (l *LRU) remove(element *list.Element) {
+	l.order.Remove(element)
+	delete(l.entries, element.Value.(*lruEntry).key)
+}
@@ -0,0 +1,62 @@
+package cache
+
+import (
+	"testing"
+	"time"
+)
+
+func TestLRU(t *testing.T) {
+	start := time.Date(2025, 10, 10, 12, 0, 0, 0, time.UTC)
+	now := start
+	lru := NewLRU(2)
+	lru.now = func() time.Time { return now }
+
+	get := func(key string) string {
+		value, ok, err := lru.Get(key)
+		if err != nil {
+			t.Fatalf("Get(%q): %v", key, err)
+		}
+		if !ok {
+			return "<missing>"
+		}
+		return string(value)
+	}
+
+	lru.Set("a", []byte("1"), time.Minute)
+	lru.Set("b", []byte("2"), time.Minute)
+	get("a") // a is now the most recently used
+	lru.Set("c", []byte("3"), time.Minute)
+
+	tests := []struct {
+		key      string
+		expected string
+	}{
+		{"a", "1"},
+		{"b", "<missing>"}, // evicted to make room for c
+		{"c", "3"},
+	}
+	for _, tt := range tests {
+		if got := get(tt.key); got != tt.expected {
+			t.Errorf("Get(%q): expected %s, got %s", tt.key, tt.expected, got)
+		}
+	}
+
+	// replacing a value doesn't take more room
+	lru.Set("c", []byte("4"), 2*time.Minute)
+	if got := get("c"); got != "4" || lru.Len() != 2 {
+		t.Errorf("expected c replaced in place, got %s with %d entries", got, lru.Len())
+	}
+
+	now = start.Add(time.Minute)
+	if got := get("a"); got != "<missing>" {
+		t.Errorf("expected a to expire, got %s", got)
+	}
+	if got := get("c"); got != "4" {
+		t.Errorf("expected c to outlive a, got %s", got)
+	}
+
+	lru.Delete("c", "unknown")
+	if lru.Len() != 0 {
+		t.Errorf("expected an empty cache, got %d entries", lru.Len())
+	}
+}
@@ -0,0 +1,95 @@
+package cache
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/redis/go-redis/v9"
+)
+
+// redisTimeout bounds every Redis command, so a slow cache can't hold up a request
+// for longer than going to the database would
+const redisTimeout = 500 * time.Millisecond
+
+// Redis is a Store on a Redis server, or anything speaking its protocol, so the cache
+// can be shared by several backend processes
+type Redis struct {
+	client *redis.Client
+}
+
+// NewRedis connects to the server at url, such as redis://localhost:6379/0
+func NewRedis(url string) (*Redis, error) {
+	options, err := redis.ParseURL(url)
+	if err != nil {
+		return nil, fmt.Errorf("invalid Redis URL: %w", err)
+	}
+	// a failed command falls back to the repository straight away rather than retrying
+	options.MaxRetries = -1
+	options.DialerRetries = 1
+	client := redis.NewClient(options)
+
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	if err := client.Ping(ctx).Err(); err != nil {
+		client.Close()
+		return nil, fmt.Errorf("error connecting to Redis: %w", err)
+	}
+	return &Redis{client: client}, nil
+}
+
+// Get returns the value stored under key
+func (r *Redis) Get(key string) ([]byte, bool, error) {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+
+	value, err := r.client.Get(ctx, key).Bytes()
+	if errors.Is(err, redis.Nil) {
+		return nil, false, nil
+	}
+	if err != nil {
+		return nil, false, err
+	}
+	return value, true, nil
+}
+
+// Set stores value under key, letting Redis expire it after ttl
+func (r *Redis) Set(key string, value []byte, ttl time.Duration) error {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+	return r.client.Set(ctx, key, value, ttl).Err()
+}
+
+// Delete forgets the values stored under keys
+func (r *Redis) Delete(keys ...string) error {
+	ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+	defer cancel()
+	return r.client.Del(ctx, keys...).Err()
+}
+
+// Clear forgets every value the cache stored, leaving any other keys on the server.
+// Keys are deleted a batch at a time, each batch bounded by redisTimeout.
+func (r *Redis) Clear() error {
+	var cursor uint64
+	for {
+		ctx, cancel := context.WithTimeout(context.Background(), redisTimeout)
+		keys, next, err := r.client.Scan(ctx, cursor, keyPrefix+"*", 1000).Result()
+		if err == nil && len(keys) > 0 {
+			err = r.client.Unlink(ctx, keys...).Err()
+		}
+		cancel()
+		if err != nil {
+			return err
+		}
+		if next == 0 {
+			return nil
+		}
+		cursor = next
+	}
+}
+
+// Close closes the connections to the server
+func (r *Redis) Close() error {
+	return r.client.Close()
+}
@@ -0,0 +1,130 @@
+// repository package provides data access logic
+package repository
+
+import (
+	"crypto/rand"
+	"database/sql"
+	"encoding/hex"
+	"errors"
+	"fmt"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+)
+
+var ErrFeedNotFound = errors.New("calendar feed not found")
+
+// feedTokenBytes is how much randomness goes into a feed token
+const feedTokenBytes = 32
+
+type CalendarFeedRepositoryInterface interface {
+	CreateFeed(owner string, listID *int) (*models.CalendarFeed, error)
+	GetFeedByToken(token string) (*models.CalendarFeed, error)
+	GetFeeds(owner string) ([]models.CalendarFeed, error)
+	DeleteFeed(id int, owner string) error
+}
+
+// CalendarFeedRepository handles the tokens of subscribable calendar feeds
+type CalendarFeedRepository struct {
+	db *sql.DB
+}
+
+// NewCalendarFeedRepository creates a new CalendarFeedRepository
+func NewCalendarFeedRepository(db *sql.DB) *CalendarFeedRepository {
+	return &CalendarFeedRepository{db: db}
+}
+
+// NewFeedToken generates a new random, unguessable feed token
+func NewFeedToken() (string, error) {
+	token := make([]byte, feedTokenBytes)
+	if _, err := rand.Read(token); err != nil {
+		return "", fmt.Errorf("could not generate token: %w", err)
+	}
+	return hex.EncodeToString(token), nil
+}
+
+// CreateFeed creates a feed with a new random token
+func (r *CalendarFeedRepository) CreateFeed(owner string, listID *int) (*models.CalendarFeed, error) {
+	token, err := NewFeedToken()
+	if err != nil {
+		return nil, err
+	}
+
+	feed := &models.CalendarFeed{Owner: owner, Token: token, ListID: listID}
+	err = r.db.QueryRow(
+		"INSERT INTO calendar_feeds (owner, token, list_id) VALUES ($1, $2, $3) RETURNING id, created_at",
+		feed.Owner, feed.Token, listID,
+	).Scan(&feed.ID, &feed.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("could not obtain new id: %w", err)
+	}
+
+	return feed, nil
+}
+
+// GetFeedByToken retrieves the feed a token belongs to
+func (r *CalendarFeedRepository) GetFeedByToken(token string) (*models.CalendarFeed, error) {
+	row := r.db.QueryRow("SELECT id, owner, token, list_id, created_at FROM calendar_feeds WHERE token = $1", token)
+
+	feed, err := scanFeed(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrFeedNotFound
+		}
+		return nil, err
+	}
+	return feed, nil
+}
+
+// GetFeeds retrieves every feed belonging to an owner
+func (r *CalendarFeedRepository) GetFeeds(owner string) ([]models.CalendarFeed, error) {
+	rows, err := r.db.Query("SELECT id, owner, token, list_id, created_at FROM calendar_feeds WHERE owner = $1 ORDER BY id", owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query calendar feeds: %w", err)
+	}
+	defer rows.Close()
+
+	feeds := []models.CalendarFeed{}
+	for rows.Next() {
+		feed, err := scanFeed(rows)
+		if err != nil {
+			return nil, err
+		}
+		feeds = append(feeds, *feed)
+	}
+	return feeds, rows.Err()
+}
+
+// DeleteFeed revokes one of an owner's feeds
+func (r *CalendarFeedRepository) DeleteFeed(id int, owner string) error {
+	res, err := r.db.Exec("DELETE FROM calendar_feeds WHERE id = $1 AND owner = $2", id, owner)
+	if err != nil {
+		return fmt.Errorf("failed to delete calendar feed: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check affected rows: %w", err)
+	}
+	if rows == 0 {
+		return ErrFeedNotFound
+	}
+
+	return nil
+}
+
+func scanFeed(s scanner) (*models.CalendarFeed, error) {
+	var feed models.CalendarFeed
+	var listID sql.NullInt64
+	if err := s.Scan(&feed.ID, &feed.Owner, &feed.Token, &listID, &feed.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to scan calendar feed: %w", err)
+	}
+
+	if listID.Valid {
+		id := int(listID.Int64)
+		feed.ListID = &id
+	}
+	return &feed, nil
+}
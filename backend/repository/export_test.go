@@ -0,0 +1,12 @@
+package repository
+
+import "github.com/jennaborowy/fullstack-Go-Docker/models"
+
+// StreamPageSize lets tests read small pages, so a few items span several
+var StreamPageSize = &streamPageSize
+
+// FilterWhere returns the WHERE clause selecting the items that match filter, and its arguments
+func FilterWhere(dialect Dialect, filter models.ItemFilter) (string, []any) {
+	q := newFilteredQuery(dialect, filter)
+	return q.where(), q.args
+}
@@ -0,0 +1,102 @@
+// repository package provides data access logic
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"time"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+)
+
+type ImportRepositoryInterface interface {
+	BeginListImport() (ListImporter, error)
+}
+
+// ListImporter inserts an imported list and its items in a single transaction.
+// Nothing is visible to other requests until Finish commits.
+type ListImporter interface {
+	AddItem(item models.Item) error
+	Finish(list models.List) (*models.List, error)
+	Abort() error
+}
+
+// ImportRepository starts bulk imports of lists
+type ImportRepository struct {
+	db *sql.DB
+}
+
+// NewImportRepository creates a new ImportRepository
+func NewImportRepository(db *sql.DB) *ImportRepository {
+	return &ImportRepository{db: db}
+}
+
+// BeginListImport starts a transaction and creates the list items will be added to.
+// The list's title and timestamps are filled in by Finish.
+func (r *ImportRepository) BeginListImport() (ListImporter, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("could not start import: %w", err)
+	}
+
+	importer := &listImporter{tx: tx}
+	if err := tx.QueryRow("INSERT INTO lists (title) VALUES ('') RETURNING id").Scan(&importer.listID); err != nil {
+		tx.Rollback()
+		return nil, fmt.Errorf("could not create list: %w", err)
+	}
+
+	return importer, nil
+}
+
+type listImporter struct {
+	tx     *sql.Tx
+	listID int64
+	done   bool
+}
+
+// AddItem inserts an item into the imported list, keeping its timestamps when it has them
+func (i *listImporter) AddItem(item models.Item) error {
+	_, err := i.tx.Exec(
+		`INSERT INTO items (title, content, item_date, list_id, completed, created_at, updated_at)
+		VALUES ($1, $2, $3, $4, $5, COALESCE($6, CURRENT_TIMESTAMP), COALESCE($7, CURRENT_TIMESTAMP))`,
+		item.Title, item.Content, item.Date, i.listID, item.Completed, nullTime(item.CreatedAt), nullTime(item.UpdatedAt),
+	)
+	if err != nil {
+		return fmt.Errorf("could not import item: %w", err)
+	}
+	return nil
+}
+
+// Finish sets the list's title and timestamps and commits the import
+func (i *listImporter) Finish(list models.List) (*models.List, error) {
+	imported := &models.List{}
+	err := i.tx.QueryRow(
+		`UPDATE lists SET title = $1, created_at = COALESCE($2, created_at), updated_at = COALESCE($3, updated_at)
+		WHERE id = $4 RETURNING id, title, created_at, updated_at`,
+		list.Title, nullTime(list.CreatedAt), nullTime(list.UpdatedAt), i.listID,
+	).Scan(&imported.ID, &imported.Title, &imported.CreatedAt, &imported.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("could not update imported list: %w", err)
+	}
+
+	if err := i.tx.Commit(); err != nil {
+		return nil, fmt.Errorf("could not commit import: %w", err)
+	}
+	i.done = true
+
+	return imported, nil
+}
+
+// Abort rolls the import back. It does nothing once Finish has committed.
+func (i *listImporter) Abort() error {
+	if i.done {
+		return nil
+	}
+	i.done = true
+	return i.tx.Rollback()
+}
+
+// nullTime turns a zero time into NULL so column defaults can apply
+func nullTime(t time.Time) sql.NullTime {
+	return sql.NullTime{Time: t, Valid: !t.IsZero()}
+}
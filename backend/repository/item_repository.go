@@ -5,19 +5,24 @@ import (
 	"database/sql"
 	"errors"
 	"fmt"
+	"slices"
+	"strings"
 	"time"
 
 	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/lib/pq"
 )
 
 var ErrNotFound = errors.New("item not found")
 
 type ItemRepositoryInterface interface {
 	GetAll() ([]models.Item, error)
-	GetByID(id int) (*models.Item, error)
+	GetByID(id int, fields models.Fields) (*models.Item, error)
+	GetFiltered(filter models.ItemFilter) ([]models.Item, error)
+	StreamFiltered(filter models.ItemFilter, fn func(item models.Item) error) error
 	DeleteItemByID(id int) error
 	CreateItem(title string, date time.Time, content string, listID int) (*models.Item, error)
-	UpdateItem(id int, title string, date time.Time, content string) error
+	ChangeItem(id int, changes models.ItemChanges, revision *models.Revision) error
 }
 
 // ItemRepository handles CRUD operations for items
@@ -30,9 +35,9 @@ func NewItemRepository(db *sql.DB) *ItemRepository {
 	return &ItemRepository{db: db}
 }
 
-// GetAll retrieves all existing items from database
+// GetAll retrieves all existing items from database, ordered by ID
 func (r *ItemRepository) GetAll() ([]models.Item, error) {
-	rows, err := r.db.Query("SELECT id, title, item_date, content, list_id, created_at, updated_at FROM items")
+	rows, err := r.db.Query("SELECT id, title, item_date, content, list_id, completed, created_at, updated_at FROM items ORDER BY id")
 	if err != nil {
 		return nil, err
 	}
@@ -41,7 +46,7 @@ func (r *ItemRepository) GetAll() ([]models.Item, error) {
 	var items []models.Item
 	for rows.Next() {
 		var item models.Item
-		if err := rows.Scan(&item.ID, &item.Title, &item.Date, &item.Content, &item.ListID, &item.CreatedAt, &item.UpdatedAt); err != nil {
+		if err := rows.Scan(&item.ID, &item.Title, &item.Date, &item.Content, &item.ListID, &item.Completed, &item.CreatedAt, &item.UpdatedAt); err != nil {
 			return nil, err
 		}
 		items = append(items, item)
@@ -50,12 +55,13 @@ func (r *ItemRepository) GetAll() ([]models.Item, error) {
 
 }
 
-// GetByID retrieves a single item by its ID
-func (r *ItemRepository) GetByID(id int) (*models.Item, error) {
-	row := r.db.QueryRow("SELECT id, title, item_date, content, list_id, created_at, updated_at FROM items WHERE id = $1", id)
+// GetByID retrieves a single item by its ID, reading only the columns holding fields
+func (r *ItemRepository) GetByID(id int, fields models.Fields) (*models.Item, error) {
+	columns, scan := SelectItemColumns(fields)
+	row := r.db.QueryRow("SELECT "+columns+" FROM items WHERE id = $1", id)
 
 	var item models.Item
-	err := row.Scan(&item.ID, &item.Title, &item.Date, &item.Content, &item.ListID, &item.CreatedAt, &item.UpdatedAt)
+	err := row.Scan(scan(&item)...)
 	if err != nil {
 		if err == sql.ErrNoRows {
 			return nil, ErrNotFound
@@ -66,6 +72,232 @@ func (r *ItemRepository) GetByID(id int) (*models.Item, error) {
 	return &item, nil
 }
 
+// GetFiltered retrieves the items matching a filter, ordered by date
+func (r *ItemRepository) GetFiltered(filter models.ItemFilter) ([]models.Item, error) {
+	return GetFilteredItems(r.db, Postgres, filter)
+}
+
+// streamPageSize is how many items StreamFiltered reads per query
+var streamPageSize = 500
+
+// StreamFiltered calls fn with each item matching a filter, in StreamOrder, so the items
+// are never all held at once. Rather than holding a pooled connection while fn writes to
+// a slow client, items are read a page at a time, each page following on from the last
+// item of the one before. Items changed between pages may be seen in either state.
+// Streaming stops at the first error fn returns.
+func (r *ItemRepository) StreamFiltered(filter models.ItemFilter, fn func(item models.Item) error) error {
+	return StreamFilteredItems(r.db, Postgres, streamPageSize, filter, fn)
+}
+
+// Dialect is how a database's SQL differs in the item queries GetFilteredItems and
+// StreamFilteredItems build
+type Dialect struct {
+	// Placeholder returns the placeholder of a query's nth argument, counting from 1
+	Placeholder func(n int) string
+	// Like is the operator matching title and content against a pattern, ignoring case
+	Like string
+	// DateCast follows the placeholder of a date compared with a row of item_date and id
+	DateCast string
+	// DateIndex, when set, is the index read when streaming items by date
+	DateIndex string
+}
+
+// Postgres is the dialect of the repositories in this package
+var Postgres = Dialect{
+	Placeholder: func(n int) string { return fmt.Sprintf("$%d", n) },
+	Like:        "ILIKE",
+	DateCast:    "::date",
+}
+
+// GetFilteredItems retrieves the items matching a filter from db, ordered by date
+func GetFilteredItems(db *sql.DB, dialect Dialect, filter models.ItemFilter) ([]models.Item, error) {
+	columns, scan := SelectItemColumns(filter.Fields)
+	q := newFilteredQuery(dialect, filter)
+	query := "SELECT " + columns + " FROM items" + q.where() + " ORDER BY item_date, id"
+
+	items := []models.Item{}
+	_, err := EachItem(db, query, q.args, scan, func(item models.Item) error {
+		items = append(items, item)
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return items, nil
+}
+
+// StreamFilteredItems calls fn with each item matching a filter in db, in StreamOrder,
+// reading pageSize items per query. Each page is read in full before fn is called with its
+// items, and follows on from the last item of the one before.
+func StreamFilteredItems(db *sql.DB, dialect Dialect, pageSize int, filter models.ItemFilter, fn func(item models.Item) error) error {
+	byDate := !filter.IsEmpty()
+	fields := filter.Fields
+	if byDate && !fields.Has("item_date") {
+		// pages follow on from the last date
+		fields = append(slices.Clone(fields), "item_date")
+	}
+	columns, scan := SelectItemColumns(fields)
+
+	from := " FROM items"
+	if byDate && dialect.DateIndex != "" {
+		from += " INDEXED BY " + dialect.DateIndex
+	}
+
+	var last *models.Item
+	for {
+		q := newFilteredQuery(dialect, filter)
+		switch {
+		case last != nil && byDate:
+			q.add("(item_date, id) > (?"+dialect.DateCast+", ?)", last.Date, last.ID)
+		case last != nil:
+			q.add("id > ?", last.ID)
+		}
+		query := "SELECT " + columns + from + q.where() +
+			" ORDER BY " + StreamOrder(filter) + fmt.Sprintf(" LIMIT %d", pageSize)
+
+		// the page is held so the connection is free again before fn is called
+		page := make([]models.Item, 0, pageSize)
+		n, err := EachItem(db, query, q.args, scan, func(item models.Item) error {
+			page = append(page, item)
+			return nil
+		})
+		if err != nil {
+			return err
+		}
+		for _, item := range page {
+			if err := fn(item); err != nil {
+				return err
+			}
+		}
+		if n < pageSize {
+			return nil
+		}
+		last = &page[len(page)-1]
+	}
+}
+
+// filteredQuery holds the WHERE conditions of a query selecting items, and their arguments
+type filteredQuery struct {
+	dialect    Dialect
+	conditions []string
+	args       []any
+}
+
+// newFilteredQuery returns the conditions selecting the items that match filter
+func newFilteredQuery(dialect Dialect, filter models.ItemFilter) *filteredQuery {
+	q := &filteredQuery{dialect: dialect}
+	if len(filter.ListIDs) > 0 {
+		ids := make([]any, len(filter.ListIDs))
+		for i, id := range filter.ListIDs {
+			ids[i] = id
+		}
+		q.add("list_id IN ("+strings.Repeat("?, ", len(ids)-1)+"?)", ids...)
+	}
+	if !filter.From.IsZero() {
+		q.add("item_date >= ?", filter.From)
+	}
+	if !filter.To.IsZero() {
+		q.add("item_date <= ?", filter.To)
+	}
+	if filter.Completed != nil {
+		q.add("completed = ?", *filter.Completed)
+	}
+	if filter.Query != "" {
+		pattern := "%" + filter.Query + "%"
+		q.add("(title "+dialect.Like+" ? OR content "+dialect.Like+" ?)", pattern, pattern)
+	}
+	return q
+}
+
+// add adds a condition, replacing each ? in it with the placeholder of the next of args
+func (q *filteredQuery) add(condition string, args ...any) {
+	parts := strings.Split(condition, "?")
+	var b strings.Builder
+	for i, arg := range args {
+		q.args = append(q.args, arg)
+		b.WriteString(parts[i])
+		b.WriteString(q.dialect.Placeholder(len(q.args)))
+	}
+	b.WriteString(parts[len(args)])
+	q.conditions = append(q.conditions, b.String())
+}
+
+// where returns the WHERE clause of the conditions, or nothing when there are none
+func (q *filteredQuery) where() string {
+	if len(q.conditions) == 0 {
+		return ""
+	}
+	return " WHERE " + strings.Join(q.conditions, " AND ")
+}
+
+// StreamOrder is the order StreamFiltered streams items in: by date like GetFiltered, but
+// by ID like GetAll when the filter matches every item
+func StreamOrder(filter models.ItemFilter) string {
+	if filter.IsEmpty() {
+		return "id"
+	}
+	return "item_date, id"
+}
+
+// EachItem runs query and calls fn with each item it selects, scanned with scan, until fn
+// returns an error. It returns how many items fn was called with.
+func EachItem(db *sql.DB, query string, args []any, scan func(item *models.Item) []any, fn func(item models.Item) error) (int, error) {
+	rows, err := db.Query(query, args...)
+	if err != nil {
+		return 0, fmt.Errorf("failed to query items: %w", err)
+	}
+	defer rows.Close()
+
+	n := 0
+	for rows.Next() {
+		var item models.Item
+		if err := rows.Scan(scan(&item)...); err != nil {
+			return n, fmt.Errorf("failed to scan item: %w", err)
+		}
+		n++
+		if err := fn(item); err != nil {
+			return n, err
+		}
+	}
+	return n, rows.Err()
+}
+
+// itemColumns are the columns of items, named like the fields they hold, in the order they are selected
+var itemColumns = []struct {
+	name string
+	dest func(item *models.Item) any
+}{
+	{"id", func(item *models.Item) any { return &item.ID }},
+	{"title", func(item *models.Item) any { return &item.Title }},
+	{"item_date", func(item *models.Item) any { return &item.Date }},
+	{"content", func(item *models.Item) any { return &item.Content }},
+	{"list_id", func(item *models.Item) any { return &item.ListID }},
+	{"completed", func(item *models.Item) any { return &item.Completed }},
+	{"created_at", func(item *models.Item) any { return &item.CreatedAt }},
+	{"updated_at", func(item *models.Item) any { return &item.UpdatedAt }},
+}
+
+// SelectItemColumns returns the columns of items holding fields, always with id, and a
+// function returning where to scan a row of them into. No fields selects every column.
+func SelectItemColumns(fields models.Fields) (string, func(item *models.Item) []any) {
+	var names []string
+	var dests []func(item *models.Item) any
+	for _, column := range itemColumns {
+		if column.name == "id" || fields.Has(column.name) {
+			names = append(names, column.name)
+			dests = append(dests, column.dest)
+		}
+	}
+
+	return strings.Join(names, ", "), func(item *models.Item) []any {
+		pointers := make([]any, len(dests))
+		for i, dest := range dests {
+			pointers[i] = dest(item)
+		}
+		return pointers
+	}
+}
+
 // // GetItemsByListID gets all items in a list by the listID it is in.
 // func (r *ItemRepository) GetByListID(listID int) (*[]models.Item, error) {
 // 	rows, err := r.db.Query("SELECT id, title, item_date, content, list_id, created_at, updated_at FROM items WHERE list_id = $1", listID)
@@ -120,10 +352,27 @@ func (r *ItemRepository) CreateItem(title string, date time.Time, content string
 	return item, nil
 }
 
-// UpdateItem updates an item's title, date, and/or content
-func (r *ItemRepository) UpdateItem(id int, title string, date time.Time, content string) error {
-	res, err := r.db.Exec("UPDATE items Set title = $1, item_date = $2, content = $3, updated_at = $4 WHERE id = ?", title, date, content, time.Now(), id)
+// ChangeItem makes every change of an update to an item in a single statement, so
+// nothing is changed when moving it to a list that doesn't exist returns ErrListNotFound.
+// The revision, unless nil, is stored in the same transaction.
+func (r *ItemRepository) ChangeItem(id int, changes models.ItemChanges, revision *models.Revision) error {
+	tx, err := r.db.Begin()
 	if err != nil {
+		return fmt.Errorf("could not start update: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec(
+		`UPDATE items SET title = $1, item_date = $2, content = $3,
+			completed = COALESCE($4, completed), list_id = COALESCE($5, list_id), updated_at = $6
+		WHERE id = $7`,
+		changes.Title, changes.Date, changes.Content, changes.Completed, changes.ListID, time.Now(), id,
+	)
+	if err != nil {
+		var pqErr *pq.Error
+		if errors.As(err, &pqErr) && pqErr.Code == "23503" { // foreign_key_violation
+			return ErrListNotFound
+		}
 		return fmt.Errorf("could not update item: %w", err)
 	}
 
@@ -136,5 +385,14 @@ func (r *ItemRepository) UpdateItem(id int, title string, date time.Time, conten
 		return fmt.Errorf("no item found with id %d", id)
 	}
 
+	if revision != nil {
+		if _, err := insertRevision(tx, revision); err != nil {
+			return err
+		}
+	}
+
+	if err := tx.Commit(); err != nil {
+		return fmt.Errorf("could not commit update: %w", err)
+	}
 	return nil
 }
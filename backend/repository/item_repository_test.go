@@ -0,0 +1,58 @@
+package repository_test
+
+import (
+	"reflect"
+	"testing"
+	"time"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+)
+
+func TestFilterWhere(t *testing.T) {
+	day := time.Date(2025, 10, 10, 0, 0, 0, 0, time.UTC)
+	completed := true
+	filter := models.ItemFilter{ListIDs: []int{1, 2}, From: day, Completed: &completed, Query: "milk"}
+	sqlite := repository.Dialect{Placeholder: func(int) string { return "?" }, Like: "LIKE"}
+
+	tests := []struct {
+		name      string
+		dialect   repository.Dialect
+		filter    models.ItemFilter
+		wantWhere string
+	}{
+		{
+			name:      "an empty filter has no conditions",
+			dialect:   repository.Postgres,
+			wantWhere: "",
+		},
+		{
+			name:      "postgres numbers its placeholders",
+			dialect:   repository.Postgres,
+			filter:    filter,
+			wantWhere: " WHERE list_id IN ($1, $2) AND item_date >= $3 AND completed = $4 AND (title ILIKE $5 OR content ILIKE $6)",
+		},
+		{
+			name:      "the dialect's placeholders and search",
+			dialect:   sqlite,
+			filter:    filter,
+			wantWhere: " WHERE list_id IN (?, ?) AND item_date >= ? AND completed = ? AND (title LIKE ? OR content LIKE ?)",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			where, args := repository.FilterWhere(tt.dialect, tt.filter)
+			if where != tt.wantWhere {
+				t.Errorf("expected %q, got %q", tt.wantWhere, where)
+			}
+			var wantArgs []any
+			if tt.wantWhere != "" {
+				wantArgs = []any{1, 2, day, true, "%milk%", "%milk%"}
+			}
+			if !reflect.DeepEqual(args, wantArgs) {
+				t.Errorf("expected arguments %v, got %v", wantArgs, args)
+			}
+		})
+	}
+}
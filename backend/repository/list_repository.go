@@ -3,17 +3,28 @@ package repository
 
 import (
 	"database/sql"
+	"errors"
 	"fmt"
+	"strings"
 	"time"
 
 	"github.com/jennaborowy/fullstack-Go-Docker/models"
 )
 
+// ErrListNotFound is returned when a list doesn't exist. It also matches ErrNotFound with errors.Is.
+var ErrListNotFound error = listNotFoundError{}
+
+type listNotFoundError struct{}
+
+func (listNotFoundError) Error() string { return "list not found" }
+
+func (listNotFoundError) Is(target error) bool { return target == ErrNotFound }
+
 type ListRepositoryInterface interface {
 	CreateList(title string) (*models.List, error)
-	GetList(id int) (*models.List, error)
-	GetAllLists() ([]models.List, error)
-	UpdateTitle(id int, title string) (*models.List, error)
+	GetList(id int, fields models.Fields) (*models.List, error)
+	GetAllLists(include models.ListInclude, fields models.Fields) ([]models.List, error)
+	UpdateTitle(id int, title string, revision *models.Revision) (*models.List, error)
 	DeleteList(id int) error
 }
 
@@ -41,41 +52,88 @@ func (r *ListRepository) CreateList(title string) (*models.List, error) {
 	return list, nil
 }
 
-// GetList retrieves a list by ID with its items
-func (r *ListRepository) GetList(id int) (*models.List, error) {
-	list := &models.List{}
-	// Get the list info
-	row := r.db.QueryRow("SELECT id, title, created_at, updated_at FROM lists WHERE id = $1", id)
-	if err := row.Scan(&list.ID, &list.Title, &list.CreatedAt, &list.UpdatedAt); err != nil {
-		if err == sql.ErrNoRows {
-			return nil, fmt.Errorf("list not found")
+// joinedItemColumns select, through a LEFT JOIN, one of a list's items
+const joinedItemColumns = "i.id, i.title, i.item_date, i.content, i.list_id, i.completed, i.created_at, i.updated_at"
+
+// listColumns are the columns of lists, named like the fields they hold, in the order they are selected
+var listColumns = []struct {
+	name string
+	dest func(list *models.List) any
+}{
+	{"id", func(list *models.List) any { return &list.ID }},
+	{"title", func(list *models.List) any { return &list.Title }},
+	{"created_at", func(list *models.List) any { return &list.CreatedAt }},
+	{"updated_at", func(list *models.List) any { return &list.UpdatedAt }},
+}
+
+// selectListColumns returns the columns of lists l holding fields, always with id, and a
+// function returning where to scan a row of them into. No fields selects every column.
+func selectListColumns(fields models.Fields) (string, func(list *models.List) []any) {
+	var names []string
+	var dests []func(list *models.List) any
+	for _, column := range listColumns {
+		if column.name == "id" || fields.Has(column.name) {
+			names = append(names, "l."+column.name)
+			dests = append(dests, column.dest)
 		}
-		return nil, fmt.Errorf("failed to scan list: %w", err)
 	}
 
-	// Get items for this list
-	itemsRows, err := r.db.Query("SELECT id, title, item_date, content, list_id, created_at, updated_at FROM items WHERE list_id = $1", id)
-	if err != nil {
-		return nil, fmt.Errorf("failed to query items: %w", err)
+	return strings.Join(names, ", "), func(list *models.List) []any {
+		pointers := make([]any, len(dests))
+		for i, dest := range dests {
+			pointers[i] = dest(list)
+		}
+		return pointers
 	}
-	defer itemsRows.Close()
+}
 
-	for itemsRows.Next() {
-		var item models.Item
-		if err := itemsRows.Scan(&item.ID, &item.Title, &item.Date, &item.Content, &item.ListID, &item.CreatedAt, &item.UpdatedAt); err != nil {
-			return nil, fmt.Errorf("failed to scan item: %w", err)
+// GetList retrieves a list by ID with its items, in a single query. Only the columns
+// holding fields are read, and the items only when fields has them.
+func (r *ListRepository) GetList(id int, fields models.Fields) (*models.List, error) {
+	columns, scan := selectListColumns(fields)
+	var lists []models.List
+	if fields.Has("items") {
+		rows, err := r.db.Query(
+			"SELECT "+columns+", "+joinedItemColumns+" FROM lists l LEFT JOIN items i ON i.list_id = l.id WHERE l.id = $1 ORDER BY i.id",
+			id,
+		)
+		if err != nil {
+			return nil, fmt.Errorf("failed to query list: %w", err)
 		}
-		list.Items = append(list.Items, item)
-	}
+		defer rows.Close()
 
-	// could instead change this to do a left join for a single query, rather than two
+		if lists, err = scanListsWithItems(rows, scan); err != nil {
+			return nil, err
+		}
+	} else {
+		var list models.List
+		err := r.db.QueryRow("SELECT "+columns+" FROM lists l WHERE l.id = $1", id).Scan(scan(&list)...)
+		if err != nil && !errors.Is(err, sql.ErrNoRows) {
+			return nil, fmt.Errorf("failed to query list: %w", err)
+		}
+		if err == nil {
+			lists = append(lists, list)
+		}
+	}
 
-	return list, nil
+	if len(lists) == 0 {
+		return nil, ErrListNotFound
+	}
+	return &lists[0], nil
 }
 
-// GetAllLists retrieves all lists without their items
-func (r *ListRepository) GetAllLists() ([]models.List, error) {
-	rows, err := r.db.Query("SELECT id, title, created_at, updated_at FROM lists")
+// GetAllLists retrieves all lists ordered by ID, with their items and item counts if asked.
+// Only the columns of lists holding fields are read. Either way it takes a single query.
+func (r *ListRepository) GetAllLists(include models.ListInclude, fields models.Fields) ([]models.List, error) {
+	columns, scan := selectListColumns(fields)
+	switch {
+	case include.Items:
+		return r.getAllListsWithItems(columns, scan, include.Counts)
+	case include.Counts:
+		return r.getAllListsWithCounts(columns, scan)
+	}
+
+	rows, err := r.db.Query("SELECT " + columns + " FROM lists l ORDER BY l.id")
 	if err != nil {
 		return nil, fmt.Errorf("failed to query lists: %w", err)
 	}
@@ -84,17 +142,106 @@ func (r *ListRepository) GetAllLists() ([]models.List, error) {
 	lists := []models.List{}
 	for rows.Next() {
 		var l models.List
-		if err := rows.Scan(&l.ID, &l.Title, &l.CreatedAt, &l.UpdatedAt); err != nil {
+		if err := rows.Scan(scan(&l)...); err != nil {
 			return nil, fmt.Errorf("failed to scan list: %w", err)
 		}
 		lists = append(lists, l)
 	}
+	return lists, rows.Err()
+}
+
+// getAllListsWithItems reads the columns of every list joined with its items, counting
+// them in Go
+func (r *ListRepository) getAllListsWithItems(columns string, scan func(list *models.List) []any, counts bool) ([]models.List, error) {
+	rows, err := r.db.Query("SELECT " + columns + ", " + joinedItemColumns + " FROM lists l LEFT JOIN items i ON i.list_id = l.id ORDER BY l.id, i.id")
+	if err != nil {
+		return nil, fmt.Errorf("failed to query lists: %w", err)
+	}
+	defer rows.Close()
+
+	lists, err := scanListsWithItems(rows, scan)
+	if err != nil {
+		return nil, err
+	}
+	if counts {
+		for i := range lists {
+			lists[i].Counts = models.CountItems(lists[i].Items)
+		}
+	}
 	return lists, nil
 }
 
-// UpdateList updates the title of a list
-func (r *ListRepository) UpdateTitle(id int, title string) (*models.List, error) {
-	res, err := r.db.Exec("UPDATE lists SET title = $1, updated_at = $2 WHERE id = $3", title, time.Now(), id)
+// getAllListsWithCounts reads the columns of every list with its item counts, aggregated
+// by the database
+func (r *ListRepository) getAllListsWithCounts(columns string, scan func(list *models.List) []any) ([]models.List, error) {
+	// grouped by the primary key, the other columns of lists can be selected as they are
+	rows, err := r.db.Query(`SELECT ` + columns + `, COUNT(i.id), COALESCE(SUM(CASE WHEN i.completed THEN 1 ELSE 0 END), 0)
+		FROM lists l LEFT JOIN items i ON i.list_id = l.id
+		GROUP BY l.id ORDER BY l.id`)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query lists: %w", err)
+	}
+	defer rows.Close()
+
+	lists := []models.List{}
+	for rows.Next() {
+		l := models.List{Counts: &models.ListCounts{}}
+		if err := rows.Scan(append(scan(&l), &l.Counts.Total, &l.Counts.Completed)...); err != nil {
+			return nil, fmt.Errorf("failed to scan list: %w", err)
+		}
+		lists = append(lists, l)
+	}
+	return lists, rows.Err()
+}
+
+// scanListsWithItems reads rows of list columns, scanned with scan, and joinedItemColumns
+// ordered by list, gathering each list's items. A list without items has a single row of
+// NULL items.
+func scanListsWithItems(rows *sql.Rows, scan func(list *models.List) []any) ([]models.List, error) {
+	lists := []models.List{}
+	for rows.Next() {
+		var l models.List
+		var (
+			id, listID                 sql.NullInt64
+			title, content             sql.NullString
+			date, createdAt, updatedAt sql.NullTime
+			completed                  sql.NullBool
+		)
+		if err := rows.Scan(append(scan(&l),
+			&id, &title, &date, &content, &listID, &completed, &createdAt, &updatedAt)...); err != nil {
+			return nil, fmt.Errorf("failed to scan list: %w", err)
+		}
+
+		if len(lists) == 0 || lists[len(lists)-1].ID != l.ID {
+			lists = append(lists, l)
+		}
+		if id.Valid {
+			last := &lists[len(lists)-1]
+			last.Items = append(last.Items, models.Item{
+				ID:        int(id.Int64),
+				Title:     title.String,
+				Date:      date.Time,
+				Content:   content.String,
+				ListID:    int(listID.Int64),
+				Completed: completed.Bool,
+				CreatedAt: createdAt.Time,
+				UpdatedAt: updatedAt.Time,
+			})
+		}
+	}
+	return lists, rows.Err()
+}
+
+// UpdateTitle updates the title of a list. The revision, unless nil, is stored in the
+// same transaction.
+func (r *ListRepository) UpdateTitle(id int, title string, revision *models.Revision) (*models.List, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("failed to start update: %w", err)
+	}
+	defer tx.Rollback()
+
+	res, err := tx.Exec("UPDATE lists SET title = $1, updated_at = $2 WHERE id = $3", title, time.Now(), id)
 	if err != nil {
 		return nil, fmt.Errorf("failed to update list: %w", err)
 	}
@@ -105,16 +252,25 @@ func (r *ListRepository) UpdateTitle(id int, title string) (*models.List, error)
 	}
 
 	if rowsAffected == 0 {
-		return nil, fmt.Errorf("list not found")
+		return nil, ErrListNotFound
+	}
+
+	if revision != nil {
+		if _, err := insertRevision(tx, revision); err != nil {
+			return nil, err
+		}
 	}
 
 	// Query the updated list
 	list := &models.List{}
-	row := r.db.QueryRow("SELECT id, title, created_at, updated_at FROM lists WHERE id = $1", id)
+	row := tx.QueryRow("SELECT id, title, created_at, updated_at FROM lists WHERE id = $1", id)
 	if err := row.Scan(&list.ID, &list.Title, &list.CreatedAt, &list.UpdatedAt); err != nil {
 		return nil, fmt.Errorf("failed to fetch updated list: %w", err)
 	}
 
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("failed to commit update: %w", err)
+	}
 	return list, nil
 }
 
@@ -133,7 +289,7 @@ func (r *ListRepository) DeleteList(id int) error {
 		return fmt.Errorf("failed to check affected rows: %w", err)
 	}
 	if rowsAffected == 0 {
-		return fmt.Errorf("list not found")
+		return ErrListNotFound
 	}
 
 	return nil
@@ -0,0 +1,151 @@
+package repository_test
+
+import (
+	"database/sql"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/database"
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+)
+
+// how much data the benchmarks read
+const (
+	benchLists        = 50
+	benchItemsPerList = 20
+)
+
+// benchDB returns a database holding benchLists lists of benchItemsPerList items: Postgres
+// at TEST_DATABASE_URL when set, otherwise SQLite. Round trips to SQLite are in-process
+// and cheap, so Postgres shows the difference the number of queries makes far better.
+func benchDB(b *testing.B) *sql.DB {
+	b.Helper()
+	var db *sql.DB
+	if databaseURL := os.Getenv("TEST_DATABASE_URL"); databaseURL != "" {
+		db = connectSchema(b, databaseURL)
+	} else {
+		var err error
+		db, err = database.ConnectSQLite(filepath.Join(b.TempDir(), "notes.db"))
+		if err != nil {
+			b.Fatalf("failed to open database: %v", err)
+		}
+		b.Cleanup(func() { db.Close() })
+	}
+
+	lists := repository.NewListRepository(db)
+	items := repository.NewItemRepository(db)
+	day := time.Date(2025, 10, 10, 0, 0, 0, 0, time.UTC)
+	for range benchLists {
+		list, err := lists.CreateList("List")
+		if err != nil {
+			b.Fatalf("CreateList: %v", err)
+		}
+		for i := range benchItemsPerList {
+			if _, err := items.CreateItem("Item", day.AddDate(0, 0, i), "content", int(list.ID)); err != nil {
+				b.Fatalf("CreateItem: %v", err)
+			}
+		}
+	}
+	return db
+}
+
+func BenchmarkGetList(b *testing.B) {
+	db := benchDB(b)
+	lists := repository.NewListRepository(db)
+
+	b.Run("join", func(b *testing.B) {
+		for b.Loop() {
+			if _, err := lists.GetList(1, nil); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("two queries", func(b *testing.B) {
+		for b.Loop() {
+			if _, err := getListTwoQueries(db, 1); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+}
+
+func BenchmarkGetAllLists(b *testing.B) {
+	db := benchDB(b)
+	lists := repository.NewListRepository(db)
+
+	b.Run("items/join", func(b *testing.B) {
+		for b.Loop() {
+			if _, err := lists.GetAllLists(models.ListInclude{Items: true}, nil); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("items/one query per list", func(b *testing.B) {
+		for b.Loop() {
+			if _, err := getAllListsOneByOne(db, lists); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("counts/aggregate", func(b *testing.B) {
+		for b.Loop() {
+			if _, err := lists.GetAllLists(models.ListInclude{Counts: true}, nil); err != nil {
+				b.Fatal(err)
+			}
+		}
+	})
+	b.Run("counts/one query per list", func(b *testing.B) {
+		for b.Loop() {
+			all, err := getAllListsOneByOne(db, lists)
+			if err != nil {
+				b.Fatal(err)
+			}
+			for i := range all {
+				all[i].Counts = models.CountItems(all[i].Items)
+			}
+		}
+	})
+}
+
+// getListTwoQueries reads a list the way GetList used to, then its items separately
+func getListTwoQueries(db *sql.DB, id int) (*models.List, error) {
+	list := &models.List{}
+	row := db.QueryRow("SELECT id, title, created_at, updated_at FROM lists WHERE id = $1", id)
+	if err := row.Scan(&list.ID, &list.Title, &list.CreatedAt, &list.UpdatedAt); err != nil {
+		return nil, err
+	}
+
+	rows, err := db.Query("SELECT id, title, item_date, content, list_id, completed, created_at, updated_at FROM items WHERE list_id = $1 ORDER BY id", id)
+	if err != nil {
+		return nil, err
+	}
+	defer rows.Close()
+	for rows.Next() {
+		var item models.Item
+		if err := rows.Scan(&item.ID, &item.Title, &item.Date, &item.Content, &item.ListID, &item.Completed, &item.CreatedAt, &item.UpdatedAt); err != nil {
+			return nil, err
+		}
+		list.Items = append(list.Items, item)
+	}
+	return list, rows.Err()
+}
+
+// getAllListsOneByOne is what a client had to do before include: read the lists, then
+// each of them with its items the way GetList used to
+func getAllListsOneByOne(db *sql.DB, lists *repository.ListRepository) ([]models.List, error) {
+	all, err := lists.GetAllLists(models.ListInclude{}, nil)
+	if err != nil {
+		return nil, err
+	}
+	for i := range all {
+		list, err := getListTwoQueries(db, int(all[i].ID))
+		if err != nil {
+			return nil, err
+		}
+		all[i].Items = list.Items
+	}
+	return all, nil
+}
@@ -0,0 +1,58 @@
+package memory
+
+import (
+	"slices"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+)
+
+// ActivityRepository appends to and queries the activity log kept in memory.
+// Entries are never updated or deleted.
+type ActivityRepository struct {
+	db *DB
+}
+
+// NewActivityRepository creates a new ActivityRepository
+func NewActivityRepository(db *DB) *ActivityRepository {
+	return &ActivityRepository{db: db}
+}
+
+// LogActivity appends an entry to the activity log
+func (r *ActivityRepository) LogActivity(activity *models.Activity) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	activity.ID = r.db.nextID("activity")
+	activity.CreatedAt = now()
+	r.db.activity = append(r.db.activity, *activity)
+	return nil
+}
+
+// GetActivity retrieves activity entries matching the filter, newest first
+func (r *ActivityRepository) GetActivity(filter models.ActivityFilter) ([]models.Activity, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	limit := filter.Limit
+	if limit <= 0 {
+		limit = repository.DefaultActivityLimit
+	}
+
+	activity := []models.Activity{}
+	// entries are appended as they happen, so walking backwards gives the newest first
+	for _, a := range slices.Backward(r.db.activity) {
+		if len(activity) == limit {
+			break
+		}
+		if filter.User != "" && a.User != filter.User ||
+			filter.Action != "" && a.Action != filter.Action ||
+			filter.ListID != 0 && !a.OnList(filter.ListID) ||
+			!filter.From.IsZero() && a.CreatedAt.Before(filter.From) ||
+			!filter.To.IsZero() && !a.CreatedAt.Before(filter.To) {
+			continue
+		}
+		activity = append(activity, a)
+	}
+	return activity, nil
+}
@@ -0,0 +1,94 @@
+package memory
+
+import (
+	"fmt"
+	"slices"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+)
+
+// CalendarFeedRepository handles the tokens of subscribable calendar feeds kept in memory
+type CalendarFeedRepository struct {
+	db *DB
+}
+
+// NewCalendarFeedRepository creates a new CalendarFeedRepository
+func NewCalendarFeedRepository(db *DB) *CalendarFeedRepository {
+	return &CalendarFeedRepository{db: db}
+}
+
+// CreateFeed creates a feed with a new random token
+func (r *CalendarFeedRepository) CreateFeed(owner string, listID *int) (*models.CalendarFeed, error) {
+	token, err := repository.NewFeedToken()
+	if err != nil {
+		return nil, err
+	}
+
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if listID != nil {
+		if _, ok := r.db.lists[*listID]; !ok {
+			return nil, fmt.Errorf("could not obtain new id: %w", repository.ErrListNotFound)
+		}
+	}
+
+	feed := models.CalendarFeed{ID: r.db.nextID("calendar_feeds"), Owner: owner, Token: token, ListID: cloneID(listID), CreatedAt: now()}
+	r.db.feeds[feed.ID] = feed
+	return cloneFeed(feed), nil
+}
+
+// GetFeedByToken retrieves the feed a token belongs to
+func (r *CalendarFeedRepository) GetFeedByToken(token string) (*models.CalendarFeed, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	for _, feed := range r.db.feeds {
+		if feed.Token == token {
+			return cloneFeed(feed), nil
+		}
+	}
+	return nil, repository.ErrFeedNotFound
+}
+
+// GetFeeds retrieves every feed belonging to an owner
+func (r *CalendarFeedRepository) GetFeeds(owner string) ([]models.CalendarFeed, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	feeds := []models.CalendarFeed{}
+	for _, feed := range r.db.feeds {
+		if feed.Owner == owner {
+			feeds = append(feeds, *cloneFeed(feed))
+		}
+	}
+	slices.SortFunc(feeds, func(a, b models.CalendarFeed) int { return a.ID - b.ID })
+	return feeds, nil
+}
+
+// DeleteFeed revokes one of an owner's feeds
+func (r *CalendarFeedRepository) DeleteFeed(id int, owner string) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	feed, ok := r.db.feeds[id]
+	if !ok || feed.Owner != owner {
+		return repository.ErrFeedNotFound
+	}
+	delete(r.db.feeds, id)
+	return nil
+}
+
+func cloneFeed(feed models.CalendarFeed) *models.CalendarFeed {
+	feed.ListID = cloneID(feed.ListID)
+	return &feed
+}
+
+func cloneID(id *int) *int {
+	if id == nil {
+		return nil
+	}
+	clone := *id
+	return &clone
+}
@@ -0,0 +1,55 @@
+// memory package provides repositories that keep everything in memory, for running the
+// backend without a database. Nothing is saved when the process exits.
+package memory
+
+import (
+	"sync"
+	"time"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+)
+
+// DB holds the rows of every in-memory repository. The repositories created over
+// the same DB see each other's changes, so deleting a list also deletes its items.
+// It is safe for concurrent use.
+type DB struct {
+	mu sync.RWMutex
+
+	lists      map[int]models.List // without their items
+	items      map[int]models.Item
+	revisions  []models.Revision
+	activity   []models.Activity
+	smartLists map[int]models.SmartList
+	feeds      map[int]models.CalendarFeed
+
+	// lastID holds the last ID given out for each table. IDs are never reused, like SERIAL.
+	lastID map[string]int
+}
+
+// NewDB creates an empty DB
+func NewDB() *DB {
+	return &DB{
+		lists:      make(map[int]models.List),
+		items:      make(map[int]models.Item),
+		smartLists: make(map[int]models.SmartList),
+		feeds:      make(map[int]models.CalendarFeed),
+		lastID:     make(map[string]int),
+	}
+}
+
+// nextID returns a new ID for a row of table. It must be called with the lock held.
+func (db *DB) nextID(table string) int {
+	db.lastID[table]++
+	return db.lastID[table]
+}
+
+// now returns the time to store as created_at or updated_at, in UTC like the
+// timestamps read back from Postgres
+func now() time.Time {
+	return time.Now().UTC()
+}
+
+// date drops the time of day from t, as storing it in a DATE column does
+func date(t time.Time) time.Time {
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC)
+}
@@ -0,0 +1,64 @@
+package memory
+
+import (
+	"errors"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+)
+
+// ImportRepository starts bulk imports of lists into memory
+type ImportRepository struct {
+	db *DB
+}
+
+// NewImportRepository creates a new ImportRepository
+func NewImportRepository(db *DB) *ImportRepository {
+	return &ImportRepository{db: db}
+}
+
+// BeginListImport starts an import. Items are held back until Finish adds them
+// along with the list, so a failed import leaves nothing behind.
+func (r *ImportRepository) BeginListImport() (repository.ListImporter, error) {
+	return &listImporter{db: r.db}, nil
+}
+
+type listImporter struct {
+	db    *DB
+	items []models.Item
+	done  bool
+}
+
+// AddItem adds an item to the imported list, keeping its timestamps when it has them
+func (i *listImporter) AddItem(item models.Item) error {
+	if i.done {
+		return errors.New("could not import item: import already finished")
+	}
+	i.items = append(i.items, item)
+	return nil
+}
+
+// Finish stores the list, with its title and timestamps, and its items
+func (i *listImporter) Finish(list models.List) (*models.List, error) {
+	if i.done {
+		return nil, errors.New("could not commit import: import already finished")
+	}
+	i.done = true
+
+	i.db.mu.Lock()
+	defer i.db.mu.Unlock()
+
+	imported := i.db.insertList(list)
+	for _, item := range i.items {
+		item.ListID = int(imported.ID)
+		i.db.insertItem(item)
+	}
+	return &imported, nil
+}
+
+// Abort drops the import. It does nothing once Finish has stored it.
+func (i *listImporter) Abort() error {
+	i.done = true
+	i.items = nil
+	return nil
+}
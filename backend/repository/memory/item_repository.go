@@ -0,0 +1,197 @@
+package memory
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+	"time"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+)
+
+// ItemRepository handles CRUD operations for items kept in memory
+type ItemRepository struct {
+	db *DB
+}
+
+// NewItemRepository creates a new ItemRepository
+func NewItemRepository(db *DB) *ItemRepository {
+	return &ItemRepository{db: db}
+}
+
+// GetAll retrieves all existing items, ordered by ID
+func (r *ItemRepository) GetAll() ([]models.Item, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	var items []models.Item
+	for _, item := range r.db.items {
+		items = append(items, item)
+	}
+	sortByID(items)
+	return items, nil
+}
+
+// GetByID retrieves a single item by its ID, whole whatever fields
+func (r *ItemRepository) GetByID(id int, fields models.Fields) (*models.Item, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	item, ok := r.db.items[id]
+	if !ok {
+		return nil, repository.ErrNotFound
+	}
+	return &item, nil
+}
+
+// GetFiltered retrieves the items matching a filter, ordered by date. Items are held in
+// memory already, so they are returned whole whatever filter.Fields.
+func (r *ItemRepository) GetFiltered(filter models.ItemFilter) ([]models.Item, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	items := []models.Item{}
+	for _, item := range r.db.items {
+		if matches(filter, item) {
+			items = append(items, item)
+		}
+	}
+
+	slices.SortFunc(items, func(a, b models.Item) int {
+		if c := a.Date.Compare(b.Date); c != 0 {
+			return c
+		}
+		return a.ID - b.ID
+	})
+	return items, nil
+}
+
+// StreamFiltered calls fn with each item matching a filter, in repository.StreamOrder.
+// The items are copied out first, so fn runs without holding the lock.
+func (r *ItemRepository) StreamFiltered(filter models.ItemFilter, fn func(item models.Item) error) error {
+	items, err := r.GetFiltered(filter)
+	if err != nil {
+		return err
+	}
+	if filter.IsEmpty() {
+		sortByID(items)
+	}
+
+	for _, item := range items {
+		if err := fn(item); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// matches reports whether item passes every condition of filter
+func matches(filter models.ItemFilter, item models.Item) bool {
+	if len(filter.ListIDs) > 0 && !slices.Contains(filter.ListIDs, item.ListID) {
+		return false
+	}
+	if !filter.From.IsZero() && item.Date.Before(filter.From) {
+		return false
+	}
+	if !filter.To.IsZero() && item.Date.After(filter.To) {
+		return false
+	}
+	if filter.Completed != nil && item.Completed != *filter.Completed {
+		return false
+	}
+	if filter.Query != "" && !containsFold(item.Title, filter.Query) && !containsFold(item.Content, filter.Query) {
+		return false
+	}
+	return true
+}
+
+func containsFold(s, substr string) bool {
+	return strings.Contains(strings.ToLower(s), strings.ToLower(substr))
+}
+
+// DeleteItemByID deletes an item by ID
+func (r *ItemRepository) DeleteItemByID(id int) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if _, ok := r.db.items[id]; !ok {
+		return fmt.Errorf("no item found with id %d", id)
+	}
+	delete(r.db.items, id)
+	return nil
+}
+
+// CreateItem creates a new item with title, date, and content
+func (r *ItemRepository) CreateItem(title string, date time.Time, content string, listID int) (*models.Item, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if _, ok := r.db.lists[listID]; !ok {
+		return nil, fmt.Errorf("could not obtain new id: %w", repository.ErrListNotFound)
+	}
+
+	item := r.db.insertItem(models.Item{Title: title, Date: date, Content: content, ListID: listID})
+	return &models.Item{ID: item.ID}, nil
+}
+
+// insertItem stores a new item, filling in its ID and any missing timestamps.
+// It must be called with the lock held.
+func (db *DB) insertItem(item models.Item) models.Item {
+	item.ID = db.nextID("items")
+	item.Date = date(item.Date)
+	if item.CreatedAt.IsZero() {
+		item.CreatedAt = now()
+	}
+	if item.UpdatedAt.IsZero() {
+		item.UpdatedAt = now()
+	}
+	db.items[item.ID] = item
+	return item
+}
+
+// ChangeItem makes every change of an update to an item at once, returning
+// repository.ErrListNotFound, and changing nothing, when moving it to a missing list. The
+// revision, unless nil, is stored along with the change.
+func (r *ItemRepository) ChangeItem(id int, changes models.ItemChanges, revision *models.Revision) error {
+	return r.update(id, func(item *models.Item) error {
+		if changes.ListID != nil {
+			if _, ok := r.db.lists[*changes.ListID]; !ok {
+				return repository.ErrListNotFound
+			}
+			item.ListID = *changes.ListID
+		}
+		if changes.Completed != nil {
+			item.Completed = *changes.Completed
+		}
+		item.Title, item.Date, item.Content = changes.Title, changes.Date, changes.Content
+		if revision != nil {
+			r.db.addRevision(revision)
+		}
+		return nil
+	})
+}
+
+// update applies change to an item and bumps its updated_at, unless change fails
+func (r *ItemRepository) update(id int, change func(item *models.Item) error) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	item, ok := r.db.items[id]
+	if !ok {
+		return fmt.Errorf("no item found with id %d", id)
+	}
+
+	if err := change(&item); err != nil {
+		return err
+	}
+	item.Date = date(item.Date)
+	item.UpdatedAt = now()
+	r.db.items[id] = item
+	return nil
+}
+
+// sortByID orders items the way they were created
+func sortByID(items []models.Item) {
+	slices.SortFunc(items, func(a, b models.Item) int { return a.ID - b.ID })
+}
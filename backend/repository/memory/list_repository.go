@@ -0,0 +1,133 @@
+package memory
+
+import (
+	"slices"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+)
+
+// ListRepository handles CRUD operations for lists kept in memory
+type ListRepository struct {
+	db *DB
+}
+
+// NewListRepository creates a new ListRepository
+func NewListRepository(db *DB) *ListRepository {
+	return &ListRepository{db: db}
+}
+
+// CreateList creates a new list
+func (r *ListRepository) CreateList(title string) (*models.List, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	list := r.db.insertList(models.List{Title: title})
+	return &list, nil
+}
+
+// insertList stores a new list, filling in its ID and any missing timestamps.
+// It must be called with the lock held.
+func (db *DB) insertList(list models.List) models.List {
+	list.ID = int64(db.nextID("lists"))
+	list.Items = nil
+	if list.CreatedAt.IsZero() {
+		list.CreatedAt = now()
+	}
+	if list.UpdatedAt.IsZero() {
+		list.UpdatedAt = now()
+	}
+	db.lists[int(list.ID)] = list
+	return list
+}
+
+// GetList retrieves a list by ID with its items, ordered by ID. Lists are held in memory
+// already, so they are returned whole whatever fields.
+func (r *ListRepository) GetList(id int, fields models.Fields) (*models.List, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	list, ok := r.db.lists[id]
+	if !ok {
+		return nil, repository.ErrListNotFound
+	}
+	list.Items = r.db.itemsOf(id)
+	return &list, nil
+}
+
+// GetAllLists retrieves all lists ordered by ID, with their items and item counts if asked,
+// whatever fields
+func (r *ListRepository) GetAllLists(include models.ListInclude, fields models.Fields) ([]models.List, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	lists := []models.List{}
+	for _, list := range r.db.lists {
+		if include.Items || include.Counts {
+			items := r.db.itemsOf(int(list.ID))
+			if include.Items {
+				list.Items = items
+			}
+			if include.Counts {
+				list.Counts = models.CountItems(items)
+			}
+		}
+		lists = append(lists, list)
+	}
+	slices.SortFunc(lists, func(a, b models.List) int { return int(a.ID - b.ID) })
+	return lists, nil
+}
+
+// itemsOf returns the items of a list ordered by ID. It must be called with the lock held.
+func (db *DB) itemsOf(listID int) []models.Item {
+	var items []models.Item
+	for _, item := range db.items {
+		if item.ListID == listID {
+			items = append(items, item)
+		}
+	}
+	sortByID(items)
+	return items
+}
+
+// UpdateTitle updates the title of a list, storing the revision unless it is nil
+func (r *ListRepository) UpdateTitle(id int, title string, revision *models.Revision) (*models.List, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	list, ok := r.db.lists[id]
+	if !ok {
+		return nil, repository.ErrListNotFound
+	}
+
+	list.Title = title
+	list.UpdatedAt = now()
+	r.db.lists[id] = list
+	if revision != nil {
+		r.db.addRevision(revision)
+	}
+	return &list, nil
+}
+
+// DeleteList deletes a list along with its items and calendar feeds
+func (r *ListRepository) DeleteList(id int) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if _, ok := r.db.lists[id]; !ok {
+		return repository.ErrListNotFound
+	}
+
+	delete(r.db.lists, id)
+	for itemID, item := range r.db.items {
+		if item.ListID == id {
+			delete(r.db.items, itemID)
+		}
+	}
+	for feedID, feed := range r.db.feeds {
+		if feed.ListID != nil && *feed.ListID == id {
+			delete(r.db.feeds, feedID)
+		}
+	}
+	return nil
+}
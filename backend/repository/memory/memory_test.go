@@ -0,0 +1,24 @@
+package memory_test
+
+import (
+	"testing"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/repository/memory"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository/repositorytest"
+)
+
+func TestConformance(t *testing.T) {
+	repositorytest.Run(t, func(t *testing.T) repositorytest.Repositories {
+		db := memory.NewDB()
+		return repositorytest.Repositories{
+			Items:      memory.NewItemRepository(db),
+			Lists:      memory.NewListRepository(db),
+			Revisions:  memory.NewRevisionRepository(db),
+			Activity:   memory.NewActivityRepository(db),
+			SmartLists: memory.NewSmartListRepository(db),
+			Feeds:      memory.NewCalendarFeedRepository(db),
+			Imports:    memory.NewImportRepository(db),
+			Search:     memory.NewSearchRepository(db),
+		}
+	})
+}
@@ -0,0 +1,82 @@
+package memory
+
+import (
+	"maps"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+)
+
+// RevisionRepository stores the change history of items and lists in memory
+type RevisionRepository struct {
+	db *DB
+}
+
+// NewRevisionRepository creates a new RevisionRepository
+func NewRevisionRepository(db *DB) *RevisionRepository {
+	return &RevisionRepository{db: db}
+}
+
+// CreateRevision stores a revision, numbering it after the latest revision of the same entity
+func (r *RevisionRepository) CreateRevision(rev *models.Revision) (*models.Revision, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	created := r.db.addRevision(rev)
+	return &created, nil
+}
+
+// addRevision stores a revision numbered after the latest revision of the same entity,
+// returning a copy. It must be called with the lock held.
+func (db *DB) addRevision(rev *models.Revision) models.Revision {
+	created := cloneRevision(*rev)
+	created.ID = db.nextID("revisions")
+	created.Revision = 1
+	for _, existing := range db.revisions {
+		if existing.EntityType == rev.EntityType && existing.EntityID == rev.EntityID && existing.Revision >= created.Revision {
+			created.Revision = existing.Revision + 1
+		}
+	}
+	created.Changes = models.DiffFields(created.Old, created.New)
+	created.CreatedAt = now()
+
+	db.revisions = append(db.revisions, created)
+	return cloneRevision(created)
+}
+
+// GetRevisions retrieves every revision of an entity, oldest first
+func (r *RevisionRepository) GetRevisions(entityType string, entityID int) ([]models.Revision, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	// revisions are appended in order, so they are already sorted by revision number
+	revisions := []models.Revision{}
+	for _, rev := range r.db.revisions {
+		if rev.EntityType == entityType && rev.EntityID == entityID {
+			revisions = append(revisions, cloneRevision(rev))
+		}
+	}
+	return revisions, nil
+}
+
+// GetRevision retrieves a single revision of an entity by its revision number
+func (r *RevisionRepository) GetRevision(entityType string, entityID int, revision int) (*models.Revision, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	for _, rev := range r.db.revisions {
+		if rev.EntityType == entityType && rev.EntityID == entityID && rev.Revision == revision {
+			rev = cloneRevision(rev)
+			return &rev, nil
+		}
+	}
+	return nil, repository.ErrRevisionNotFound
+}
+
+// cloneRevision copies a revision's maps, so callers can't change what is stored
+func cloneRevision(rev models.Revision) models.Revision {
+	rev.Old = maps.Clone(rev.Old)
+	rev.New = maps.Clone(rev.New)
+	rev.Changes = maps.Clone(rev.Changes)
+	return rev
+}
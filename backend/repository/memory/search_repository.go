@@ -0,0 +1,164 @@
+package memory
+
+import (
+	"regexp"
+	"slices"
+	"strings"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+)
+
+// Search ranking weights, matching the A and B weights of the Postgres search_vector columns
+const (
+	titleWeight   = 1.0
+	contentWeight = 0.4
+)
+
+// snippetWords is about how long a snippet is, like MaxWords of ts_headline
+const snippetWords = 20
+
+// SearchRepository searches the items and lists kept in memory
+type SearchRepository struct {
+	db *DB
+}
+
+// NewSearchRepository creates a new SearchRepository
+func NewSearchRepository(db *DB) *SearchRepository {
+	return &SearchRepository{db: db}
+}
+
+// Search finds items and lists matching the query, best matches first.
+// Terms match anywhere in a word ignoring case, without the stemming Postgres does.
+// Date filters apply to item dates, so lists are left out when one is given.
+func (r *SearchRepository) Search(query models.SearchQuery) ([]models.SearchResult, error) {
+	terms := repository.ParseSearch(query.Query)
+	if terms.IsEmpty() {
+		return []models.SearchResult{}, nil
+	}
+	highlight := highlighter(terms)
+
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	results := []models.SearchResult{}
+
+	if query.Type == "" || query.Type == models.EntityItem {
+		for _, item := range r.db.items {
+			if query.ListID != 0 && item.ListID != query.ListID ||
+				!query.From.IsZero() && item.Date.Before(query.From) ||
+				!query.To.IsZero() && item.Date.After(query.To) {
+				continue
+			}
+
+			rank, ok := score(terms, item.Title, item.Content)
+			if !ok {
+				continue
+			}
+
+			date := item.Date
+			results = append(results, models.SearchResult{
+				Type:     models.EntityItem,
+				ID:       item.ID,
+				ListID:   item.ListID,
+				Title:    item.Title,
+				Snippet:  snippet(highlight, item.Title+" "+item.Content),
+				Rank:     rank,
+				ItemDate: &date,
+			})
+		}
+	}
+
+	if (query.Type == "" || query.Type == models.EntityList) && query.From.IsZero() && query.To.IsZero() {
+		for id, list := range r.db.lists {
+			if query.ListID != 0 && id != query.ListID {
+				continue
+			}
+
+			rank, ok := score(terms, list.Title, "")
+			if !ok {
+				continue
+			}
+
+			results = append(results, models.SearchResult{
+				Type:    models.EntityList,
+				ID:      id,
+				ListID:  id,
+				Title:   list.Title,
+				Snippet: snippet(highlight, list.Title),
+				Rank:    rank,
+			})
+		}
+	}
+
+	slices.SortFunc(results, func(a, b models.SearchResult) int {
+		switch {
+		case a.Rank > b.Rank:
+			return -1
+		case a.Rank < b.Rank:
+			return 1
+		}
+		return a.ID - b.ID
+	})
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = repository.DefaultSearchLimit
+	}
+	if len(results) > limit {
+		results = results[:limit]
+	}
+	return results, nil
+}
+
+// score ranks how well a title and content match, and reports whether they match at all
+func score(terms repository.SearchTerms, title, content string) (float64, bool) {
+	title, content = strings.ToLower(title), strings.ToLower(content)
+
+	for _, term := range terms.Excluded {
+		term = strings.ToLower(term)
+		if strings.Contains(title, term) || strings.Contains(content, term) {
+			return 0, false
+		}
+	}
+
+	rank := 0.0
+	for _, alternatives := range terms.Required {
+		best := 0.0
+		for _, term := range alternatives {
+			term = strings.ToLower(term)
+			if strings.Contains(title, term) {
+				best = max(best, titleWeight)
+			} else if strings.Contains(content, term) {
+				best = max(best, contentWeight)
+			}
+		}
+		if best == 0 {
+			return 0, false
+		}
+		rank += best
+	}
+	return rank / float64(len(terms.Required)), true
+}
+
+// highlighter matches every term that can be highlighted, ignoring case
+func highlighter(terms repository.SearchTerms) *regexp.Regexp {
+	var patterns []string
+	for _, alternatives := range terms.Required {
+		for _, term := range alternatives {
+			patterns = append(patterns, regexp.QuoteMeta(term))
+		}
+	}
+	return regexp.MustCompile("(?i)" + strings.Join(patterns, "|"))
+}
+
+// snippet wraps the matched terms of text in <mark> tags, keeping about snippetWords
+// words from just before the first match
+func snippet(highlight *regexp.Regexp, text string) string {
+	words := strings.Fields(highlight.ReplaceAllString(text, repository.SnippetStart+"$0"+repository.SnippetStop))
+
+	start := slices.IndexFunc(words, func(word string) bool { return strings.Contains(word, repository.SnippetStart) })
+	start = max(0, start-snippetWords/4)
+	end := min(len(words), start+snippetWords)
+	return repository.HighlightSnippet(strings.Join(words[start:end], " "))
+}
@@ -0,0 +1,103 @@
+package memory
+
+import (
+	"slices"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+)
+
+// SmartListRepository handles CRUD operations for saved item filters kept in memory
+type SmartListRepository struct {
+	db *DB
+}
+
+// NewSmartListRepository creates a new SmartListRepository
+func NewSmartListRepository(db *DB) *SmartListRepository {
+	return &SmartListRepository{db: db}
+}
+
+// CreateSmartList saves a new filter for the given owner
+func (r *SmartListRepository) CreateSmartList(owner, title string, filter models.ItemFilterDefinition) (*models.SmartList, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	list := models.NewSmartList(owner, title, cloneFilter(filter))
+	list.ID = r.db.nextID("smart_lists")
+	list.CreatedAt = now()
+	list.UpdatedAt = list.CreatedAt
+	r.db.smartLists[list.ID] = *list
+
+	return cloneSmartList(*list), nil
+}
+
+// GetSmartList retrieves a smart list by ID
+func (r *SmartListRepository) GetSmartList(id int) (*models.SmartList, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	list, ok := r.db.smartLists[id]
+	if !ok {
+		return nil, repository.ErrSmartListNotFound
+	}
+	return cloneSmartList(list), nil
+}
+
+// GetSmartLists retrieves every smart list belonging to an owner
+func (r *SmartListRepository) GetSmartLists(owner string) ([]models.SmartList, error) {
+	r.db.mu.RLock()
+	defer r.db.mu.RUnlock()
+
+	lists := []models.SmartList{}
+	for _, list := range r.db.smartLists {
+		if list.Owner == owner {
+			lists = append(lists, *cloneSmartList(list))
+		}
+	}
+	slices.SortFunc(lists, func(a, b models.SmartList) int { return a.ID - b.ID })
+	return lists, nil
+}
+
+// UpdateSmartList replaces the title and filter of a smart list
+func (r *SmartListRepository) UpdateSmartList(id int, title string, filter models.ItemFilterDefinition) (*models.SmartList, error) {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	list, ok := r.db.smartLists[id]
+	if !ok {
+		return nil, repository.ErrSmartListNotFound
+	}
+
+	list.Title = title
+	list.Filter = cloneFilter(filter)
+	list.UpdatedAt = now()
+	r.db.smartLists[id] = list
+	return cloneSmartList(list), nil
+}
+
+// DeleteSmartList deletes a smart list. The items it matched are not touched.
+func (r *SmartListRepository) DeleteSmartList(id int) error {
+	r.db.mu.Lock()
+	defer r.db.mu.Unlock()
+
+	if _, ok := r.db.smartLists[id]; !ok {
+		return repository.ErrSmartListNotFound
+	}
+	delete(r.db.smartLists, id)
+	return nil
+}
+
+func cloneSmartList(list models.SmartList) *models.SmartList {
+	list.Filter = cloneFilter(list.Filter)
+	return &list
+}
+
+// cloneFilter copies the parts of a filter shared by reference, as saving it as JSON would
+func cloneFilter(filter models.ItemFilterDefinition) models.ItemFilterDefinition {
+	filter.ListIDs = slices.Clone(filter.ListIDs)
+	if filter.Completed != nil {
+		completed := *filter.Completed
+		filter.Completed = &completed
+	}
+	return filter
+}
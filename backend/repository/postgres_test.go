@@ -0,0 +1,120 @@
+package repository_test
+
+import (
+	"database/sql"
+	"fmt"
+	"net/url"
+	"os"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/database"
+	"github.com/jennaborowy/fullstack-Go-Docker/database/databasetest"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository/repositorytest"
+)
+
+// TestConformance runs the conformance suite against the Postgres database at
+// TEST_DATABASE_URL, see databasetest.URL. Everything is created in a throwaway schema
+// that is dropped afterwards.
+func TestConformance(t *testing.T) {
+	databaseURL := databasetest.URL(t)
+
+	// stream a few items over several pages
+	pageSize := *repository.StreamPageSize
+	*repository.StreamPageSize = 2
+	t.Cleanup(func() { *repository.StreamPageSize = pageSize })
+
+	db := connectSchema(t, databaseURL)
+	tables := schemaTables(t, db)
+
+	repositorytest.Run(t, func(t *testing.T) repositorytest.Repositories {
+		// every test starts from empty tables, with IDs counting from 1 again
+		if _, err := db.Exec("TRUNCATE " + strings.Join(tables, ", ") + " RESTART IDENTITY CASCADE"); err != nil {
+			t.Fatalf("failed to empty the tables: %v", err)
+		}
+
+		return repositorytest.Repositories{
+			Items:      repository.NewItemRepository(db),
+			Lists:      repository.NewListRepository(db),
+			Revisions:  repository.NewRevisionRepository(db),
+			Activity:   repository.NewActivityRepository(db),
+			SmartLists: repository.NewSmartListRepository(db),
+			Feeds:      repository.NewCalendarFeedRepository(db),
+			Imports:    repository.NewImportRepository(db),
+			Search:     repository.NewSearchRepository(db),
+		}
+	})
+}
+
+// connectSchema creates a new schema holding the full database schema, and returns
+// a connection using it. The schema is dropped when the test ends.
+func connectSchema(t testing.TB, databaseURL string) *sql.DB {
+	t.Helper()
+	admin, err := database.Connect(databaseURL, database.Pool{})
+	if err != nil {
+		t.Fatalf("failed to connect: %v", err)
+	}
+	t.Cleanup(func() { admin.Close() })
+
+	schema := fmt.Sprintf("conformance_%d", time.Now().UnixNano())
+	if _, err := admin.Exec("CREATE SCHEMA " + schema); err != nil {
+		t.Fatalf("failed to create schema: %v", err)
+	}
+	t.Cleanup(func() {
+		if _, err := admin.Exec("DROP SCHEMA " + schema + " CASCADE"); err != nil {
+			t.Errorf("failed to drop schema %s: %v", schema, err)
+		}
+	})
+
+	u, err := url.Parse(databaseURL)
+	if err != nil {
+		t.Fatalf("TEST_DATABASE_URL must be a URL: %v", err)
+	}
+	query := u.Query()
+	query.Set("search_path", schema)
+	u.RawQuery = query.Encode()
+
+	db, err := database.Connect(u.String(), database.Pool{})
+	if err != nil {
+		t.Fatalf("failed to connect to schema %s: %v", schema, err)
+	}
+	t.Cleanup(func() { db.Close() })
+
+	// init.sql is run by the Postgres image rather than the backend, so it isn't embedded
+	initSQL, err := os.ReadFile("../database/init.sql")
+	if err != nil {
+		t.Fatalf("failed to read init.sql: %v", err)
+	}
+	if _, err := db.Exec(string(initSQL)); err != nil {
+		t.Fatalf("failed to run init.sql: %v", err)
+	}
+	if err := database.Migrate(db); err != nil {
+		t.Fatalf("failed to migrate: %v", err)
+	}
+	return db
+}
+
+// schemaTables lists the tables of the current schema, apart from schema_migrations
+func schemaTables(t *testing.T, db *sql.DB) []string {
+	t.Helper()
+	rows, err := db.Query("SELECT tablename FROM pg_tables WHERE schemaname = current_schema() AND tablename <> 'schema_migrations'")
+	if err != nil {
+		t.Fatalf("failed to list tables: %v", err)
+	}
+	defer rows.Close()
+
+	var tables []string
+	for rows.Next() {
+		var table string
+		if err := rows.Scan(&table); err != nil {
+			t.Fatalf("failed to scan table: %v", err)
+		}
+		tables = append(tables, table)
+	}
+	if err := rows.Err(); err != nil {
+		t.Fatalf("failed to list tables: %v", err)
+	}
+	return tables
+}
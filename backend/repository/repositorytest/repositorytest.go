@@ -0,0 +1,1211 @@
+// repositorytest package is the conformance suite the repositories of every storage backend
+// must pass, so that switching backends doesn't change how the API behaves
+package repositorytest
+
+import (
+	"errors"
+	"fmt"
+	"slices"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+)
+
+// Repositories are the repositories of the backend under test, sharing one store.
+// The tests of a repository left nil are skipped.
+type Repositories struct {
+	Items      repository.ItemRepositoryInterface
+	Lists      repository.ListRepositoryInterface
+	Revisions  repository.RevisionRepositoryInterface
+	Activity   repository.ActivityRepositoryInterface
+	SmartLists repository.SmartListRepositoryInterface
+	Feeds      repository.CalendarFeedRepositoryInterface
+	Imports    repository.ImportRepositoryInterface
+	Search     repository.SearchRepositoryInterface
+}
+
+// test is a single conformance test and the repositories it needs
+type test struct {
+	name  string
+	needs func(r Repositories) bool
+	run   func(t *testing.T, r Repositories)
+}
+
+// Run runs the suite. open is called for every test, and must return repositories over an empty store.
+func Run(t *testing.T, open func(t *testing.T) Repositories) {
+	var tests []test
+	tests = append(tests, itemTests...)
+	tests = append(tests, listTests...)
+	tests = append(tests, revisionTests...)
+	tests = append(tests, activityTests...)
+	tests = append(tests, smartListTests...)
+	tests = append(tests, feedTests...)
+	tests = append(tests, importTests...)
+	tests = append(tests, searchTests...)
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			r := open(t)
+			if !tt.needs(r) {
+				t.Skip("not supported by this backend")
+			}
+			tt.run(t, r)
+		})
+	}
+}
+
+func withItems(r Repositories) bool { return r.Items != nil && r.Lists != nil }
+
+var day = time.Date(2025, 10, 10, 0, 0, 0, 0, time.UTC)
+
+func mustCreateList(t *testing.T, r Repositories, title string) int {
+	t.Helper()
+	list, err := r.Lists.CreateList(title)
+	if err != nil {
+		t.Fatalf("CreateList(%q): %v", title, err)
+	}
+	return int(list.ID)
+}
+
+func mustCreateItem(t *testing.T, r Repositories, title string, date time.Time, content string, listID int) int {
+	t.Helper()
+	item, err := r.Items.CreateItem(title, date, content, listID)
+	if err != nil {
+		t.Fatalf("CreateItem(%q): %v", title, err)
+	}
+	return item.ID
+}
+
+func mustGetItem(t *testing.T, r Repositories, id int) *models.Item {
+	t.Helper()
+	item, err := r.Items.GetByID(id, nil)
+	if err != nil {
+		t.Fatalf("GetByID(%d): %v", id, err)
+	}
+	return item
+}
+
+// mustComplete marks an item completed, keeping the rest of it as it is
+func mustComplete(t *testing.T, r Repositories, id int) {
+	t.Helper()
+	item := mustGetItem(t, r, id)
+	completed := true
+	changes := models.ItemChanges{Title: item.Title, Content: item.Content, Date: item.Date, Completed: &completed}
+	if err := r.Items.ChangeItem(id, changes, nil); err != nil {
+		t.Fatalf("ChangeItem(%d): %v", id, err)
+	}
+}
+
+// tick waits long enough for the next change to get a later timestamp, even on a
+// backend storing them to the millisecond
+func tick() {
+	time.Sleep(2 * time.Millisecond)
+}
+
+func dateOf(t time.Time) string {
+	return t.Format("2006-01-02")
+}
+
+func titles(items []models.Item) []string {
+	result := make([]string, len(items))
+	for i, item := range items {
+		result[i] = item.Title
+	}
+	return result
+}
+
+func checkErrorIs(t *testing.T, what string, err, target error) {
+	t.Helper()
+	if !errors.Is(err, target) {
+		t.Errorf("%s: expected %v, got %v", what, target, err)
+	}
+}
+
+func checkError(t *testing.T, what string, err error) {
+	t.Helper()
+	if err == nil {
+		t.Errorf("%s: expected an error", what)
+	}
+}
+
+var itemTests = []test{
+	{
+		name:  "items/create and get",
+		needs: withItems,
+		run: func(t *testing.T, r Repositories) {
+			listID := mustCreateList(t, r, "Groceries")
+			id := mustCreateItem(t, r, "Milk", day, "2%", listID)
+			if id == 0 {
+				t.Fatal("expected the new item's ID")
+			}
+
+			item := mustGetItem(t, r, id)
+			if item.ID != id || item.Title != "Milk" || item.Content != "2%" || dateOf(item.Date) != "2025-10-10" ||
+				item.ListID != listID || item.Completed {
+				t.Errorf("unexpected item %+v", item)
+			}
+			if item.CreatedAt.IsZero() || item.UpdatedAt.IsZero() {
+				t.Errorf("expected timestamps, got %+v", item)
+			}
+		},
+	},
+	{
+		name:  "items/get missing",
+		needs: withItems,
+		run: func(t *testing.T, r Repositories) {
+			_, err := r.Items.GetByID(999, nil)
+			checkErrorIs(t, "GetByID", err, repository.ErrNotFound)
+		},
+	},
+	{
+		name:  "items/create in missing list",
+		needs: withItems,
+		run: func(t *testing.T, r Repositories) {
+			_, err := r.Items.CreateItem("Milk", day, "", 999)
+			checkError(t, "CreateItem", err)
+		},
+	},
+	{
+		name:  "items/change title, content and date",
+		needs: withItems,
+		run: func(t *testing.T, r Repositories) {
+			listID := mustCreateList(t, r, "Groceries")
+			id := mustCreateItem(t, r, "Milk", day, "", listID)
+
+			if err := r.Items.ChangeItem(id, models.ItemChanges{Title: "Oat milk", Content: "1l", Date: day.AddDate(0, 0, 1)}, nil); err != nil {
+				t.Fatalf("ChangeItem: %v", err)
+			}
+			item := mustGetItem(t, r, id)
+			if item.Title != "Oat milk" || item.Content != "1l" || dateOf(item.Date) != "2025-10-11" {
+				t.Errorf("unexpected item %+v", item)
+			}
+
+			checkError(t, "ChangeItem of a missing item", r.Items.ChangeItem(999, models.ItemChanges{Title: "Milk", Date: day}, nil))
+		},
+	},
+	{
+		name:  "items/change completed",
+		needs: withItems,
+		run: func(t *testing.T, r Repositories) {
+			listID := mustCreateList(t, r, "Groceries")
+			id := mustCreateItem(t, r, "Milk", day, "", listID)
+
+			yes, no := true, false
+			if err := r.Items.ChangeItem(id, models.ItemChanges{Title: "Milk", Date: day, Completed: &yes}, nil); err != nil {
+				t.Fatalf("ChangeItem: %v", err)
+			}
+			if item := mustGetItem(t, r, id); !item.Completed {
+				t.Error("expected the item to be completed")
+			}
+			// leaving Completed out keeps it
+			if err := r.Items.ChangeItem(id, models.ItemChanges{Title: "Milk", Date: day}, nil); err != nil {
+				t.Fatalf("ChangeItem: %v", err)
+			}
+			if item := mustGetItem(t, r, id); !item.Completed {
+				t.Error("expected the item to stay completed")
+			}
+			if err := r.Items.ChangeItem(id, models.ItemChanges{Title: "Milk", Date: day, Completed: &no}, nil); err != nil {
+				t.Fatalf("ChangeItem: %v", err)
+			}
+			if item := mustGetItem(t, r, id); item.Completed {
+				t.Error("expected the item to be reopened")
+			}
+		},
+	},
+	{
+		name:  "items/change list",
+		needs: withItems,
+		run: func(t *testing.T, r Repositories) {
+			from := mustCreateList(t, r, "Groceries")
+			to := mustCreateList(t, r, "Chores")
+			id := mustCreateItem(t, r, "Milk", day, "", from)
+
+			if err := r.Items.ChangeItem(id, models.ItemChanges{Title: "Milk", Date: day, ListID: &to}, nil); err != nil {
+				t.Fatalf("ChangeItem: %v", err)
+			}
+			if item := mustGetItem(t, r, id); item.ListID != to {
+				t.Errorf("expected the item on list %d, got %d", to, item.ListID)
+			}
+
+			missing := 999
+			checkErrorIs(t, "ChangeItem to a missing list",
+				r.Items.ChangeItem(id, models.ItemChanges{Title: "Milk", Date: day, ListID: &missing}, nil), repository.ErrListNotFound)
+			if item := mustGetItem(t, r, id); item.ListID != to {
+				t.Errorf("expected a failed move to leave the item on list %d, got %d", to, item.ListID)
+			}
+			checkError(t, "ChangeItem of a missing item", r.Items.ChangeItem(999, models.ItemChanges{Title: "Milk", Date: day, ListID: &to}, nil))
+		},
+	},
+	{
+		name:  "items/change",
+		needs: withItems,
+		run: func(t *testing.T, r Repositories) {
+			from := mustCreateList(t, r, "Groceries")
+			to := mustCreateList(t, r, "Chores")
+			id := mustCreateItem(t, r, "Milk", day, "", from)
+
+			// without Completed or ListID, only the title, content and date change
+			if err := r.Items.ChangeItem(id, models.ItemChanges{Title: "Oat milk", Content: "1l", Date: day.AddDate(0, 0, 1)}, nil); err != nil {
+				t.Fatalf("ChangeItem: %v", err)
+			}
+			item := mustGetItem(t, r, id)
+			if item.Title != "Oat milk" || item.Content != "1l" || dateOf(item.Date) != "2025-10-11" || item.Completed || item.ListID != from {
+				t.Errorf("unexpected item %+v", item)
+			}
+
+			completed := true
+			if err := r.Items.ChangeItem(id, models.ItemChanges{Title: "Oat milk", Date: day, Completed: &completed, ListID: &to}, nil); err != nil {
+				t.Fatalf("ChangeItem: %v", err)
+			}
+			item = mustGetItem(t, r, id)
+			if !item.Completed || item.ListID != to || item.Content != "" {
+				t.Errorf("expected the item completed on list %d, got %+v", to, item)
+			}
+
+			// moving to a missing list fails as a whole
+			missing := 999
+			checkErrorIs(t, "ChangeItem to a missing list",
+				r.Items.ChangeItem(id, models.ItemChanges{Title: "Soy milk", Date: day, ListID: &missing}, nil), repository.ErrListNotFound)
+			if unchanged := mustGetItem(t, r, id); *unchanged != *item {
+				t.Errorf("expected a failed move to change nothing, got %+v, was %+v", unchanged, item)
+			}
+			checkError(t, "ChangeItem of a missing item", r.Items.ChangeItem(999, models.ItemChanges{Title: "Milk", Date: day}, nil))
+		},
+	},
+	{
+		name:  "items/delete",
+		needs: withItems,
+		run: func(t *testing.T, r Repositories) {
+			listID := mustCreateList(t, r, "Groceries")
+			id := mustCreateItem(t, r, "Milk", day, "", listID)
+
+			if err := r.Items.DeleteItemByID(id); err != nil {
+				t.Fatalf("DeleteItemByID: %v", err)
+			}
+			_, err := r.Items.GetByID(id, nil)
+			checkErrorIs(t, "GetByID of a deleted item", err, repository.ErrNotFound)
+			checkError(t, "DeleteItemByID of a deleted item", r.Items.DeleteItemByID(id))
+		},
+	},
+	{
+		name:  "items/get all, ordered by ID",
+		needs: withItems,
+		run: func(t *testing.T, r Repositories) {
+			groceries := mustCreateList(t, r, "Groceries")
+			chores := mustCreateList(t, r, "Chores")
+			ids := []int{
+				mustCreateItem(t, r, "Milk", day.AddDate(0, 0, 1), "", groceries),
+				mustCreateItem(t, r, "Eggs", day, "", groceries),
+				mustCreateItem(t, r, "Laundry", day, "", chores),
+			}
+			// a change doesn't move an item
+			if err := r.Items.ChangeItem(ids[0], models.ItemChanges{Title: "Oat milk", Date: day}, nil); err != nil {
+				t.Fatalf("ChangeItem: %v", err)
+			}
+
+			items, err := r.Items.GetAll()
+			if err != nil {
+				t.Fatalf("GetAll: %v", err)
+			}
+			var got []int
+			for _, item := range items {
+				got = append(got, item.ID)
+			}
+			if !slices.Equal(got, ids) {
+				t.Errorf("expected items %v, got %v", ids, got)
+			}
+		},
+	},
+	{
+		name:  "items/timestamps",
+		needs: withItems,
+		run: func(t *testing.T, r Repositories) {
+			listID := mustCreateList(t, r, "Groceries")
+			other := mustCreateList(t, r, "Chores")
+			id := mustCreateItem(t, r, "Milk", day, "", listID)
+			created := mustGetItem(t, r, id)
+			if created.UpdatedAt.Before(created.CreatedAt) {
+				t.Errorf("expected updated_at %v not to be before created_at %v", created.UpdatedAt, created.CreatedAt)
+			}
+
+			completed := true
+			changes := []struct {
+				name   string
+				change func() error
+			}{
+				{"title", func() error { return r.Items.ChangeItem(id, models.ItemChanges{Title: "Oat milk", Date: day}, nil) }},
+				{"completed", func() error {
+					return r.Items.ChangeItem(id, models.ItemChanges{Title: "Oat milk", Date: day, Completed: &completed}, nil)
+				}},
+				{"list", func() error {
+					return r.Items.ChangeItem(id, models.ItemChanges{Title: "Oat milk", Date: day, ListID: &other}, nil)
+				}},
+			}
+			previous := created
+			for _, c := range changes {
+				tick()
+				if err := c.change(); err != nil {
+					t.Fatalf("ChangeItem of the %s: %v", c.name, err)
+				}
+				item := mustGetItem(t, r, id)
+				if !item.CreatedAt.Equal(created.CreatedAt) {
+					t.Errorf("%s: expected created_at to stay %v, got %v", c.name, created.CreatedAt, item.CreatedAt)
+				}
+				if !item.UpdatedAt.After(previous.UpdatedAt) {
+					t.Errorf("%s: expected updated_at to move on from %v, got %v", c.name, previous.UpdatedAt, item.UpdatedAt)
+				}
+				previous = item
+			}
+
+			// a failed change leaves the item alone
+			missing := 999
+			_ = r.Items.ChangeItem(id, models.ItemChanges{Title: "Soy milk", Date: day, ListID: &missing}, nil)
+			if item := mustGetItem(t, r, id); !item.UpdatedAt.Equal(previous.UpdatedAt) {
+				t.Errorf("expected a failed move to keep updated_at %v, got %v", previous.UpdatedAt, item.UpdatedAt)
+			}
+		},
+	},
+	{
+		name:  "items/filter",
+		needs: withItems,
+		run: func(t *testing.T, r Repositories) {
+			groceries := mustCreateList(t, r, "Groceries")
+			chores := mustCreateList(t, r, "Chores")
+			mustCreateItem(t, r, "Eggs", day.AddDate(0, 0, 2), "a dozen", groceries)
+			milk := mustCreateItem(t, r, "Milk", day, "semi-skimmed", groceries)
+			mustCreateItem(t, r, "Bread", day, "", groceries)
+			mustCreateItem(t, r, "Laundry", day.AddDate(0, 0, 1), "", chores)
+			mustComplete(t, r, milk)
+
+			yes, no := true, false
+			tests := []struct {
+				name     string
+				filter   models.ItemFilter
+				expected []string
+			}{
+				{"by list, ordered by date then ID", models.ItemFilter{ListIDs: []int{groceries}}, []string{"Milk", "Bread", "Eggs"}},
+				{"by several lists", models.ItemFilter{ListIDs: []int{groceries, chores}}, []string{"Milk", "Bread", "Laundry", "Eggs"}},
+				{"from is inclusive", models.ItemFilter{From: day.AddDate(0, 0, 1)}, []string{"Laundry", "Eggs"}},
+				{"to is inclusive", models.ItemFilter{To: day.AddDate(0, 0, 1)}, []string{"Milk", "Bread", "Laundry"}},
+				{"a single day", models.ItemFilter{From: day, To: day}, []string{"Milk", "Bread"}},
+				{"completed", models.ItemFilter{Completed: &yes}, []string{"Milk"}},
+				{"not completed", models.ItemFilter{Completed: &no, ListIDs: []int{groceries}}, []string{"Bread", "Eggs"}},
+				{"query matches titles ignoring case", models.ItemFilter{Query: "LAUN"}, []string{"Laundry"}},
+				{"query matches content", models.ItemFilter{Query: "dozen"}, []string{"Eggs"}},
+				{"nothing matches", models.ItemFilter{Query: "cheese"}, []string{}},
+			}
+
+			for _, tt := range tests {
+				items, err := r.Items.GetFiltered(tt.filter)
+				if err != nil {
+					t.Fatalf("%s: GetFiltered: %v", tt.name, err)
+				}
+				if items == nil {
+					t.Errorf("%s: expected an empty slice rather than nil", tt.name)
+				}
+				if got := titles(items); !slices.Equal(got, tt.expected) {
+					t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, got)
+				}
+			}
+		},
+	},
+	{
+		name:  "items/stream",
+		needs: withItems,
+		run: func(t *testing.T, r Repositories) {
+			groceries := mustCreateList(t, r, "Groceries")
+			chores := mustCreateList(t, r, "Chores")
+			mustCreateItem(t, r, "Eggs", day.AddDate(0, 0, 2), "", groceries)
+			mustCreateItem(t, r, "Milk", day, "", groceries)
+			mustCreateItem(t, r, "Laundry", day.AddDate(0, 0, 1), "", chores)
+			mustCreateItem(t, r, "Bread", day, "", groceries)
+			mustCreateItem(t, r, "Butter", day, "", groceries)
+
+			stream := func(filter models.ItemFilter) []models.Item {
+				t.Helper()
+				var items []models.Item
+				err := r.Items.StreamFiltered(filter, func(item models.Item) error {
+					items = append(items, item)
+					return nil
+				})
+				if err != nil {
+					t.Fatalf("StreamFiltered: %v", err)
+				}
+				return items
+			}
+
+			// every item comes in ID order, like GetAll
+			if got := titles(stream(models.ItemFilter{})); !slices.Equal(got, []string{"Eggs", "Milk", "Laundry", "Bread", "Butter"}) {
+				t.Errorf("expected every item by ID, got %v", got)
+			}
+			// filtered items come in date order, like GetFiltered
+			filter := models.ItemFilter{ListIDs: []int{groceries}}
+			if got := titles(stream(filter)); !slices.Equal(got, []string{"Milk", "Bread", "Butter", "Eggs"}) {
+				t.Errorf("expected groceries by date, got %v", got)
+			}
+			// the ID is always read, other fields may be left zero
+			filter.Fields = models.Fields{"title"}
+			if items := stream(filter); len(items) != 4 || items[0].ID == 0 || items[0].Title != "Milk" {
+				t.Errorf("unexpected items %+v", items)
+			}
+
+			// an error from fn stops the stream and is returned
+			stop := errors.New("stop")
+			calls := 0
+			err := r.Items.StreamFiltered(models.ItemFilter{}, func(item models.Item) error {
+				calls++
+				if calls == 3 {
+					return stop
+				}
+				return nil
+			})
+			if !errors.Is(err, stop) || calls != 3 {
+				t.Errorf("expected the stream to stop with fn's error after 3 items, got %v after %d", err, calls)
+			}
+		},
+	},
+	{
+		name:  "items/filter reads the asked fields",
+		needs: withItems,
+		run: func(t *testing.T, r Repositories) {
+			groceries := mustCreateList(t, r, "Groceries")
+			milk := mustCreateItem(t, r, "Milk", day, "semi-skimmed", groceries)
+			mustComplete(t, r, milk)
+
+			// every item, with only some fields
+			items, err := r.Items.GetFiltered(models.ItemFilter{Fields: models.Fields{"title", "completed"}})
+			if err != nil {
+				t.Fatalf("GetFiltered: %v", err)
+			}
+			if len(items) != 1 {
+				t.Fatalf("expected 1 item, got %d", len(items))
+			}
+			// the ID is always read, other fields may be left zero
+			if item := items[0]; item.ID != milk || item.Title != "Milk" || !item.Completed {
+				t.Errorf("unexpected item %+v", item)
+			}
+		},
+	},
+	{
+		name:  "items/get reads the asked fields",
+		needs: withItems,
+		run: func(t *testing.T, r Repositories) {
+			groceries := mustCreateList(t, r, "Groceries")
+			milk := mustCreateItem(t, r, "Milk", day, "semi-skimmed", groceries)
+
+			item, err := r.Items.GetByID(milk, models.Fields{"title"})
+			if err != nil {
+				t.Fatalf("GetByID: %v", err)
+			}
+			// the ID is always read, other fields may be left zero
+			if item.ID != milk || item.Title != "Milk" {
+				t.Errorf("unexpected item %+v", item)
+			}
+			_, err = r.Items.GetByID(999, models.Fields{"title"})
+			checkErrorIs(t, "GetByID of a missing item", err, repository.ErrNotFound)
+		},
+	},
+}
+
+var listTests = []test{
+	{
+		name:  "lists/create and get",
+		needs: withItems,
+		run: func(t *testing.T, r Repositories) {
+			list, err := r.Lists.CreateList("Groceries")
+			if err != nil {
+				t.Fatalf("CreateList: %v", err)
+			}
+			if list.ID == 0 || list.Title != "Groceries" || list.CreatedAt.IsZero() || list.UpdatedAt.IsZero() {
+				t.Errorf("unexpected list %+v", list)
+			}
+
+			other := mustCreateList(t, r, "Chores")
+			mustCreateItem(t, r, "Milk", day, "", int(list.ID))
+			mustCreateItem(t, r, "Laundry", day, "", other)
+
+			got, err := r.Lists.GetList(int(list.ID), nil)
+			if err != nil {
+				t.Fatalf("GetList: %v", err)
+			}
+			if got.ID != list.ID || got.Title != "Groceries" || !slices.Equal(titles(got.Items), []string{"Milk"}) {
+				t.Errorf("unexpected list %+v", got)
+			}
+			if !got.CreatedAt.Equal(list.CreatedAt) || !got.UpdatedAt.Equal(list.UpdatedAt) {
+				t.Errorf("expected the timestamps CreateList returned, got %+v", got)
+			}
+		},
+	},
+	{
+		name:  "lists/get orders items by ID",
+		needs: withItems,
+		run: func(t *testing.T, r Repositories) {
+			id := mustCreateList(t, r, "Groceries")
+			mustCreateItem(t, r, "Milk", day.AddDate(0, 0, 2), "", id)
+			eggs := mustCreateItem(t, r, "Eggs", day, "", id)
+			mustCreateItem(t, r, "Bread", day.AddDate(0, 0, 1), "", id)
+			if err := r.Items.ChangeItem(eggs, models.ItemChanges{Title: "Eggs", Content: "a dozen", Date: day.AddDate(0, 0, 3)}, nil); err != nil {
+				t.Fatalf("ChangeItem: %v", err)
+			}
+
+			list, err := r.Lists.GetList(id, nil)
+			if err != nil {
+				t.Fatalf("GetList: %v", err)
+			}
+			if got := titles(list.Items); !slices.Equal(got, []string{"Milk", "Eggs", "Bread"}) {
+				t.Errorf("expected items in the order they were created, got %v", got)
+			}
+		},
+	},
+	{
+		name:  "lists/get missing",
+		needs: withItems,
+		run: func(t *testing.T, r Repositories) {
+			_, err := r.Lists.GetList(999, nil)
+			checkErrorIs(t, "GetList", err, repository.ErrListNotFound)
+			checkErrorIs(t, "GetList", err, repository.ErrNotFound)
+		},
+	},
+	{
+		name:  "lists/get all",
+		needs: withItems,
+		run: func(t *testing.T, r Repositories) {
+			lists, err := r.Lists.GetAllLists(models.ListInclude{}, nil)
+			if err != nil {
+				t.Fatalf("GetAllLists: %v", err)
+			}
+			if lists == nil || len(lists) != 0 {
+				t.Errorf("expected an empty slice, got %#v", lists)
+			}
+
+			groceries := mustCreateList(t, r, "Groceries")
+			mustCreateList(t, r, "Chores")
+			mustCreateItem(t, r, "Milk", day, "", groceries)
+
+			lists, err = r.Lists.GetAllLists(models.ListInclude{}, nil)
+			if err != nil {
+				t.Fatalf("GetAllLists: %v", err)
+			}
+			var got []string
+			for _, list := range lists {
+				got = append(got, list.Title)
+				if len(list.Items) != 0 {
+					t.Errorf("expected %s without its items, got %v", list.Title, list.Items)
+				}
+			}
+			if !slices.Equal(got, []string{"Groceries", "Chores"}) {
+				t.Errorf("expected lists ordered by ID, got %v", got)
+			}
+		},
+	},
+	{
+		name:  "lists/get all with items and counts",
+		needs: withItems,
+		run: func(t *testing.T, r Repositories) {
+			groceries := mustCreateList(t, r, "Groceries")
+			mustCreateList(t, r, "Empty")
+			chores := mustCreateList(t, r, "Chores")
+			mustCreateItem(t, r, "Milk", day.AddDate(0, 0, 1), "2%", groceries)
+			laundry := mustCreateItem(t, r, "Laundry", day, "", chores)
+			eggs := mustCreateItem(t, r, "Eggs", day, "", groceries)
+			mustComplete(t, r, eggs)
+
+			type summary struct {
+				title  string
+				items  []string
+				counts *models.ListCounts
+			}
+			tests := []struct {
+				name     string
+				include  models.ListInclude
+				expected []summary
+			}{
+				{"items", models.ListInclude{Items: true}, []summary{
+					{"Groceries", []string{"Milk", "Eggs"}, nil},
+					{"Empty", nil, nil},
+					{"Chores", []string{"Laundry"}, nil},
+				}},
+				{"counts", models.ListInclude{Counts: true}, []summary{
+					{"Groceries", nil, &models.ListCounts{Total: 2, Completed: 1}},
+					{"Empty", nil, &models.ListCounts{}},
+					{"Chores", nil, &models.ListCounts{Total: 1}},
+				}},
+				{"items and counts", models.ListInclude{Items: true, Counts: true}, []summary{
+					{"Groceries", []string{"Milk", "Eggs"}, &models.ListCounts{Total: 2, Completed: 1}},
+					{"Empty", nil, &models.ListCounts{}},
+					{"Chores", []string{"Laundry"}, &models.ListCounts{Total: 1}},
+				}},
+			}
+
+			for _, tt := range tests {
+				lists, err := r.Lists.GetAllLists(tt.include, nil)
+				if err != nil {
+					t.Fatalf("%s: GetAllLists: %v", tt.name, err)
+				}
+				if len(lists) != len(tt.expected) {
+					t.Fatalf("%s: expected %d lists, got %+v", tt.name, len(tt.expected), lists)
+				}
+				for i, expected := range tt.expected {
+					list := lists[i]
+					if list.Title != expected.title || !slices.Equal(titles(list.Items), expected.items) {
+						t.Errorf("%s: expected %s with %v, got %s with %v", tt.name, expected.title, expected.items, list.Title, titles(list.Items))
+					}
+					if (list.Counts == nil) != (expected.counts == nil) || list.Counts != nil && *list.Counts != *expected.counts {
+						t.Errorf("%s: expected %s to count %+v, got %+v", tt.name, expected.title, expected.counts, list.Counts)
+					}
+				}
+			}
+
+			// joined items are read in full
+			lists, err := r.Lists.GetAllLists(models.ListInclude{Items: true}, nil)
+			if err != nil {
+				t.Fatalf("GetAllLists: %v", err)
+			}
+			milk, stored := lists[0].Items[0], mustGetItem(t, r, lists[0].Items[0].ID)
+			if milk.Title != stored.Title || milk.Content != "2%" || !milk.Date.Equal(stored.Date) || milk.ListID != groceries ||
+				!milk.CreatedAt.Equal(stored.CreatedAt) || !milk.UpdatedAt.Equal(stored.UpdatedAt) {
+				t.Errorf("expected %+v, got %+v", stored, milk)
+			}
+			if lists[2].Items[0].ID != laundry {
+				t.Errorf("expected Laundry to be item %d, got %d", laundry, lists[2].Items[0].ID)
+			}
+		},
+	},
+	{
+		name:  "lists/get reads the asked fields",
+		needs: withItems,
+		run: func(t *testing.T, r Repositories) {
+			groceries := mustCreateList(t, r, "Groceries")
+			mustCreateItem(t, r, "Milk", day, "", groceries)
+			mustCreateList(t, r, "Chores")
+
+			// the ID is always read, other fields may be left zero
+			list, err := r.Lists.GetList(groceries, models.Fields{"title"})
+			if err != nil {
+				t.Fatalf("GetList: %v", err)
+			}
+			if int(list.ID) != groceries || list.Title != "Groceries" {
+				t.Errorf("unexpected list %+v", list)
+			}
+			list, err = r.Lists.GetList(groceries, models.Fields{"items"})
+			if err != nil {
+				t.Fatalf("GetList: %v", err)
+			}
+			if int(list.ID) != groceries || len(list.Items) != 1 || list.Items[0].Title != "Milk" {
+				t.Errorf("expected the list's items, got %+v", list)
+			}
+			_, err = r.Lists.GetList(999, models.Fields{"title"})
+			checkErrorIs(t, "GetList of a missing list", err, repository.ErrListNotFound)
+
+			for _, include := range []models.ListInclude{{}, {Items: true}, {Counts: true}} {
+				lists, err := r.Lists.GetAllLists(include, models.Fields{"title", "items", "counts"})
+				if err != nil {
+					t.Fatalf("GetAllLists(%+v): %v", include, err)
+				}
+				if len(lists) != 2 || int(lists[0].ID) != groceries || lists[0].Title != "Groceries" || lists[1].Title != "Chores" {
+					t.Errorf("GetAllLists(%+v): unexpected lists %+v", include, lists)
+					continue
+				}
+				if include.Items && len(lists[0].Items) != 1 {
+					t.Errorf("GetAllLists(%+v): expected the items, got %+v", include, lists[0])
+				}
+				if include.Counts && (lists[0].Counts == nil || lists[0].Counts.Total != 1) {
+					t.Errorf("GetAllLists(%+v): expected the counts, got %+v", include, lists[0])
+				}
+			}
+		},
+	},
+	{
+		name:  "lists/update title",
+		needs: withItems,
+		run: func(t *testing.T, r Repositories) {
+			created, err := r.Lists.CreateList("Groceries")
+			if err != nil {
+				t.Fatalf("CreateList: %v", err)
+			}
+			id := int(created.ID)
+			tick()
+
+			updated, err := r.Lists.UpdateTitle(id, "Shopping", nil)
+			if err != nil {
+				t.Fatalf("UpdateTitle: %v", err)
+			}
+			if int(updated.ID) != id || updated.Title != "Shopping" {
+				t.Errorf("unexpected list %+v", updated)
+			}
+			if list, err := r.Lists.GetList(id, nil); err != nil || list.Title != "Shopping" {
+				t.Errorf("expected the new title to be stored, got %+v, %v", list, err)
+			}
+			if !updated.CreatedAt.Equal(created.CreatedAt) {
+				t.Errorf("expected created_at to stay %v, got %v", created.CreatedAt, updated.CreatedAt)
+			}
+			if !updated.UpdatedAt.After(created.UpdatedAt) {
+				t.Errorf("expected updated_at to move on from %v, got %v", created.UpdatedAt, updated.UpdatedAt)
+			}
+
+			_, err = r.Lists.UpdateTitle(999, "Shopping", nil)
+			checkErrorIs(t, "UpdateTitle of a missing list", err, repository.ErrListNotFound)
+		},
+	},
+	{
+		name:  "lists/delete removes items",
+		needs: withItems,
+		run: func(t *testing.T, r Repositories) {
+			id := mustCreateList(t, r, "Groceries")
+			other := mustCreateList(t, r, "Chores")
+			milk := mustCreateItem(t, r, "Milk", day, "", id)
+			laundry := mustCreateItem(t, r, "Laundry", day, "", other)
+
+			if err := r.Lists.DeleteList(id); err != nil {
+				t.Fatalf("DeleteList: %v", err)
+			}
+			_, err := r.Lists.GetList(id, nil)
+			checkErrorIs(t, "GetList of a deleted list", err, repository.ErrListNotFound)
+			_, err = r.Items.GetByID(milk, nil)
+			checkErrorIs(t, "GetByID of an item of a deleted list", err, repository.ErrNotFound)
+			mustGetItem(t, r, laundry)
+
+			checkErrorIs(t, "DeleteList of a deleted list", r.Lists.DeleteList(id), repository.ErrListNotFound)
+		},
+	},
+}
+
+var revisionTests = []test{
+	{
+		name:  "revisions/numbered per entity",
+		needs: func(r Repositories) bool { return r.Revisions != nil },
+		run: func(t *testing.T, r Repositories) {
+			create := func(entityType string, entityID int, oldTitle, newTitle string) *models.Revision {
+				t.Helper()
+				rev, err := r.Revisions.CreateRevision(models.NewRevision(entityType, entityID, "jenna", models.ListSnapshot(oldTitle), models.ListSnapshot(newTitle)))
+				if err != nil {
+					t.Fatalf("CreateRevision: %v", err)
+				}
+				return rev
+			}
+
+			first := create(models.EntityList, 1, "a", "b")
+			second := create(models.EntityList, 1, "b", "c")
+			other := create(models.EntityItem, 1, "a", "b")
+			if first.Revision != 1 || second.Revision != 2 || other.Revision != 1 {
+				t.Errorf("expected revisions 1, 2 and 1, got %d, %d and %d", first.Revision, second.Revision, other.Revision)
+			}
+			if first.ID == 0 || first.CreatedAt.IsZero() || first.Changes["title"] != (models.FieldChange{Old: "a", New: "b"}) {
+				t.Errorf("unexpected revision %+v", first)
+			}
+
+			revisions, err := r.Revisions.GetRevisions(models.EntityList, 1)
+			if err != nil {
+				t.Fatalf("GetRevisions: %v", err)
+			}
+			if len(revisions) != 2 || revisions[0].Revision != 1 || revisions[1].Revision != 2 || revisions[1].Author != "jenna" {
+				t.Errorf("unexpected revisions %+v", revisions)
+			}
+
+			rev, err := r.Revisions.GetRevision(models.EntityList, 1, 2)
+			if err != nil {
+				t.Fatalf("GetRevision: %v", err)
+			}
+			if rev.Old["title"] != "b" || rev.New["title"] != "c" || rev.Changes["title"] != (models.FieldChange{Old: "b", New: "c"}) {
+				t.Errorf("unexpected revision %+v", rev)
+			}
+
+			_, err = r.Revisions.GetRevision(models.EntityList, 1, 3)
+			checkErrorIs(t, "GetRevision of a missing revision", err, repository.ErrRevisionNotFound)
+			if revisions, err := r.Revisions.GetRevisions(models.EntityList, 2); err != nil || revisions == nil || len(revisions) != 0 {
+				t.Errorf("expected no revisions, got %v, %v", revisions, err)
+			}
+		},
+	},
+	{
+		name:  "revisions/item edited twice",
+		needs: func(r Repositories) bool { return withItems(r) && r.Revisions != nil },
+		run: func(t *testing.T, r Repositories) {
+			listID := mustCreateList(t, r, "Groceries")
+			id := mustCreateItem(t, r, "Milk", day, "", listID)
+
+			for _, title := range []string{"Oat milk", "Soy milk"} {
+				revision := models.NewRevision(models.EntityItem, id, "jenna",
+					models.ItemSnapshot("Milk", day, ""), models.ItemSnapshot(title, day, ""))
+				if err := r.Items.ChangeItem(id, models.ItemChanges{Title: title, Date: day}, revision); err != nil {
+					t.Fatalf("ChangeItem to %q: %v", title, err)
+				}
+			}
+
+			revisions, err := r.Revisions.GetRevisions(models.EntityItem, id)
+			if err != nil {
+				t.Fatalf("GetRevisions: %v", err)
+			}
+			if len(revisions) != 2 {
+				t.Fatalf("expected 2 revisions, got %+v", revisions)
+			}
+			for i, title := range []string{"Oat milk", "Soy milk"} {
+				rev := revisions[i]
+				if rev.Revision != i+1 || rev.EntityType != models.EntityItem || rev.EntityID != id || rev.Author != "jenna" || rev.New["title"] != title {
+					t.Errorf("unexpected revision %d: %+v", i+1, rev)
+				}
+			}
+		},
+	},
+	{
+		name:  "revisions/stored with changes",
+		needs: func(r Repositories) bool { return withItems(r) && r.Revisions != nil },
+		run: func(t *testing.T, r Repositories) {
+			listID := mustCreateList(t, r, "Groceries")
+			id := mustCreateItem(t, r, "Milk", day, "", listID)
+
+			// concurrent changes each get a revision of their own
+			const changes = 8
+			var wg sync.WaitGroup
+			errs := make([]error, changes)
+			for i := range changes {
+				wg.Go(func() {
+					title := fmt.Sprintf("Milk %d", i)
+					revision := models.NewRevision(models.EntityItem, id, "jenna",
+						models.ItemSnapshot("Milk", day, ""), models.ItemSnapshot(title, day, ""))
+					errs[i] = r.Items.ChangeItem(id, models.ItemChanges{Title: title, Date: day}, revision)
+				})
+			}
+			wg.Wait()
+			if err := errors.Join(errs...); err != nil {
+				t.Fatalf("ChangeItem: %v", err)
+			}
+
+			// a failed change stores no revision
+			missing := 999
+			revision := models.NewRevision(models.EntityItem, id, "jenna", models.ItemSnapshot("Milk", day, ""), models.ItemSnapshot("Soy milk", day, ""))
+			checkErrorIs(t, "ChangeItem to a missing list",
+				r.Items.ChangeItem(id, models.ItemChanges{Title: "Soy milk", Date: day, ListID: &missing}, revision), repository.ErrListNotFound)
+
+			revisions, err := r.Revisions.GetRevisions(models.EntityItem, id)
+			if err != nil {
+				t.Fatalf("GetRevisions: %v", err)
+			}
+			if len(revisions) != changes {
+				t.Fatalf("expected %d revisions, got %d", changes, len(revisions))
+			}
+			for i, rev := range revisions {
+				if rev.Revision != i+1 || rev.New["title"] == "Soy milk" {
+					t.Errorf("unexpected revision %d: %+v", i+1, rev)
+				}
+			}
+
+			list := models.NewRevision(models.EntityList, listID, "jenna", models.ListSnapshot("Groceries"), models.ListSnapshot("Shopping"))
+			if _, err := r.Lists.UpdateTitle(listID, "Shopping", list); err != nil {
+				t.Fatalf("UpdateTitle: %v", err)
+			}
+			_, err = r.Lists.UpdateTitle(999, "Shopping", models.NewRevision(models.EntityList, 999, "jenna", models.ListSnapshot("a"), models.ListSnapshot("b")))
+			checkErrorIs(t, "UpdateTitle of a missing list", err, repository.ErrListNotFound)
+			if revisions, err := r.Revisions.GetRevisions(models.EntityList, listID); err != nil || len(revisions) != 1 || revisions[0].New["title"] != "Shopping" {
+				t.Errorf("expected the title change's revision, got %+v, %v", revisions, err)
+			}
+			if revisions, err := r.Revisions.GetRevisions(models.EntityList, 999); err != nil || len(revisions) != 0 {
+				t.Errorf("expected no revisions of a missing list, got %+v, %v", revisions, err)
+			}
+		},
+	},
+}
+
+var activityTests = []test{
+	{
+		name:  "activity/newest first and filtered",
+		needs: func(r Repositories) bool { return r.Activity != nil },
+		run: func(t *testing.T, r Repositories) {
+			entries := []*models.Activity{
+				models.NewActivity("jenna", models.ActionCreate, models.EntityList, 1, 1),
+				models.NewActivity("sam", models.ActionCreate, models.EntityItem, 5, 1),
+				models.NewActivity("jenna", models.ActionUpdate, models.EntityItem, 6, 2),
+				{User: "sam", Action: models.ActionMove, EntityType: models.EntityItem, EntityID: 5, ListID: 2, FromListID: 1},
+			}
+			for _, entry := range entries {
+				if err := r.Activity.LogActivity(entry); err != nil {
+					t.Fatalf("LogActivity: %v", err)
+				}
+				if entry.ID == 0 || entry.CreatedAt.IsZero() {
+					t.Errorf("expected the ID and time to be filled in, got %+v", entry)
+				}
+			}
+
+			ids := func(activity []models.Activity) []int {
+				result := make([]int, len(activity))
+				for i, a := range activity {
+					result[i] = a.EntityID
+				}
+				return result
+			}
+
+			tests := []struct {
+				name     string
+				filter   models.ActivityFilter
+				expected []int
+			}{
+				{"everything, newest first", models.ActivityFilter{}, []int{5, 6, 5, 1}},
+				{"by user", models.ActivityFilter{User: "jenna"}, []int{6, 1}},
+				{"by action", models.ActivityFilter{Action: models.ActionCreate}, []int{5, 1}},
+				{"by list", models.ActivityFilter{ListID: 1}, []int{5, 5, 1}},
+				{"a move is on both lists", models.ActivityFilter{ListID: 2}, []int{5, 6}},
+				{"limited", models.ActivityFilter{Limit: 2}, []int{5, 6}},
+				{"to is exclusive", models.ActivityFilter{To: entries[0].CreatedAt}, []int{}},
+				{"from is inclusive", models.ActivityFilter{From: entries[0].CreatedAt, User: "jenna", Action: models.ActionCreate}, []int{1}},
+			}
+			for _, tt := range tests {
+				activity, err := r.Activity.GetActivity(tt.filter)
+				if err != nil {
+					t.Fatalf("%s: GetActivity: %v", tt.name, err)
+				}
+				if got := ids(activity); !slices.Equal(got, tt.expected) {
+					t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, got)
+				}
+			}
+
+			activity, err := r.Activity.GetActivity(models.ActivityFilter{Action: models.ActionMove})
+			if err != nil || len(activity) != 1 || activity[0].ListID != 2 || activity[0].FromListID != 1 {
+				t.Errorf("expected the move from list 1 to 2, got %+v, %v", activity, err)
+			}
+		},
+	},
+}
+
+var smartListTests = []test{
+	{
+		name:  "smart lists/crud",
+		needs: func(r Repositories) bool { return r.SmartLists != nil },
+		run: func(t *testing.T, r Repositories) {
+			completed := false
+			filter := models.ItemFilterDefinition{ListIDs: []int{1, 2}, Due: models.DueThisWeek, Completed: &completed}
+
+			created, err := r.SmartLists.CreateSmartList("jenna", "This week", filter)
+			if err != nil {
+				t.Fatalf("CreateSmartList: %v", err)
+			}
+			if created.ID == 0 || created.Owner != "jenna" || created.CreatedAt.IsZero() {
+				t.Errorf("unexpected smart list %+v", created)
+			}
+			if _, err := r.SmartLists.CreateSmartList("sam", "Mine", models.ItemFilterDefinition{}); err != nil {
+				t.Fatalf("CreateSmartList: %v", err)
+			}
+
+			got, err := r.SmartLists.GetSmartList(created.ID)
+			if err != nil {
+				t.Fatalf("GetSmartList: %v", err)
+			}
+			if got.Title != "This week" || !slices.Equal(got.Filter.ListIDs, []int{1, 2}) || got.Filter.Due != models.DueThisWeek ||
+				got.Filter.Completed == nil || *got.Filter.Completed {
+				t.Errorf("unexpected smart list %+v", got)
+			}
+
+			lists, err := r.SmartLists.GetSmartLists("jenna")
+			if err != nil || len(lists) != 1 || lists[0].ID != created.ID {
+				t.Errorf("expected only jenna's smart list, got %+v, %v", lists, err)
+			}
+
+			updated, err := r.SmartLists.UpdateSmartList(created.ID, "Today", models.ItemFilterDefinition{Due: models.DueToday})
+			if err != nil {
+				t.Fatalf("UpdateSmartList: %v", err)
+			}
+			if updated.Title != "Today" || updated.Owner != "jenna" || updated.Filter.Due != models.DueToday || updated.Filter.ListIDs != nil {
+				t.Errorf("unexpected smart list %+v", updated)
+			}
+
+			if err := r.SmartLists.DeleteSmartList(created.ID); err != nil {
+				t.Fatalf("DeleteSmartList: %v", err)
+			}
+			_, err = r.SmartLists.GetSmartList(created.ID)
+			checkErrorIs(t, "GetSmartList of a deleted smart list", err, repository.ErrSmartListNotFound)
+			_, err = r.SmartLists.UpdateSmartList(created.ID, "Today", models.ItemFilterDefinition{})
+			checkErrorIs(t, "UpdateSmartList of a deleted smart list", err, repository.ErrSmartListNotFound)
+			checkErrorIs(t, "DeleteSmartList of a deleted smart list", r.SmartLists.DeleteSmartList(created.ID), repository.ErrSmartListNotFound)
+		},
+	},
+}
+
+var feedTests = []test{
+	{
+		name:  "feeds/tokens and owners",
+		needs: func(r Repositories) bool { return r.Feeds != nil && r.Lists != nil },
+		run: func(t *testing.T, r Repositories) {
+			listID := mustCreateList(t, r, "Groceries")
+
+			all, err := r.Feeds.CreateFeed("jenna", nil)
+			if err != nil {
+				t.Fatalf("CreateFeed: %v", err)
+			}
+			list, err := r.Feeds.CreateFeed("jenna", &listID)
+			if err != nil {
+				t.Fatalf("CreateFeed: %v", err)
+			}
+			if len(all.Token) != 64 || all.Token == list.Token || all.ListID != nil || list.ListID == nil || *list.ListID != listID {
+				t.Errorf("unexpected feeds %+v and %+v", all, list)
+			}
+
+			got, err := r.Feeds.GetFeedByToken(list.Token)
+			if err != nil || got.ID != list.ID || got.Owner != "jenna" {
+				t.Errorf("expected the list's feed, got %+v, %v", got, err)
+			}
+			_, err = r.Feeds.GetFeedByToken("nope")
+			checkErrorIs(t, "GetFeedByToken of a missing token", err, repository.ErrFeedNotFound)
+
+			if feeds, err := r.Feeds.GetFeeds("sam"); err != nil || feeds == nil || len(feeds) != 0 {
+				t.Errorf("expected no feeds for sam, got %v, %v", feeds, err)
+			}
+			checkErrorIs(t, "DeleteFeed of someone else's feed", r.Feeds.DeleteFeed(all.ID, "sam"), repository.ErrFeedNotFound)
+			if err := r.Feeds.DeleteFeed(all.ID, "jenna"); err != nil {
+				t.Fatalf("DeleteFeed: %v", err)
+			}
+
+			// deleting a list revokes its feeds
+			if err := r.Lists.DeleteList(listID); err != nil {
+				t.Fatalf("DeleteList: %v", err)
+			}
+			if feeds, err := r.Feeds.GetFeeds("jenna"); err != nil || len(feeds) != 0 {
+				t.Errorf("expected no feeds left, got %v, %v", feeds, err)
+			}
+		},
+	},
+}
+
+var importTests = []test{
+	{
+		name:  "imports/finish and abort",
+		needs: func(r Repositories) bool { return r.Imports != nil && withItems(r) },
+		run: func(t *testing.T, r Repositories) {
+			created := time.Date(2024, 1, 2, 3, 4, 5, 0, time.UTC)
+
+			aborted, err := r.Imports.BeginListImport()
+			if err != nil {
+				t.Fatalf("BeginListImport: %v", err)
+			}
+			if err := aborted.AddItem(models.Item{Title: "Lost", Date: day}); err != nil {
+				t.Fatalf("AddItem: %v", err)
+			}
+			if err := aborted.Abort(); err != nil {
+				t.Fatalf("Abort: %v", err)
+			}
+
+			importer, err := r.Imports.BeginListImport()
+			if err != nil {
+				t.Fatalf("BeginListImport: %v", err)
+			}
+			for _, item := range []models.Item{
+				{Title: "Milk", Date: day, Completed: true, CreatedAt: created},
+				{Title: "Eggs", Date: day, Content: "a dozen"},
+			} {
+				if err := importer.AddItem(item); err != nil {
+					t.Fatalf("AddItem: %v", err)
+				}
+			}
+			list, err := importer.Finish(models.List{Title: "Groceries", CreatedAt: created})
+			if err != nil {
+				t.Fatalf("Finish: %v", err)
+			}
+			if err := importer.Abort(); err != nil {
+				t.Errorf("expected Abort after Finish to do nothing, got %v", err)
+			}
+			if list.Title != "Groceries" || !list.CreatedAt.Equal(created) || list.UpdatedAt.IsZero() {
+				t.Errorf("unexpected list %+v", list)
+			}
+
+			lists, err := r.Lists.GetAllLists(models.ListInclude{}, nil)
+			if err != nil || len(lists) != 1 {
+				t.Fatalf("expected only the finished import, got %+v, %v", lists, err)
+			}
+
+			got, err := r.Lists.GetList(int(list.ID), nil)
+			if err != nil {
+				t.Fatalf("GetList: %v", err)
+			}
+			items := got.Items
+			slices.SortFunc(items, func(a, b models.Item) int { return a.ID - b.ID })
+			if !slices.Equal(titles(items), []string{"Milk", "Eggs"}) || !items[0].Completed || !items[0].CreatedAt.Equal(created) ||
+				items[1].Content != "a dozen" || items[1].CreatedAt.IsZero() {
+				t.Errorf("unexpected items %+v", items)
+			}
+		},
+	},
+}
+
+var searchTests = []test{
+	{
+		name:  "search/items and lists",
+		needs: func(r Repositories) bool { return r.Search != nil && withItems(r) },
+		run: func(t *testing.T, r Repositories) {
+			groceries := mustCreateList(t, r, "Groceries")
+			chores := mustCreateList(t, r, "Chores for the weekend")
+			mustCreateItem(t, r, "Milk", day, "from the farm shop", groceries)
+			mustCreateItem(t, r, "Farm eggs", day.AddDate(0, 0, 1), "", groceries)
+			mustCreateItem(t, r, "Laundry", day, "before the weekend", chores)
+
+			type result struct {
+				Type  string
+				Title string
+			}
+			tests := []struct {
+				name     string
+				query    models.SearchQuery
+				expected []result
+			}{
+				{"title matches rank above content", models.SearchQuery{Query: "farm"},
+					[]result{{models.EntityItem, "Farm eggs"}, {models.EntityItem, "Milk"}}},
+				{"items and lists", models.SearchQuery{Query: "weekend"},
+					[]result{{models.EntityList, "Chores for the weekend"}, {models.EntityItem, "Laundry"}}},
+				{"only lists", models.SearchQuery{Query: "weekend", Type: models.EntityList},
+					[]result{{models.EntityList, "Chores for the weekend"}}},
+				{"dates leave out lists", models.SearchQuery{Query: "weekend", From: day, To: day},
+					[]result{{models.EntityItem, "Laundry"}}},
+				{"by list", models.SearchQuery{Query: "farm", ListID: chores}, []result{}},
+				{"every word must match", models.SearchQuery{Query: "farm eggs"},
+					[]result{{models.EntityItem, "Farm eggs"}}},
+				{"or", models.SearchQuery{Query: "eggs or laundry"},
+					[]result{{models.EntityItem, "Farm eggs"}, {models.EntityItem, "Laundry"}}},
+				{"excluded words", models.SearchQuery{Query: "farm -eggs"},
+					[]result{{models.EntityItem, "Milk"}}},
+				{"limited", models.SearchQuery{Query: "farm", Limit: 1},
+					[]result{{models.EntityItem, "Farm eggs"}}},
+			}
+
+			for _, tt := range tests {
+				results, err := r.Search.Search(tt.query)
+				if err != nil {
+					t.Fatalf("%s: Search: %v", tt.name, err)
+				}
+				got := []result{}
+				for _, res := range results {
+					got = append(got, result{res.Type, res.Title})
+					if res.Type == models.EntityItem && res.ItemDate == nil {
+						t.Errorf("%s: expected the date of %s", tt.name, res.Title)
+					}
+				}
+				if !slices.Equal(got, tt.expected) {
+					t.Errorf("%s: expected %v, got %v", tt.name, tt.expected, got)
+				}
+			}
+		},
+	},
+	{
+		name:  "search/snippets escaped",
+		needs: func(r Repositories) bool { return r.Search != nil && withItems(r) },
+		run: func(t *testing.T, r Repositories) {
+			listID := mustCreateList(t, r, "<i>Farm</i> shop")
+			mustCreateItem(t, r, "Eggs", day, `from the farm <script>alert("hi")</script>`, listID)
+
+			results, err := r.Search.Search(models.SearchQuery{Query: "farm"})
+			if err != nil {
+				t.Fatalf("Search: %v", err)
+			}
+			if len(results) != 2 {
+				t.Fatalf("expected the item and the list, got %+v", results)
+			}
+			for _, res := range results {
+				// the markup typed in is text, the matches are marked
+				if strings.Contains(res.Snippet, "<script") || strings.Contains(res.Snippet, "<i>") ||
+					!strings.Contains(res.Snippet, "<mark>") || !strings.Contains(res.Snippet, "&lt;") {
+					t.Errorf("unexpected snippet of %s %q: %q", res.Type, res.Title, res.Snippet)
+				}
+			}
+		},
+	},
+}
@@ -0,0 +1,152 @@
+// repository package provides data access logic
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+)
+
+var ErrRevisionNotFound = errors.New("revision not found")
+
+type RevisionRepositoryInterface interface {
+	CreateRevision(rev *models.Revision) (*models.Revision, error)
+	GetRevisions(entityType string, entityID int) ([]models.Revision, error)
+	GetRevision(entityType string, entityID int, revision int) (*models.Revision, error)
+}
+
+// RevisionRepository stores the change history of items and lists
+type RevisionRepository struct {
+	db *sql.DB
+}
+
+// NewRevisionRepository creates a new RevisionRepository
+func NewRevisionRepository(db *sql.DB) *RevisionRepository {
+	return &RevisionRepository{db: db}
+}
+
+// CreateRevision stores a revision, numbering it after the latest revision of the same entity.
+// Revisions of changes are stored by the change itself, see ItemRepository.ChangeItem.
+func (r *RevisionRepository) CreateRevision(rev *models.Revision) (*models.Revision, error) {
+	tx, err := r.db.Begin()
+	if err != nil {
+		return nil, fmt.Errorf("could not start revision: %w", err)
+	}
+	defer tx.Rollback()
+
+	created, err := insertRevision(tx, rev)
+	if err != nil {
+		return nil, err
+	}
+	if err := tx.Commit(); err != nil {
+		return nil, fmt.Errorf("could not commit revision: %w", err)
+	}
+	return created, nil
+}
+
+// insertRevision stores a revision numbered after the latest revision of the same entity,
+// read by a statement of its own so Postgres can tell the parameters' types from the columns.
+// Inserted in the transaction that changed the entity, after its UPDATE, the entity's row
+// lock keeps concurrent changes from counting the same latest revision.
+func insertRevision(tx *sql.Tx, rev *models.Revision) (*models.Revision, error) {
+	oldValues, err := json.Marshal(rev.Old)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode old values: %w", err)
+	}
+	newValues, err := json.Marshal(rev.New)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode new values: %w", err)
+	}
+
+	created := *rev
+	err = tx.QueryRow(
+		"SELECT COALESCE(MAX(revision), 0) + 1 FROM revisions WHERE entity_type = $1 AND entity_id = $2",
+		rev.EntityType, rev.EntityID,
+	).Scan(&created.Revision)
+	if err != nil {
+		return nil, fmt.Errorf("could not number revision: %w", err)
+	}
+
+	err = tx.QueryRow(
+		`INSERT INTO revisions (entity_type, entity_id, revision, author, old_values, new_values)
+		VALUES ($1, $2, $3, $4, $5, $6)
+		RETURNING id, created_at`,
+		rev.EntityType, rev.EntityID, created.Revision, rev.Author, oldValues, newValues,
+	).Scan(&created.ID, &created.CreatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("could not create revision: %w", err)
+	}
+
+	created.Changes = models.DiffFields(created.Old, created.New)
+	return &created, nil
+}
+
+// GetRevisions retrieves every revision of an entity, oldest first
+func (r *RevisionRepository) GetRevisions(entityType string, entityID int) ([]models.Revision, error) {
+	rows, err := r.db.Query(
+		`SELECT id, entity_type, entity_id, revision, author, old_values, new_values, created_at
+		FROM revisions WHERE entity_type = $1 AND entity_id = $2 ORDER BY revision`,
+		entityType, entityID,
+	)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query revisions: %w", err)
+	}
+	defer rows.Close()
+
+	revisions := []models.Revision{}
+	for rows.Next() {
+		rev, err := scanRevision(rows)
+		if err != nil {
+			return nil, err
+		}
+		revisions = append(revisions, *rev)
+	}
+	return revisions, rows.Err()
+}
+
+// GetRevision retrieves a single revision of an entity by its revision number
+func (r *RevisionRepository) GetRevision(entityType string, entityID int, revision int) (*models.Revision, error) {
+	row := r.db.QueryRow(
+		`SELECT id, entity_type, entity_id, revision, author, old_values, new_values, created_at
+		FROM revisions WHERE entity_type = $1 AND entity_id = $2 AND revision = $3`,
+		entityType, entityID, revision,
+	)
+
+	rev, err := scanRevision(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrRevisionNotFound
+		}
+		return nil, err
+	}
+	return rev, nil
+}
+
+// scanner is implemented by both *sql.Row and *sql.Rows
+type scanner interface {
+	Scan(dest ...any) error
+}
+
+func scanRevision(s scanner) (*models.Revision, error) {
+	var rev models.Revision
+	var oldValues, newValues []byte
+	if err := s.Scan(&rev.ID, &rev.EntityType, &rev.EntityID, &rev.Revision, &rev.Author, &oldValues, &newValues, &rev.CreatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to scan revision: %w", err)
+	}
+
+	if err := json.Unmarshal(oldValues, &rev.Old); err != nil {
+		return nil, fmt.Errorf("failed to decode old values: %w", err)
+	}
+	if err := json.Unmarshal(newValues, &rev.New); err != nil {
+		return nil, fmt.Errorf("failed to decode new values: %w", err)
+	}
+	rev.Changes = models.DiffFields(rev.Old, rev.New)
+
+	return &rev, nil
+}
@@ -0,0 +1,123 @@
+// repository package provides data access logic
+package repository
+
+import (
+	"database/sql"
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+)
+
+// DefaultSearchLimit caps how many search results are returned when no limit is given
+const DefaultSearchLimit = 50
+
+// Search backends wrap matches in these rather than in <mark> tags, and HighlightSnippet
+// turns them into tags once the rest of the snippet is escaped. Being control characters,
+// they don't turn up in titles and content typed in.
+const (
+	SnippetStart = "\x02"
+	SnippetStop  = "\x03"
+)
+
+// headlineOptions controls the snippets produced by ts_headline
+var headlineOptions = fmt.Sprintf(`StartSel="%s", StopSel="%s", MaxFragments=2, MaxWords=20, MinWords=5`, SnippetStart, SnippetStop)
+
+var snippetMarks = strings.NewReplacer(SnippetStart, "<mark>", SnippetStop, "</mark>")
+
+// HighlightSnippet escapes a snippet as HTML, so markup in titles and content shows as
+// text, then wraps the matches between SnippetStart and SnippetStop in <mark> tags
+func HighlightSnippet(snippet string) string {
+	return snippetMarks.Replace(html.EscapeString(snippet))
+}
+
+type SearchRepositoryInterface interface {
+	Search(query models.SearchQuery) ([]models.SearchResult, error)
+}
+
+// SearchRepository runs full-text searches using the search_vector columns
+type SearchRepository struct {
+	db *sql.DB
+}
+
+// NewSearchRepository creates a new SearchRepository
+func NewSearchRepository(db *sql.DB) *SearchRepository {
+	return &SearchRepository{db: db}
+}
+
+// Search finds items and lists matching the query, best matches first.
+// Date filters apply to item dates, so lists are left out when one is given.
+func (r *SearchRepository) Search(query models.SearchQuery) ([]models.SearchResult, error) {
+	args := []any{query.Query, headlineOptions}
+	addArg := func(arg any) string {
+		args = append(args, arg)
+		return fmt.Sprintf("$%d", len(args))
+	}
+
+	var selects []string
+
+	if query.Type == "" || query.Type == models.EntityItem {
+		conditions := []string{"i.search_vector @@ q.query"}
+		if query.ListID != 0 {
+			conditions = append(conditions, "i.list_id = "+addArg(query.ListID))
+		}
+		if !query.From.IsZero() {
+			conditions = append(conditions, "i.item_date >= "+addArg(query.From))
+		}
+		if !query.To.IsZero() {
+			conditions = append(conditions, "i.item_date <= "+addArg(query.To))
+		}
+
+		selects = append(selects, `SELECT 'item', i.id, i.list_id, i.title,
+			ts_headline('english', i.title || ' ' || coalesce(i.content, ''), q.query, $2),
+			ts_rank(i.search_vector, q.query), i.item_date
+			FROM items i, q WHERE `+strings.Join(conditions, " AND "))
+	}
+
+	if (query.Type == "" || query.Type == models.EntityList) && query.From.IsZero() && query.To.IsZero() {
+		conditions := []string{"l.search_vector @@ q.query"}
+		if query.ListID != 0 {
+			conditions = append(conditions, "l.id = "+addArg(query.ListID))
+		}
+
+		selects = append(selects, `SELECT 'list', l.id, l.id, l.title,
+			ts_headline('english', l.title, q.query, $2),
+			ts_rank(l.search_vector, q.query), NULL::date
+			FROM lists l, q WHERE `+strings.Join(conditions, " AND "))
+	}
+
+	if len(selects) == 0 {
+		return []models.SearchResult{}, nil
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = DefaultSearchLimit
+	}
+
+	sqlQuery := "WITH q AS (SELECT websearch_to_tsquery('english', $1) AS query) " +
+		strings.Join(selects, " UNION ALL ") +
+		" ORDER BY 6 DESC, 2 LIMIT " + addArg(limit)
+
+	rows, err := r.db.Query(sqlQuery, args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+	defer rows.Close()
+
+	results := []models.SearchResult{}
+	for rows.Next() {
+		var result models.SearchResult
+		var itemDate sql.NullTime
+		if err := rows.Scan(&result.Type, &result.ID, &result.ListID, &result.Title, &result.Snippet, &result.Rank, &itemDate); err != nil {
+			return nil, fmt.Errorf("failed to scan search result: %w", err)
+		}
+		if itemDate.Valid {
+			result.ItemDate = &itemDate.Time
+		}
+		result.Snippet = HighlightSnippet(result.Snippet)
+		results = append(results, result)
+	}
+	return results, rows.Err()
+}
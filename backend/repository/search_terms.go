@@ -0,0 +1,67 @@
+// repository package provides data access logic
+package repository
+
+import "strings"
+
+// SearchTerms is a search in the syntax of Postgres' websearch_to_tsquery, split up for
+// the backends that can't hand it to Postgres
+type SearchTerms struct {
+	// Required holds groups of alternative terms. Every group must match, through any
+	// one of its terms. A term is a word or a phrase.
+	Required [][]string
+	// Excluded terms must not match
+	Excluded []string
+}
+
+// IsEmpty reports whether there is nothing to search for, which matches nothing
+func (t SearchTerms) IsEmpty() bool {
+	return len(t.Required) == 0
+}
+
+// ParseSearch splits a search into its terms: words and "quoted phrases" must all match,
+// or between two of them matches either, and a leading - leaves out matches of a word
+func ParseSearch(search string) SearchTerms {
+	var terms SearchTerms
+	or := false
+
+	for {
+		search = strings.TrimSpace(search)
+		if search == "" {
+			return terms
+		}
+
+		var term string
+		phrase := search[0] == '"'
+		if phrase {
+			end := strings.IndexByte(search[1:], '"')
+			if end < 0 {
+				end = len(search) - 1 // an unclosed quote runs to the end
+			}
+			term = strings.TrimSpace(search[1 : end+1])
+			search = search[min(end+2, len(search)):]
+		} else {
+			end := strings.IndexAny(search, " \t\n\"")
+			if end < 0 {
+				end = len(search)
+			}
+			term, search = search[:end], search[end:]
+		}
+
+		switch {
+		case term == "":
+		case !phrase && strings.EqualFold(term, "or"):
+			or = len(terms.Required) > 0
+			continue
+		case !phrase && strings.HasPrefix(term, "-"):
+			if term = strings.TrimLeft(term, "-"); term != "" {
+				terms.Excluded = append(terms.Excluded, term)
+			}
+		case or:
+			last := len(terms.Required) - 1
+			terms.Required[last] = append(terms.Required[last], term)
+		default:
+			terms.Required = append(terms.Required, []string{term})
+		}
+		or = false
+	}
+}
@@ -0,0 +1,140 @@
+// repository package provides data access logic
+package repository
+
+import (
+	"database/sql"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+)
+
+var ErrSmartListNotFound = errors.New("smart list not found")
+
+type SmartListRepositoryInterface interface {
+	CreateSmartList(owner, title string, filter models.ItemFilterDefinition) (*models.SmartList, error)
+	GetSmartList(id int) (*models.SmartList, error)
+	GetSmartLists(owner string) ([]models.SmartList, error)
+	UpdateSmartList(id int, title string, filter models.ItemFilterDefinition) (*models.SmartList, error)
+	DeleteSmartList(id int) error
+}
+
+// SmartListRepository handles CRUD operations for saved item filters
+type SmartListRepository struct {
+	db *sql.DB
+}
+
+// NewSmartListRepository creates a new SmartListRepository
+func NewSmartListRepository(db *sql.DB) *SmartListRepository {
+	return &SmartListRepository{db: db}
+}
+
+// CreateSmartList saves a new filter for the given owner
+func (r *SmartListRepository) CreateSmartList(owner, title string, filter models.ItemFilterDefinition) (*models.SmartList, error) {
+	encoded, err := json.Marshal(filter)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode filter: %w", err)
+	}
+
+	list := models.NewSmartList(owner, title, filter)
+	err = r.db.QueryRow(
+		"INSERT INTO smart_lists (owner, title, filter) VALUES ($1, $2, $3) RETURNING id, created_at, updated_at",
+		owner, title, encoded,
+	).Scan(&list.ID, &list.CreatedAt, &list.UpdatedAt)
+	if err != nil {
+		return nil, fmt.Errorf("could not obtain new id: %w", err)
+	}
+
+	return list, nil
+}
+
+// GetSmartList retrieves a smart list by ID
+func (r *SmartListRepository) GetSmartList(id int) (*models.SmartList, error) {
+	row := r.db.QueryRow("SELECT id, owner, title, filter, created_at, updated_at FROM smart_lists WHERE id = $1", id)
+
+	list, err := scanSmartList(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrSmartListNotFound
+		}
+		return nil, err
+	}
+	return list, nil
+}
+
+// GetSmartLists retrieves every smart list belonging to an owner
+func (r *SmartListRepository) GetSmartLists(owner string) ([]models.SmartList, error) {
+	rows, err := r.db.Query("SELECT id, owner, title, filter, created_at, updated_at FROM smart_lists WHERE owner = $1 ORDER BY id", owner)
+	if err != nil {
+		return nil, fmt.Errorf("failed to query smart lists: %w", err)
+	}
+	defer rows.Close()
+
+	lists := []models.SmartList{}
+	for rows.Next() {
+		list, err := scanSmartList(rows)
+		if err != nil {
+			return nil, err
+		}
+		lists = append(lists, *list)
+	}
+	return lists, rows.Err()
+}
+
+// UpdateSmartList replaces the title and filter of a smart list
+func (r *SmartListRepository) UpdateSmartList(id int, title string, filter models.ItemFilterDefinition) (*models.SmartList, error) {
+	encoded, err := json.Marshal(filter)
+	if err != nil {
+		return nil, fmt.Errorf("could not encode filter: %w", err)
+	}
+
+	row := r.db.QueryRow(
+		"UPDATE smart_lists SET title = $1, filter = $2, updated_at = $3 WHERE id = $4 RETURNING id, owner, title, filter, created_at, updated_at",
+		title, encoded, time.Now(), id,
+	)
+
+	list, err := scanSmartList(row)
+	if err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, ErrSmartListNotFound
+		}
+		return nil, err
+	}
+	return list, nil
+}
+
+// DeleteSmartList deletes a smart list. The items it matched are not touched.
+func (r *SmartListRepository) DeleteSmartList(id int) error {
+	res, err := r.db.Exec("DELETE FROM smart_lists WHERE id = $1", id)
+	if err != nil {
+		return fmt.Errorf("failed to delete smart list: %w", err)
+	}
+
+	rows, err := res.RowsAffected()
+	if err != nil {
+		return fmt.Errorf("failed to check affected rows: %w", err)
+	}
+	if rows == 0 {
+		return ErrSmartListNotFound
+	}
+
+	return nil
+}
+
+func scanSmartList(s scanner) (*models.SmartList, error) {
+	var list models.SmartList
+	var filter []byte
+	if err := s.Scan(&list.ID, &list.Owner, &list.Title, &filter, &list.CreatedAt, &list.UpdatedAt); err != nil {
+		if errors.Is(err, sql.ErrNoRows) {
+			return nil, err
+		}
+		return nil, fmt.Errorf("failed to scan smart list: %w", err)
+	}
+
+	if err := json.Unmarshal(filter, &list.Filter); err != nil {
+		return nil, fmt.Errorf("failed to decode filter: %w", err)
+	}
+	return &list, nil
+}
@@ -0,0 +1,4 @@
+package sqlite
+
+// StreamPageSize lets tests read small pages, so a few items span several
+var StreamPageSize = &streamPageSize
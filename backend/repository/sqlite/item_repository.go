@@ -0,0 +1,65 @@
+// sqlite package provides the repositories whose SQL differs between Postgres and SQLite.
+// The other repositories in the repository package work on either database.
+package sqlite
+
+import (
+	"database/sql"
+	"errors"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+	"modernc.org/sqlite"
+	sqlite3 "modernc.org/sqlite/lib"
+)
+
+// ItemRepository handles CRUD operations for items stored in SQLite
+type ItemRepository struct {
+	*repository.ItemRepository
+	db *sql.DB
+}
+
+// NewItemRepository creates a new ItemRepository
+func NewItemRepository(db *sql.DB) *ItemRepository {
+	return &ItemRepository{ItemRepository: repository.NewItemRepository(db), db: db}
+}
+
+// dialect is how SQLite's item queries differ from Postgres': it has no ILIKE, but LIKE
+// ignores case, and dates need no cast
+var dialect = repository.Dialect{
+	Placeholder: func(int) string { return "?" },
+	Like:        "LIKE",
+	// walking the date index from the last item, the pages of a stream read it through once.
+	// Left to itself SQLite may pick the list_id index, and sort every page again.
+	DateIndex: "items_item_date_idx",
+}
+
+// GetFiltered retrieves the items matching a filter, ordered by date
+func (r *ItemRepository) GetFiltered(filter models.ItemFilter) ([]models.Item, error) {
+	return repository.GetFilteredItems(r.db, dialect, filter)
+}
+
+// streamPageSize is how many items StreamFiltered reads per query
+var streamPageSize = 500
+
+// StreamFiltered calls fn with each item matching a filter, in repository.StreamOrder.
+// SQLite has a single connection, so rather than holding it while fn writes to a slow
+// client, items are read a page at a time, each page following on from the last item
+// of the one before. Items changed between pages may be seen in either state.
+func (r *ItemRepository) StreamFiltered(filter models.ItemFilter, fn func(item models.Item) error) error {
+	return repository.StreamFilteredItems(r.db, dialect, streamPageSize, filter, fn)
+}
+
+// ChangeItem makes every change of an update to an item in a single statement, returning
+// repository.ErrListNotFound, and changing nothing, when moving it to a missing list
+func (r *ItemRepository) ChangeItem(id int, changes models.ItemChanges, revision *models.Revision) error {
+	err := r.ItemRepository.ChangeItem(id, changes, revision)
+	if isForeignKeyViolation(err) {
+		return repository.ErrListNotFound
+	}
+	return err
+}
+
+func isForeignKeyViolation(err error) bool {
+	var sqliteErr *sqlite.Error
+	return errors.As(err, &sqliteErr) && sqliteErr.Code() == sqlite3.SQLITE_CONSTRAINT_FOREIGNKEY
+}
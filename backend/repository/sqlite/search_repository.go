@@ -0,0 +1,153 @@
+package sqlite
+
+import (
+	"database/sql"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+)
+
+// SearchRepository runs full-text searches using the items_fts and lists_fts tables
+type SearchRepository struct {
+	db *sql.DB
+}
+
+// NewSearchRepository creates a new SearchRepository
+func NewSearchRepository(db *sql.DB) *SearchRepository {
+	return &SearchRepository{db: db}
+}
+
+// Search finds items and lists matching the query, best matches first.
+// Date filters apply to item dates, so lists are left out when one is given.
+func (r *SearchRepository) Search(query models.SearchQuery) ([]models.SearchResult, error) {
+	match := matchQuery(query.Query)
+	if match == "" {
+		return []models.SearchResult{}, nil
+	}
+
+	var selects []string
+	var args []any
+
+	if query.Type == "" || query.Type == models.EntityItem {
+		// the first argument ranks title matches above content matches, as the weights of the
+		// Postgres search_vector do
+		args = append(args, "title : ("+match+")", match)
+		conditions := []string{"items_fts MATCH ?"}
+		if query.ListID != 0 {
+			conditions = append(conditions, "i.list_id = ?")
+			args = append(args, query.ListID)
+		}
+		if !query.From.IsZero() {
+			conditions = append(conditions, "i.item_date >= ?")
+			args = append(args, query.From)
+		}
+		if !query.To.IsZero() {
+			conditions = append(conditions, "i.item_date <= ?")
+			args = append(args, query.To)
+		}
+
+		selects = append(selects, `SELECT 'item', i.id, i.list_id, i.title,
+			snippet(items_fts, -1, char(2), char(3), '...', 20),
+			CASE WHEN i.id IN (SELECT rowid FROM items_fts WHERE items_fts MATCH ?) THEN 1.0 ELSE 0.4 END, i.item_date
+			FROM items_fts JOIN items i ON i.id = items_fts.rowid WHERE `+strings.Join(conditions, " AND "))
+	}
+
+	if (query.Type == "" || query.Type == models.EntityList) && query.From.IsZero() && query.To.IsZero() {
+		conditions := []string{"lists_fts MATCH ?"}
+		args = append(args, match)
+		if query.ListID != 0 {
+			conditions = append(conditions, "l.id = ?")
+			args = append(args, query.ListID)
+		}
+
+		selects = append(selects, `SELECT 'list', l.id, l.id, l.title,
+			snippet(lists_fts, 0, char(2), char(3), '...', 20),
+			1.0, NULL
+			FROM lists_fts JOIN lists l ON l.id = lists_fts.rowid WHERE `+strings.Join(conditions, " AND "))
+	}
+
+	if len(selects) == 0 {
+		return []models.SearchResult{}, nil
+	}
+
+	limit := query.Limit
+	if limit <= 0 {
+		limit = repository.DefaultSearchLimit
+	}
+	args = append(args, limit)
+
+	rows, err := r.db.Query(strings.Join(selects, " UNION ALL ")+" ORDER BY 6 DESC, 2 LIMIT ?", args...)
+	if err != nil {
+		return nil, fmt.Errorf("failed to search: %w", err)
+	}
+	defer rows.Close()
+
+	results := []models.SearchResult{}
+	for rows.Next() {
+		var result models.SearchResult
+		var itemDate any // the union loses the column's date type, so it may come back as text
+		if err := rows.Scan(&result.Type, &result.ID, &result.ListID, &result.Title, &result.Snippet, &result.Rank, &itemDate); err != nil {
+			return nil, fmt.Errorf("failed to scan search result: %w", err)
+		}
+		if result.ItemDate, err = parseDate(itemDate); err != nil {
+			return nil, fmt.Errorf("failed to scan search result: %w", err)
+		}
+		result.Snippet = repository.HighlightSnippet(result.Snippet)
+		results = append(results, result)
+	}
+	return results, rows.Err()
+}
+
+// matchQuery turns a search in the syntax of Postgres' websearch_to_tsquery into an FTS5 query.
+// Every term is quoted so it can't be mistaken for FTS5 syntax. It returns "" when there
+// is nothing to search for.
+func matchQuery(search string) string {
+	terms := repository.ParseSearch(search)
+	if terms.IsEmpty() {
+		return ""
+	}
+
+	groups := make([]string, len(terms.Required))
+	for i, alternatives := range terms.Required {
+		quoted := make([]string, len(alternatives))
+		for j, term := range alternatives {
+			quoted[j] = quote(term)
+		}
+		groups[i] = strings.Join(quoted, " OR ")
+		if len(quoted) > 1 {
+			groups[i] = "(" + groups[i] + ")"
+		}
+	}
+
+	query := strings.Join(groups, " AND ")
+	for _, term := range terms.Excluded {
+		query += " NOT " + quote(term)
+	}
+	return query
+}
+
+func quote(term string) string {
+	return `"` + strings.ReplaceAll(term, `"`, `""`) + `"`
+}
+
+// parseDate reads an item date that SQLite returned as a time or as text
+func parseDate(value any) (*time.Time, error) {
+	switch v := value.(type) {
+	case nil:
+		return nil, nil
+	case time.Time:
+		return &v, nil
+	case string:
+		for _, layout := range []string{"2006-01-02 15:04:05.999999999-07:00", time.RFC3339Nano, "2006-01-02"} {
+			if t, err := time.Parse(layout, v); err == nil {
+				return &t, nil
+			}
+		}
+		return nil, fmt.Errorf("unexpected date %q", v)
+	default:
+		return nil, fmt.Errorf("unexpected date %v", v)
+	}
+}
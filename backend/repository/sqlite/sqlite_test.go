@@ -0,0 +1,73 @@
+package sqlite_test
+
+import (
+	"database/sql"
+	"path/filepath"
+	"testing"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/database"
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository/repositorytest"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository/sqlite"
+)
+
+func TestConformance(t *testing.T) {
+	// stream a few items over several pages
+	pageSize := *sqlite.StreamPageSize
+	*sqlite.StreamPageSize = 2
+	t.Cleanup(func() { *sqlite.StreamPageSize = pageSize })
+
+	repositorytest.Run(t, func(t *testing.T) repositorytest.Repositories {
+		db, err := database.ConnectSQLite(filepath.Join(t.TempDir(), "notes.db"))
+		if err != nil {
+			t.Fatalf("failed to open database: %v", err)
+		}
+		t.Cleanup(func() { db.Close() })
+
+		return repositorytest.Repositories{
+			Items:      sqlite.NewItemRepository(db),
+			Lists:      repository.NewListRepository(db),
+			Revisions:  repository.NewRevisionRepository(db),
+			Activity:   repository.NewActivityRepository(db),
+			SmartLists: repository.NewSmartListRepository(db),
+			Feeds:      repository.NewCalendarFeedRepository(db),
+			Imports:    repository.NewImportRepository(db),
+			Search:     sqlite.NewSearchRepository(db),
+		}
+	})
+}
+
+// TestUpgrade opens a database created before activity had from_list_id
+func TestUpgrade(t *testing.T) {
+	path := filepath.Join(t.TempDir(), "notes.db")
+	old, err := sql.Open("sqlite", "file:"+path)
+	if err != nil {
+		t.Fatalf("failed to create database: %v", err)
+	}
+	_, err = old.Exec(`CREATE TABLE activity (
+		id INTEGER PRIMARY KEY AUTOINCREMENT, user_name VARCHAR(255) NOT NULL, action VARCHAR(32) NOT NULL,
+		entity_type VARCHAR(16) NOT NULL, entity_id INT NOT NULL, list_id INT NOT NULL,
+		created_at TIMESTAMP DEFAULT (strftime('%Y-%m-%d %H:%M:%f+00:00', 'now')))`)
+	old.Close()
+	if err != nil {
+		t.Fatalf("failed to create activity: %v", err)
+	}
+
+	// twice, as the upgrade runs every time
+	for range 2 {
+		db, err := database.ConnectSQLite(path)
+		if err != nil {
+			t.Fatalf("failed to open database: %v", err)
+		}
+		defer db.Close()
+
+		activity := repository.NewActivityRepository(db)
+		if err := activity.LogActivity(&models.Activity{User: "jenna", Action: models.ActionMove, EntityType: models.EntityItem, EntityID: 1, ListID: 2, FromListID: 1}); err != nil {
+			t.Fatalf("LogActivity: %v", err)
+		}
+		if entries, err := activity.GetActivity(models.ActivityFilter{ListID: 1}); err != nil || len(entries) == 0 {
+			t.Errorf("expected the move on the list it left, got %+v, %v", entries, err)
+		}
+	}
+}
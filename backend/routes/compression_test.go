@@ -0,0 +1,147 @@
+package routes_test
+
+import (
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+
+	"github.com/andybalholm/brotli"
+	"github.com/gin-gonic/gin"
+	"github.com/jennaborowy/fullstack-Go-Docker/middleware"
+	"github.com/jennaborowy/fullstack-Go-Docker/mocks"
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/routes"
+	"github.com/klauspost/compress/zstd"
+	"go.uber.org/mock/gomock"
+)
+
+func TestCompression(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	// enough items to be worth compressing
+	var items []models.Item
+	for i := range 100 {
+		items = append(items, models.Item{ID: i + 1, Title: fmt.Sprintf("Item %d", i+1), ListID: 1})
+	}
+
+	tests := []struct {
+		name             string
+		path             string
+		acceptEncoding   string
+		accept           string
+		validation       middleware.ValidationMode
+		expectedEncoding string
+		expectedItems    int
+	}{
+		{name: "gzip", path: "/api/v2/items", acceptEncoding: "gzip", expectedEncoding: "gzip", expectedItems: 100},
+		{name: "brotli", path: "/api/v2/items", acceptEncoding: "br", expectedEncoding: "br", expectedItems: 100},
+		{name: "zstd", path: "/api/v2/items", acceptEncoding: "zstd", expectedEncoding: "zstd", expectedItems: 100},
+		{name: "best of several", path: "/api/v2/items", acceptEncoding: "gzip, deflate, br, zstd", expectedEncoding: "zstd", expectedItems: 100},
+		{name: "by weight", path: "/api/v2/items", acceptEncoding: "zstd;q=0.5, gzip;q=0.8, br;q=0.1", expectedEncoding: "gzip", expectedItems: 100},
+		{name: "anything", path: "/api/v2/items", acceptEncoding: "*", expectedEncoding: "zstd", expectedItems: 100},
+		{name: "anything but zstd", path: "/api/v2/items", acceptEncoding: "zstd;q=0, *", expectedEncoding: "br", expectedItems: 100},
+		{name: "nothing known", path: "/api/v2/items", acceptEncoding: "deflate, identity", expectedItems: 100},
+		{name: "not asked for", path: "/api/v2/items", expectedItems: 100},
+		{name: "too small", path: "/api/v2/items?list_id=2", acceptEncoding: "gzip"},
+		{name: "NDJSON", path: "/api/v2/items", accept: "application/x-ndjson", acceptEncoding: "gzip", expectedEncoding: "gzip", expectedItems: 100},
+		{name: "validated first", path: "/api/v2/items", acceptEncoding: "br", validation: middleware.ValidateAll, expectedEncoding: "br", expectedItems: 100},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			repo := mocks.NewMockItemRepositoryInterface(ctrl)
+			repo.EXPECT().StreamFiltered(gomock.Any(), gomock.Any()).DoAndReturn(func(filter models.ItemFilter, fn func(models.Item) error) error {
+				for _, item := range items {
+					if len(filter.ListIDs) > 0 && !slices.Contains(filter.ListIDs, item.ListID) {
+						continue
+					}
+					if err := fn(item); err != nil {
+						return err
+					}
+				}
+				return nil
+			}).Times(1)
+
+			router := routes.NewRouter(routes.Repositories{Items: repo}, testConfig(tt.validation))
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			if tt.acceptEncoding != "" {
+				req.Header.Set("Accept-Encoding", tt.acceptEncoding)
+			}
+			if tt.accept != "" {
+				req.Header.Set("Accept", tt.accept)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+			if vary := w.Header().Values("Vary"); !slices.Contains(vary, "Accept-Encoding") {
+				t.Errorf("expected Vary: Accept-Encoding, got %q", vary)
+			}
+			if encoding := w.Header().Get("Content-Encoding"); encoding != tt.expectedEncoding {
+				t.Fatalf("expected Content-Encoding %q, got %q", tt.expectedEncoding, encoding)
+			}
+
+			body := decompress(t, tt.expectedEncoding, w.Body.Bytes())
+			var got int
+			if tt.accept == "application/x-ndjson" {
+				scanner := bufio.NewScanner(bytes.NewReader(body))
+				for scanner.Scan() {
+					var item map[string]any
+					if err := json.Unmarshal(scanner.Bytes(), &item); err != nil {
+						t.Fatalf("invalid NDJSON line %q: %v", scanner.Text(), err)
+					}
+					got++
+				}
+			} else {
+				var decoded []map[string]any
+				if err := json.Unmarshal(body, &decoded); err != nil {
+					t.Fatalf("invalid JSON %q: %v", body, err)
+				}
+				got = len(decoded)
+			}
+			if got != tt.expectedItems {
+				t.Errorf("expected %d items, got %d", tt.expectedItems, got)
+			}
+		})
+	}
+}
+
+func decompress(t *testing.T, encoding string, data []byte) []byte {
+	t.Helper()
+	var reader io.Reader
+	switch encoding {
+	case "":
+		return data
+	case "gzip":
+		gz, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("invalid gzip: %v", err)
+		}
+		reader = gz
+	case "br":
+		reader = brotli.NewReader(bytes.NewReader(data))
+	case "zstd":
+		decoder, err := zstd.NewReader(bytes.NewReader(data))
+		if err != nil {
+			t.Fatalf("invalid zstd: %v", err)
+		}
+		defer decoder.Close()
+		reader = decoder
+	}
+	body, err := io.ReadAll(reader)
+	if err != nil {
+		t.Fatalf("failed to decompress %s: %v", encoding, err)
+	}
+	return body
+}
@@ -0,0 +1,215 @@
+package routes
+
+import (
+	"net/http"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/backup"
+	"github.com/jennaborowy/fullstack-Go-Docker/dto"
+	"github.com/jennaborowy/fullstack-Go-Docker/handlers"
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/openapi"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+	"github.com/jennaborowy/fullstack-Go-Docker/transfer"
+)
+
+const (
+	specPath = "/openapi.json"
+	docsPath = "/docs"
+)
+
+// responseTypes are the bodies a version of the API responds with
+type responseTypes struct {
+	item, items, list, lists, revision, revisions, activity                   any
+	smartList, smartLists, feed, feeds, calendar, searchResults, importResult any
+}
+
+var versionResponses = map[APIVersion]responseTypes{
+	V1: {
+		item: models.Item{}, items: []models.Item{}, list: models.List{}, lists: []models.List{},
+		revision: models.Revision{}, revisions: []models.Revision{}, activity: []models.Activity{},
+		smartList: models.SmartList{}, smartLists: []models.SmartList{}, feed: models.CalendarFeed{}, feeds: []models.CalendarFeed{},
+		calendar: models.Calendar{}, searchResults: []models.SearchResult{}, importResult: transfer.Result{},
+	},
+	V2: {
+		item: dto.Item{}, items: []dto.Item{}, list: dto.List{}, lists: []dto.List{},
+		revision: dto.Revision{}, revisions: []dto.Revision{}, activity: []dto.Activity{},
+		smartList: dto.SmartList{}, smartLists: []dto.SmartList{}, feed: dto.CalendarFeed{}, feeds: []dto.CalendarFeed{},
+		calendar: dto.Calendar{}, searchResults: []dto.SearchResult{}, importResult: dto.ImportResult{},
+	},
+}
+
+// Document describes the routes of one API version, served under basePath. Request and
+// response schemas are generated from the handler, model and DTO types, so they follow
+// those structs; routes have to be added here by hand, which TestDocumentCoversRoutes checks.
+func Document(version APIVersion, basePath string) *openapi.Document {
+	description := "Lists, items and everything around them. Changes are attributed to the user named " +
+		"in the " + handlers.UserHeader + " header, or to \"anonymous\"."
+	if version == V1 {
+		description += " This version is deprecated in favour of v2, whose responses are consistently snake_case."
+	}
+	doc := openapi.New(openapi.Info{
+		Title:       "Notes API",
+		Version:     string(version),
+		Description: description,
+	})
+	doc.Servers = []openapi.Server{{URL: basePath}}
+	t := versionResponses[version]
+
+	const (
+		ok          = http.StatusOK
+		created     = http.StatusCreated
+		noContent   = http.StatusNoContent
+		badRequest  = http.StatusBadRequest
+		notFound    = http.StatusNotFound
+		serverError = http.StatusInternalServerError
+	)
+
+	itemFilter := func(b *openapi.OperationBuilder) *openapi.OperationBuilder {
+		return b.
+			Query("list_id", openapi.ArrayOf(openapi.Integer()), "only items in these lists; repeat for several").
+			Query("due", openapi.Enum(models.DueOverdue, models.DueToday, models.DueThisWeek, models.DueNext7Days, models.DueThisMonth), "relative due date range").
+			Query("from", openapi.String("date"), "earliest item date").
+			Query("to", openapi.String("date"), "latest item date").
+			Query("completed", openapi.Boolean(), "only completed or only open items").
+			Query("q", openapi.String(), "full text search on title and content")
+	}
+	timeRange := func(b *openapi.OperationBuilder) *openapi.OperationBuilder {
+		return b.
+			Query("from", openapi.String(), "RFC 3339 timestamp or YYYY-MM-DD date").
+			Query("to", openapi.String(), "RFC 3339 timestamp or YYYY-MM-DD date")
+	}
+	activityFilter := func(b *openapi.OperationBuilder) *openapi.OperationBuilder {
+		b.Query("user", openapi.String(), "only activity of this user").
+			Query("action", openapi.Enum(models.ActionCreate, models.ActionUpdate, models.ActionDelete, models.ActionRevert, models.ActionImport, models.ActionMove), "only this kind of change").
+			Query("limit", openapi.Integer(), "maximum number of entries")
+		return timeRange(b)
+	}
+	fields := func(b *openapi.OperationBuilder, known models.Fields) *openapi.OperationBuilder {
+		return b.ListQuery("fields", openapi.Enum(known...), "only respond with these fields, comma separated or repeated")
+	}
+	feedCalendar := func(b *openapi.OperationBuilder) *openapi.OperationBuilder {
+		return b.
+			RequiredQuery("token", openapi.String(), "calendar feed token").
+			Query("component", openapi.Enum("event", "todo"), "write items as all-day events (default) or todos").
+			File(ok, "text/calendar").
+			Errors(badRequest, http.StatusUnauthorized, serverError)
+	}
+
+	// items
+	fields(itemFilter(doc.Route("GET", "/items", "getItems", "List items, as NDJSON if accepted").Tags("items")), models.ItemFields).
+		JSON(ok, t.items).NDJSON(ok, t.item).Errors(badRequest, serverError)
+	fields(doc.Route("GET", "/items/:id", "getItem", "Get an item").Tags("items"), models.ItemFields).
+		JSON(ok, t.item).Errors(badRequest, notFound, serverError)
+	doc.Route("POST", "/items", "createItem", "Create an item").Tags("items").
+		Body(handlers.CreateItemRequest{}).JSON(created, t.item).Errors(badRequest, serverError)
+	doc.Route("DELETE", "/items/:id", "deleteItem", "Delete an item").Tags("items").
+		NoContent(noContent).Errors(badRequest, notFound, serverError)
+	doc.Route("PUT", "/items/:id", "updateItem", "Update, complete or move an item").Tags("items").
+		Body(handlers.UpdateItemRequest{}).JSON(ok, t.item).Errors(badRequest, notFound, serverError)
+	doc.Route("GET", "/items/:id/revisions", "getItemRevisions", "List an item's revisions, oldest first").Tags("items").
+		JSON(ok, t.revisions).Errors(badRequest, serverError)
+	doc.Route("GET", "/items/:id/revisions/:rev", "getItemRevision", "Get one revision of an item").Tags("items").
+		JSON(ok, t.revision).Errors(badRequest, notFound, serverError)
+	doc.Route("POST", "/items/:id/revisions/:rev/revert", "revertItem", "Revert an item to a revision").Tags("items").
+		JSON(ok, t.item).Errors(badRequest, notFound, serverError)
+
+	// lists
+	fields(doc.Route("GET", "/lists", "getLists", "List lists, with their items or item counts if asked").Tags("lists").
+		ListQuery("include", openapi.Enum(models.IncludeItems, models.IncludeCounts), "what to read along with each list, comma separated or repeated"), models.ListFields).
+		JSON(ok, t.lists).Errors(badRequest, serverError)
+	fields(doc.Route("GET", "/lists/:id", "getList", "Get a list with its items").Tags("lists"), models.ListFields).
+		JSON(ok, t.list).Errors(badRequest, notFound, serverError)
+	doc.Route("POST", "/lists", "createList", "Create a list").Tags("lists").
+		Body(handlers.ListTitleRequest{}).JSON(created, t.list).Errors(badRequest, serverError)
+	doc.Route("DELETE", "/lists/:id", "deleteList", "Delete a list and its items").Tags("lists").
+		NoContent(noContent).Errors(badRequest, serverError)
+	doc.Route("PUT", "/lists/:id", "updateListTitle", "Rename a list").Tags("lists").
+		Body(handlers.ListTitleRequest{}).JSON(ok, t.list).Errors(badRequest, notFound, serverError)
+	doc.Route("GET", "/lists/:id/revisions", "getListRevisions", "List a list's revisions, oldest first").Tags("lists").
+		JSON(ok, t.revisions).Errors(badRequest, serverError)
+	doc.Route("POST", "/lists/:id/revisions/:rev/revert", "revertList", "Revert a list to a revision").Tags("lists").
+		JSON(ok, t.list).Errors(badRequest, notFound, serverError)
+	activityFilter(doc.Route("GET", "/lists/:id/activity", "getListActivity", "List the activity of a list, newest first").Tags("activity")).
+		JSON(ok, t.activity).Errors(badRequest, serverError)
+	feedCalendar(doc.Route("GET", "/lists/:id/calendar.ics", "getListCalendar", "Get a list's items as an iCalendar feed").Tags("calendar")).
+		Errors(http.StatusForbidden, notFound)
+
+	formats := transfer.Names()
+	contentTypes := make([]string, 0, len(formats))
+	for _, name := range formats {
+		format, _ := transfer.Lookup(name)
+		contentTypes = append(contentTypes, format.ContentType)
+	}
+	doc.Route("GET", "/lists/:id/export", "exportList", "Download a list in a file format").Tags("lists").
+		Query("format", openapi.Enum(formats...), "file format, json by default").
+		File(ok, contentTypes...).Errors(badRequest, notFound, serverError)
+	doc.Route("POST", "/lists/import", "importList", "Create a list from an uploaded file").Tags("lists").
+		Query("format", openapi.Enum(formats...), "file format, detected from the upload when omitted").
+		Query("title", openapi.String(), "replaces the title read from the file").
+		Upload(contentTypes...).
+		JSON(created, t.importResult).JSON(http.StatusUnprocessableEntity, t.importResult).
+		Errors(badRequest, http.StatusRequestEntityTooLarge, serverError)
+
+	// smart lists
+	doc.Route("GET", "/smart-lists", "getSmartLists", "List the current user's smart lists").Tags("smart lists").
+		JSON(ok, t.smartLists).Errors(serverError)
+	doc.Route("GET", "/smart-lists/:id", "getSmartList", "Get a smart list").Tags("smart lists").
+		JSON(ok, t.smartList).Errors(badRequest, notFound, serverError)
+	doc.Route("GET", "/smart-lists/:id/items", "getSmartListItems", "List the items matching a smart list").Tags("smart lists").
+		JSON(ok, t.items).Errors(badRequest, notFound, serverError)
+	doc.Route("POST", "/smart-lists", "createSmartList", "Save a filter as a smart list").Tags("smart lists").
+		Body(handlers.SmartListRequest{}).JSON(created, t.smartList).Errors(badRequest, serverError)
+	doc.Route("PUT", "/smart-lists/:id", "updateSmartList", "Update a smart list").Tags("smart lists").
+		Body(handlers.SmartListRequest{}).JSON(ok, t.smartList).Errors(badRequest, notFound, serverError)
+	doc.Route("DELETE", "/smart-lists/:id", "deleteSmartList", "Delete a smart list").Tags("smart lists").
+		NoContent(noContent).Errors(badRequest, notFound, serverError)
+
+	// calendar
+	itemFilter(doc.Route("GET", "/calendar", "getCalendar", "Get items grouped by day, week or month").Tags("calendar")).
+		Query("tz", openapi.String(), "IANA time zone deciding what today is, UTC by default").
+		Query("group", openapi.Enum(models.GroupDay, models.GroupWeek, models.GroupMonth), "bucket size, day by default").
+		JSON(ok, t.calendar).Errors(badRequest, serverError)
+	feedCalendar(doc.Route("GET", "/calendar.ics", "getFeedCalendar", "Get a feed's items as an iCalendar feed").Tags("calendar"))
+	doc.Route("GET", "/feeds", "getFeeds", "List the current user's calendar feeds").Tags("calendar").
+		JSON(ok, t.feeds).Errors(serverError)
+	doc.Route("POST", "/feeds", "createFeed", "Create a calendar feed").Tags("calendar").
+		Body(handlers.CreateFeedRequest{}).JSON(created, t.feed).Errors(badRequest, notFound, serverError)
+	doc.Route("DELETE", "/feeds/:id", "deleteFeed", "Delete a calendar feed").Tags("calendar").
+		NoContent(noContent).Errors(badRequest, notFound, serverError)
+
+	// activity and search
+	activityFilter(doc.Route("GET", "/activity", "getActivity", "List activity, newest first").Tags("activity")).
+		Query("list_id", openapi.Integer(), "only activity in this list").
+		JSON(ok, t.activity).Errors(badRequest, serverError)
+	timeRange(doc.Route("GET", "/search", "search", "Search items and lists").Tags("search")).
+		RequiredQuery("q", openapi.String(), "search terms").
+		Query("type", openapi.Enum(models.EntityItem, models.EntityList), "only items or only lists").
+		Query("list_id", openapi.Integer(), "only results in this list").
+		Query("limit", openapi.Integer(), "maximum number of results").
+		JSON(ok, t.searchResults).Errors(badRequest, serverError)
+
+	// admin
+	adminToken := &openapi.SecurityScheme{Type: "http", Scheme: "bearer"}
+	doc.Route("GET", "/admin/backup", "backup", "Download a backup of the whole database").Tags("admin").
+		Security("adminToken", adminToken).
+		File(ok, "application/gzip").Errors(http.StatusUnauthorized, http.StatusForbidden, serverError, http.StatusNotImplemented)
+	doc.Route("POST", "/admin/restore", "restore", "Restore a backup").Tags("admin").
+		Security("adminToken", adminToken).
+		Query("strategy", openapi.Enum(string(repository.ConflictFail), string(repository.ConflictSkip), string(repository.ConflictOverwrite), string(repository.ConflictCopy)), "what to do with rows that already exist, fail by default").
+		Upload("application/gzip").
+		JSON(ok, backup.Report{}).
+		Errors(badRequest, http.StatusUnauthorized, http.StatusForbidden, http.StatusConflict, http.StatusRequestEntityTooLarge, serverError, http.StatusNotImplemented)
+
+	// documentation
+	doc.Route("GET", specPath, "getOpenAPI", "Get this OpenAPI document").Tags("docs").
+		File(ok, "application/json")
+	doc.Route("GET", docsPath, "getDocs", "Read the API reference").Tags("docs").
+		File(ok, "text/html")
+
+	if version == V1 {
+		doc.Operations(func(method, path string, op *openapi.Operation) {
+			op.Deprecated = true
+		})
+	}
+	return doc
+}
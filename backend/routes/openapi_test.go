@@ -0,0 +1,155 @@
+package routes_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jennaborowy/fullstack-Go-Docker/config"
+	"github.com/jennaborowy/fullstack-Go-Docker/openapi"
+	"github.com/jennaborowy/fullstack-Go-Docker/routes"
+)
+
+func TestDocumentCoversRoutes(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	router := routes.NewRouter(routes.Repositories{}, config.Default())
+
+	// longest first, so routes belong to the most specific prefix
+	prefixes := []struct {
+		prefix  string
+		version routes.APIVersion
+	}{
+		{"/api/v2", routes.V2},
+		{"/api/v1", routes.V1},
+		{"/api", routes.V1},
+	}
+
+	served := map[string]map[string]bool{}
+	for _, route := range router.Routes() {
+		for _, p := range prefixes {
+			if strings.HasPrefix(route.Path, p.prefix+"/") {
+				if served[p.prefix] == nil {
+					served[p.prefix] = map[string]bool{}
+				}
+				served[p.prefix][route.Method+" "+openapi.Path(strings.TrimPrefix(route.Path, p.prefix))] = true
+				break
+			}
+		}
+	}
+
+	for _, p := range prefixes {
+		t.Run(p.prefix, func(t *testing.T) {
+			doc := routes.Document(p.version, p.prefix)
+			if err := doc.Validate(); err != nil {
+				t.Fatal(err)
+			}
+
+			for route := range served[p.prefix] {
+				method, path, _ := strings.Cut(route, " ")
+				if doc.Operation(method, p.prefix+path) == nil {
+					t.Errorf("%s %s%s is not in the OpenAPI document", method, p.prefix, path)
+				}
+			}
+
+			doc.Operations(func(method, path string, op *openapi.Operation) {
+				if !served[p.prefix][method+" "+path] {
+					t.Errorf("%s %s%s (%s) is documented but not served", method, p.prefix, path, op.OperationID)
+				}
+				if len(op.Responses) == 0 {
+					t.Errorf("%s %s has no documented responses", method, path)
+				}
+				if op.Deprecated != (p.version == routes.V1) {
+					t.Errorf("%s %s%s should be deprecated only in v1", method, p.prefix, path)
+				}
+			})
+		})
+	}
+}
+
+func TestDocumentSchemas(t *testing.T) {
+	v1 := routes.Document(routes.V1, "/api/v1")
+	v2 := routes.Document(routes.V2, "/api/v2")
+
+	tests := []struct {
+		name     string
+		doc      *openapi.Document
+		schema   string
+		property string
+		expected string
+	}{
+		{"v1 list fields have no json tags", v1, "List", "Title", "string"},
+		{"v1 item dates are timestamps", v1, "Item", "item_date", "date-time"},
+		{"request dates are days", v1, "CreateItemRequest", "item_date", "date"},
+		{"filters are embedded", v1, "SmartList", "filter", "#/components/schemas/ItemFilterDefinition"},
+		{"v2 list fields are snake_case", v2, "List", "title", "string"},
+		{"v2 item dates are days", v2, "Item", "item_date", "date"},
+		{"v2 search result dates are days", v2, "SearchResult", "item_date", "date"},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			schema := tt.doc.Components.Schemas[tt.schema]
+			if schema == nil {
+				t.Fatalf("schema %s is missing", tt.schema)
+			}
+			property := schema.Properties[tt.property]
+			if property == nil {
+				t.Fatalf("%s has no property %s", tt.schema, tt.property)
+			}
+			got := property.Type
+			switch {
+			case property.Ref != "":
+				got = property.Ref
+			case property.Format != "":
+				got = property.Format
+			}
+			if got != tt.expected {
+				t.Errorf("expected %s.%s to be %s, got %s", tt.schema, tt.property, tt.expected, got)
+			}
+		})
+	}
+
+	// optional request fields must be omitempty, or generated clients would always send them
+	update := v1.Components.Schemas["UpdateItemRequest"]
+	for _, required := range update.Required {
+		if required == "completed" || required == "list_id" {
+			t.Errorf("UpdateItemRequest.%s should be optional", required)
+		}
+	}
+}
+
+func TestServeDocument(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	router := routes.NewRouter(routes.Repositories{}, config.Default())
+
+	for _, prefix := range []string{"/api", "/api/v1", "/api/v2"} {
+		t.Run(prefix, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, prefix+"/openapi.json", nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status %d, got %d", http.StatusOK, w.Code)
+			}
+			var doc openapi.Document
+			if err := json.Unmarshal(w.Body.Bytes(), &doc); err != nil {
+				t.Fatalf("invalid document: %v", err)
+			}
+			if doc.OpenAPI != openapi.Version || doc.BasePath() != prefix || doc.Paths["/items/{id}"] == nil {
+				t.Errorf("unexpected document: %s", w.Body.String())
+			}
+
+			req = httptest.NewRequest(http.MethodGet, prefix+"/docs", nil)
+			w = httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK || !strings.Contains(w.Body.String(), `spec-url="`+prefix+`/openapi.json"`) {
+				t.Errorf("unexpected docs page %d: %s", w.Code, w.Body.String())
+			}
+		})
+	}
+}
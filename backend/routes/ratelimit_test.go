@@ -0,0 +1,137 @@
+package routes_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jennaborowy/fullstack-Go-Docker/config"
+	"github.com/jennaborowy/fullstack-Go-Docker/middleware"
+	"github.com/jennaborowy/fullstack-Go-Docker/mocks"
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/ratelimit"
+	"github.com/jennaborowy/fullstack-Go-Docker/routes"
+	"go.uber.org/mock/gomock"
+)
+
+func TestRateLimit(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+
+	type request struct {
+		method            string
+		path              string
+		header            map[string]string
+		expectedStatus    int
+		expectedRemaining string // "" for no RateLimit headers
+		expectedRetry     string
+	}
+
+	tests := []struct {
+		name       string
+		rateLimit  config.RateLimitConfig
+		validation middleware.ValidationMode
+		requests   []request
+	}{
+		{
+			name:      "off",
+			rateLimit: config.RateLimitConfig{},
+			requests: []request{
+				{method: http.MethodGet, path: "/api/v2/items", expectedStatus: http.StatusOK},
+				{method: http.MethodGet, path: "/api/v2/items", expectedStatus: http.StatusOK},
+			},
+		},
+		{
+			name:      "by IP",
+			rateLimit: config.RateLimitConfig{ParsedLimit: ratelimit.Limit{Requests: 2, Period: time.Hour}},
+			requests: []request{
+				{method: http.MethodGet, path: "/api/v2/items", expectedStatus: http.StatusOK, expectedRemaining: "1"},
+				{method: http.MethodGet, path: "/api/items/1", header: map[string]string{"X-User": "alice"}, expectedStatus: http.StatusOK, expectedRemaining: "0"},
+				{method: http.MethodGet, path: "/api/v2/items", expectedStatus: http.StatusTooManyRequests, expectedRemaining: "0", expectedRetry: "1800"},
+				{method: http.MethodPost, path: "/graphql", expectedStatus: http.StatusTooManyRequests, expectedRemaining: "0", expectedRetry: "1800"},
+			},
+		},
+		{
+			name:      "by user",
+			rateLimit: config.RateLimitConfig{Key: string(middleware.RateLimitByUser), ParsedLimit: ratelimit.Limit{Requests: 1, Period: time.Hour}},
+			requests: []request{
+				{method: http.MethodGet, path: "/api/v2/items", header: map[string]string{"X-User": "alice"}, expectedStatus: http.StatusOK, expectedRemaining: "0"},
+				{method: http.MethodGet, path: "/api/v2/items", header: map[string]string{"X-User": "bob"}, expectedStatus: http.StatusOK, expectedRemaining: "0"},
+				{method: http.MethodGet, path: "/api/v2/items", header: map[string]string{"X-User": "alice"}, expectedStatus: http.StatusTooManyRequests, expectedRemaining: "0", expectedRetry: "3600"},
+			},
+		},
+		{
+			name:      "by API key",
+			rateLimit: config.RateLimitConfig{Key: string(middleware.RateLimitByAPIKey), ParsedLimit: ratelimit.Limit{Requests: 1, Period: time.Hour}},
+			requests: []request{
+				{method: http.MethodGet, path: "/api/v2/items", header: map[string]string{"Authorization": "Bearer one"}, expectedStatus: http.StatusOK, expectedRemaining: "0"},
+				{method: http.MethodGet, path: "/api/v2/items", header: map[string]string{"Authorization": "Bearer two"}, expectedStatus: http.StatusOK, expectedRemaining: "0"},
+				{method: http.MethodGet, path: "/api/v2/items", header: map[string]string{"Authorization": "Bearer one", "X-User": "bob"}, expectedStatus: http.StatusTooManyRequests, expectedRemaining: "0", expectedRetry: "3600"},
+				{method: http.MethodGet, path: "/api/v2/items", expectedStatus: http.StatusOK, expectedRemaining: "0"},
+			},
+		},
+		{
+			name: "route override shared by versions",
+			rateLimit: config.RateLimitConfig{
+				ParsedLimit: ratelimit.Limit{Requests: 10, Period: time.Hour},
+				ParsedRoutes: []ratelimit.Rule{
+					{Method: http.MethodGet, Path: "/items/:id", Limit: ratelimit.Limit{Requests: 1, Period: time.Minute}},
+					{Method: http.MethodGet, Path: "/items"},
+				},
+			},
+			requests: []request{
+				{method: http.MethodGet, path: "/api/v2/items/1", expectedStatus: http.StatusOK, expectedRemaining: "0"},
+				{method: http.MethodGet, path: "/api/v1/items/1", expectedStatus: http.StatusTooManyRequests, expectedRemaining: "0", expectedRetry: "60"},
+				{method: http.MethodGet, path: "/api/v2/items", expectedStatus: http.StatusOK},
+				{method: http.MethodGet, path: "/api/v2/lists/1", expectedStatus: http.StatusOK, expectedRemaining: "9"},
+			},
+		},
+		{
+			name:       "before validation",
+			rateLimit:  config.RateLimitConfig{ParsedLimit: ratelimit.Limit{Requests: 1, Period: time.Hour}},
+			validation: middleware.ValidateAll,
+			requests: []request{
+				{method: http.MethodGet, path: "/api/v2/items", expectedStatus: http.StatusOK, expectedRemaining: "0"},
+				{method: http.MethodGet, path: "/api/v2/items", expectedStatus: http.StatusTooManyRequests, expectedRemaining: "0", expectedRetry: "3600"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			items := mocks.NewMockItemRepositoryInterface(ctrl)
+			items.EXPECT().StreamFiltered(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
+			items.EXPECT().GetByID(1, nil).Return(&models.Item{ID: 1, Title: "Milk", ListID: 1}, nil).AnyTimes()
+			lists := mocks.NewMockListRepositoryInterface(ctrl)
+			lists.EXPECT().GetList(1, nil).Return(&models.List{ID: 1, Title: "Groceries"}, nil).AnyTimes()
+
+			cfg := testConfig(tt.validation)
+			cfg.RateLimit = tt.rateLimit
+			router := routes.NewRouter(routes.Repositories{Items: items, Lists: lists}, cfg)
+
+			for i, r := range tt.requests {
+				req := httptest.NewRequest(r.method, r.path, nil)
+				for name, value := range r.header {
+					req.Header.Set(name, value)
+				}
+				w := httptest.NewRecorder()
+				router.ServeHTTP(w, req)
+
+				if w.Code != r.expectedStatus {
+					t.Fatalf("request %d: expected status %d, got %d: %s", i, r.expectedStatus, w.Code, w.Body.String())
+				}
+				if remaining := w.Header().Get("RateLimit-Remaining"); remaining != r.expectedRemaining {
+					t.Errorf("request %d: expected RateLimit-Remaining %q, got %q", i, r.expectedRemaining, remaining)
+				}
+				if retry := w.Header().Get("Retry-After"); retry != r.expectedRetry {
+					t.Errorf("request %d: expected Retry-After %q, got %q", i, r.expectedRetry, retry)
+				}
+				if r.expectedRemaining != "" && w.Header().Get("RateLimit-Limit") == "" {
+					t.Errorf("request %d: expected RateLimit-Limit", i)
+				}
+			}
+		})
+	}
+}
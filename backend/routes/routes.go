@@ -2,39 +2,169 @@ package routes
 
 import (
 	"database/sql"
+	"expvar"
+	"log"
+	"time"
 
 	"github.com/gin-gonic/gin"
+	"github.com/jennaborowy/fullstack-Go-Docker/config"
+	"github.com/jennaborowy/fullstack-Go-Docker/dto"
+	"github.com/jennaborowy/fullstack-Go-Docker/gql"
 	"github.com/jennaborowy/fullstack-Go-Docker/handlers"
 	"github.com/jennaborowy/fullstack-Go-Docker/middleware"
+	"github.com/jennaborowy/fullstack-Go-Docker/openapi"
+	"github.com/jennaborowy/fullstack-Go-Docker/ratelimit"
 	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository/memory"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository/sqlite"
+	"github.com/jennaborowy/fullstack-Go-Docker/service"
 )
 
-func SetupRoutes(db *sql.DB) *gin.Engine {
+// Repositories holds every repository the routes use
+type Repositories struct {
+	Items      repository.ItemRepositoryInterface
+	Lists      repository.ListRepositoryInterface
+	Revisions  repository.RevisionRepositoryInterface
+	Activity   repository.ActivityRepositoryInterface
+	SmartLists repository.SmartListRepositoryInterface
+	Feeds      repository.CalendarFeedRepositoryInterface
+	Imports    repository.ImportRepositoryInterface
+	Search     repository.SearchRepositoryInterface
+	Backup     repository.BackupRepositoryInterface // nil when the storage doesn't support backups
+	RateLimits ratelimit.Store                      // nil keeps rate limits in memory
+}
+
+// NewRepositories creates the Postgres repositories
+func NewRepositories(db *sql.DB) Repositories {
+	return Repositories{
+		Items:      repository.NewItemRepository(db),
+		Lists:      repository.NewListRepository(db),
+		Revisions:  repository.NewRevisionRepository(db),
+		Activity:   repository.NewActivityRepository(db),
+		SmartLists: repository.NewSmartListRepository(db),
+		Feeds:      repository.NewCalendarFeedRepository(db),
+		Imports:    repository.NewImportRepository(db),
+		Search:     repository.NewSearchRepository(db),
+		Backup:     repository.NewBackupRepository(db),
+	}
+}
+
+// NewSQLiteRepositories creates the repositories of the SQLite storage. Most of the
+// Postgres repositories work on SQLite too; backups are not supported.
+func NewSQLiteRepositories(db *sql.DB) Repositories {
+	return Repositories{
+		Items:      sqlite.NewItemRepository(db),
+		Lists:      repository.NewListRepository(db),
+		Revisions:  repository.NewRevisionRepository(db),
+		Activity:   repository.NewActivityRepository(db),
+		SmartLists: repository.NewSmartListRepository(db),
+		Feeds:      repository.NewCalendarFeedRepository(db),
+		Imports:    repository.NewImportRepository(db),
+		Search:     sqlite.NewSearchRepository(db),
+	}
+}
+
+// NewMemoryRepositories creates the repositories of the in-memory storage, over an
+// empty store. Backups are not supported.
+func NewMemoryRepositories() Repositories {
+	db := memory.NewDB()
+	return Repositories{
+		Items:      memory.NewItemRepository(db),
+		Lists:      memory.NewListRepository(db),
+		Revisions:  memory.NewRevisionRepository(db),
+		Activity:   memory.NewActivityRepository(db),
+		SmartLists: memory.NewSmartListRepository(db),
+		Feeds:      memory.NewCalendarFeedRepository(db),
+		Imports:    memory.NewImportRepository(db),
+		Search:     memory.NewSearchRepository(db),
+	}
+}
+
+func SetupRoutes(db *sql.DB, cfg *config.Config) *gin.Engine {
+	return NewRouter(NewRepositories(db), cfg)
+}
+
+// APIVersion is a version of the API, served under its own path prefix
+type APIVersion string
+
+const (
+	// V1 responds with the models as they are stored. It is deprecated.
+	V1 APIVersion = "v1"
+	// V2 responds with the snake_case DTOs of the dto package
+	V2 APIVersion = "v2"
+)
+
+// v1DeprecatedSince is when v2 replaced v1, sent in v1's Deprecation header
+var v1DeprecatedSince = time.Date(2026, time.October, 19, 0, 0, 0, 0, time.UTC)
+
+// prefixes maps each path prefix to the version it serves. The unversioned /api
+// prefix is v1, so clients written before versioning keep working.
+var prefixes = []struct {
+	prefix  string
+	version APIVersion
+}{
+	{"/api", V1},
+	{"/api/v1", V1},
+	{"/api/v2", V2},
+}
+
+// NewRouter creates the gin engine serving every route on top of repos
+func NewRouter(repos Repositories, cfg *config.Config) *gin.Engine {
 	// create a new gin engine
-	router := gin.Default()
+	router := gin.New()
+	if cfg.Log.Requests {
+		router.Use(middleware.RequestLogger())
+	}
+	router.Use(gin.Recovery())
 
-	router.Use(middleware.CORSMiddleware())
+	router.Use(middleware.CORSMiddleware(cfg.CORS))
+	router.Use(middleware.SecurityHeadersMiddleware(cfg.Security))
+	if cfg.Features.Compression {
+		// outside validation, which checks responses before they are compressed
+		router.Use(middleware.CompressionMiddleware())
+	}
 
-	// create repositories and handlers
-	itemRepo := repository.NewItemRepository(db)
-	itemHandler := handlers.NewItemHandler(itemRepo)
+	validation := middleware.ValidationMode(cfg.Features.OpenAPIValidation)
+	limiter, rateLimitKey := newLimiter(repos, cfg)
 
-	listRepo := repository.NewListRepository(db)
-	listHandler := handlers.NewListHandler(listRepo)
+	for _, p := range prefixes {
+		doc := Document(p.version, p.prefix)
+		api := router.Group(p.prefix)
+		if limiter != nil {
+			// before validation, so refused requests cost as little as possible
+			api.Use(middleware.RateLimitMiddleware(limiter, rateLimitKey, p.prefix))
+		}
+		switch p.version {
+		case V1:
+			api.Use(middleware.DeprecationMiddleware(v1DeprecatedSince, p.prefix, "/api/v2"))
+		case V2:
+			api.Use(handlers.UsePresenter(dto.Present))
+		}
+		api.Use(middleware.OpenAPIValidationMiddleware(doc, validation))
+		addRoutes(api, repos, cfg, doc)
+	}
 
-	// define routes that can be used
-	router.GET("/api/items", itemHandler.GetItems)
-	router.GET("/api/items/:id", itemHandler.GetItem)
-	// router.GET("/api/items/:list_id", itemHandler.GetItemFromList)
-	router.POST("/api/items", itemHandler.CreateItem)
-	router.DELETE("/api/items/:id", itemHandler.DeleteItem)
-	router.PUT("/api/items/:id", itemHandler.UpdateItem)
-
-	router.GET("/api/lists", listHandler.GetLists)
-	router.GET("/api/lists/:id", listHandler.GetList)
-	router.POST("/api/lists", listHandler.CreateList)
-	router.DELETE("/api/lists/:id", listHandler.DeleteList)
-	router.PUT("/api/lists/:id", listHandler.UpdateListTitle)
+	if cfg.Features.GraphQL {
+		schema, err := gql.NewSchema(gql.Resolvers{
+			Items:      service.NewItemService(repos.Items, repos.Revisions, repos.Activity),
+			Lists:      service.NewListService(repos.Lists, repos.Revisions, repos.Activity),
+			Activity:   repos.Activity,
+			SmartLists: repos.SmartLists,
+		})
+		if err != nil {
+			log.Fatalf("could not build GraphQL schema: %v", err)
+		}
+		graphqlHandler := handlers.NewGraphQLHandler(schema)
+		graphql := router.Group("/graphql")
+		if limiter != nil {
+			graphql.Use(middleware.RateLimitMiddleware(limiter, rateLimitKey, ""))
+		}
+		graphql.GET("", graphqlHandler.Query)
+		graphql.POST("", graphqlHandler.Query)
+	}
+
+	// runtime and cache metrics, for the admin
+	router.GET("/debug/vars", middleware.AdminAuthMiddleware(cfg.Auth.AdminToken), gin.WrapH(expvar.Handler()))
 
 	// for testing
 	router.GET("/", func(c *gin.Context) {
@@ -46,3 +176,87 @@ func SetupRoutes(db *sql.DB) *gin.Engine {
 	return router
 
 }
+
+// newLimiter creates the rate limiter cfg sets up, keeping its buckets in repos.RateLimits
+// or else in memory. It is nil when nothing is limited.
+func newLimiter(repos Repositories, cfg *config.Config) (*ratelimit.Limiter, middleware.RateLimitKey) {
+	limit, rules := cfg.RateLimit.ParsedLimit, cfg.RateLimit.ParsedRoutes
+	key := middleware.RateLimitKey(cfg.RateLimit.Key)
+	if limit.Unlimited() && len(rules) == 0 {
+		return nil, key
+	}
+
+	store := repos.RateLimits
+	if store == nil {
+		store = ratelimit.NewMemory()
+	}
+	return ratelimit.New(store, limit, rules), key
+}
+
+// addRoutes adds every API route to one version's group. Versions share handlers,
+// which respond through the presenter the group sets.
+func addRoutes(router *gin.RouterGroup, repos Repositories, cfg *config.Config, doc *openapi.Document) {
+	// create handlers
+	activityHandler := handlers.NewActivityHandler(repos.Activity)
+	itemHandler := handlers.NewItemHandler(repos.Items, repos.Revisions, repos.Activity)
+	listHandler := handlers.NewListHandler(repos.Lists, repos.Revisions, repos.Activity)
+	smartListHandler := handlers.NewSmartListHandler(repos.SmartLists, repos.Items)
+	calendarHandler := handlers.NewCalendarHandler(repos.Items)
+	feedHandler := handlers.NewCalendarFeedHandler(repos.Feeds, repos.Lists, repos.Items)
+	transferHandler := handlers.NewTransferHandler(repos.Lists, repos.Imports, repos.Activity)
+	searchHandler := handlers.NewSearchHandler(repos.Search)
+	docsHandler, err := handlers.NewDocsHandler(doc, doc.BasePath()+specPath)
+	if err != nil {
+		log.Fatalf("could not encode OpenAPI document: %v", err)
+	}
+
+	// define routes that can be used
+	router.GET("/items", itemHandler.GetItems)
+	router.GET("/items/:id", itemHandler.GetItem)
+	// router.GET("/items/:list_id", itemHandler.GetItemFromList)
+	router.POST("/items", itemHandler.CreateItem)
+	router.DELETE("/items/:id", itemHandler.DeleteItem)
+	router.PUT("/items/:id", itemHandler.UpdateItem)
+	router.GET("/items/:id/revisions", itemHandler.GetItemRevisions)
+	router.GET("/items/:id/revisions/:rev", itemHandler.GetItemRevision)
+	router.POST("/items/:id/revisions/:rev/revert", itemHandler.RevertItem)
+
+	router.GET("/lists", listHandler.GetLists)
+	router.GET("/lists/:id", listHandler.GetList)
+	router.POST("/lists", listHandler.CreateList)
+	router.DELETE("/lists/:id", listHandler.DeleteList)
+	router.PUT("/lists/:id", listHandler.UpdateListTitle)
+	router.GET("/lists/:id/revisions", listHandler.GetListRevisions)
+	router.POST("/lists/:id/revisions/:rev/revert", listHandler.RevertList)
+	router.GET("/lists/:id/activity", activityHandler.GetListActivity)
+	router.GET("/lists/:id/calendar.ics", feedHandler.GetListCalendar)
+	router.GET("/lists/:id/export", transferHandler.ExportList)
+	router.POST("/lists/import", transferHandler.ImportList)
+
+	router.GET("/smart-lists", smartListHandler.GetSmartLists)
+	router.GET("/smart-lists/:id", smartListHandler.GetSmartList)
+	router.GET("/smart-lists/:id/items", smartListHandler.GetSmartListItems)
+	router.POST("/smart-lists", smartListHandler.CreateSmartList)
+	router.PUT("/smart-lists/:id", smartListHandler.UpdateSmartList)
+	router.DELETE("/smart-lists/:id", smartListHandler.DeleteSmartList)
+
+	router.GET("/calendar", calendarHandler.GetCalendar)
+	router.GET("/calendar.ics", feedHandler.GetFeedCalendar)
+	router.GET("/feeds", feedHandler.GetFeeds)
+	router.POST("/feeds", feedHandler.CreateFeed)
+	router.DELETE("/feeds/:id", feedHandler.DeleteFeed)
+
+	router.GET("/activity", activityHandler.GetActivity)
+
+	router.GET("/search", searchHandler.Search)
+
+	backupHandler := handlers.NewBackupHandler(repos.Backup)
+	admin := router.Group("/admin", middleware.AdminAuthMiddleware(cfg.Auth.AdminToken))
+	admin.GET("/backup", backupHandler.Backup)
+	admin.POST("/restore", backupHandler.Restore)
+
+	if cfg.Features.Docs {
+		router.GET(specPath, docsHandler.GetSpec)
+		router.GET(docsPath, docsHandler.GetDocs)
+	}
+}
@@ -0,0 +1,112 @@
+package routes_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jennaborowy/fullstack-Go-Docker/config"
+	"github.com/jennaborowy/fullstack-Go-Docker/middleware"
+	"github.com/jennaborowy/fullstack-Go-Docker/mocks"
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/routes"
+	"go.uber.org/mock/gomock"
+)
+
+func TestAPIVersions(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	created := time.Date(2025, 10, 1, 12, 0, 0, 0, time.UTC)
+	list := &models.List{
+		ID:        1,
+		Title:     "Groceries",
+		CreatedAt: created,
+		UpdatedAt: created,
+		Items:     []models.Item{{ID: 2, Title: "Milk", Date: time.Date(2025, 10, 10, 0, 0, 0, 0, time.UTC), ListID: 1, CreatedAt: created, UpdatedAt: created}},
+	}
+
+	tests := []struct {
+		name                string
+		path                string
+		expectedBody        string
+		expectedDeprecation string
+		expectedLink        string
+	}{
+		{
+			name:                "unversioned paths are v1",
+			path:                "/api/lists/1",
+			expectedBody:        `{"ID":1,"Title":"Groceries","CreatedAt":"2025-10-01T12:00:00Z","UpdatedAt":"2025-10-01T12:00:00Z","Items":[{"id":2,"title":"Milk","item_date":"2025-10-10T00:00:00Z","content":"","list_id":1,"completed":false,"created_at":"2025-10-01T12:00:00Z","updated_at":"2025-10-01T12:00:00Z"}]}`,
+			expectedDeprecation: "@1792368000",
+			expectedLink:        `</api/v2/lists/1>; rel="successor-version"`,
+		},
+		{
+			name:                "v1",
+			path:                "/api/v1/lists/1",
+			expectedBody:        `{"ID":1,"Title":"Groceries","CreatedAt":"2025-10-01T12:00:00Z","UpdatedAt":"2025-10-01T12:00:00Z","Items":[{"id":2,"title":"Milk","item_date":"2025-10-10T00:00:00Z","content":"","list_id":1,"completed":false,"created_at":"2025-10-01T12:00:00Z","updated_at":"2025-10-01T12:00:00Z"}]}`,
+			expectedDeprecation: "@1792368000",
+			expectedLink:        `</api/v2/lists/1>; rel="successor-version"`,
+		},
+		{
+			name:         "v2",
+			path:         "/api/v2/lists/1",
+			expectedBody: `{"id":1,"title":"Groceries","created_at":"2025-10-01T12:00:00Z","updated_at":"2025-10-01T12:00:00Z","items":[{"id":2,"list_id":1,"title":"Milk","content":"","item_date":"2025-10-10","completed":false,"created_at":"2025-10-01T12:00:00Z","updated_at":"2025-10-01T12:00:00Z"}]}`,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			lists := mocks.NewMockListRepositoryInterface(ctrl)
+			lists.EXPECT().GetList(1, nil).Return(list, nil).Times(1)
+
+			router := routes.NewRouter(routes.Repositories{Lists: lists}, testConfig(middleware.ValidateAll))
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status %d, got %d: %s", http.StatusOK, w.Code, w.Body.String())
+			}
+			if w.Body.String() != tt.expectedBody {
+				t.Errorf("expected body %s, got %s", tt.expectedBody, w.Body.String())
+			}
+			if got := w.Header().Get("Deprecation"); got != tt.expectedDeprecation {
+				t.Errorf("expected Deprecation %q, got %q", tt.expectedDeprecation, got)
+			}
+			if got := w.Header().Get("Link"); got != tt.expectedLink {
+				t.Errorf("expected Link %q, got %q", tt.expectedLink, got)
+			}
+		})
+	}
+}
+
+func TestAPIV2EmptyCollections(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	ctrl := gomock.NewController(t)
+	lists := mocks.NewMockListRepositoryInterface(ctrl)
+	lists.EXPECT().GetAllLists(models.ListInclude{}, nil).Return(nil, nil).Times(2)
+
+	router := routes.NewRouter(routes.Repositories{Lists: lists}, testConfig(middleware.ValidateAll))
+
+	for path, expected := range map[string]string{"/api/v1/lists": "null", "/api/v2/lists": "[]"} {
+		req := httptest.NewRequest(http.MethodGet, path, nil)
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+
+		if w.Code != http.StatusOK || w.Body.String() != expected {
+			t.Errorf("%s: expected %s, got %d %s", path, expected, w.Code, w.Body.String())
+		}
+	}
+}
+
+// testConfig returns the default configuration, validating traffic against the OpenAPI
+// document in the given mode, or the default's when it is empty
+func testConfig(validation middleware.ValidationMode) *config.Config {
+	cfg := config.Default()
+	if validation != "" {
+		cfg.Features.OpenAPIValidation = string(validation)
+	}
+	return cfg
+}
@@ -0,0 +1,206 @@
+package routes_test
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"slices"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jennaborowy/fullstack-Go-Docker/config"
+	"github.com/jennaborowy/fullstack-Go-Docker/openapi"
+	"github.com/jennaborowy/fullstack-Go-Docker/routes"
+)
+
+func TestCORS(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	cors := config.CORSConfig{
+		AllowedOrigins: []string{"http://localhost:5173", "https://notes.example.com"},
+		AllowedMethods: []string{"GET", "POST"},
+		AllowedHeaders: []string{"Content-Type", "X-User"},
+		MaxAge:         10 * time.Minute,
+	}
+
+	tests := []struct {
+		name           string
+		cors           config.CORSConfig
+		method         string
+		header         map[string]string
+		expectedStatus int
+		expected       map[string]string // "" for a header that must be missing
+	}{
+		{
+			name:           "not cross-origin",
+			cors:           cors,
+			method:         http.MethodGet,
+			expectedStatus: http.StatusOK,
+			expected:       map[string]string{"Access-Control-Allow-Origin": ""},
+		},
+		{
+			name:           "allowed origin",
+			cors:           cors,
+			method:         http.MethodGet,
+			header:         map[string]string{"Origin": "https://notes.example.com"},
+			expectedStatus: http.StatusOK,
+			expected: map[string]string{
+				"Access-Control-Allow-Origin":      "https://notes.example.com",
+				"Access-Control-Allow-Credentials": "",
+				"Access-Control-Expose-Headers":    "Deprecation, Link, RateLimit-Limit, RateLimit-Remaining, RateLimit-Reset, RateLimit-Policy, Retry-After",
+				"Access-Control-Allow-Methods":     "",
+			},
+		},
+		{
+			name:           "other origin",
+			cors:           cors,
+			method:         http.MethodGet,
+			header:         map[string]string{"Origin": "https://evil.example.com"},
+			expectedStatus: http.StatusOK,
+			expected:       map[string]string{"Access-Control-Allow-Origin": "", "Access-Control-Expose-Headers": ""},
+		},
+		{
+			name:           "preflight",
+			cors:           cors,
+			method:         http.MethodOptions,
+			header:         map[string]string{"Origin": "http://localhost:5173", "Access-Control-Request-Method": "POST"},
+			expectedStatus: http.StatusNoContent,
+			expected: map[string]string{
+				"Access-Control-Allow-Origin":  "http://localhost:5173",
+				"Access-Control-Allow-Methods": "GET, POST",
+				"Access-Control-Allow-Headers": "Content-Type, X-User",
+				"Access-Control-Max-Age":       "600",
+			},
+		},
+		{
+			name:           "preflight from other origin",
+			cors:           cors,
+			method:         http.MethodOptions,
+			header:         map[string]string{"Origin": "https://evil.example.com", "Access-Control-Request-Method": "POST"},
+			expectedStatus: http.StatusForbidden,
+			expected:       map[string]string{"Access-Control-Allow-Origin": "", "Access-Control-Allow-Methods": ""},
+		},
+		{
+			name:           "any origin",
+			cors:           config.CORSConfig{AllowedOrigins: []string{"*"}},
+			method:         http.MethodGet,
+			header:         map[string]string{"Origin": "https://anywhere.example.com"},
+			expectedStatus: http.StatusOK,
+			expected:       map[string]string{"Access-Control-Allow-Origin": "https://anywhere.example.com"},
+		},
+		{
+			name:           "credentials",
+			cors:           config.CORSConfig{AllowedOrigins: []string{"https://notes.example.com"}, AllowCredentials: true},
+			method:         http.MethodGet,
+			header:         map[string]string{"Origin": "https://notes.example.com"},
+			expectedStatus: http.StatusOK,
+			expected: map[string]string{
+				"Access-Control-Allow-Origin":      "https://notes.example.com",
+				"Access-Control-Allow-Credentials": "true",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Default()
+			cfg.CORS = tt.cors
+			router := routes.NewRouter(routes.Repositories{}, cfg)
+
+			req := httptest.NewRequest(tt.method, "/", nil)
+			for name, value := range tt.header {
+				req.Header.Set(name, value)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if vary := w.Header().Values("Vary"); !slices.Contains(vary, "Origin") {
+				t.Errorf("expected Vary: Origin, got %q", vary)
+			}
+			for name, expected := range tt.expected {
+				if got := w.Header().Get(name); got != expected {
+					t.Errorf("expected %s %q, got %q", name, expected, got)
+				}
+			}
+		})
+	}
+}
+
+func TestSecurityHeaders(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	security := config.SecurityConfig{
+		HSTSMaxAge:            365 * 24 * time.Hour,
+		ContentSecurityPolicy: "default-src 'none'",
+		FrameOptions:          "DENY",
+	}
+
+	tests := []struct {
+		name     string
+		security config.SecurityConfig
+		path     string
+		header   map[string]string
+		expected map[string]string // "" for a header that must be missing
+	}{
+		{
+			name:     "plain HTTP",
+			security: security,
+			path:     "/",
+			expected: map[string]string{
+				"X-Content-Type-Options":    "nosniff",
+				"X-Frame-Options":           "DENY",
+				"Content-Security-Policy":   "default-src 'none'",
+				"Strict-Transport-Security": "",
+			},
+		},
+		{
+			name:     "behind an HTTPS proxy",
+			security: security,
+			path:     "/",
+			header:   map[string]string{"X-Forwarded-Proto": "https"},
+			expected: map[string]string{"Strict-Transport-Security": "max-age=31536000; includeSubDomains"},
+		},
+		{
+			name:     "docs page",
+			security: security,
+			path:     "/api/v2/docs",
+			expected: map[string]string{"Content-Security-Policy": openapi.DocsContentSecurityPolicy},
+		},
+		{
+			name:   "turned off",
+			path:   "/api/v2/docs",
+			header: map[string]string{"X-Forwarded-Proto": "https"},
+			expected: map[string]string{
+				"X-Content-Type-Options":    "nosniff",
+				"X-Frame-Options":           "",
+				"Content-Security-Policy":   "",
+				"Strict-Transport-Security": "",
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			cfg := config.Default()
+			cfg.Security = tt.security
+			router := routes.NewRouter(routes.Repositories{}, cfg)
+
+			req := httptest.NewRequest(http.MethodGet, tt.path, nil)
+			for name, value := range tt.header {
+				req.Header.Set(name, value)
+			}
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != http.StatusOK {
+				t.Fatalf("expected status 200, got %d: %s", w.Code, w.Body.String())
+			}
+			for name, expected := range tt.expected {
+				if got := w.Header().Get(name); got != expected {
+					t.Errorf("expected %s %q, got %q", name, expected, got)
+				}
+			}
+		})
+	}
+}
@@ -0,0 +1,225 @@
+package routes_test
+
+import (
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/gin-gonic/gin"
+	"github.com/jennaborowy/fullstack-Go-Docker/middleware"
+	"github.com/jennaborowy/fullstack-Go-Docker/mocks"
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/routes"
+	"go.uber.org/mock/gomock"
+)
+
+func TestOpenAPIValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	date := time.Date(2025, 10, 10, 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name           string
+		method         string
+		path           string
+		body           string
+		setupMocks     func(items *mocks.MockItemRepositoryInterface, revisions *mocks.MockRevisionRepositoryInterface)
+		expectedStatus int
+		expectedErrors []string
+	}{
+		{
+			name:   "valid update",
+			method: http.MethodPut,
+			path:   "/api/items/1",
+			body:   `{"title": "new title", "item_date": "2025-10-10", "completed": true}`,
+			setupMocks: func(items *mocks.MockItemRepositoryInterface, revisions *mocks.MockRevisionRepositoryInterface) {
+				existing := models.NewItem("old title", date, "", 1)
+				items.EXPECT().GetByID(1, nil).Return(existing, nil).Times(1)
+				completed := true
+				items.EXPECT().ChangeItem(1, models.ItemChanges{Title: "new title", Date: date, Completed: &completed}, gomock.Any()).Return(nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "invalid body",
+			method:         http.MethodPut,
+			path:           "/api/items/1",
+			body:           `{"title": 5, "item_date": "tomorrow", "completed": "yes"}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []string{"completed must be a boolean", "item_date must be a YYYY-MM-DD date", "title must be a string"},
+		},
+		{
+			name:           "missing required property",
+			method:         http.MethodPost,
+			path:           "/api/items",
+			body:           `{"title": "new", "list_id": 1}`,
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []string{"body is missing required property item_date"},
+		},
+		{
+			name:           "invalid path parameter",
+			method:         http.MethodGet,
+			path:           "/api/items/abc",
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []string{"path parameter id must be an integer"},
+		},
+		{
+			name:           "invalid query parameters",
+			method:         http.MethodGet,
+			path:           "/api/items?completed=maybe&due=someday&list_id=1&list_id=x",
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []string{
+				"query parameter list_id must be an integer",
+				"query parameter due must be one of overdue, today, this_week, next_7_days, this_month",
+				"query parameter completed must be true or false",
+			},
+		},
+		{
+			name:   "valid query parameters",
+			method: http.MethodGet,
+			path:   "/api/items?completed=true&due=today&list_id=1&list_id=2",
+			setupMocks: func(items *mocks.MockItemRepositoryInterface, revisions *mocks.MockRevisionRepositoryInterface) {
+				items.EXPECT().StreamFiltered(gomock.Any(), gomock.Any()).DoAndReturn(func(_ models.ItemFilter, fn func(models.Item) error) error {
+					return fn(*models.NewItem("title", date, "", 1))
+				}).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "sparse fieldset responses may leave out required properties",
+			method: http.MethodGet,
+			path:   "/api/v2/items?fields=id&fields=title",
+			setupMocks: func(items *mocks.MockItemRepositoryInterface, revisions *mocks.MockRevisionRepositoryInterface) {
+				items.EXPECT().StreamFiltered(gomock.Any(), gomock.Any()).DoAndReturn(func(_ models.ItemFilter, fn func(models.Item) error) error {
+					return fn(models.Item{ID: 1, Title: "title"})
+				}).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:   "comma separated fields",
+			method: http.MethodGet,
+			path:   "/api/v2/items/1?fields=id,title",
+			setupMocks: func(items *mocks.MockItemRepositoryInterface, revisions *mocks.MockRevisionRepositoryInterface) {
+				items.EXPECT().GetByID(1, models.Fields{"id", "title"}).Return(&models.Item{ID: 1, Title: "title"}, nil).Times(1)
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name:           "unknown field after a comma",
+			method:         http.MethodGet,
+			path:           "/api/v2/items/1?fields=id,owner",
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []string{"query parameter fields must be one of id, title, item_date, content, list_id, completed, created_at, updated_at"},
+		},
+		{
+			name:           "unknown field",
+			method:         http.MethodGet,
+			path:           "/api/items/1?fields=owner",
+			expectedStatus: http.StatusBadRequest,
+			expectedErrors: []string{"query parameter fields must be one of id, title, item_date, content, list_id, completed, created_at, updated_at"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			ctrl := gomock.NewController(t)
+			items := mocks.NewMockItemRepositoryInterface(ctrl)
+			revisions := mocks.NewMockRevisionRepositoryInterface(ctrl)
+			activity := mocks.NewMockActivityRepositoryInterface(ctrl)
+			activity.EXPECT().LogActivity(gomock.Any()).Return(nil).AnyTimes()
+			if tt.setupMocks != nil {
+				tt.setupMocks(items, revisions)
+			}
+
+			router := routes.NewRouter(routes.Repositories{Items: items, Revisions: revisions, Activity: activity},
+				testConfig(middleware.ValidateAll))
+
+			req := httptest.NewRequest(tt.method, tt.path, strings.NewReader(tt.body))
+			req.Header.Set("Content-Type", "application/json")
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Fatalf("expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+			if tt.expectedErrors == nil {
+				return
+			}
+
+			if contentType := w.Header().Get("Content-Type"); !strings.HasPrefix(contentType, "application/problem+json") {
+				t.Errorf("expected a problem, got content type %s", contentType)
+			}
+			var problem middleware.Problem
+			if err := json.Unmarshal(w.Body.Bytes(), &problem); err != nil {
+				t.Fatalf("invalid problem: %v", err)
+			}
+			if strings.Join(problem.Errors, "\n") != strings.Join(tt.expectedErrors, "\n") {
+				t.Errorf("expected errors %q, got %q", tt.expectedErrors, problem.Errors)
+			}
+		})
+	}
+}
+
+func TestOpenAPIResponseValidation(t *testing.T) {
+	gin.SetMode(gin.TestMode)
+	doc := routes.Document(routes.V1, "/api")
+
+	tests := []struct {
+		name           string
+		mode           middleware.ValidationMode
+		handler        gin.HandlerFunc
+		expectedStatus int
+	}{
+		{
+			name: "matching response",
+			mode: middleware.ValidateAll,
+			handler: func(c *gin.Context) {
+				c.JSON(http.StatusOK, models.NewItem("title", time.Now(), "", 1))
+			},
+			expectedStatus: http.StatusOK,
+		},
+		{
+			name: "wrong shape",
+			mode: middleware.ValidateAll,
+			handler: func(c *gin.Context) {
+				c.JSON(http.StatusOK, gin.H{"ID": 1, "Title": "title"})
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "undocumented status",
+			mode: middleware.ValidateAll,
+			handler: func(c *gin.Context) {
+				c.JSON(http.StatusConflict, gin.H{"error": "conflict"})
+			},
+			expectedStatus: http.StatusInternalServerError,
+		},
+		{
+			name: "responses aren't checked in requests mode",
+			mode: middleware.ValidateRequests,
+			handler: func(c *gin.Context) {
+				c.JSON(http.StatusOK, gin.H{"ID": 1, "Title": "title"})
+			},
+			expectedStatus: http.StatusOK,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			router := gin.New()
+			router.Use(middleware.OpenAPIValidationMiddleware(doc, tt.mode))
+			router.PUT("/api/items/:id", tt.handler)
+
+			req := httptest.NewRequest(http.MethodPut, "/api/items/1", strings.NewReader(`{"title": "title", "item_date": "2025-10-10"}`))
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			if w.Code != tt.expectedStatus {
+				t.Errorf("expected status %d, got %d: %s", tt.expectedStatus, w.Code, w.Body.String())
+			}
+		})
+	}
+}
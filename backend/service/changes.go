@@ -0,0 +1,69 @@
+package service
+
+import (
+	"log"
+	"sync"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+)
+
+// changeBuffer is how many changes a subscriber can fall behind by before it misses some
+const changeBuffer = 64
+
+// Changes is an activity repository that also tells subscribers about every entry
+// logged through it, so changes made through any API can be watched as they happen.
+// Only changes made by this process are seen.
+type Changes struct {
+	repository.ActivityRepositoryInterface
+
+	mu          sync.Mutex
+	subscribers map[chan models.Activity]struct{}
+}
+
+// NewChanges wraps repo so the activity it logs is also sent to subscribers
+func NewChanges(repo repository.ActivityRepositoryInterface) *Changes {
+	return &Changes{
+		ActivityRepositoryInterface: repo,
+		subscribers:                 make(map[chan models.Activity]struct{}),
+	}
+}
+
+// LogActivity appends an entry to the activity log, then sends it to every subscriber
+func (c *Changes) LogActivity(activity *models.Activity) error {
+	if err := c.ActivityRepositoryInterface.LogActivity(activity); err != nil {
+		return err
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	for ch := range c.subscribers {
+		select {
+		case ch <- *activity:
+		default:
+			// never hold up a change for a slow subscriber
+			log.Printf("dropped %s of %s %d for a slow subscriber", activity.Action, activity.EntityType, activity.EntityID)
+		}
+	}
+	return nil
+}
+
+// Subscribe returns a channel receiving every change from now on, and a function
+// that unsubscribes and closes it
+func (c *Changes) Subscribe() (<-chan models.Activity, func()) {
+	ch := make(chan models.Activity, changeBuffer)
+
+	c.mu.Lock()
+	c.subscribers[ch] = struct{}{}
+	c.mu.Unlock()
+
+	var once sync.Once
+	return ch, func() {
+		once.Do(func() {
+			c.mu.Lock()
+			delete(c.subscribers, ch)
+			c.mu.Unlock()
+			close(ch)
+		})
+	}
+}
@@ -0,0 +1,164 @@
+package service
+
+import (
+	"errors"
+	"time"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+)
+
+// ErrInvalidRevision is returned when a revision can't be reverted to
+var ErrInvalidRevision = errors.New("revision has an invalid date")
+
+// ItemService creates and changes items
+type ItemService struct {
+	repo      repository.ItemRepositoryInterface
+	revisions repository.RevisionRepositoryInterface
+	activity  repository.ActivityRepositoryInterface
+}
+
+// NewItemService creates a new ItemService
+func NewItemService(repo repository.ItemRepositoryInterface, revisions repository.RevisionRepositoryInterface, activity repository.ActivityRepositoryInterface) *ItemService {
+	return &ItemService{repo: repo, revisions: revisions, activity: activity}
+}
+
+// Get returns an item, or repository.ErrNotFound. Only fields need be read, none reading all of them.
+func (s *ItemService) Get(id int, fields models.Fields) (*models.Item, error) {
+	return s.repo.GetByID(id, fields)
+}
+
+// Find returns every item matching filter, or every item for an empty filter
+func (s *ItemService) Find(filter models.ItemFilter) ([]models.Item, error) {
+	if filter.IsEmpty() {
+		return s.repo.GetAll()
+	}
+	return s.repo.GetFiltered(filter)
+}
+
+// Stream calls fn with each item matching filter, or every item for an empty filter, in
+// the order Find returns them. Items are read as they are streamed rather than all at once.
+func (s *ItemService) Stream(filter models.ItemFilter, fn func(item models.Item) error) error {
+	return s.repo.StreamFiltered(filter, fn)
+}
+
+// Create adds an item to a list
+func (s *ItemService) Create(user, title string, date time.Time, content string, listID int) (*models.Item, error) {
+	item, err := s.repo.CreateItem(title, date, content, listID)
+	if err != nil {
+		return nil, err
+	}
+
+	LogActivity(s.activity, user, models.ActionCreate, models.EntityItem, item.ID, listID)
+	return item, nil
+}
+
+// Update changes an item, recording a revision of its title, content and date.
+// It returns repository.ErrNotFound for a missing item and repository.ErrListNotFound,
+// having changed nothing, when moving it to a missing list.
+func (s *ItemService) Update(user string, id int, changes models.ItemChanges) (*models.Item, error) {
+	existing, err := s.repo.GetByID(id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	revision := s.revision(user, id, existing, changes.Title, changes.Date, changes.Content)
+	if err := s.repo.ChangeItem(id, changes, revision); err != nil {
+		return nil, err
+	}
+
+	completed := existing.Completed
+	if changes.Completed != nil {
+		completed = *changes.Completed
+	}
+	listID := existing.ListID
+	if changes.ListID != nil {
+		listID = *changes.ListID
+	}
+
+	if listID != existing.ListID {
+		LogMove(s.activity, user, id, existing.ListID, listID)
+	}
+	// a move alone is logged as just that
+	if listID == existing.ListID || edited(existing, changes.Title, changes.Date, changes.Content, completed) {
+		LogActivity(s.activity, user, models.ActionUpdate, models.EntityItem, id, listID)
+	}
+
+	updated := models.NewItem(changes.Title, changes.Date, changes.Content, listID)
+	updated.ID = id
+	updated.Completed = completed
+	return updated, nil
+}
+
+// Delete removes an item, or returns repository.ErrNotFound
+func (s *ItemService) Delete(user string, id int) error {
+	// fetch item to get listID for the activity log
+	existing, err := s.repo.GetByID(id, nil)
+	if err != nil {
+		return err
+	}
+
+	if err := s.repo.DeleteItemByID(id); err != nil {
+		return err
+	}
+
+	LogActivity(s.activity, user, models.ActionDelete, models.EntityItem, id, existing.ListID)
+	return nil
+}
+
+// Revisions returns the change history of an item, oldest first
+func (s *ItemService) Revisions(id int) ([]models.Revision, error) {
+	return s.revisions.GetRevisions(models.EntityItem, id)
+}
+
+// Revision returns one revision of an item, or repository.ErrRevisionNotFound
+func (s *ItemService) Revision(id, rev int) (*models.Revision, error) {
+	return s.revisions.GetRevision(models.EntityItem, id, rev)
+}
+
+// Revert rolls an item back to how it was before the given revision.
+// The revert is itself recorded as a new revision.
+func (s *ItemService) Revert(user string, id, rev int) (*models.Item, error) {
+	revision, err := s.revisions.GetRevision(models.EntityItem, id, rev)
+	if err != nil {
+		return nil, err
+	}
+
+	date, err := time.Parse("2006-01-02", revision.Old["item_date"])
+	if err != nil {
+		return nil, ErrInvalidRevision
+	}
+	title, content := revision.Old["title"], revision.Old["content"]
+
+	existing, err := s.repo.GetByID(id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	// a revert changes what revisions record, leaving the item on its list
+	changes := models.ItemChanges{Title: title, Date: date, Content: content}
+	if err := s.repo.ChangeItem(id, changes, s.revision(user, id, existing, title, date, content)); err != nil {
+		return nil, err
+	}
+
+	LogActivity(s.activity, user, models.ActionRevert, models.EntityItem, id, existing.ListID)
+
+	reverted := models.NewItem(title, date, content, existing.ListID)
+	reverted.ID = id
+	reverted.Completed = existing.Completed
+	return reverted, nil
+}
+
+// edited reports whether an update changes more of an item than the list it is on
+func edited(existing *models.Item, title string, date time.Time, content string, completed bool) bool {
+	return title != existing.Title || content != existing.Content || completed != existing.Completed ||
+		date.Format("2006-01-02") != existing.Date.Format("2006-01-02")
+}
+
+// revision is the revision of an item changing to title, date and content, or nil when
+// none of them change
+func (s *ItemService) revision(user string, id int, existing *models.Item, title string, date time.Time, content string) *models.Revision {
+	oldValues := models.ItemSnapshot(existing.Title, existing.Date, existing.Content)
+	newValues := models.ItemSnapshot(title, date, content)
+	return changed(models.NewRevision(models.EntityItem, id, user, oldValues, newValues))
+}
@@ -0,0 +1,101 @@
+package service
+
+import (
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+)
+
+// ListService creates and changes lists
+type ListService struct {
+	repo      repository.ListRepositoryInterface
+	revisions repository.RevisionRepositoryInterface
+	activity  repository.ActivityRepositoryInterface
+}
+
+// NewListService creates a new ListService
+func NewListService(repo repository.ListRepositoryInterface, revisions repository.RevisionRepositoryInterface, activity repository.ActivityRepositoryInterface) *ListService {
+	return &ListService{repo: repo, revisions: revisions, activity: activity}
+}
+
+// Get returns a list with its items, or repository.ErrNotFound. Only fields need be read,
+// none reading all of them.
+func (s *ListService) Get(id int, fields models.Fields) (*models.List, error) {
+	return s.repo.GetList(id, fields)
+}
+
+// All returns every list, with its items and item counts if asked. Only fields need be read.
+func (s *ListService) All(include models.ListInclude, fields models.Fields) ([]models.List, error) {
+	return s.repo.GetAllLists(include, fields)
+}
+
+// Create adds an empty list
+func (s *ListService) Create(user, title string) (*models.List, error) {
+	list, err := s.repo.CreateList(title)
+	if err != nil {
+		return nil, err
+	}
+
+	LogActivity(s.activity, user, models.ActionCreate, models.EntityList, int(list.ID), int(list.ID))
+	return list, nil
+}
+
+// Rename changes a list's title, recording a revision. It returns repository.ErrNotFound
+// for a missing list.
+func (s *ListService) Rename(user string, id int, title string) (*models.List, error) {
+	// fetch list to record the old title
+	existing, err := s.repo.GetList(id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	updated, err := s.repo.UpdateTitle(id, title, s.revision(user, id, existing.Title, title))
+	if err != nil {
+		return nil, err
+	}
+
+	LogActivity(s.activity, user, models.ActionUpdate, models.EntityList, id, id)
+	return updated, nil
+}
+
+// Delete removes a list and its items
+func (s *ListService) Delete(user string, id int) error {
+	if err := s.repo.DeleteList(id); err != nil {
+		return err
+	}
+
+	LogActivity(s.activity, user, models.ActionDelete, models.EntityList, id, id)
+	return nil
+}
+
+// Revisions returns the title history of a list, oldest first
+func (s *ListService) Revisions(id int) ([]models.Revision, error) {
+	return s.revisions.GetRevisions(models.EntityList, id)
+}
+
+// Revert rolls a list's title back to what it was before the given revision.
+// The revert is itself recorded as a new revision.
+func (s *ListService) Revert(user string, id, rev int) (*models.List, error) {
+	revision, err := s.revisions.GetRevision(models.EntityList, id, rev)
+	if err != nil {
+		return nil, err
+	}
+
+	existing, err := s.repo.GetList(id, nil)
+	if err != nil {
+		return nil, err
+	}
+
+	title := revision.Old["title"]
+	updated, err := s.repo.UpdateTitle(id, title, s.revision(user, id, existing.Title, title))
+	if err != nil {
+		return nil, err
+	}
+
+	LogActivity(s.activity, user, models.ActionRevert, models.EntityList, id, id)
+	return updated, nil
+}
+
+// revision is the revision of a list's title changing, or nil when it stays the same
+func (s *ListService) revision(user string, id int, oldTitle, newTitle string) *models.Revision {
+	return changed(models.NewRevision(models.EntityList, id, user, models.ListSnapshot(oldTitle), models.ListSnapshot(newTitle)))
+}
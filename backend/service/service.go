@@ -0,0 +1,38 @@
+// service package holds the business rules shared by every API: the REST handlers,
+// GraphQL and gRPC. Changes made through it record revisions and activity.
+package service
+
+import (
+	"log"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+)
+
+// LogActivity appends an entry to the activity log for user.
+// The action has already happened, so a failure here is logged rather than returned.
+func LogActivity(repo repository.ActivityRepositoryInterface, user, action, entityType string, entityID, listID int) {
+	activity := models.NewActivity(user, action, entityType, entityID, listID)
+	if err := repo.LogActivity(activity); err != nil {
+		log.Printf("failed to log %s of %s %d: %v", action, entityType, entityID, err)
+	}
+}
+
+// LogMove appends an entry to the activity log for user moving an item from one list to
+// another, which shows in the feeds of both lists
+func LogMove(repo repository.ActivityRepositoryInterface, user string, itemID, fromListID, toListID int) {
+	activity := models.NewActivity(user, models.ActionMove, models.EntityItem, itemID, toListID)
+	activity.FromListID = fromListID
+	if err := repo.LogActivity(activity); err != nil {
+		log.Printf("failed to log %s of %s %d: %v", activity.Action, activity.EntityType, itemID, err)
+	}
+}
+
+// changed returns revision for the repository to store along with its change, or nil
+// when it records no changes
+func changed(revision *models.Revision) *models.Revision {
+	if len(revision.Changes) == 0 {
+		return nil
+	}
+	return revision
+}
@@ -0,0 +1,96 @@
+package main
+
+import (
+	"database/sql"
+	"expvar"
+	"fmt"
+	"log"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/config"
+	"github.com/jennaborowy/fullstack-Go-Docker/database"
+	"github.com/jennaborowy/fullstack-Go-Docker/ratelimit"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository/cache"
+	"github.com/jennaborowy/fullstack-Go-Docker/routes"
+)
+
+// openStorage connects to the storage backend cfg.Storage selects and creates its repositories.
+// The database is nil for the in-memory storage.
+func openStorage(cfg *config.Config) (routes.Repositories, *sql.DB, error) {
+	switch cfg.Database.Storage {
+	case config.StoragePostgres:
+		log.Println("Connecting to DB with URL:", config.RedactURL(cfg.Database.URL))
+		db, err := database.Connect(cfg.Database.URL, database.Pool{
+			MaxOpenConns:    cfg.Database.MaxOpenConns,
+			MaxIdleConns:    cfg.Database.MaxIdleConns,
+			ConnMaxLifetime: cfg.Database.ConnMaxLifetime,
+		})
+		if err != nil {
+			return routes.Repositories{}, nil, fmt.Errorf("failed to connect to database: %w", err)
+		}
+		log.Println("Database connected successfully")
+
+		if err := database.Migrate(db); err != nil {
+			db.Close()
+			return routes.Repositories{}, nil, fmt.Errorf("failed to migrate database: %w", err)
+		}
+		return routes.NewRepositories(db), db, nil
+
+	case config.StorageSQLite:
+		db, err := database.ConnectSQLite(cfg.Database.SQLitePath)
+		if err != nil {
+			return routes.Repositories{}, nil, fmt.Errorf("failed to open SQLite database: %w", err)
+		}
+		log.Println("Using SQLite database", cfg.Database.SQLitePath)
+		return routes.NewSQLiteRepositories(db), db, nil
+
+	case config.StorageMemory:
+		log.Println("Using in-memory storage, nothing is saved when the server stops")
+		return routes.NewMemoryRepositories(), nil, nil
+
+	default:
+		return routes.Repositories{}, nil, fmt.Errorf("unknown STORAGE %q, expected %s, %s or %s",
+			cfg.Database.Storage, config.StoragePostgres, config.StorageSQLite, config.StorageMemory)
+	}
+}
+
+// rateLimitStore shares rate limits between replicas through the database, when
+// RATE_LIMIT_STORE asks for it. The config makes sure the storage is Postgres then.
+func rateLimitStore(repos routes.Repositories, db *sql.DB, cfg *config.Config) routes.Repositories {
+	if cfg.RateLimit.Store == config.RateLimitStorePostgres {
+		repos.RateLimits = ratelimit.NewPostgres(db)
+		log.Println("Sharing rate limits through the database")
+	}
+	return repos
+}
+
+// cacheRepositories puts the list and item repositories behind a read-through cache,
+// unless it is turned off or the storage is in memory anyway, and has imports and
+// restores invalidate it. The cache's hits and misses are published as the "cache" expvar.
+func cacheRepositories(repos routes.Repositories, cfg *config.Config) (routes.Repositories, error) {
+	if cfg.Cache.TTL == 0 || cfg.Database.Storage == config.StorageMemory {
+		return repos, nil
+	}
+
+	var store cache.Store = cache.NewLRU(cfg.Cache.Size)
+	if cfg.Cache.RedisURL != "" {
+		redis, err := cache.NewRedis(cfg.Cache.RedisURL)
+		if err != nil {
+			return routes.Repositories{}, err
+		}
+		store = redis
+		log.Printf("Caching reads in Redis for %v", cfg.Cache.TTL)
+	} else {
+		log.Printf("Caching up to %d reads for %v", cfg.Cache.Size, cfg.Cache.TTL)
+	}
+
+	c := cache.New(store, cfg.Cache.TTL)
+	expvar.Publish("cache", expvar.Func(func() any { return c.Stats() }))
+
+	repos.Items = cache.NewItemRepository(repos.Items, c)
+	repos.Lists = cache.NewListRepository(repos.Lists, c)
+	repos.Imports = cache.NewImportRepository(repos.Imports, c)
+	if repos.Backup != nil {
+		repos.Backup = cache.NewBackupRepository(repos.Backup, c)
+	}
+	return repos, nil
+}
@@ -0,0 +1,175 @@
+package transfer
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+	"strings"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+)
+
+// csvColumns are the columns written on export. List columns are repeated on every row;
+// a row with list columns but no item fields stands for a list without items.
+var csvColumns = []string{
+	"list_id", "list_title", "list_created_at", "list_updated_at",
+	"id", "title", "item_date", "content", "completed", "created_at", "updated_at",
+}
+
+func init() {
+	register(Format{
+		Name:        "csv",
+		ContentType: "text/csv",
+		Extension:   ".csv",
+		NewReader:   newCSVReader,
+		Write:       writeCSV,
+	})
+}
+
+func writeCSV(w io.Writer, list *models.List) error {
+	cw := csv.NewWriter(w)
+	if err := cw.Write(csvColumns); err != nil {
+		return err
+	}
+
+	listFields := []string{
+		strconv.FormatInt(list.ID, 10), list.Title, formatTimestamp(list.CreatedAt), formatTimestamp(list.UpdatedAt),
+	}
+
+	if len(list.Items) == 0 {
+		if err := cw.Write(append(listFields, "", "", "", "", "", "", "")); err != nil {
+			return err
+		}
+	}
+
+	for _, item := range list.Items {
+		record := append(append([]string{}, listFields...),
+			strconv.Itoa(item.ID),
+			item.Title,
+			item.Date.Format("2006-01-02"),
+			item.Content,
+			strconv.FormatBool(item.Completed),
+			formatTimestamp(item.CreatedAt),
+			formatTimestamp(item.UpdatedAt),
+		)
+		if err := cw.Write(record); err != nil {
+			return err
+		}
+	}
+
+	cw.Flush()
+	return cw.Error()
+}
+
+// csvReader streams items from CSV with a header row naming the columns.
+// Only title and item_date are required; columns can be in any order.
+type csvReader struct {
+	r       *csv.Reader
+	columns map[string]int
+	list    models.List
+	row     int
+	err     error
+}
+
+func newCSVReader(r io.Reader) Reader {
+	cr := csv.NewReader(r)
+	cr.ReuseRecord = true
+	return &csvReader{r: cr}
+}
+
+func (r *csvReader) List() models.List {
+	return r.list
+}
+
+func (r *csvReader) Next() (*models.Item, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	if r.columns == nil {
+		if err := r.readHeader(); err != nil {
+			r.err = err
+			return nil, err
+		}
+	}
+
+	for {
+		record, err := r.r.Read()
+		r.row++
+		if errors.Is(err, io.EOF) {
+			r.err = io.EOF
+			return nil, io.EOF
+		}
+
+		var parseErr *csv.ParseError
+		if errors.As(err, &parseErr) {
+			return nil, &RowError{Row: r.row, Message: parseErr.Err.Error()}
+		}
+		if err != nil {
+			r.err = err
+			return nil, err
+		}
+
+		get := func(column string) string {
+			if i, ok := r.columns[column]; ok && i < len(record) {
+				return record[i]
+			}
+			return ""
+		}
+
+		if r.list.Title == "" {
+			r.list.Title = get("list_title")
+		}
+		if r.list.CreatedAt.IsZero() {
+			if r.list.CreatedAt, err = parseTimestamp(get("list_created_at")); err != nil {
+				return nil, &RowError{Row: r.row, Message: "invalid list_created_at " + strconv.Quote(get("list_created_at"))}
+			}
+		}
+		if r.list.UpdatedAt.IsZero() {
+			if r.list.UpdatedAt, err = parseTimestamp(get("list_updated_at")); err != nil {
+				return nil, &RowError{Row: r.row, Message: "invalid list_updated_at " + strconv.Quote(get("list_updated_at"))}
+			}
+		}
+
+		fields := itemFields{
+			Title:     get("title"),
+			ItemDate:  get("item_date"),
+			Content:   get("content"),
+			Completed: get("completed"),
+			CreatedAt: get("created_at"),
+			UpdatedAt: get("updated_at"),
+		}
+
+		// a list-only row, written for lists without items
+		if fields == (itemFields{}) {
+			continue
+		}
+
+		return parseItem(r.row, fields)
+	}
+}
+
+func (r *csvReader) readHeader() error {
+	header, err := r.r.Read()
+	r.row++
+	if errors.Is(err, io.EOF) {
+		return fmt.Errorf("%w: missing header row", ErrMalformed)
+	}
+	if err != nil {
+		return err
+	}
+
+	r.columns = map[string]int{}
+	for i, column := range header {
+		r.columns[strings.ToLower(strings.TrimSpace(column))] = i
+	}
+
+	for _, required := range []string{"title", "item_date"} {
+		if _, ok := r.columns[required]; !ok {
+			return fmt.Errorf("%w: missing %s column", ErrMalformed, required)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,192 @@
+package transfer
+
+import (
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+)
+
+func init() {
+	register(Format{
+		Name:        "json",
+		ContentType: "application/json",
+		Extension:   ".json",
+		NewReader:   newJSONReader,
+		Write:       writeJSON,
+	})
+}
+
+// jsonList is the exported JSON document of a list
+type jsonList struct {
+	ID        int64      `json:"id"`
+	Title     string     `json:"title"`
+	CreatedAt string     `json:"created_at,omitempty"`
+	UpdatedAt string     `json:"updated_at,omitempty"`
+	Items     []jsonItem `json:"items"`
+}
+
+type jsonItem struct {
+	ID        int    `json:"id,omitempty"`
+	Title     string `json:"title"`
+	ItemDate  string `json:"item_date"`
+	Content   string `json:"content"`
+	ListID    int    `json:"list_id,omitempty"`
+	Completed bool   `json:"completed"`
+	CreatedAt string `json:"created_at,omitempty"`
+	UpdatedAt string `json:"updated_at,omitempty"`
+}
+
+func writeJSON(w io.Writer, list *models.List) error {
+	doc := jsonList{
+		ID:        list.ID,
+		Title:     list.Title,
+		CreatedAt: formatTimestamp(list.CreatedAt),
+		UpdatedAt: formatTimestamp(list.UpdatedAt),
+		Items:     make([]jsonItem, 0, len(list.Items)),
+	}
+	for _, item := range list.Items {
+		doc.Items = append(doc.Items, jsonItem{
+			ID:        item.ID,
+			Title:     item.Title,
+			ItemDate:  item.Date.Format("2006-01-02"),
+			Content:   item.Content,
+			ListID:    item.ListID,
+			Completed: item.Completed,
+			CreatedAt: formatTimestamp(item.CreatedAt),
+			UpdatedAt: formatTimestamp(item.UpdatedAt),
+		})
+	}
+
+	encoder := json.NewEncoder(w)
+	encoder.SetIndent("", "  ")
+	return encoder.Encode(doc)
+}
+
+// jsonReader streams items out of a jsonList document without holding the whole
+// items array in memory. The list fields may come before or after the items.
+type jsonReader struct {
+	dec     *json.Decoder
+	list    models.List
+	row     int
+	started bool
+	inItems bool
+	err     error
+}
+
+func newJSONReader(r io.Reader) Reader {
+	return &jsonReader{dec: json.NewDecoder(r)}
+}
+
+func (r *jsonReader) List() models.List {
+	return r.list
+}
+
+func (r *jsonReader) Next() (*models.Item, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	item, err := r.next()
+	var rowErr *RowError
+	if err != nil && !errors.As(err, &rowErr) {
+		r.err = err
+	}
+	return item, err
+}
+
+func (r *jsonReader) next() (*models.Item, error) {
+	if !r.started {
+		if err := r.expectDelim('{'); err != nil {
+			return nil, err
+		}
+		r.started = true
+	}
+
+	for {
+		if r.inItems {
+			if r.dec.More() {
+				r.row++
+				var raw jsonItem
+				if err := r.dec.Decode(&raw); err != nil {
+					var typeErr *json.UnmarshalTypeError
+					if errors.As(err, &typeErr) {
+						return nil, &RowError{Row: r.row, Message: fmt.Sprintf("invalid %s", typeErr.Field)}
+					}
+					return nil, fmt.Errorf("%w: invalid JSON: %w", ErrMalformed, err)
+				}
+				return parseItem(r.row, itemFields{
+					Title:     raw.Title,
+					ItemDate:  raw.ItemDate,
+					Content:   raw.Content,
+					Completed: fmt.Sprint(raw.Completed),
+					CreatedAt: raw.CreatedAt,
+					UpdatedAt: raw.UpdatedAt,
+				})
+			}
+			if err := r.expectDelim(']'); err != nil {
+				return nil, err
+			}
+			r.inItems = false
+			continue
+		}
+
+		if !r.dec.More() {
+			if err := r.expectDelim('}'); err != nil {
+				return nil, err
+			}
+			return nil, io.EOF
+		}
+
+		token, err := r.dec.Token()
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid JSON: %w", ErrMalformed, err)
+		}
+		key, _ := token.(string)
+
+		switch key {
+		case "items":
+			if err := r.expectDelim('['); err != nil {
+				return nil, err
+			}
+			r.inItems = true
+		case "title":
+			if err := r.dec.Decode(&r.list.Title); err != nil {
+				return nil, &RowError{Row: 0, Message: "invalid title"}
+			}
+		case "created_at", "updated_at":
+			var value string
+			if err := r.dec.Decode(&value); err != nil {
+				return nil, &RowError{Row: 0, Message: "invalid " + key}
+			}
+			t, err := parseTimestamp(value)
+			if err != nil {
+				return nil, &RowError{Row: 0, Message: "invalid " + key}
+			}
+			if key == "created_at" {
+				r.list.CreatedAt = t
+			} else {
+				r.list.UpdatedAt = t
+			}
+		default:
+			// ids and unknown fields are skipped; imported rows always get new ids
+			var skip json.RawMessage
+			if err := r.dec.Decode(&skip); err != nil {
+				return nil, fmt.Errorf("%w: invalid JSON: %w", ErrMalformed, err)
+			}
+		}
+	}
+}
+
+func (r *jsonReader) expectDelim(delim json.Delim) error {
+	token, err := r.dec.Token()
+	if err != nil {
+		return fmt.Errorf("%w: invalid JSON: %w", ErrMalformed, err)
+	}
+	if token != delim {
+		return fmt.Errorf("%w: invalid JSON: expected %q, got %v", ErrMalformed, delim, token)
+	}
+	return nil
+}
@@ -0,0 +1,187 @@
+package transfer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+)
+
+// markdownTask matches a GitHub-flavored Markdown task list entry, at any depth
+var markdownTask = regexp.MustCompile(`^\s*[-*+] \[([ xX])\](?:\s+(.*))?$`)
+
+// markdownIndent prefixes the content lines written under a task
+const markdownIndent = "  "
+
+func init() {
+	register(Format{
+		Name:        "markdown",
+		ContentType: "text/markdown",
+		Extension:   ".md",
+		NewReader:   newMarkdownReader,
+		Write:       writeMarkdown,
+	})
+}
+
+// writeMarkdown writes the list as a heading followed by a task list. The item date is
+// written as a trailing due:YYYY-MM-DD, the same tag todo.txt uses, and content is
+// indented under its task.
+func writeMarkdown(w io.Writer, list *models.List) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintf(bw, "# %s\n\n", list.Title)
+
+	for _, item := range list.Items {
+		check := " "
+		if item.Completed {
+			check = "x"
+		}
+		fmt.Fprintf(bw, "- [%s] %s due:%s\n", check, item.Title, item.Date.Format("2006-01-02"))
+
+		if item.Content != "" {
+			for _, line := range strings.Split(item.Content, "\n") {
+				fmt.Fprintf(bw, "%s%s\n", markdownIndent, line)
+			}
+		}
+	}
+
+	return bw.Flush()
+}
+
+// markdownReader reads the first level one heading as the list title and every task
+// list entry as an item; other lines are ignored. Nested tasks become items of their
+// own, and tasks without a due date are due today.
+type markdownReader struct {
+	lines   *lineScanner
+	list    models.List
+	today   time.Time
+	err     error
+	started bool
+}
+
+func newMarkdownReader(r io.Reader) Reader {
+	return &markdownReader{lines: newLineScanner(r), today: today()}
+}
+
+func (r *markdownReader) List() models.List {
+	return r.list
+}
+
+func (r *markdownReader) Next() (*models.Item, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	for {
+		line, ok := r.lines.next()
+		if !ok {
+			r.err = r.lines.finish()
+			return nil, r.err
+		}
+
+		if title, found := strings.CutPrefix(line, "# "); found && r.list.Title == "" && !r.started {
+			r.list.Title = strings.TrimSpace(title)
+			continue
+		}
+
+		match := markdownTask.FindStringSubmatch(line)
+		if match == nil {
+			continue
+		}
+		r.started = true
+		row := r.lines.row
+
+		fields := itemFields{Completed: fmt.Sprint(match[1] != " ")}
+		fields.Title, fields.ItemDate = cutDueTag(match[2])
+		if fields.ItemDate == "" {
+			fields.ItemDate = r.today.Format("2006-01-02")
+		}
+
+		// content is every following line indented under the task
+		var content []string
+		for {
+			next, ok := r.lines.peek()
+			if !ok || markdownTask.MatchString(next) {
+				break
+			}
+			rest, indented := strings.CutPrefix(next, markdownIndent)
+			if !indented {
+				rest, indented = strings.CutPrefix(next, "\t")
+			}
+			if !indented {
+				break
+			}
+			content = append(content, rest)
+			r.lines.next()
+		}
+		fields.Content = strings.Join(content, "\n")
+
+		return parseItem(row, fields)
+	}
+}
+
+// cutDueTag removes a due:YYYY-MM-DD tag from text, returning the remaining text and the date
+func cutDueTag(text string) (string, string) {
+	var due string
+	words := strings.Fields(text)
+	kept := words[:0]
+	for _, word := range words {
+		if value, found := strings.CutPrefix(word, "due:"); found && due == "" {
+			due = value
+			continue
+		}
+		kept = append(kept, word)
+	}
+	return strings.Join(kept, " "), due
+}
+
+// today returns the current date, the item date given to tasks that don't have one
+func today() time.Time {
+	now := time.Now().UTC()
+	return time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+}
+
+// lineScanner reads a file line by line, counting rows and allowing one line of lookahead
+type lineScanner struct {
+	scanner *bufio.Scanner
+	row     int
+	peeked  *string
+}
+
+func newLineScanner(r io.Reader) *lineScanner {
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 1024*1024)
+	return &lineScanner{scanner: scanner}
+}
+
+func (s *lineScanner) next() (string, bool) {
+	line, ok := s.peek()
+	if ok {
+		s.peeked = nil
+		s.row++
+	}
+	return line, ok
+}
+
+func (s *lineScanner) peek() (string, bool) {
+	if s.peeked != nil {
+		return *s.peeked, true
+	}
+	if !s.scanner.Scan() {
+		return "", false
+	}
+	line := strings.TrimRight(s.scanner.Text(), "\r")
+	s.peeked = &line
+	return line, true
+}
+
+// finish returns io.EOF once every line has been read, or why reading stopped early
+func (s *lineScanner) finish() error {
+	if err := s.scanner.Err(); err != nil {
+		return fmt.Errorf("%w: %w", ErrMalformed, err)
+	}
+	return io.EOF
+}
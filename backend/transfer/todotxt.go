@@ -0,0 +1,174 @@
+package transfer
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+)
+
+var (
+	todoPriority = regexp.MustCompile(`^\(([A-Z])\) `)
+	todoDate     = regexp.MustCompile(`^\d{4}-\d{2}-\d{2} `)
+)
+
+func init() {
+	register(Format{
+		Name:        "todotxt",
+		ContentType: "text/plain",
+		Extension:   ".txt",
+		NewReader:   newTodoTxtReader,
+		Write:       writeTodoTxt,
+	})
+}
+
+// writeTodoTxt writes one todo.txt task per item. The list becomes a +project tag and
+// the item date a due: tag. Items have no priority of their own, so a leading (A) in
+// the title is treated as one. todo.txt has nowhere to put content, so it isn't exported.
+func writeTodoTxt(w io.Writer, list *models.List) error {
+	bw := bufio.NewWriter(w)
+	project := todoProject(list.Title)
+
+	for _, item := range list.Items {
+		var parts []string
+		priority, title := cutPriority(item.Title)
+
+		if item.Completed {
+			// completed tasks carry a completion date, and the priority moves to a pri: tag
+			completedAt := item.UpdatedAt
+			if completedAt.IsZero() {
+				completedAt = item.Date
+			}
+			parts = append(parts, "x", completedAt.UTC().Format("2006-01-02"))
+		} else if priority != "" {
+			parts = append(parts, "("+priority+")")
+		}
+
+		if !item.CreatedAt.IsZero() {
+			parts = append(parts, item.CreatedAt.UTC().Format("2006-01-02"))
+		} else if item.Completed {
+			// a completion date must be followed by a creation date
+			parts = append(parts, item.Date.Format("2006-01-02"))
+		}
+
+		parts = append(parts, title)
+		if project != "" {
+			parts = append(parts, "+"+project)
+		}
+		parts = append(parts, "due:"+item.Date.Format("2006-01-02"))
+		if item.Completed && priority != "" {
+			parts = append(parts, "pri:"+priority)
+		}
+
+		fmt.Fprintln(bw, strings.Join(parts, " "))
+	}
+
+	return bw.Flush()
+}
+
+// todoTxtReader reads todo.txt tasks. The first +project names the list and is removed
+// from every task it appears in; other projects and @contexts stay in the title, as
+// items have no tags. The due: tag sets the item date, defaulting to today.
+type todoTxtReader struct {
+	lines *lineScanner
+	list  models.List
+	today time.Time
+	err   error
+}
+
+func newTodoTxtReader(r io.Reader) Reader {
+	return &todoTxtReader{lines: newLineScanner(r), today: today()}
+}
+
+func (r *todoTxtReader) List() models.List {
+	return r.list
+}
+
+func (r *todoTxtReader) Next() (*models.Item, error) {
+	if r.err != nil {
+		return nil, r.err
+	}
+
+	for {
+		line, ok := r.lines.next()
+		if !ok {
+			r.err = r.lines.finish()
+			return nil, r.err
+		}
+		if strings.TrimSpace(line) == "" {
+			continue
+		}
+		return r.parseTask(r.lines.row, line)
+	}
+}
+
+func (r *todoTxtReader) parseTask(row int, line string) (*models.Item, error) {
+	fields := itemFields{Completed: "false"}
+	var priority, completedAt string
+
+	if rest, found := strings.CutPrefix(line, "x "); found {
+		fields.Completed = "true"
+		line = rest
+		if todoDate.MatchString(line) {
+			completedAt, line = line[:10], line[11:]
+		}
+	} else if match := todoPriority.FindStringSubmatch(line); match != nil {
+		priority = match[1]
+		line = line[len(match[0]):]
+	}
+
+	if todoDate.MatchString(line) {
+		fields.CreatedAt, line = line[:10]+"T00:00:00Z", line[11:]
+	}
+	if completedAt != "" {
+		fields.UpdatedAt = completedAt + "T00:00:00Z"
+	}
+
+	var words []string
+	for _, word := range strings.Fields(line) {
+		if project, found := strings.CutPrefix(word, "+"); found && project != "" {
+			if r.list.Title == "" {
+				r.list.Title = strings.ReplaceAll(project, "_", " ")
+			}
+			if todoProject(r.list.Title) == project {
+				continue
+			}
+		}
+		if value, found := strings.CutPrefix(word, "due:"); found && fields.ItemDate == "" {
+			fields.ItemDate = value
+			continue
+		}
+		if value, found := strings.CutPrefix(word, "pri:"); found && priority == "" && len(value) == 1 {
+			priority = value
+			continue
+		}
+		words = append(words, word)
+	}
+
+	fields.Title = strings.Join(words, " ")
+	if priority != "" && fields.Title != "" {
+		fields.Title = "(" + priority + ") " + fields.Title
+	}
+	if fields.ItemDate == "" {
+		fields.ItemDate = r.today.Format("2006-01-02")
+	}
+
+	return parseItem(row, fields)
+}
+
+// todoProject turns a list title into a +project tag, which can't contain spaces
+func todoProject(title string) string {
+	return strings.Join(strings.Fields(title), "_")
+}
+
+// cutPriority splits a leading todo.txt priority such as "(A) " off a title
+func cutPriority(title string) (string, string) {
+	if match := todoPriority.FindStringSubmatch(title); match != nil {
+		return match[1], title[len(match[0]):]
+	}
+	return "", title
+}
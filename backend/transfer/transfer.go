@@ -0,0 +1,236 @@
+// transfer package imports and exports lists in different file formats
+package transfer
+
+import (
+	"errors"
+	"fmt"
+	"io"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+)
+
+// maxRowErrors caps how many row errors an import reports, so a badly broken
+// file can't grow the response without bound
+const maxRowErrors = 100
+
+// ErrMalformed is returned when a file can't be read any further, as opposed to a
+// single invalid row
+var ErrMalformed = errors.New("malformed file")
+
+// Format is a file format lists can be imported from and exported to
+type Format struct {
+	Name        string
+	ContentType string
+	Extension   string
+	NewReader   func(r io.Reader) Reader
+	Write       func(w io.Writer, list *models.List) error
+}
+
+// Reader reads the items of an imported list one at a time
+type Reader interface {
+	// Next returns the next item, a *RowError for a row that failed validation
+	// (reading can continue after one), or io.EOF once every row has been read
+	Next() (*models.Item, error)
+	// List returns the list fields read so far. They are complete once Next has returned io.EOF.
+	List() models.List
+}
+
+// RowError describes why a single row of an import was rejected
+type RowError struct {
+	Row     int    `json:"row"` // 0 for problems with the list itself
+	Message string `json:"error"`
+}
+
+func (e *RowError) Error() string {
+	return fmt.Sprintf("row %d: %s", e.Row, e.Message)
+}
+
+// Result reports the outcome of an import
+type Result struct {
+	List     *models.List `json:"list,omitempty"`
+	Imported int          `json:"imported"`
+	Errors   []RowError   `json:"errors,omitempty"`
+}
+
+var formats = map[string]Format{}
+
+// register makes a format available by name, called from each format's init
+func register(format Format) {
+	formats[format.Name] = format
+}
+
+// Lookup returns the format with the given name
+func Lookup(name string) (Format, bool) {
+	format, ok := formats[strings.ToLower(name)]
+	return format, ok
+}
+
+// LookupByContentType returns the format for a MIME type or file name, if one matches
+func LookupByContentType(contentType, filename string) (Format, bool) {
+	contentType, _, _ = strings.Cut(contentType, ";")
+	contentType = strings.TrimSpace(strings.ToLower(contentType))
+	for _, format := range formats {
+		if contentType == format.ContentType {
+			return format, true
+		}
+	}
+	for _, format := range formats {
+		if filename != "" && strings.HasSuffix(strings.ToLower(filename), format.Extension) {
+			return format, true
+		}
+	}
+	return Format{}, false
+}
+
+// Names returns the names of every registered format, sorted
+func Names() []string {
+	names := make([]string, 0, len(formats))
+	for name := range formats {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
+
+// Import reads and validates every row from reader before adding them through an
+// importer from imports, so no transaction is held open while a slow upload is read.
+// When any row fails validation every error is reported and nothing is imported.
+// title, when not empty, replaces the list title read from the file.
+func Import(reader Reader, imports repository.ImportRepositoryInterface, title string) (*Result, error) {
+	result := &Result{}
+	var items []models.Item
+	for {
+		item, err := reader.Next()
+		if errors.Is(err, io.EOF) {
+			break
+		}
+
+		var rowErr *RowError
+		if errors.As(err, &rowErr) {
+			if len(result.Errors) < maxRowErrors {
+				result.Errors = append(result.Errors, *rowErr)
+			}
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+
+		if len(result.Errors) == 0 {
+			items = append(items, *item)
+		}
+	}
+
+	if len(result.Errors) > 0 {
+		return result, nil
+	}
+
+	list := reader.List()
+	if title != "" {
+		list.Title = title
+	}
+	if strings.TrimSpace(list.Title) == "" {
+		result.Errors = append(result.Errors, RowError{Row: 0, Message: "list title is required"})
+		return result, nil
+	}
+
+	importer, err := imports.BeginListImport()
+	if err != nil {
+		return nil, err
+	}
+	defer importer.Abort()
+
+	for _, item := range items {
+		if err := importer.AddItem(item); err != nil {
+			return nil, err
+		}
+	}
+
+	imported, err := importer.Finish(list)
+	if err != nil {
+		return nil, err
+	}
+	result.List = imported
+	result.Imported = len(items)
+	return result, nil
+}
+
+// itemFields holds the raw text of an item read from a file, before validation
+type itemFields struct {
+	Title     string
+	ItemDate  string
+	Content   string
+	Completed string
+	CreatedAt string
+	UpdatedAt string
+}
+
+// parseItem validates the raw fields of an item read from row
+func parseItem(row int, fields itemFields) (*models.Item, error) {
+	item := &models.Item{
+		Title:   strings.TrimSpace(fields.Title),
+		Content: fields.Content,
+	}
+
+	if item.Title == "" {
+		return nil, &RowError{Row: row, Message: "title is required"}
+	}
+
+	date, err := parseDate(fields.ItemDate)
+	if err != nil {
+		return nil, &RowError{Row: row, Message: "invalid item_date " + strconv.Quote(fields.ItemDate)}
+	}
+	item.Date = date
+
+	if fields.Completed != "" {
+		completed, err := strconv.ParseBool(fields.Completed)
+		if err != nil {
+			return nil, &RowError{Row: row, Message: "invalid completed " + strconv.Quote(fields.Completed)}
+		}
+		item.Completed = completed
+	}
+
+	if item.CreatedAt, err = parseTimestamp(fields.CreatedAt); err != nil {
+		return nil, &RowError{Row: row, Message: "invalid created_at " + strconv.Quote(fields.CreatedAt)}
+	}
+	if item.UpdatedAt, err = parseTimestamp(fields.UpdatedAt); err != nil {
+		return nil, &RowError{Row: row, Message: "invalid updated_at " + strconv.Quote(fields.UpdatedAt)}
+	}
+
+	return item, nil
+}
+
+// parseDate accepts a YYYY-MM-DD date or an RFC 3339 timestamp, keeping only the date
+func parseDate(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if t, err := time.Parse("2006-01-02", value); err == nil {
+		return t, nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return time.Time{}, err
+	}
+	return time.Date(t.Year(), t.Month(), t.Day(), 0, 0, 0, 0, time.UTC), nil
+}
+
+// parseTimestamp accepts an optional RFC 3339 timestamp
+func parseTimestamp(value string) (time.Time, error) {
+	value = strings.TrimSpace(value)
+	if value == "" {
+		return time.Time{}, nil
+	}
+	return time.Parse(time.RFC3339Nano, value)
+}
+
+// formatTimestamp formats an optional timestamp for export
+func formatTimestamp(t time.Time) string {
+	if t.IsZero() {
+		return ""
+	}
+	return t.UTC().Format(time.RFC3339Nano)
+}
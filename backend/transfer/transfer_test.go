@@ -0,0 +1,387 @@
+package transfer_test
+
+import (
+	"bytes"
+	"errors"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/jennaborowy/fullstack-Go-Docker/models"
+	"github.com/jennaborowy/fullstack-Go-Docker/repository"
+	"github.com/jennaborowy/fullstack-Go-Docker/transfer"
+)
+
+// fakeImporter collects what an import would have inserted
+type fakeImporter struct {
+	begun bool
+	items []models.Item
+	list  *models.List
+}
+
+func (f *fakeImporter) BeginListImport() (repository.ListImporter, error) {
+	f.begun = true
+	return f, nil
+}
+
+func (f *fakeImporter) AddItem(item models.Item) error {
+	f.items = append(f.items, item)
+	return nil
+}
+
+func (f *fakeImporter) Finish(list models.List) (*models.List, error) {
+	list.ID = 42
+	f.list = &list
+	return &list, nil
+}
+
+func (f *fakeImporter) Abort() error {
+	return nil
+}
+
+var exportedList = &models.List{
+	ID:        1,
+	Title:     "Daily, \"Tasks\"",
+	CreatedAt: time.Date(2025, 9, 1, 8, 30, 0, 0, time.UTC),
+	UpdatedAt: time.Date(2025, 9, 2, 9, 0, 0, 0, time.UTC),
+	Items: []models.Item{
+		{
+			ID: 1, Title: "First Item", Date: time.Date(2025, 10, 3, 0, 0, 0, 0, time.UTC),
+			Content: "multi\nline, with \"quotes\"", ListID: 1, Completed: true,
+			CreatedAt: time.Date(2025, 9, 1, 8, 31, 0, 0, time.UTC), UpdatedAt: time.Date(2025, 9, 3, 10, 0, 0, 0, time.UTC),
+		},
+		{
+			ID: 2, Title: "Second Item", Date: time.Date(2025, 10, 7, 0, 0, 0, 0, time.UTC),
+			ListID: 1, CreatedAt: time.Date(2025, 9, 1, 8, 32, 0, 0, time.UTC), UpdatedAt: time.Date(2025, 9, 1, 8, 32, 0, 0, time.UTC),
+		},
+	},
+}
+
+func TestRoundTrip(t *testing.T) {
+	for _, name := range []string{"csv", "json"} {
+		t.Run(name, func(t *testing.T) {
+			format, ok := transfer.Lookup(name)
+			if !ok {
+				t.Fatalf("format %s is not registered", name)
+			}
+
+			var buf bytes.Buffer
+			if err := format.Write(&buf, exportedList); err != nil {
+				t.Fatalf("failed to export: %v", err)
+			}
+
+			importer := &fakeImporter{}
+			result, err := transfer.Import(format.NewReader(&buf), importer, "")
+			if err != nil {
+				t.Fatalf("failed to import: %v", err)
+			}
+			if len(result.Errors) > 0 {
+				t.Fatalf("unexpected row errors: %+v", result.Errors)
+			}
+
+			if result.Imported != len(exportedList.Items) {
+				t.Errorf("expected %d items imported, got %d", len(exportedList.Items), result.Imported)
+			}
+			if importer.list.Title != exportedList.Title ||
+				!importer.list.CreatedAt.Equal(exportedList.CreatedAt) ||
+				!importer.list.UpdatedAt.Equal(exportedList.UpdatedAt) {
+				t.Errorf("list fields did not round trip: %+v", importer.list)
+			}
+
+			for i, item := range importer.items {
+				expected := exportedList.Items[i]
+				if item.Title != expected.Title || item.Content != expected.Content ||
+					!item.Date.Equal(expected.Date) || item.Completed != expected.Completed ||
+					!item.CreatedAt.Equal(expected.CreatedAt) || !item.UpdatedAt.Equal(expected.UpdatedAt) {
+					t.Errorf("item %d did not round trip:\nexpected %+v\ngot      %+v", i, expected, item)
+				}
+			}
+		})
+	}
+}
+
+func TestRoundTripEmptyList(t *testing.T) {
+	format, _ := transfer.Lookup("csv")
+
+	var buf bytes.Buffer
+	if err := format.Write(&buf, &models.List{ID: 3, Title: "Empty"}); err != nil {
+		t.Fatalf("failed to export: %v", err)
+	}
+
+	importer := &fakeImporter{}
+	result, err := transfer.Import(format.NewReader(&buf), importer, "")
+	if err != nil {
+		t.Fatalf("failed to import: %v", err)
+	}
+	if result.Imported != 0 || importer.list == nil || importer.list.Title != "Empty" {
+		t.Errorf("expected an empty list titled 'Empty', got %+v", result)
+	}
+}
+
+func TestImportReportsRowErrors(t *testing.T) {
+	tests := []struct {
+		name           string
+		format         string
+		input          string
+		expectedErrors []transfer.RowError
+	}{
+		{
+			name:   "csv",
+			format: "csv",
+			input: "list_title,title,item_date,completed\n" +
+				"Groceries,Milk,2025-10-01,false\n" +
+				"Groceries,,2025-10-02,false\n" +
+				"Groceries,Eggs,tomorrow,false\n" +
+				"Groceries,Bread,2025-10-03,nope\n" +
+				"Groceries,Butter,2025-10-04\n",
+			expectedErrors: []transfer.RowError{
+				{Row: 3, Message: "title is required"},
+				{Row: 4, Message: `invalid item_date "tomorrow"`},
+				{Row: 5, Message: `invalid completed "nope"`},
+				{Row: 6, Message: "wrong number of fields"},
+			},
+		},
+		{
+			name:   "json",
+			format: "json",
+			input: `{"title": "Groceries", "items": [
+				{"title": "Milk", "item_date": "2025-10-01"},
+				{"title": "Eggs", "item_date": "soon"},
+				{"title": "Bread", "item_date": "2025-10-03", "completed": "yes"}
+			]}`,
+			expectedErrors: []transfer.RowError{
+				{Row: 2, Message: `invalid item_date "soon"`},
+				{Row: 3, Message: "invalid completed"},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, _ := transfer.Lookup(tt.format)
+
+			importer := &fakeImporter{}
+			result, err := transfer.Import(format.NewReader(strings.NewReader(tt.input)), importer, "")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+
+			if len(result.Errors) != len(tt.expectedErrors) {
+				t.Fatalf("expected %d errors, got %+v", len(tt.expectedErrors), result.Errors)
+			}
+			for i, expected := range tt.expectedErrors {
+				if result.Errors[i] != expected {
+					t.Errorf("expected error %+v, got %+v", expected, result.Errors[i])
+				}
+			}
+
+			if importer.begun {
+				t.Errorf("expected the import not to be begun")
+			}
+			if result.Imported != 0 {
+				t.Errorf("expected nothing to be reported as imported, got %d", result.Imported)
+			}
+		})
+	}
+}
+
+func TestImportMalformedFile(t *testing.T) {
+	tests := []struct {
+		name   string
+		format string
+		input  string
+	}{
+		{name: "csv without required columns", format: "csv", input: "name,when\nMilk,2025-10-01\n"},
+		{name: "empty csv", format: "csv", input: ""},
+		{name: "truncated json", format: "json", input: `{"title": "Groceries", "items": [{"title": "Milk"`},
+		{name: "json array", format: "json", input: `[]`},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, _ := transfer.Lookup(tt.format)
+
+			_, err := transfer.Import(format.NewReader(strings.NewReader(tt.input)), &fakeImporter{}, "")
+			if !errors.Is(err, transfer.ErrMalformed) {
+				t.Errorf("expected ErrMalformed, got %v", err)
+			}
+		})
+	}
+}
+
+func TestImportTitleOverride(t *testing.T) {
+	format, _ := transfer.Lookup("csv")
+	input := "title,item_date\nMilk,2025-10-01\n"
+
+	importer := &fakeImporter{}
+	result, err := transfer.Import(format.NewReader(strings.NewReader(input)), importer, "Groceries")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if result.List == nil || result.List.Title != "Groceries" {
+		t.Errorf("expected list titled 'Groceries', got %+v", result)
+	}
+
+	importer = &fakeImporter{}
+	result, err = transfer.Import(format.NewReader(strings.NewReader(input)), importer, "")
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if len(result.Errors) != 1 || result.Errors[0].Row != 0 {
+		t.Errorf("expected a missing title error, got %+v", result.Errors)
+	}
+	if importer.begun {
+		t.Errorf("expected the import not to be begun")
+	}
+}
+
+func TestWriteText(t *testing.T) {
+	list := &models.List{
+		Title: "Daily Tasks",
+		Items: []models.Item{
+			exportedList.Items[0],
+			exportedList.Items[1],
+			{Title: "(B) Call mom @phone", Date: time.Date(2025, 10, 8, 0, 0, 0, 0, time.UTC)},
+		},
+	}
+
+	tests := []struct {
+		format   string
+		expected string
+	}{
+		{
+			format: "markdown",
+			expected: "# Daily Tasks\n\n" +
+				"- [x] First Item due:2025-10-03\n" +
+				"  multi\n" +
+				"  line, with \"quotes\"\n" +
+				"- [ ] Second Item due:2025-10-07\n" +
+				"- [ ] (B) Call mom @phone due:2025-10-08\n",
+		},
+		{
+			format: "todotxt",
+			expected: "x 2025-09-03 2025-09-01 First Item +Daily_Tasks due:2025-10-03\n" +
+				"2025-09-01 Second Item +Daily_Tasks due:2025-10-07\n" +
+				"(B) Call mom @phone +Daily_Tasks due:2025-10-08\n",
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			format, ok := transfer.Lookup(tt.format)
+			if !ok {
+				t.Fatalf("format %s is not registered", tt.format)
+			}
+
+			var buf bytes.Buffer
+			if err := format.Write(&buf, list); err != nil {
+				t.Fatalf("failed to export: %v", err)
+			}
+			if buf.String() != tt.expected {
+				t.Errorf("expected:\n%s\ngot:\n%s", tt.expected, buf.String())
+			}
+		})
+	}
+}
+
+func TestImportText(t *testing.T) {
+	date := func(day int) time.Time { return time.Date(2025, 10, day, 0, 0, 0, 0, time.UTC) }
+	now := time.Now().UTC()
+	today := time.Date(now.Year(), now.Month(), now.Day(), 0, 0, 0, 0, time.UTC)
+
+	tests := []struct {
+		name          string
+		format        string
+		input         string
+		expectedTitle string
+		expectedItems []models.Item
+	}{
+		{
+			name:   "markdown",
+			format: "markdown",
+			input: "# Groceries\n\nSome notes about the list.\n\n" +
+				"- [ ] Milk due:2025-10-01\n" +
+				"* [X] Eggs\n" +
+				"  free range\n" +
+				"\tsix of them\n" +
+				"    - [ ] Nested due:2025-10-02\n" +
+				"- not a task\n",
+			expectedTitle: "Groceries",
+			expectedItems: []models.Item{
+				{Title: "Milk", Date: date(1)},
+				{Title: "Eggs", Date: today, Completed: true, Content: "free range\nsix of them"},
+				{Title: "Nested", Date: date(2)},
+			},
+		},
+		{
+			name:   "todotxt",
+			format: "todotxt",
+			input: "(A) 2025-09-30 Call mom +Family @phone due:2025-10-01\n\n" +
+				"x 2025-10-02 2025-09-29 Buy gift +Family +Birthday due:2025-10-03 pri:C\n" +
+				"Plan party\n",
+			expectedTitle: "Family",
+			expectedItems: []models.Item{
+				{Title: "(A) Call mom @phone", Date: date(1), CreatedAt: time.Date(2025, 9, 30, 0, 0, 0, 0, time.UTC)},
+				{Title: "(C) Buy gift +Birthday", Date: date(3), Completed: true,
+					CreatedAt: time.Date(2025, 9, 29, 0, 0, 0, 0, time.UTC), UpdatedAt: date(2)},
+				{Title: "Plan party", Date: today},
+			},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			format, _ := transfer.Lookup(tt.format)
+
+			importer := &fakeImporter{}
+			result, err := transfer.Import(format.NewReader(strings.NewReader(tt.input)), importer, "")
+			if err != nil {
+				t.Fatalf("failed to import: %v", err)
+			}
+			if len(result.Errors) > 0 {
+				t.Fatalf("unexpected row errors: %+v", result.Errors)
+			}
+
+			if importer.list.Title != tt.expectedTitle {
+				t.Errorf("expected list title %q, got %q", tt.expectedTitle, importer.list.Title)
+			}
+			if len(importer.items) != len(tt.expectedItems) {
+				t.Fatalf("expected %d items, got %+v", len(tt.expectedItems), importer.items)
+			}
+			for i, expected := range tt.expectedItems {
+				item := importer.items[i]
+				if item.Title != expected.Title || item.Content != expected.Content ||
+					!item.Date.Equal(expected.Date) || item.Completed != expected.Completed ||
+					!item.CreatedAt.Equal(expected.CreatedAt) || !item.UpdatedAt.Equal(expected.UpdatedAt) {
+					t.Errorf("item %d:\nexpected %+v\ngot      %+v", i, expected, item)
+				}
+			}
+		})
+	}
+}
+
+func TestImportTextRowErrors(t *testing.T) {
+	tests := []struct {
+		format      string
+		input       string
+		expectedRow int
+	}{
+		{format: "markdown", input: "# Groceries\n- [ ] Milk\n- [ ] Eggs due:soon\n", expectedRow: 3},
+		{format: "todotxt", input: "Milk +Groceries\nEggs due:soon\n", expectedRow: 2},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.format, func(t *testing.T) {
+			format, _ := transfer.Lookup(tt.format)
+
+			result, err := transfer.Import(format.NewReader(strings.NewReader(tt.input)), &fakeImporter{}, "")
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			expected := transfer.RowError{Row: tt.expectedRow, Message: `invalid item_date "soon"`}
+			if len(result.Errors) != 1 || result.Errors[0] != expected {
+				t.Errorf("expected %+v, got %+v", expected, result.Errors)
+			}
+		})
+	}
+}
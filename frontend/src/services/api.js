@@ -4,7 +4,7 @@ import axios from 'axios';
 
 const API_URL = 'http://localhost:8080/api';
 
-export const getItems = () => axios.get(`${API_URL}/items`);
+export const getItems = (params) => axios.get(`${API_URL}/items`, { params });
 export const getItem = (id) => axios.get(`${API_URL}/items/${id}`);
 export const createItem = (item) => axios.post(`${API_URL}/items`, item);
 export const updateItem = (id, item) => axios.put(`${API_URL}/items/${id}`, item);
@@ -14,4 +14,33 @@ export const getLists = () => axios.get(`${API_URL}/lists`);
 export const getList = (id) => axios.get(`${API_URL}/lists/${id}`);
 export const createList = (title) => axios.post(`${API_URL}/lists`, title);
 export const updateList = (id, title) => axios.put(`${API_URL}/lists/${id}`, title);
-export const deleteList = (id) => axios.delete(`${API_URL}/lists/${id}`);
\ No newline at end of file
+export const deleteList = (id) => axios.delete(`${API_URL}/lists/${id}`);
+export const getItemRevisions = (id) => axios.get(`${API_URL}/items/${id}/revisions`);
+export const revertItem = (id, rev) => axios.post(`${API_URL}/items/${id}/revisions/${rev}/revert`);
+export const getListRevisions = (id) => axios.get(`${API_URL}/lists/${id}/revisions`);
+export const revertList = (id, rev) => axios.post(`${API_URL}/lists/${id}/revisions/${rev}/revert`);
+
+export const search = (params) => axios.get(`${API_URL}/search`, { params });
+
+export const getSmartLists = () => axios.get(`${API_URL}/smart-lists`);
+export const getSmartListItems = (id) => axios.get(`${API_URL}/smart-lists/${id}/items`);
+export const createSmartList = (smartList) => axios.post(`${API_URL}/smart-lists`, smartList);
+export const updateSmartList = (id, smartList) => axios.put(`${API_URL}/smart-lists/${id}`, smartList);
+export const deleteSmartList = (id) => axios.delete(`${API_URL}/smart-lists/${id}`);
+export const getCalendar = (params) => axios.get(`${API_URL}/calendar`, { params });
+
+export const getFeeds = () => axios.get(`${API_URL}/feeds`);
+export const createFeed = (listId) => axios.post(`${API_URL}/feeds`, { list_id: listId });
+export const deleteFeed = (id) => axios.delete(`${API_URL}/feeds/${id}`);
+
+export const exportList = (id, format) =>
+  axios.get(`${API_URL}/lists/${id}/export`, { params: { format }, responseType: 'blob' });
+export const importList = (file, format) => {
+  const form = new FormData();
+  form.append('file', file);
+  return axios.post(`${API_URL}/lists/import`, form, { params: { format } });
+};
+
+// GraphQL lives beside the REST API, at /graphql rather than under /api
+export const graphql = (query, variables) =>
+  axios.post(API_URL.replace(/\/api\/?$/, '/graphql'), { query, variables });